@@ -16,7 +16,7 @@ func main() {
 
 	mcpPath := file.GetPathFile("bin/finance-mcp")
 
-	transport := &mcp.CommandTransport{Command: exec.Command(mcpPath)}
+	transport := &mcp.CommandTransport{Command: exec.Command(mcpPath, "--transport=stdio")}
 	session, err := client.Connect(ctx, transport, nil)
 	if err != nil {
 		log.Fatal(err)
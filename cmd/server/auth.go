@@ -0,0 +1,29 @@
+package main
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// hasBearerToken reports whether the request sends token as
+// "Authorization: Bearer <token>", comparing in constant time so response
+// timing does not reveal how much of a guess was right.
+func hasBearerToken(c *fiber.Ctx, token string) bool {
+	provided, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// bearerAuth rejects requests that do not send token as a bearer token with
+// 401 Unauthorized. An empty token lets every request through.
+func bearerAuth(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" || hasBearerToken(c, token) {
+			return c.Next()
+		}
+
+		c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+		return fiber.NewError(fiber.StatusUnauthorized, "A valid bearer token is required")
+	}
+}
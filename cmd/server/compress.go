@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// compressMiddleware compresses response bodies of at least minSize bytes for
+// clients that accept gzip or deflate, so large tool results such as full
+// intraday series cost remote agents less bandwidth. A negative minSize
+// disables it. Bodies under 200 bytes are never compressed, as gzip would
+// rarely make them smaller.
+//
+// Streamed responses, such as MCP server-sent events, have no size up front
+// and are always compressed, flushing after each event. Responses that
+// already carry a Content-Encoding or have an incompressible content type are
+// sent unchanged.
+func compressMiddleware(minSize int) fiber.Handler {
+	// Wrapping a no-op handler compresses the response already in the context
+	compress := fasthttp.CompressHandlerLevel(func(*fasthttp.RequestCtx) {}, fasthttp.CompressDefaultCompression)
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if minSize < 0 {
+			return nil
+		}
+
+		resp := c.Response()
+		if !resp.IsBodyStream() && len(resp.Body()) < minSize {
+			return nil
+		}
+
+		compress(c.Context())
+		return nil
+	}
+}
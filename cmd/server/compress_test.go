@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test-client","version":"1.0.0"}}}`
+
+func newCompressApp(minSize int, body string) *fiber.App {
+	app := fiber.New()
+	app.Use(compressMiddleware(minSize))
+	app.Get("/data", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		return c.SendString(body)
+	})
+	return app
+}
+
+// gunzip returns the decompressed body of a gzipped response
+func gunzip(t *testing.T, res *http.Response) string {
+	t.Helper()
+
+	reader, err := gzip.NewReader(res.Body)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	body, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestCompressMiddleware(t *testing.T) {
+	body := `{"data": "` + strings.Repeat("x", 4096) + `"}`
+
+	testCases := []struct {
+		name           string
+		minSize        int
+		body           string
+		acceptEncoding string
+		wantGzip       bool
+	}{
+		{name: "accepts gzip", minSize: 1024, body: body, acceptEncoding: "gzip, deflate", wantGzip: true},
+		{name: "no accept encoding", minSize: 1024, body: body},
+		{name: "below minimum size", minSize: 1024, body: `{"status": "ok"}`, acceptEncoding: "gzip"},
+		{name: "disabled", minSize: -1, body: body, acceptEncoding: "gzip"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/data", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set(fiber.HeaderAcceptEncoding, tc.acceptEncoding)
+			}
+
+			res, err := newCompressApp(tc.minSize, tc.body).Test(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			if !tc.wantGzip {
+				assert.Empty(t, res.Header.Get(fiber.HeaderContentEncoding))
+				got, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				assert.Equal(t, tc.body, string(got))
+				return
+			}
+
+			assert.Equal(t, "gzip", res.Header.Get(fiber.HeaderContentEncoding))
+			assert.Contains(t, res.Header.Get(fiber.HeaderVary), fiber.HeaderAcceptEncoding)
+			assert.Equal(t, tc.body, gunzip(t, res))
+		})
+	}
+}
+
+func TestCompressMiddleware_MCPHandler(t *testing.T) {
+	// Long instructions make the initialize result worth compressing
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, &mcp.ServerOptions{
+		Instructions: strings.Repeat("Use get_intraday_price_stock for intraday bars. ", 50),
+	})
+
+	testCases := []struct {
+		name        string
+		options     *mcp.StreamableHTTPOptions
+		contentType string
+	}{
+		{name: "json response", options: &mcp.StreamableHTTPOptions{JSONResponse: true}, contentType: "application/json"},
+		{name: "event stream", contentType: "text/event-stream"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+				return server
+			}, tc.options)
+
+			app := fiber.New()
+			app.Use(compressMiddleware(1024))
+			app.All("/mcp", adaptor.HTTPHandler(handler))
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(initializeRequest))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			req.Header.Set(fiber.HeaderAccept, "application/json, text/event-stream")
+			req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+
+			res, err := app.Test(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			// Headers and status set by the adapted handler survive compression
+			assert.Equal(t, "gzip", res.Header.Get(fiber.HeaderContentEncoding))
+			assert.True(t, strings.HasPrefix(res.Header.Get(fiber.HeaderContentType), tc.contentType))
+			assert.NotEmpty(t, res.Header.Get("Mcp-Session-Id"))
+
+			payload := gunzip(t, res)
+			if tc.contentType == "text/event-stream" {
+				payload = eventData(t, payload)
+			}
+
+			var message struct {
+				ID     int             `json:"id"`
+				Result json.RawMessage `json:"result"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(payload), &message))
+			assert.Equal(t, 1, message.ID)
+			assert.Contains(t, string(message.Result), `"serverInfo"`)
+		})
+	}
+}
+
+// eventData returns the data of the first server-sent event in body
+func eventData(t *testing.T, body string) string {
+	t.Helper()
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			return data
+		}
+	}
+
+	t.Fatalf("No event data in %q", body)
+	return ""
+}
+
+func TestCompressMiddleware_AdaptorContentLength(t *testing.T) {
+	body := strings.Repeat(`{"timestamp": "2024-01-02T09:30:00Z", "close": 187.15},`, 2000)
+
+	// The handler declares the length of the uncompressed body
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		_, _ = io.WriteString(w, body)
+	})
+
+	app := fiber.New()
+	app.Use(compressMiddleware(1024))
+	app.All("/mcp", adaptor.HTTPHandler(handler))
+
+	testCases := []struct {
+		name           string
+		acceptEncoding string
+	}{
+		{name: "compressed", acceptEncoding: "gzip"},
+		{name: "uncompressed"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/mcp", nil)
+			if tc.acceptEncoding != "" {
+				req.Header.Set(fiber.HeaderAcceptEncoding, tc.acceptEncoding)
+			}
+
+			res, err := app.Test(req)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, res.StatusCode)
+
+			raw, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(raw)), res.ContentLength, "Content-Length must match the bytes sent")
+
+			if tc.acceptEncoding == "" {
+				assert.Equal(t, body, string(raw))
+				return
+			}
+
+			assert.Less(t, len(raw), len(body))
+			reader, err := gzip.NewReader(bytes.NewReader(raw))
+			require.NoError(t, err)
+			got, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(got))
+		})
+	}
+}
+
+func TestCompressMiddleware_AdaptorStreams(t *testing.T) {
+	event := "data: " + strings.Repeat("x", 8192) + "\n\n"
+	release := make(chan struct{})
+
+	// The handler flushes one event, then blocks until the client has read it
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Content-Length", "1")
+		_, _ = io.WriteString(w, event)
+		w.(http.Flusher).Flush()
+
+		<-release
+		_, _ = io.WriteString(w, event)
+	})
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(compressMiddleware(1024))
+	app.All("/mcp", adaptor.HTTPHandler(handler))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	defer app.Shutdown()
+
+	// Unblock the handler even when an assertion fails, so shutdown completes
+	unblock := sync.OnceFunc(func() { close(release) })
+	defer unblock()
+
+	// A buffered stream would block on release, so fail instead of hanging
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+listener.Addr().String()+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set(fiber.HeaderAcceptEncoding, "gzip")
+
+	// A bare transport leaves the gzip body for the test to decode
+	res, err := (&http.Transport{DisableCompression: true}).RoundTrip(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, "gzip", res.Header.Get(fiber.HeaderContentEncoding))
+	assert.Equal(t, int64(-1), res.ContentLength, "Streamed responses must not declare a length")
+
+	reader, err := gzip.NewReader(res.Body)
+	require.NoError(t, err)
+
+	first := make([]byte, len(event))
+	_, err = io.ReadFull(reader, first)
+	require.NoError(t, err, "The first event must arrive before the handler finishes")
+	assert.Equal(t, event, string(first))
+
+	unblock()
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, event, string(rest))
+}
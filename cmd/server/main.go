@@ -14,13 +14,23 @@
 //
 // Usage:
 //
-//	The server listens on port 8080 and can be queried by MCP clients
-//	for real-time financial market data with enterprise-grade performance.
+//	The server listens on port 8080 (configurable with PORT and HOST) and can be
+//	queried by MCP clients for real-time financial market data with
+//	enterprise-grade performance.
 package main
 
 import (
+	"context"
+	"flag"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -32,44 +42,38 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/yeferson59/finance-mcp/internal/config"
+	"github.com/yeferson59/finance-mcp/internal/health"
 	"github.com/yeferson59/finance-mcp/internal/tools"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/logging"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+	"github.com/yeferson59/finance-mcp/pkg/tracing/oteltracing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// maxRequestBodySize is the largest request body accepted, in bytes
+const maxRequestBodySize = 10 * 1024 * 1024
+
 // setupFiberApp configures a Fiber app with optimal performance settings
-func setupFiberApp() *fiber.App {
+func setupFiberApp(cfg *config.Config) *fiber.App {
 	app := fiber.New(fiber.Config{
 		Prefork:              false,
 		StrictRouting:        false,
 		CaseSensitive:        false,
 		UnescapePath:         true,
-		BodyLimit:            10 * 1024 * 1024,
+		BodyLimit:            maxRequestBodySize,
 		Concurrency:          256 * 1024,
-		ReadTimeout:          30 * time.Second,
-		WriteTimeout:         30 * time.Second,
-		IdleTimeout:          60 * time.Second,
+		ReadTimeout:          cfg.ReadTimeout,
+		WriteTimeout:         cfg.WriteTimeout,
+		IdleTimeout:          cfg.IdleTimeout,
 		ReadBufferSize:       8192,
 		WriteBufferSize:      8192,
 		CompressedFileSuffix: ".fiber.gz",
 		ProxyHeader:          fiber.HeaderXForwardedFor,
 
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			message := "Internal Server Error"
-
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-				message = e.Message
-			}
-
-			return c.Status(code).JSON(fiber.Map{
-				"error":     message,
-				"timestamp": time.Now().UTC().Format(time.RFC3339),
-				"path":      c.Path(),
-				"method":    c.Method(),
-			})
-		},
+		ErrorHandler: errorHandler,
 
 		ServerHeader: "Finance-MCP-Server/1.0",
 		AppName:      "Finance MCP Server",
@@ -78,24 +82,70 @@ func setupFiberApp() *fiber.App {
 	return app
 }
 
+// errorHandler renders errors as a JSON envelope. Oversized requests, which
+// fasthttp rejects before any handler runs, also report the size limit.
+func errorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	message := "Internal Server Error"
+
+	if e, ok := err.(*fiber.Error); ok {
+		code = e.Code
+		message = e.Message
+	}
+
+	body := fiber.Map{
+		"error":     message,
+		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"path":      c.Path(),
+		"method":    c.Method(),
+	}
+
+	if code == fiber.StatusRequestEntityTooLarge {
+		body["error"] = "request too large"
+		body["max_bytes"] = maxRequestBodySize
+	}
+
+	return c.Status(code).JSON(body)
+}
+
 // setupMiddleware configures all necessary middleware for the application
-func setupMiddleware(app *fiber.App) {
+func setupMiddleware(app *fiber.App, cfg *config.Config, readiness *health.Checker) {
 	app.Use(requestid.New())
+	// Expose the generated request ID to the MCP handler as a request header
+	app.Use(func(c *fiber.Ctx) error {
+		if len(c.Request().Header.Peek(fiber.HeaderXRequestID)) == 0 {
+			c.Request().Header.Set(fiber.HeaderXRequestID, c.GetRespHeader(fiber.HeaderXRequestID))
+		}
+		return c.Next()
+	})
 
 	app.Use(recover.New(recover.Config{
 		EnableStackTrace: true,
 	}))
 
 	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "*",
+		AllowOrigins:     cfg.CORSAllowedOrigins,
 		AllowMethods:     "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
 		AllowHeaders:     "*",
 		AllowCredentials: false,
 		ExposeHeaders:    "X-Request-ID",
 		MaxAge:           86400,
+		// Unknown paths get no CORS headers, so preflights for them end in 404
+		Next: func(c *fiber.Ctx) bool {
+			return !isKnownPath(c.Path())
+		},
 	}))
 
-	app.Use(etag.New())
+	app.Use(rateLimitMiddleware(cfg.RateLimitPerMinute))
+
+	app.Use(etag.New(etag.Config{
+		// Hashing a streamed body reads it to the end, which would hold back
+		// MCP server-sent events until the tool call completes
+		Next: acceptsEventStream,
+	}))
+
+	// Inside etag, so ETags describe the encoded body that is sent
+	app.Use(compressMiddleware(cfg.CompressMinSize))
 
 	app.Use(logger.New(logger.Config{
 		Format:     "${time} | ${status} | ${latency} | ${method} ${path} | ${ip} | ${error}\n",
@@ -108,13 +158,48 @@ func setupMiddleware(app *fiber.App) {
 			return true
 		},
 		ReadinessProbe: func(c *fiber.Ctx) bool {
-			return true
+			return readiness.Check(c.UserContext()).Ready
 		},
 	}))
 }
 
-// setupRoutes configures all application routes
-func setupRoutes(app *fiber.App, mcpHandler http.Handler) {
+// acceptsEventStream reports whether the client accepts server-sent events,
+// as MCP streamable HTTP clients do, so the response may be streamed
+func acceptsEventStream(c *fiber.Ctx) bool {
+	return strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream")
+}
+
+// requireJSONBody rejects POST requests whose body is not declared as JSON
+// with 415 Unsupported Media Type, since MCP messages are JSON-RPC
+func requireJSONBody(c *fiber.Ctx) error {
+	if c.Method() == fiber.MethodPost && !c.Is("json") {
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, "Content-Type must be application/json")
+	}
+
+	return c.Next()
+}
+
+// isKnownPath reports whether path is served by the MCP, health, stats or
+// info routes
+func isKnownPath(path string) bool {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	path = strings.ToLower(path)
+
+	switch path {
+	case "/", "/mcp", "/health", "/health/live", "/health/ready", "/stats", "/info",
+		healthcheck.DefaultLivenessEndpoint, healthcheck.DefaultReadinessEndpoint:
+		return true
+	}
+
+	return strings.HasPrefix(path, "/mcp/")
+}
+
+// setupRoutes configures all application routes. When authToken is set, MCP
+// requests must send it as "Authorization: Bearer <token>". A nil wsHandler
+// leaves the WebSocket transport disabled.
+func setupRoutes(app *fiber.App, mcpHandler, wsHandler http.Handler, authToken string, readiness *health.Checker, stats fiber.Handler) {
 
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
@@ -132,15 +217,29 @@ func setupRoutes(app *fiber.App, mcpHandler http.Handler) {
 		})
 	})
 
+	// Readiness verifies Alpha Vantage is reachable and accepts the API key,
+	// so load balancers stop routing here when the backend is broken
 	app.Get("/health/ready", func(c *fiber.Ctx) error {
+		result := readiness.Check(c.UserContext())
+		if !result.Ready {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"status": "not_ready",
+				"checks": fiber.Map{
+					"api": result,
+				},
+			})
+		}
+
 		return c.JSON(fiber.Map{
 			"status": "ready",
 			"checks": fiber.Map{
-				"api": "ok",
+				"api": result,
 			},
 		})
 	})
 
+	app.Get("/stats", stats)
+
 	app.Get("/info", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"name":        "Finance MCP Server",
@@ -148,15 +247,20 @@ func setupRoutes(app *fiber.App, mcpHandler http.Handler) {
 			"description": "Model Context Protocol server for financial market data",
 			"endpoints": fiber.Map{
 				"health":  "/health",
+				"stats":   "/stats",
 				"mcp":     "/",
 				"mcp_alt": "/mcp",
 			},
 		})
 	})
 
-	app.All("/", adaptor.HTTPHandler(mcpHandler))
-	app.All("/mcp", adaptor.HTTPHandler(mcpHandler))
-	app.All("/mcp/*", adaptor.HTTPHandler(mcpHandler))
+	auth := bearerAuth(authToken)
+	if wsHandler != nil {
+		app.Get("/mcp/ws", auth, adaptor.HTTPHandler(wsHandler))
+	}
+	app.All("/", auth, requireJSONBody, adaptor.HTTPHandler(mcpHandler))
+	app.All("/mcp", auth, requireJSONBody, adaptor.HTTPHandler(mcpHandler))
+	app.All("/mcp/*", auth, requireJSONBody, adaptor.HTTPHandler(mcpHandler))
 
 	app.Use(func(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusNotFound, "Endpoint not found")
@@ -169,52 +273,359 @@ func main() {
 	log.Println("🚀 Starting Finance MCP Server with Fiber framework...")
 
 	cfg := config.NewConfig()
-	if cfg.APIURL == "" || cfg.APIKey == "" {
-		log.Fatal("❌ Missing required configuration: APIURL and APIKey must be set")
+	flag.StringVar(&cfg.Transport, "transport", cfg.Transport, "MCP transport: http or stdio (default from MCP_TRANSPORT)")
+	flag.Parse()
+
+	slog.SetDefault(logging.New(os.Stderr, logging.ParseLevel(cfg.LogLevel)))
+	if cfg.APIURL == "" {
+		log.Fatal("❌ Missing required configuration: APIURL must be set")
 	}
 
+	if err := cfg.Validate(); err != nil {
+		if cfg.RequireAPIKey {
+			log.Fatalf("❌ Invalid API key configuration: %v (REQUIRE_API_KEY=true)", err)
+		}
+		log.Printf("⚠️ WARNING: %v - requests will fail or be limited to example symbols. Set API_KEY to your Alpha Vantage key.", err)
+	}
+
+	if err := cfg.ValidateTransport(); err != nil {
+		log.Fatalf("❌ Invalid transport: %v", err)
+	}
+
+	if err := cfg.ValidateIntradayDefaults(); err != nil {
+		log.Fatalf("❌ Invalid intraday defaults: %v", err)
+	}
+
+	if err := cfg.ValidateCORSOrigins(); err != nil {
+		log.Fatalf("❌ Invalid CORS configuration: %v", err)
+	}
+
+	if err := validation.SetSymbolRules(cfg.SymbolRules()); err != nil {
+		log.Fatalf("❌ Invalid symbol rules (SYMBOL_MAX_LENGTH, SYMBOL_PUNCTUATION): %v", err)
+	}
+
+	// Tools build their HTTP clients from client.DefaultConfig
+	client.SetDefaultAcceptEncoding(cfg.HTTPAcceptEncoding)
+	request.SetDefaultUserAgent(cfg.UserAgent)
+
 	impl := cfg.Implementation
 	server := mcp.NewServer(impl, nil)
+	server.AddReceivingMiddleware(tools.LoggingMiddleware, tools.TracingMiddleware)
+
+	var shutdownTracing func(context.Context) error
+	if cfg.OTelEnabled {
+		var err error
+		shutdownTracing, err = oteltracing.Setup(context.Background(), impl.Name)
+		if err != nil {
+			log.Fatalf("❌ Failed to set up OpenTelemetry tracing: %v", err)
+		}
+		log.Println("🔭 OpenTelemetry tracing enabled")
+	}
 
 	log.Println("📊 Initializing financial data tools with DI architecture...")
 
-	stockOverviewTool := tools.NewOverviewStock(cfg.APIURL, cfg.APIKey)
-	stockIntradayPriceTool := tools.NewIntradayPriceStock(cfg.APIURL, cfg.APIKey)
+	// Tools share one HTTP client, so connections, statistics and rate limits are unified
+	toolset := tools.NewToolset(&request.AlphaVantageConfig{
+		BaseURL:             cfg.APIURL,
+		APIKey:              cfg.APIKey,
+		Timeout:             30 * time.Second,
+		DefaultInterval:     cfg.DefaultInterval,
+		DefaultOutputSize:   cfg.DefaultOutputSize,
+		IntervalOutputSizes: cfg.IntervalOutputSizes,
+		DefaultAdjusted:     cfg.IntradayAdjusted(),
+		StaleAfter:          cfg.StaleAfter,
+
+		MaxConcurrentRequests: cfg.MaxConcurrentRequests,
+		RequestsPerMinute:     cfg.UpstreamRequestsPerMinute,
+		RequestsPerDay:        cfg.UpstreamRequestsPerDay,
+	})
+	if cfg.UpstreamRequestsPerMinute > 0 || cfg.UpstreamRequestsPerDay > 0 {
+		log.Printf("🚦 Alpha Vantage quota: %s requests per minute, %s per day",
+			quotaLimit(cfg.UpstreamRequestsPerMinute), quotaLimit(cfg.UpstreamRequestsPerDay))
+	} else {
+		log.Println("🚦 Alpha Vantage quota is not enforced; set RATE_LIMIT_REQUESTS_PER_MINUTE and RATE_LIMIT_REQUESTS_PER_DAY to match your plan")
+	}
+	stockOverviewTool := toolset.OverviewStock()
+	stockOverviewsTool := toolset.OverviewStocks()
+	globalQuoteTool := toolset.GlobalQuote()
+	globalQuotesTool := toolset.GlobalQuotes()
+	stockIntradayPriceTool := toolset.IntradayPriceStock()
+	stockWeeklyPriceTool := toolset.WeeklyPriceStock()
+	stockMonthlyPriceTool := toolset.MonthlyPriceStock()
+	newsSentimentTool := toolset.NewsSentiment()
+	incomeStatementTool := toolset.IncomeStatement()
+	balanceSheetTool := toolset.BalanceSheet()
+	cashFlowTool := toolset.CashFlow()
+	earningsTool := toolset.Earnings()
+	earningsTranscriptTool := toolset.EarningsTranscript()
+	exchangeRateTool := toolset.CurrencyExchangeRate()
+	fxIntradayTool := toolset.FXIntraday()
+	fxDailyTool := toolset.FXDaily()
+	cryptoDailyTool := toolset.CryptoDaily()
+	cryptoIntradayTool := toolset.CryptoIntraday()
+	smaTool := toolset.SMA()
+	emaTool := toolset.EMA()
+	rsiTool := toolset.RSI()
+	macdTool := toolset.MACD()
+	listingStatusTool := toolset.ListingStatus()
+	alphaFunctionTool := toolset.AlphaFunction()
+	readinessChecker := health.NewCheckerWithClient(toolset.AlphaVantageClient(), cfg.ReadinessCacheTTL)
+
+	// Tool and readiness check clients closed on shutdown once in-flight requests have drained
+	toolClosers := []io.Closer{toolset, readinessChecker}
+
+	// Clients whose statistics are served by /stats; the tools share one
+	toolStats := map[string]statsReporter{
+		"alpha_vantage": toolset,
+	}
 
 	log.Println("🔧 Registering MCP tools...")
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get_overview_stock",
-		Description: "Get comprehensive stock market data for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns detailed financial metrics, company information, and market data.",
-	}, stockOverviewTool.Get)
+	registrations := []toolRegistration{
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_overview_stock",
+			Description: "Get comprehensive stock market data for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns detailed financial metrics, company information, and market data.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(stockOverviewTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_overview_stocks",
+			Description: "Get company overviews for up to 20 stock symbols at once (e.g., [AAPL, GOOGL, MSFT]). Returns a map of symbol to financial metrics and company information, plus a map of symbol to error for any symbols that could not be fetched.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(stockOverviewsTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_global_quote",
+			Description: "Get the latest quote of a stock using its symbol (e.g., AAPL, GOOGL, MSFT). Returns the latest price, the day's open, high, low and volume, and the change from the previous close.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(globalQuoteTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_global_quotes",
+			Description: "Get the latest quotes of up to 20 stock symbols at once (e.g., [AAPL, GOOGL, MSFT]), e.g. to refresh a watchlist. Returns a map of symbol to quote, plus a map of symbol to error for any symbols that could not be fetched.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(globalQuotesTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_intraday_price_stock",
+			Description: "Get intraday stock price data for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns price, volume, and other financial metrics for the specified time interval.",
+			InputSchema: stockIntradayPriceTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(stockIntradayPriceTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_weekly_price_stock",
+			Description: "Get weekly stock price data for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns open, high, low, close and volume for each week, optionally adjusted for splits and dividends.",
+			InputSchema: stockWeeklyPriceTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(stockWeeklyPriceTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_monthly_price_stock",
+			Description: "Get monthly stock price data for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns open, high, low, close and volume for each month, optionally adjusted for splits and dividends.",
+			InputSchema: stockMonthlyPriceTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(stockMonthlyPriceTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_news_sentiment",
+			Description: "Get recent market news with sentiment analysis, optionally filtered by tickers (e.g., AAPL or CRYPTO:BTC), topics and publication time. Returns article titles, URLs, overall sentiment and per-ticker relevance and sentiment scores.",
+			InputSchema: newsSentimentTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(newsSentimentTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_income_statement",
+			Description: "Get annual and quarterly income statements for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns revenue, gross profit, operating income, EBITDA, net income and other line items.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(incomeStatementTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_balance_sheet",
+			Description: "Get annual and quarterly balance sheets for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns assets, liabilities, debt, shareholder equity and other line items.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(balanceSheetTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_cash_flow",
+			Description: "Get annual and quarterly cash flow statements for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns operating, investing and financing cash flows, capital expenditures, dividends and other line items.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(cashFlowTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_earnings",
+			Description: "Get annual and quarterly earnings (EPS) history for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns reported date, reported and estimated EPS, surprise and surprise percentage.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(earningsTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_earnings_transcript",
+			Description: "Get the earnings call transcript of a company for a fiscal quarter using its stock symbol (e.g., IBM) and quarter (e.g., 2024Q1). Returns each speaker turn with the speaker's name, title, text and sentiment score.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(earningsTranscriptTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_exchange_rate",
+			Description: "Get the realtime exchange rate between two physical or digital currencies using 3-letter codes (e.g., USD to JPY, BTC to EUR). Returns the exchange rate, bid and ask prices.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(exchangeRateTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_fx_intraday",
+			Description: "Get intraday foreign exchange rates for a currency pair using 3-letter codes (e.g., EUR to USD). Returns open, high, low and close rates for the specified time interval.",
+			InputSchema: fxIntradayTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(fxIntradayTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_fx_daily",
+			Description: "Get daily foreign exchange rates for a currency pair using 3-letter codes (e.g., EUR to USD). Returns open, high, low and close rates for each trading day.",
+			InputSchema: fxDailyTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(fxDailyTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_crypto_daily",
+			Description: "Get daily digital currency prices for a symbol (e.g., BTC, ETH) quoted in a market currency (e.g., USD, EUR). Returns open, high, low, close and volume for each day.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(cryptoDailyTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_crypto_intraday",
+			Description: "Get intraday digital currency prices for a symbol (e.g., BTC, ETH) quoted in a market currency (e.g., USD). Returns open, high, low, close and volume for the specified time interval.",
+			InputSchema: cryptoIntradayTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(cryptoIntradayTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_sma",
+			Description: "Get the simple moving average (SMA) of a stock's price for a symbol (e.g., AAPL), interval, time period and series type. Returns indicator values sorted oldest first.",
+			InputSchema: smaTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(smaTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_ema",
+			Description: "Get the exponential moving average (EMA) of a stock's price for a symbol (e.g., AAPL), interval, time period and series type. Returns indicator values sorted oldest first.",
+			InputSchema: emaTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(emaTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_rsi",
+			Description: "Get the relative strength index (RSI) of a stock's price for a symbol (e.g., AAPL), interval, time period and series type. Returns indicator values sorted oldest first.",
+			InputSchema: rsiTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(rsiTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_macd",
+			Description: "Get the moving average convergence/divergence (MACD) of a stock's price for a symbol (e.g., AAPL), interval and series type. Returns MACD, signal and histogram values sorted oldest first.",
+			InputSchema: macdTool.InputSchema(),
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(macdTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_listing_status",
+			Description: "Get the list of active or delisted US stocks and ETFs, optionally as of a past date (e.g., 2015-06-01). Returns symbol, name, exchange, asset type, IPO date, delisting date and status for each security.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(listingStatusTool.Get))),
+		newToolRegistration(&mcp.Tool{
+			Name:        "get_alpha_function",
+			Description: "Call an Alpha Vantage function that has no dedicated tool, e.g. SYMBOL_SEARCH with params {\"keywords\": \"tesla\"} or TOP_GAINERS_LOSERS. Only functions answering in JSON are allowed. Returns the function's JSON response unchanged under data.",
+		}, tools.WithErrorEnvelope(tools.WithCallTimeout(alphaFunctionTool.Get))),
+	}
 
-	mcp.AddTool(server, &mcp.Tool{
-		Name:        "get_intraday_price_stock",
-		Description: "Get intraday stock price data for a specific company using its stock symbol (e.g., AAPL, GOOGL, MSFT). Returns price, volume, and other financial metrics for the specified time interval.",
-	}, stockIntradayPriceTool.Get)
+	registered, skipped, err := registerTools(server, registrations, cfg.EnabledTools, cfg.DisabledTools)
+	if err != nil {
+		log.Fatalf("❌ Invalid tool selection (ENABLED_TOOLS, DISABLED_TOOLS): %v", err)
+	}
+	log.Printf("✅ Enabled %d tools: %s", len(registered), strings.Join(registered, ", "))
+	if len(skipped) > 0 {
+		log.Printf("⏭️ Skipped %d tools: %s", len(skipped), strings.Join(skipped, ", "))
+	}
+
+	if cfg.Transport == config.TransportStdio {
+		runStdio(server, toolClosers, shutdownTracing, cfg.ShutdownTimeout)
+		return
+	}
 
 	mcpHTTPHandler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
 		return server
 	}, nil)
 
 	log.Println("⚡ Configuring Fiber application...")
-	app := setupFiberApp()
+	app := setupFiberApp(cfg)
+
+	setupMiddleware(app, cfg, readinessChecker)
 
-	setupMiddleware(app)
+	var wsHandler http.Handler
+	if cfg.EnableWebSocket {
+		wsHandler = websocketHandler(server, cfg)
+	}
 
-	setupRoutes(app, mcpHTTPHandler)
+	setupRoutes(app, mcpHTTPHandler, wsHandler, cfg.AuthToken, readinessChecker, statsHandler(toolStats, cfg.StatsToken))
 
-	port := ":8080"
+	addr, err := cfg.ListenAddress()
+	if err != nil {
+		log.Fatalf("❌ Invalid listen address: %v", err)
+	}
+	// Endpoint URLs name the bind address, or localhost when listening on
+	// all interfaces
+	host := addr
+	if cfg.Host == "" {
+		host = "localhost" + addr
+	}
 
 	log.Println("✅ Finance MCP Server configured successfully")
-	log.Printf("🌐 Server starting on port %s", port)
-	log.Printf("🏥 Health check: http://localhost%s/health", port)
-	log.Printf("📋 API info: http://localhost%s/info", port)
-	log.Printf("🔗 MCP endpoint: http://localhost%s/", port)
+	log.Printf("🌐 Server starting on %s", addr)
+	log.Printf("🏥 Health check: http://%s/health", host)
+	log.Printf("🩺 Readiness check: http://%s/health/ready (upstream checked every %s)", host, cfg.ReadinessCacheTTL)
+	log.Printf("📋 API info: http://%s/info", host)
+	log.Printf("🔗 MCP endpoint: http://%s/", host)
+	if cfg.EnableWebSocket {
+		log.Printf("🔌 MCP WebSocket endpoint: ws://%s/mcp/ws", host)
+	}
+	if cfg.AuthToken == "" {
+		log.Println("⚠️ AUTH_TOKEN is not set - the MCP endpoint is served without authentication")
+	}
 	log.Println("⚡ Using FastHTTP client with connection pooling")
-	log.Printf("🔧 Client stats endpoint: http://localhost%s/health (includes client metrics)", port)
+	log.Printf("🔧 Client stats endpoint: http://%s/stats", host)
+	if cfg.StatsToken == "" {
+		log.Println("⚠️ STATS_TOKEN is not set - /stats is served without authentication")
+	}
 	log.Println("📈 Ready to serve financial market data requests with optimized performance!")
 
-	if err := app.Listen(port); err != nil {
-		log.Fatalf("❌ Fiber server failed to start: %v", err)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- app.Listen(addr)
+	}()
+
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			log.Fatalf("❌ Fiber server failed to start: %v", err)
+		}
+	case <-ctx.Done():
+		stop()
+		shutdown(app, toolClosers, shutdownTracing, cfg.ShutdownTimeout)
+	}
+}
+
+// quotaLimit describes a rate limit for the startup log, where zero or less
+// means no limit
+func quotaLimit(limit int) string {
+	if limit <= 0 {
+		return "unlimited"
 	}
+
+	return strconv.Itoa(limit)
+}
+
+// shutdown stops accepting connections, waits up to timeout for in-flight
+// requests to finish, closes the tool clients and flushes pending spans
+func shutdown(app *fiber.App, closers []io.Closer, shutdownTracing func(context.Context) error, timeout time.Duration) {
+	log.Printf("🛑 Shutdown signal received, draining requests (up to %s)...", timeout)
+
+	if err := app.ShutdownWithTimeout(timeout); err != nil {
+		log.Printf("⚠️ Server did not shut down cleanly: %v", err)
+	} else {
+		log.Println("✅ HTTP server stopped")
+	}
+
+	releaseResources(closers, shutdownTracing, timeout)
+}
+
+// runStdio serves a single MCP session over stdin and stdout, as desktop MCP
+// clients expect when they launch the server as a subprocess, until the
+// client disconnects or SIGINT/SIGTERM is received. Logs go to stderr, so
+// they never corrupt the protocol stream.
+func runStdio(server *mcp.Server, closers []io.Closer, shutdownTracing func(context.Context) error, timeout time.Duration) {
+	log.Println("📟 Serving MCP over stdio")
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil && ctx.Err() == nil {
+		log.Printf("⚠️ stdio session ended with an error: %v", err)
+	}
+
+	releaseResources(closers, shutdownTracing, timeout)
+}
+
+// releaseResources closes the tool clients and flushes pending traces
+func releaseResources(closers []io.Closer, shutdownTracing func(context.Context) error, timeout time.Duration) {
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			log.Printf("⚠️ Failed to close tool client: %v", err)
+		}
+	}
+	log.Println("✅ Tool clients closed")
+
+	if shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			log.Printf("⚠️ Failed to flush traces: %v", err)
+		}
+	}
+
+	log.Println("👋 Finance MCP Server stopped")
 }
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/config"
+	"github.com/yeferson59/finance-mcp/internal/health"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// newTestConfig returns the defaults of the settings used by setupMiddleware
+func newTestConfig() *config.Config {
+	return &config.Config{CompressMinSize: 1024, CORSAllowedOrigins: "*"}
+}
+
+// newTestApp returns the server's middleware and routes in front of the MCP
+// handlers; a nil wsHandler disables the WebSocket transport
+func newTestApp(cfg *config.Config, mcpHandler, wsHandler http.Handler) *fiber.App {
+	readiness := health.NewCheckerWithClient(request.NewAlphaVantageClient(client.NewMockClient(), &request.AlphaVantageConfig{}), time.Minute)
+
+	app := fiber.New(fiber.Config{
+		DisableStartupMessage: true,
+		BodyLimit:             maxRequestBodySize,
+		ErrorHandler:          errorHandler,
+	})
+	setupMiddleware(app, cfg, readiness)
+	setupRoutes(app, mcpHandler, wsHandler, cfg.AuthToken, readiness, func(c *fiber.Ctx) error { return nil })
+	return app
+}
+
+func TestPreflight(t *testing.T) {
+	mcpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	})
+	app := newTestApp(newTestConfig(), mcpHandler, nil)
+
+	testCases := []struct {
+		path       string
+		wantStatus int
+		wantCORS   bool
+	}{
+		{path: "/mcp", wantStatus: http.StatusNoContent, wantCORS: true},
+		{path: "/mcp/", wantStatus: http.StatusNoContent, wantCORS: true},
+		{path: "/", wantStatus: http.StatusNoContent, wantCORS: true},
+		{path: "/health", wantStatus: http.StatusNoContent, wantCORS: true},
+		{path: "/health/ready", wantStatus: http.StatusNoContent, wantCORS: true},
+		{path: "/nonexistent", wantStatus: http.StatusNotFound},
+		{path: "/mcpx", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, tc.path, nil)
+			req.Header.Set(fiber.HeaderOrigin, "https://agent.example.com")
+			req.Header.Set(fiber.HeaderAccessControlRequestMethod, http.MethodPost)
+
+			res, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, res.StatusCode)
+
+			if tc.wantCORS {
+				assert.Equal(t, "*", res.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+				assert.Contains(t, res.Header.Get(fiber.HeaderAccessControlAllowMethods), http.MethodPost)
+			} else {
+				assert.Empty(t, res.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+			}
+		})
+	}
+}
+
+func TestCORSHeadersOnRoutes(t *testing.T) {
+	app := newTestApp(newTestConfig(), http.NotFoundHandler(), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	req.Header.Set(fiber.HeaderOrigin, "https://agent.example.com")
+
+	res, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "*", res.Header.Get(fiber.HeaderAccessControlAllowOrigin))
+}
+
+func TestMCPAuthToken(t *testing.T) {
+	mcpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	cfg := newTestConfig()
+	cfg.AuthToken = "secret"
+	app := newTestApp(cfg, mcpHandler, nil)
+
+	testCases := []struct {
+		name          string
+		path          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "missing token", path: "/mcp", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", path: "/mcp", authorization: "Bearer nope", wantStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", path: "/mcp", authorization: "secret", wantStatus: http.StatusUnauthorized},
+		{name: "valid token", path: "/mcp", authorization: "Bearer secret", wantStatus: http.StatusOK},
+		{name: "root without token", path: "/", wantStatus: http.StatusUnauthorized},
+		{name: "root with token", path: "/", authorization: "Bearer secret", wantStatus: http.StatusOK},
+		{name: "health without token", path: "/health", wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			method := http.MethodPost
+			if tc.path == "/health" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, tc.path, strings.NewReader(`{}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tc.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tc.authorization)
+			}
+
+			res, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, res.StatusCode)
+			if tc.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", res.Header.Get(fiber.HeaderWWWAuthenticate))
+			}
+		})
+	}
+}
+
+func TestRequestTooLarge(t *testing.T) {
+	mcpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Oversized requests must not reach the MCP handler")
+	})
+	app := newTestApp(newTestConfig(), mcpHandler, nil)
+
+	// fasthttp rejects the request from its Content-Length before reading the
+	// body, which app.Test reports as an error instead of the response
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	defer app.Shutdown()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "POST /mcp HTTP/1.1\r\nHost: localhost\r\nContent-Type: application/json\r\nContent-Length: %d\r\n\r\n", maxRequestBodySize+1)
+	require.NoError(t, err)
+
+	res, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, res.StatusCode)
+
+	var body struct {
+		Error    string `json:"error"`
+		MaxBytes int    `json:"max_bytes"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.Equal(t, "request too large", body.Error)
+	assert.Equal(t, maxRequestBodySize, body.MaxBytes)
+}
+
+func TestRequireJSONBody(t *testing.T) {
+	mcpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	app := newTestApp(newTestConfig(), mcpHandler, nil)
+
+	testCases := []struct {
+		name        string
+		method      string
+		contentType string
+		wantStatus  int
+	}{
+		{name: "json", method: http.MethodPost, contentType: "application/json", wantStatus: http.StatusOK},
+		{name: "json with charset", method: http.MethodPost, contentType: "application/json; charset=utf-8", wantStatus: http.StatusOK},
+		{name: "plain text", method: http.MethodPost, contentType: "text/plain", wantStatus: http.StatusUnsupportedMediaType},
+		{name: "missing content type", method: http.MethodPost, wantStatus: http.StatusUnsupportedMediaType},
+		{name: "event stream without body", method: http.MethodGet, wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/mcp", strings.NewReader(initializeRequest))
+			if tc.contentType != "" {
+				req.Header.Set(fiber.HeaderContentType, tc.contentType)
+			}
+
+			res, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, res.StatusCode)
+
+			if tc.wantStatus == http.StatusUnsupportedMediaType {
+				var body struct {
+					Error string `json:"error"`
+				}
+				require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+				assert.Equal(t, "Content-Type must be application/json", body.Error)
+			}
+		})
+	}
+}
+
+func TestCORSAllowedOrigins(t *testing.T) {
+	testCases := []struct {
+		name       string
+		allowed    string
+		origin     string
+		wantOrigin string
+	}{
+		{name: "allowed origin", allowed: "https://app.example.com, https://admin.example.com", origin: "https://admin.example.com", wantOrigin: "https://admin.example.com"},
+		{name: "allowed subdomain", allowed: "https://*.example.org", origin: "https://agent.example.org", wantOrigin: "https://agent.example.org"},
+		{name: "disallowed origin", allowed: "https://app.example.com", origin: "https://evil.example.net"},
+		{name: "wildcard", allowed: "*", origin: "https://evil.example.net", wantOrigin: "*"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.CORSAllowedOrigins = tc.allowed
+			require.NoError(t, cfg.ValidateCORSOrigins())
+			app := newTestApp(cfg, http.NotFoundHandler(), nil)
+
+			for _, method := range []string{http.MethodGet, http.MethodOptions} {
+				req := httptest.NewRequest(method, "/health", nil)
+				req.Header.Set(fiber.HeaderOrigin, tc.origin)
+				req.Header.Set(fiber.HeaderAccessControlRequestMethod, http.MethodGet)
+
+				res, err := app.Test(req)
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantOrigin, res.Header.Get(fiber.HeaderAccessControlAllowOrigin), method)
+				if tc.allowed != "*" {
+					assert.Contains(t, res.Header.Get(fiber.HeaderVary), fiber.HeaderOrigin, method)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCORSOrigins(t *testing.T) {
+	for _, origins := range []string{"https://*", "app.example.com", "https://app.example.com/path", "*, https://app.example.com"} {
+		cfg := newTestConfig()
+		cfg.CORSAllowedOrigins = origins
+		assert.Error(t, cfg.ValidateCORSOrigins(), origins)
+	}
+}
+
+func TestConfigValidate_APIKey(t *testing.T) {
+	testCases := []struct {
+		name    string
+		apiKey  string
+		wantErr error
+	}{
+		{name: "unset", apiKey: "", wantErr: config.ErrAPIKeyMissing},
+		{name: "placeholder", apiKey: "demo", wantErr: config.ErrAPIKeyPlaceholder},
+		{name: "real key", apiKey: "ABCDEF123456"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("API_KEY", tc.apiKey)
+
+			err := config.NewConfig().Validate()
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMCPStreamsProgressBeforeToolCompletes(t *testing.T) {
+	release := make(chan struct{})
+	unblock := sync.OnceFunc(func() { close(release) })
+	defer unblock()
+
+	// The tool reports progress, then waits until the client has received it
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "slow"}, func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+		err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: req.Params.GetProgressToken(),
+			Message:       "fetching intraday bars",
+			Progress:      1,
+			Total:         2,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "done"}}}, nil, nil
+	})
+
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return server
+	}, nil)
+
+	// The production middleware stack, so etag and compression cannot buffer the stream
+	app := newTestApp(newTestConfig(), handler, nil)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	defer app.Shutdown()
+
+	progress := make(chan string, 1)
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params.Message
+		},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session, err := mcpClient.Connect(ctx, &mcp.StreamableClientTransport{
+		Endpoint: "http://" + listener.Addr().String() + "/mcp",
+	}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	type callResult struct {
+		result *mcp.CallToolResult
+		err    error
+	}
+	done := make(chan callResult, 1)
+	go func() {
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name: "slow",
+			Meta: mcp.Meta{"progressToken": "intraday"},
+		})
+		done <- callResult{result: result, err: err}
+	}()
+
+	select {
+	case message := <-progress:
+		assert.Equal(t, "fetching intraday bars", message)
+	case <-done:
+		t.Fatal("The tool call completed before its progress arrived")
+	case <-ctx.Done():
+		t.Fatal("Progress was not streamed while the tool was running")
+	}
+
+	unblock()
+	call := <-done
+	require.NoError(t, call.err)
+	require.Len(t, call.result.Content, 1)
+	assert.Equal(t, "done", call.result.Content[0].(*mcp.TextContent).Text)
+}
+
+// stdioHelperEnv makes the test binary run the server, for TestStdioTransport
+const stdioHelperEnv = "FINANCE_MCP_STDIO_HELPER"
+
+func TestStdioHelper(t *testing.T) {
+	if os.Getenv(stdioHelperEnv) != "1" {
+		t.Skip("Only runs as the server launched by TestStdioTransport")
+	}
+
+	main()
+}
+
+func TestStdioTransport(t *testing.T) {
+	// -test.v=false keeps test output off stdout, which carries the protocol
+	cmd := exec.Command(os.Args[0], "-test.run=^TestStdioHelper$", "-test.v=false")
+	cmd.Env = append(os.Environ(), stdioHelperEnv+"=1", "MCP_TRANSPORT=stdio", "API_KEY=test-key", "LOG_LEVEL=error")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := mcpClient.Connect(ctx, &mcp.CommandTransport{Command: cmd}, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Contains(t, names, "get_overview_stock")
+	assert.Contains(t, names, "get_intraday_price_stock")
+}
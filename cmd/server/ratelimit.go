@@ -0,0 +1,104 @@
+package main
+
+import (
+	"container/list"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/healthcheck"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// maxRateLimitedClients bounds how many client IPs the edge rate limiter
+// tracks. The least recently seen IP is forgotten first, so a flood of
+// spoofed addresses cannot grow memory without limit.
+const maxRateLimitedClients = 10000
+
+// ipRateLimiter keeps a token bucket per client IP
+type ipRateLimiter struct {
+	requestsPerMinute int
+	maxClients        int
+
+	mu sync.Mutex
+	// recent orders clients from most to least recently seen
+	recent  *list.List
+	clients map[string]*list.Element
+}
+
+// ipBucket is the element stored in ipRateLimiter.recent
+type ipBucket struct {
+	ip      string
+	limiter *request.RateLimiter
+}
+
+// newIPRateLimiter creates a limiter allowing each IP requestsPerMinute
+// requests per minute, tracking at most maxClients IPs
+func newIPRateLimiter(requestsPerMinute, maxClients int) *ipRateLimiter {
+	return &ipRateLimiter{
+		requestsPerMinute: requestsPerMinute,
+		maxClients:        maxClients,
+		recent:            list.New(),
+		clients:           make(map[string]*list.Element),
+	}
+}
+
+// limiter returns the bucket of ip, creating it and evicting the least
+// recently seen IP when the limit of tracked IPs is reached
+func (l *ipRateLimiter) limiter(ip string) *request.RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.clients[ip]; ok {
+		l.recent.MoveToFront(element)
+		return element.Value.(*ipBucket).limiter
+	}
+
+	if l.recent.Len() >= l.maxClients {
+		oldest := l.recent.Back()
+		l.recent.Remove(oldest)
+		delete(l.clients, oldest.Value.(*ipBucket).ip)
+	}
+
+	bucket := &ipBucket{ip: ip, limiter: request.NewRateLimiter(l.requestsPerMinute, 0)}
+	l.clients[ip] = l.recent.PushFront(bucket)
+	return bucket.limiter
+}
+
+// rateLimitMiddleware answers 429 Too Many Requests once a client IP exceeds
+// requestsPerMinute, whichever route it calls. Health checks are exempt so
+// probes keep working, and a non-positive requestsPerMinute disables it.
+//
+// Clients are told apart by the remote address of their connection, never by
+// X-Forwarded-For, which any caller can set to reset its budget.
+func rateLimitMiddleware(requestsPerMinute int) fiber.Handler {
+	if requestsPerMinute <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	limiters := newIPRateLimiter(requestsPerMinute, maxRateLimitedClients)
+
+	return func(c *fiber.Ctx) error {
+		if isHealthPath(c.Path()) {
+			return c.Next()
+		}
+
+		retryAfter, ok := limiters.limiter(c.Context().RemoteIP().String()).Allow()
+		if !ok {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			return fiber.NewError(fiber.StatusTooManyRequests, "Rate limit exceeded, retry later")
+		}
+
+		return c.Next()
+	}
+}
+
+// isHealthPath reports whether path is a liveness or readiness endpoint
+func isHealthPath(path string) bool {
+	return path == "/health" || strings.HasPrefix(path, "/health/") ||
+		path == healthcheck.DefaultLivenessEndpoint || path == healthcheck.DefaultReadinessEndpoint
+}
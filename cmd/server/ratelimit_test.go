@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	const limit = 3
+
+	cfg := newTestConfig()
+	cfg.RateLimitPerMinute = limit
+	app := newTestApp(cfg, http.NotFoundHandler(), nil)
+
+	for i := range limit {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/info", nil))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "request %d", i+1)
+	}
+
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/info", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+	// One token is added every 20 seconds
+	assert.Equal(t, "20", res.Header.Get("Retry-After"))
+
+	for _, path := range []string{"/health", "/health/live"} {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode, "%s must be exempt", path)
+	}
+}
+
+func TestRateLimitMiddleware_IgnoresForwardedFor(t *testing.T) {
+	const limit = 2
+
+	// As in production, c.IP() would read the client-supplied header
+	app := fiber.New(fiber.Config{DisableStartupMessage: true, ProxyHeader: fiber.HeaderXForwardedFor})
+	app.Use(rateLimitMiddleware(limit))
+	app.Get("/info", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	for i := range limit + 1 {
+		req := httptest.NewRequest(http.MethodGet, "/info", nil)
+		req.Header.Set(fiber.HeaderXForwardedFor, fmt.Sprintf("203.0.113.%d", i+1))
+
+		res, err := app.Test(req)
+		require.NoError(t, err)
+		if i < limit {
+			assert.Equal(t, http.StatusOK, res.StatusCode, "request %d", i+1)
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, res.StatusCode, "A spoofed X-Forwarded-For must not reset the budget")
+		}
+	}
+}
+
+func TestRateLimitMiddleware_Disabled(t *testing.T) {
+	app := newTestApp(newTestConfig(), http.NotFoundHandler(), nil)
+
+	for range 100 {
+		res, err := app.Test(httptest.NewRequest(http.MethodGet, "/info", nil))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	}
+}
+
+func TestIPRateLimiter_EvictsLeastRecentlySeen(t *testing.T) {
+	limiters := newIPRateLimiter(1, 2)
+
+	first := limiters.limiter("192.0.2.1")
+	_, ok := limiters.limiter("192.0.2.2").Allow()
+	require.True(t, ok)
+	assert.Same(t, first, limiters.limiter("192.0.2.1"))
+
+	// 192.0.2.2 is now the least recently seen
+	limiters.limiter("192.0.2.3")
+	assert.Len(t, limiters.clients, 2)
+	assert.Equal(t, 2, limiters.recent.Len())
+	assert.Contains(t, limiters.clients, "192.0.2.1")
+	assert.NotContains(t, limiters.clients, "192.0.2.2")
+
+	// An evicted IP starts again with a full bucket
+	_, ok = limiters.limiter("192.0.2.2").Allow()
+	assert.True(t, ok)
+}
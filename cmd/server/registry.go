@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// toolRegistration adds one tool to an MCP server. It lets tools with
+// different input and output types be listed together and registered
+// selectively.
+type toolRegistration struct {
+	name     string
+	register func(*mcp.Server)
+}
+
+// newToolRegistration captures a typed tool and its handler for registerTools
+func newToolRegistration[In, Out any](tool *mcp.Tool, handler mcp.ToolHandlerFor[In, Out]) toolRegistration {
+	return toolRegistration{
+		name: tool.Name,
+		register: func(server *mcp.Server) {
+			mcp.AddTool(server, tool, handler)
+		},
+	}
+}
+
+// registerTools adds the selected tools to server and returns the names of
+// the tools registered and skipped, in registration order.
+//
+// An empty enabled list selects every tool; disabled then removes tools from
+// the selection. Unknown names in either list are reported as an error
+// before any tool is registered, so a typo cannot silently expose or hide a
+// tool.
+func registerTools(server *mcp.Server, registrations []toolRegistration, enabled, disabled []string) (registered, skipped []string, err error) {
+	known := make([]string, 0, len(registrations))
+	for _, registration := range registrations {
+		known = append(known, registration.name)
+	}
+
+	for _, name := range slices.Concat(enabled, disabled) {
+		if !slices.Contains(known, name) {
+			return nil, nil, fmt.Errorf("unknown tool '%s': must be one of %s", name, strings.Join(known, ", "))
+		}
+	}
+
+	for _, registration := range registrations {
+		if (len(enabled) > 0 && !slices.Contains(enabled, registration.name)) || slices.Contains(disabled, registration.name) {
+			skipped = append(skipped, registration.name)
+			continue
+		}
+
+		registration.register(server)
+		registered = append(registered, registration.name)
+	}
+
+	return registered, skipped, nil
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/tools"
+)
+
+type symbolInput struct {
+	Symbol string `json:"symbol"`
+}
+
+func testRegistrations() []toolRegistration {
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, in symbolInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{}, nil, nil
+	}
+
+	return []toolRegistration{
+		newToolRegistration(&mcp.Tool{Name: "get_overview_stock"}, handler),
+		newToolRegistration(&mcp.Tool{Name: "get_intraday_price_stock"}, handler),
+		newToolRegistration(&mcp.Tool{Name: "get_earnings"}, handler),
+	}
+}
+
+// listedTools returns the names of the tools server advertises to clients
+func listedTools(t *testing.T, server *mcp.Server) []string {
+	t.Helper()
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.ListTools(ctx, nil)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestRegisterTools(t *testing.T) {
+	testCases := []struct {
+		name           string
+		enabled        []string
+		disabled       []string
+		wantRegistered []string
+		wantSkipped    []string
+	}{
+		{
+			name:           "all by default",
+			wantRegistered: []string{"get_overview_stock", "get_intraday_price_stock", "get_earnings"},
+		},
+		{
+			name:           "allowlist",
+			enabled:        []string{"get_overview_stock"},
+			wantRegistered: []string{"get_overview_stock"},
+			wantSkipped:    []string{"get_intraday_price_stock", "get_earnings"},
+		},
+		{
+			name:           "denylist",
+			disabled:       []string{"get_intraday_price_stock"},
+			wantRegistered: []string{"get_overview_stock", "get_earnings"},
+			wantSkipped:    []string{"get_intraday_price_stock"},
+		},
+		{
+			name:           "denylist overrides allowlist",
+			enabled:        []string{"get_overview_stock", "get_earnings"},
+			disabled:       []string{"get_earnings"},
+			wantRegistered: []string{"get_overview_stock"},
+			wantSkipped:    []string{"get_intraday_price_stock", "get_earnings"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+
+			registered, skipped, err := registerTools(server, testRegistrations(), tc.enabled, tc.disabled)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRegistered, registered)
+			assert.Equal(t, tc.wantSkipped, skipped)
+			assert.ElementsMatch(t, tc.wantRegistered, listedTools(t, server))
+		})
+	}
+}
+
+func TestRegisterTools_UnknownTool(t *testing.T) {
+	for _, lists := range [][2][]string{
+		{{"get_overveiw_stock"}, nil},
+		{nil, {"get_intraday"}},
+	} {
+		server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+
+		_, _, err := registerTools(server, testRegistrations(), lists[0], lists[1])
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown tool")
+		assert.Empty(t, listedTools(t, server), "No tool may be registered when the selection is invalid")
+	}
+}
+
+func TestRegisterTools_InputSchemaRejectsInvalidValues(t *testing.T) {
+	intradayTool := tools.NewIntradayPriceStock("https://www.alphavantage.co", "test-key")
+	defer intradayTool.Close()
+
+	called := false
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, in models.IntradayPriceInput) (*mcp.CallToolResult, models.IntradayStockOutput, error) {
+		called = true
+		return nil, models.IntradayStockOutput{}, nil
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	_, _, err := registerTools(server, []toolRegistration{
+		newToolRegistration(&mcp.Tool{Name: "get_intraday_price_stock", InputSchema: intradayTool.InputSchema()}, handler),
+	}, nil, nil)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err = server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	_, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_intraday_price_stock",
+		Arguments: map[string]any{"symbol": "AAPL", "interval": "2min"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "interval")
+	assert.False(t, called, "Invalid arguments must be rejected before the handler runs")
+}
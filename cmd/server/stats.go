@@ -0,0 +1,85 @@
+package main
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+)
+
+// statsReporter is implemented by tools that expose HTTP client statistics
+type statsReporter interface {
+	GetStats() client.ClientStats
+}
+
+// clientStatsJSON is the JSON form of client statistics, with latencies in milliseconds
+type clientStatsJSON struct {
+	TotalRequests      int64   `json:"totalRequests"`
+	SuccessfulRequests int64   `json:"successfulRequests"`
+	FailedRequests     int64   `json:"failedRequests"`
+	AverageLatencyMs   float64 `json:"averageLatencyMs"`
+	P50LatencyMs       float64 `json:"p50LatencyMs,omitempty"`
+	P95LatencyMs       float64 `json:"p95LatencyMs,omitempty"`
+	ConnectionsActive  int     `json:"connectionsActive"`
+	CircuitState       string  `json:"circuitState,omitempty"`
+}
+
+// statsResponse is the body served by /stats
+type statsResponse struct {
+	Total clientStatsJSON            `json:"total"`
+	Tools map[string]clientStatsJSON `json:"tools"`
+}
+
+// milliseconds converts a duration to fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// aggregateStats collects the statistics of every tool and sums them into a
+// total. Average latencies cover successful requests only, so the total
+// average is weighted by each tool's successful request count; percentiles
+// cannot be combined, so they are only reported per tool.
+func aggregateStats(reporters map[string]statsReporter) statsResponse {
+	response := statsResponse{
+		Tools: make(map[string]clientStatsJSON, len(reporters)),
+	}
+
+	var totalLatency time.Duration
+	for name, reporter := range reporters {
+		stats := reporter.GetStats()
+		response.Tools[name] = clientStatsJSON{
+			TotalRequests:      stats.TotalRequests,
+			SuccessfulRequests: stats.SuccessfulRequests,
+			FailedRequests:     stats.FailedRequests,
+			AverageLatencyMs:   milliseconds(stats.AverageLatency),
+			P50LatencyMs:       milliseconds(stats.P50Latency),
+			P95LatencyMs:       milliseconds(stats.P95Latency),
+			ConnectionsActive:  stats.ConnectionsActive,
+			CircuitState:       stats.CircuitState.String(),
+		}
+
+		response.Total.TotalRequests += stats.TotalRequests
+		response.Total.SuccessfulRequests += stats.SuccessfulRequests
+		response.Total.FailedRequests += stats.FailedRequests
+		response.Total.ConnectionsActive += stats.ConnectionsActive
+		totalLatency += stats.AverageLatency * time.Duration(stats.SuccessfulRequests)
+	}
+
+	if response.Total.SuccessfulRequests > 0 {
+		response.Total.AverageLatencyMs = milliseconds(totalLatency / time.Duration(response.Total.SuccessfulRequests))
+	}
+
+	return response
+}
+
+// statsHandler serves the aggregated client statistics of the tools. When
+// token is set, requests must send it as "Authorization: Bearer <token>".
+func statsHandler(reporters map[string]statsReporter, token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token != "" && !hasBearerToken(c, token) {
+			return fiber.NewError(fiber.StatusUnauthorized, "A valid stats token is required")
+		}
+
+		return c.JSON(aggregateStats(reporters))
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+)
+
+// fakeReporter reports fixed client statistics
+type fakeReporter client.ClientStats
+
+func (f fakeReporter) GetStats() client.ClientStats {
+	return client.ClientStats(f)
+}
+
+func newStatsApp(token string) *fiber.App {
+	app := fiber.New()
+	app.Get("/stats", statsHandler(map[string]statsReporter{
+		"get_overview_stock": fakeReporter{
+			TotalRequests:      3,
+			SuccessfulRequests: 2,
+			FailedRequests:     1,
+			AverageLatency:     100 * time.Millisecond,
+			P95Latency:         250 * time.Millisecond,
+		},
+		"get_intraday_price_stock": fakeReporter{
+			TotalRequests:      1,
+			SuccessfulRequests: 1,
+			AverageLatency:     500 * time.Millisecond,
+			CircuitState:       client.CircuitOpen,
+		},
+		"get_earnings": fakeReporter{},
+	}, token))
+	return app
+}
+
+func TestStatsHandler(t *testing.T) {
+	res, err := newStatsApp("").Test(httptest.NewRequest(http.MethodGet, "/stats", nil))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var body statsResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+
+	assert.Equal(t, int64(4), body.Total.TotalRequests)
+	assert.Equal(t, int64(3), body.Total.SuccessfulRequests)
+	assert.Equal(t, int64(1), body.Total.FailedRequests)
+	// (2*100ms + 1*500ms) / 3 successful requests
+	assert.InDelta(t, 233.333, body.Total.AverageLatencyMs, 0.001)
+
+	require.Len(t, body.Tools, 3)
+	assert.Equal(t, 250.0, body.Tools["get_overview_stock"].P95LatencyMs)
+	assert.Equal(t, "open", body.Tools["get_intraday_price_stock"].CircuitState)
+	assert.Zero(t, body.Tools["get_earnings"].AverageLatencyMs)
+}
+
+func TestAggregateStats_FailedRequests(t *testing.T) {
+	stats := aggregateStats(map[string]statsReporter{
+		"get_overview_stock": fakeReporter{
+			TotalRequests:      10,
+			SuccessfulRequests: 2,
+			FailedRequests:     8,
+			AverageLatency:     100 * time.Millisecond,
+		},
+		"get_global_quote": fakeReporter{
+			TotalRequests:      2,
+			SuccessfulRequests: 2,
+			AverageLatency:     400 * time.Millisecond,
+		},
+		"get_earnings": fakeReporter{
+			TotalRequests:  5,
+			FailedRequests: 5,
+		},
+	})
+
+	// Failed requests have no latency: (2*100ms + 2*400ms) / 4 successful requests
+	assert.Equal(t, 250.0, stats.Total.AverageLatencyMs)
+	assert.Equal(t, int64(13), stats.Total.FailedRequests)
+}
+
+func TestStatsHandler_Token(t *testing.T) {
+	app := newStatsApp("secret")
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "missing token", wantStatus: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer nope", wantStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", authorization: "secret", wantStatus: http.StatusUnauthorized},
+		{name: "valid token", authorization: "Bearer secret", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			res, err := app.Test(req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, res.StatusCode)
+		})
+	}
+}
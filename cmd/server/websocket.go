@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/jsonrpc"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yeferson59/finance-mcp/internal/config"
+	"github.com/yeferson59/finance-mcp/pkg/logging"
+	"golang.org/x/net/websocket"
+)
+
+// websocketHandler serves MCP sessions over WebSocket, one session per
+// connection, for clients that keep a connection open across many tool
+// calls instead of sending a request per call.
+func websocketHandler(server *mcp.Server, cfg *config.Config) http.Handler {
+	return websocket.Server{
+		// Non-browser MCP clients send no Origin. Browsers always do, so
+		// theirs must be allowed by CORS_ALLOWED_ORIGINS, or any web page
+		// could open a session against a local server.
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			origin := r.Header.Get("Origin")
+			if origin != "" && !cfg.AllowsOrigin(origin) {
+				return fmt.Errorf("websocket origin '%s' is not allowed", origin)
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = maxRequestBodySize
+
+			ctx := ws.Request().Context()
+			session, err := server.Connect(ctx, newWebsocketTransport(ws), nil)
+			if err != nil {
+				logging.FromContext(ctx).ErrorContext(ctx, "websocket session failed to start", "error", err)
+				return
+			}
+
+			_ = session.Wait()
+		},
+	}
+}
+
+// websocketTransport is an mcp.Transport carrying each JSON-RPC message in
+// its own text frame
+type websocketTransport struct {
+	ws *websocket.Conn
+}
+
+// newWebsocketTransport creates a transport over an accepted connection
+func newWebsocketTransport(ws *websocket.Conn) *websocketTransport {
+	return &websocketTransport{ws: ws}
+}
+
+// Connect implements mcp.Transport
+func (t *websocketTransport) Connect(context.Context) (mcp.Connection, error) {
+	conn := &websocketConn{
+		ws:       t.ws,
+		incoming: make(chan websocketFrame),
+		closed:   make(chan struct{}),
+	}
+	go conn.readFrames()
+
+	return conn, nil
+}
+
+// websocketFrame is a received frame or the error that ended reading
+type websocketFrame struct {
+	data []byte
+	err  error
+}
+
+// websocketConn is an mcp.Connection over a WebSocket. Frames are read by a
+// single goroutine, so Read can honor its context and be unblocked by Close.
+type websocketConn struct {
+	ws       *websocket.Conn
+	incoming chan websocketFrame
+
+	closeOnce sync.Once
+	closed    chan struct{}
+
+	// writeMu serializes frames, since Write may be called concurrently
+	writeMu sync.Mutex
+}
+
+// readFrames forwards received frames to Read until the connection fails
+func (c *websocketConn) readFrames() {
+	for {
+		var frame websocketFrame
+		frame.err = websocket.Message.Receive(c.ws, &frame.data)
+
+		select {
+		case c.incoming <- frame:
+		case <-c.closed:
+			return
+		}
+
+		if frame.err != nil {
+			return
+		}
+	}
+}
+
+// Read implements mcp.Connection
+func (c *websocketConn) Read(ctx context.Context) (jsonrpc.Message, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.closed:
+		return nil, io.EOF
+	case frame := <-c.incoming:
+		if frame.err != nil {
+			return nil, frame.err
+		}
+
+		msg, err := jsonrpc.DecodeMessage(frame.data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode websocket message: %w", err)
+		}
+		return msg, nil
+	}
+}
+
+// Write implements mcp.Connection
+func (c *websocketConn) Write(ctx context.Context, msg jsonrpc.Message) error {
+	data, err := jsonrpc.EncodeMessage(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode websocket message: %w", err)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Sending a string writes a text frame
+	return websocket.Message.Send(c.ws, string(data))
+}
+
+// Close implements mcp.Connection
+func (c *websocketConn) Close() error {
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		err = c.ws.Close()
+	})
+	return err
+}
+
+// SessionID implements mcp.Connection. WebSocket sessions are identified by
+// their connection, so there is no session ID.
+func (c *websocketConn) SessionID() string {
+	return ""
+}
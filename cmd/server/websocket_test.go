@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/config"
+	"golang.org/x/net/websocket"
+)
+
+type echoInput struct {
+	Text string `json:"text"`
+}
+
+// startWebsocketServer serves an MCP server with an echo tool, with the
+// WebSocket transport enabled, and returns its address
+func startWebsocketServer(t *testing.T, cfg *config.Config) string {
+	t.Helper()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "echo"}, func(ctx context.Context, req *mcp.CallToolRequest, in echoInput) (*mcp.CallToolResult, any, error) {
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: in.Text}}}, nil, nil
+	})
+
+	app := newTestApp(cfg, http.NotFoundHandler(), websocketHandler(server, cfg))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	t.Cleanup(func() { _ = app.Shutdown() })
+
+	return listener.Addr().String()
+}
+
+// dialWebsocket opens a WebSocket to /mcp/ws from origin, sending
+// authorization when set
+func dialWebsocket(addr, origin, authorization string) (*websocket.Conn, error) {
+	config, err := websocket.NewConfig("ws://"+addr+"/mcp/ws", origin)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		config.Header.Set(fiber.HeaderAuthorization, authorization)
+	}
+
+	return websocket.DialConfig(config)
+}
+
+func TestWebsocketTransport(t *testing.T) {
+	addr := startWebsocketServer(t, newTestConfig())
+
+	ws, err := dialWebsocket(addr, "http://localhost", "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// The transport is symmetric, so the client uses it too
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, newWebsocketTransport(ws), nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	for _, text := range []string{"IBM", "AAPL"} {
+		result, err := session.CallTool(ctx, &mcp.CallToolParams{
+			Name:      "echo",
+			Arguments: map[string]any{"text": text},
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Content, 1)
+		assert.Equal(t, text, result.Content[0].(*mcp.TextContent).Text)
+	}
+}
+
+func TestWebsocketTransport_AuthToken(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.AuthToken = "secret"
+	addr := startWebsocketServer(t, cfg)
+
+	_, err := dialWebsocket(addr, "http://localhost", "")
+	require.Error(t, err, "Connections without the token must be rejected")
+
+	ws, err := dialWebsocket(addr, "http://localhost", "Bearer secret")
+	require.NoError(t, err)
+	ws.Close()
+}
+
+func TestWebsocketTransport_Origin(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.CORSAllowedOrigins = "https://app.example.com, https://*.example.org"
+	addr := startWebsocketServer(t, cfg)
+
+	testCases := []struct {
+		origin  string
+		allowed bool
+	}{
+		{origin: "https://app.example.com", allowed: true},
+		{origin: "https://agent.example.org", allowed: true},
+		{origin: "https://evil.example.net", allowed: false},
+		{origin: "http://app.example.com", allowed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.origin, func(t *testing.T) {
+			ws, err := dialWebsocket(addr, tc.origin, "")
+			if !tc.allowed {
+				assert.Error(t, err, "Origins outside CORS_ALLOWED_ORIGINS must be refused")
+				return
+			}
+			require.NoError(t, err)
+			ws.Close()
+		})
+	}
+
+	// Non-browser clients send no Origin at all
+	req, err := http.NewRequest(http.MethodGet, "http://"+addr+"/mcp/ws", nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+}
+
+func TestWebsocketTransport_Disabled(t *testing.T) {
+	app := newTestApp(newTestConfig(), http.NotFoundHandler(), nil)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	defer app.Shutdown()
+
+	_, err = dialWebsocket(listener.Addr().String(), "http://localhost", "")
+	assert.Error(t, err, "Without a WebSocket handler /mcp/ws must not upgrade")
+}
@@ -0,0 +1,37 @@
+// Package clock abstracts the passage of time, so the rate limiter, response
+// cache, retry backoff and staleness checks can be tested without sleeping.
+package clock
+
+import "time"
+
+// Clock tells the current time and waits for durations to pass
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has passed
+	After(d time.Duration) <-chan time.Time
+}
+
+// Real is the Clock of the time package
+var Real Clock = realClock{}
+
+// OrReal returns c, or Real when c is nil, so a Clock can be left unset in
+// configurations
+func OrReal(c Clock) Clock {
+	if c == nil {
+		return Real
+	}
+
+	return c
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
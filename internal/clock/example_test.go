@@ -0,0 +1,31 @@
+package clock_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// A fake clock lets a test spend a rate limiter's quota and wait out the
+// refill without sleeping.
+func ExampleFake() {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	limiter := request.NewRateLimiter(2, 0).WithClock(fake)
+
+	for range 3 {
+		retryAfter, ok := limiter.Allow()
+		fmt.Println(ok, retryAfter)
+	}
+
+	fake.Advance(30 * time.Second)
+	_, ok := limiter.Allow()
+	fmt.Println(ok)
+
+	// Output:
+	// true 0s
+	// true 0s
+	// false 30s
+	// true
+}
@@ -0,0 +1,51 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake_After(t *testing.T) {
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	soon := fake.After(time.Second)
+	later := fake.After(time.Minute)
+	assert.Equal(t, 2, fake.Waiters())
+
+	fake.Advance(30 * time.Second)
+	assert.Equal(t, start.Add(30*time.Second), fake.Now())
+	select {
+	case fired := <-soon:
+		assert.Equal(t, start.Add(30*time.Second), fired)
+	default:
+		t.Fatal("Expected the due channel to fire")
+	}
+	select {
+	case <-later:
+		t.Fatal("Expected the channel not yet due to wait")
+	default:
+	}
+	assert.Equal(t, 1, fake.Waiters())
+
+	fake.Advance(30 * time.Second)
+	assert.Len(t, later, 1)
+	assert.Zero(t, fake.Waiters())
+}
+
+func TestFake_AfterNonPositive(t *testing.T) {
+	fake := NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+
+	assert.Len(t, fake.After(0), 1)
+	assert.Len(t, fake.After(-time.Second), 1)
+	assert.Zero(t, fake.Waiters())
+}
+
+func TestOrReal(t *testing.T) {
+	assert.Equal(t, Real, OrReal(nil))
+
+	fake := NewFake(time.Time{})
+	assert.Equal(t, Clock(fake), OrReal(fake))
+}
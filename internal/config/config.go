@@ -1,13 +1,133 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// Transports the server can serve MCP over
+const (
+	TransportHTTP  = "http"
+	TransportStdio = "stdio"
+)
+
+// Errors returned by Config.Validate
+var (
+	ErrAPIKeyMissing     = errors.New("API_KEY is not set")
+	ErrAPIKeyPlaceholder = errors.New("API_KEY is the 'demo' or example placeholder key")
 )
 
+// placeholderAPIKeys are keys that only work for a few documented example symbols
+var placeholderAPIKeys = []string{"demo", "your_alpha_vantage_api_key_here"}
+
 type Config struct {
 	APIURL         string              `json:"apiURL"`
 	APIKey         string              `json:"apiKey"`
+	RequireAPIKey  bool                `json:"requireAPIKey"`
 	Implementation *mcp.Implementation `json:"implementation"`
+
+	// Transport is how MCP is served: TransportHTTP or TransportStdio
+	Transport string `json:"transport"`
+
+	// Listen address; an empty Host listens on all interfaces
+	Host string `json:"host"`
+	Port string `json:"port"`
+
+	// HTTP server timeouts
+	ReadTimeout  time.Duration `json:"readTimeout"`
+	WriteTimeout time.Duration `json:"writeTimeout"`
+	IdleTimeout  time.Duration `json:"idleTimeout"`
+
+	// Defaults for intraday requests that omit interval or outputSize; empty
+	// keeps interval required and lets Alpha Vantage pick the output size
+	DefaultInterval   string `json:"defaultInterval"`
+	DefaultOutputSize string `json:"defaultOutputSize"`
+
+	// IntervalOutputSizes sets the default output size per intraday
+	// interval, taking precedence over DefaultOutputSize; nil keeps the
+	// built-in request.DefaultIntervalOutputSizes
+	IntervalOutputSizes map[string]string `json:"intervalOutputSizes"`
+
+	// DefaultAdjusted is "true" or "false" to always send adjusted on
+	// intraday requests that omit it; empty leaves it to Alpha Vantage
+	DefaultAdjusted string `json:"defaultAdjusted"`
+
+	// StaleAfter is the age beyond which intraday bars and quotes are
+	// reported as stale
+	StaleAfter time.Duration `json:"staleAfter"`
+
+	// Symbol validation limits; see validation.SymbolRules
+	SymbolMaxLength   int    `json:"symbolMaxLength"`
+	SymbolPunctuation string `json:"symbolPunctuation"`
+
+	// HTTPAcceptEncoding overrides the Accept-Encoding of upstream requests,
+	// e.g. "identity" to receive uncompressed payloads; empty negotiates
+	// gzip, deflate and brotli
+	HTTPAcceptEncoding string `json:"httpAcceptEncoding"`
+
+	// UserAgent identifies this deployment in upstream requests
+	UserAgent string `json:"userAgent"`
+
+	// MaxConcurrentRequests bounds the upstream requests the tools have in
+	// flight at once; non-positive places no bound
+	MaxConcurrentRequests int `json:"maxConcurrentRequests"`
+
+	// UpstreamRequestsPerMinute and UpstreamRequestsPerDay are the Alpha
+	// Vantage quota shared by all tools; zero, the default, leaves that
+	// window unlimited
+	UpstreamRequestsPerMinute int `json:"upstreamRequestsPerMinute"`
+	UpstreamRequestsPerDay    int `json:"upstreamRequestsPerDay"`
+
+	// CompressMinSize is the smallest response body, in bytes, compressed for
+	// clients that accept it; negative disables response compression
+	CompressMinSize int `json:"compressMinSize"`
+
+	// ReadinessCacheTTL is how long a /health/ready upstream check is reused;
+	// checks only ping Alpha Vantage except for periodic key validations
+	ReadinessCacheTTL time.Duration `json:"readinessCacheTTL"`
+
+	// CORSAllowedOrigins is "*" or a comma-separated list of origins, such
+	// as "https://app.example.com", allowed to call the server from browsers
+	CORSAllowedOrigins string `json:"corsAllowedOrigins"`
+
+	// EnabledTools lists the tools to register, all of them when empty;
+	// DisabledTools are then left out
+	EnabledTools  []string `json:"enabledTools"`
+	DisabledTools []string `json:"disabledTools"`
+
+	// EnableWebSocket serves MCP sessions over WebSocket at /mcp/ws in
+	// addition to streamable HTTP
+	EnableWebSocket bool `json:"enableWebSocket"`
+
+	// RateLimitPerMinute caps requests per minute from each client IP,
+	// except health checks; zero disables the limit
+	RateLimitPerMinute int `json:"rateLimitPerMinute"`
+
+	// AuthToken, when set, must be sent as a bearer token on MCP requests
+	AuthToken string `json:"authToken"`
+
+	// StatsToken, when set, must be sent as a bearer token to read /stats
+	StatsToken string `json:"statsToken"`
+
+	// LogLevel is the minimum level of emitted logs: debug, info, warn or error
+	LogLevel string `json:"logLevel"`
+
+	// OTelEnabled turns on OpenTelemetry tracing exported over OTLP/HTTP
+	OTelEnabled bool `json:"otelEnabled"`
+
+	// ShutdownTimeout is how long in-flight requests may run after SIGINT/SIGTERM
+	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 }
 
 func NewConfig() *Config {
@@ -15,15 +135,210 @@ func NewConfig() *Config {
 	_ = env.loadEnv()
 
 	apiURL := env.GetEnv("API_URL", "https://www.alphavantage.co")
-	apiKey := env.GetEnv("API_KEY", "demo")
+	// A missing key stays empty so Validate reports it apart from the
+	// "demo" placeholder
+	apiKey := env.GetEnv("API_KEY", "")
 
 	return &Config{
-		APIURL: apiURL,
-		APIKey: apiKey,
+		APIURL:        apiURL,
+		APIKey:        apiKey,
+		RequireAPIKey: env.GetEnvBool("REQUIRE_API_KEY", false),
 		Implementation: &mcp.Implementation{
 			Title:   env.GetEnv("TITLE", "finance-mcp"),
 			Name:    env.GetEnv("NAME", "Market-mcp"),
 			Version: env.GetEnv("VERSION", "v1.0.0"),
 		},
+		Transport:    strings.ToLower(env.GetEnv("MCP_TRANSPORT", TransportHTTP)),
+		Host:         env.GetEnv("HOST", ""),
+		Port:         env.GetEnv("PORT", "8080"),
+		ReadTimeout:  env.GetEnvDuration("SERVER_READ_TIMEOUT", 30*time.Second),
+		WriteTimeout: env.GetEnvDuration("SERVER_WRITE_TIMEOUT", 30*time.Second),
+		IdleTimeout:  env.GetEnvDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+
+		DefaultInterval:     env.GetEnv("DEFAULT_INTERVAL", ""),
+		DefaultOutputSize:   env.GetEnv("DEFAULT_OUTPUT_SIZE", ""),
+		IntervalOutputSizes: parseIntervalOutputSizes(env.GetEnvList("INTERVAL_OUTPUT_SIZES")),
+		DefaultAdjusted:     strings.ToLower(strings.TrimSpace(env.GetEnv("DEFAULT_ADJUSTED", ""))),
+		StaleAfter:          env.GetEnvDuration("STALE_AFTER", request.DefaultStaleAfter),
+
+		SymbolMaxLength:   env.GetEnvInt("SYMBOL_MAX_LENGTH", validation.DefaultSymbolRules.MaxLength),
+		SymbolPunctuation: env.GetEnv("SYMBOL_PUNCTUATION", validation.DefaultSymbolRules.Punctuation),
+
+		HTTPAcceptEncoding: env.GetEnv("HTTP_ACCEPT_ENCODING", ""),
+		UserAgent:          env.GetEnv("USER_AGENT", request.DefaultUserAgent),
+		CompressMinSize:    env.GetEnvInt("COMPRESS_MIN_SIZE", 1024),
+
+		MaxConcurrentRequests:     env.GetEnvInt("MAX_CONCURRENT_REQUESTS", 0),
+		UpstreamRequestsPerMinute: env.GetEnvInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 0),
+		UpstreamRequestsPerDay:    env.GetEnvInt("RATE_LIMIT_REQUESTS_PER_DAY", 0),
+
+		ReadinessCacheTTL: env.GetEnvDuration("READINESS_CACHE_TTL", 5*time.Minute),
+		AuthToken:         env.GetEnv("AUTH_TOKEN", ""),
+		StatsToken:        env.GetEnv("STATS_TOKEN", ""),
+
+		EnabledTools:       env.GetEnvList("ENABLED_TOOLS"),
+		DisabledTools:      env.GetEnvList("DISABLED_TOOLS"),
+		EnableWebSocket:    env.GetEnvBool("ENABLE_WEBSOCKET", false),
+		RateLimitPerMinute: env.GetEnvInt("RATE_LIMIT_PER_MINUTE", 0),
+		CORSAllowedOrigins: strings.TrimSpace(env.GetEnv("CORS_ALLOWED_ORIGINS", "*")),
+
+		LogLevel:        env.GetEnv("LOG_LEVEL", "info"),
+		OTelEnabled:     env.GetEnvBool("OTEL_ENABLED", false),
+		ShutdownTimeout: env.GetEnvDuration("SERVER_SHUTDOWN_TIMEOUT", 15*time.Second),
+	}
+}
+
+// ListenAddress returns the host:port address the server listens on.
+//
+// Returns an error if Port is not a number between 1 and 65535.
+func (c *Config) ListenAddress() (string, error) {
+	port, err := strconv.Atoi(c.Port)
+	if err != nil || port < 1 || port > 65535 {
+		return "", fmt.Errorf("invalid PORT '%s': must be a number between 1 and 65535", c.Port)
+	}
+
+	return net.JoinHostPort(c.Host, strconv.Itoa(port)), nil
+}
+
+// Validate checks that a real Alpha Vantage API key is configured.
+//
+// Returns ErrAPIKeyMissing or ErrAPIKeyPlaceholder so callers can decide
+// whether to warn or abort; RequireAPIKey reports which the operator wants.
+func (c *Config) Validate() error {
+	if strings.TrimSpace(c.APIKey) == "" {
+		return ErrAPIKeyMissing
+	}
+
+	if slices.Contains(placeholderAPIKeys, c.APIKey) {
+		return ErrAPIKeyPlaceholder
+	}
+
+	return nil
+}
+
+// SymbolRules returns the configured symbol validation rules
+func (c *Config) SymbolRules() validation.SymbolRules {
+	return validation.SymbolRules{
+		MaxLength:   c.SymbolMaxLength,
+		Punctuation: c.SymbolPunctuation,
+	}
+}
+
+// ValidateTransport checks that Transport is TransportHTTP or TransportStdio
+func (c *Config) ValidateTransport() error {
+	switch c.Transport {
+	case TransportHTTP, TransportStdio:
+		return nil
+	}
+
+	return fmt.Errorf("MCP_TRANSPORT: unknown transport '%s': must be %s or %s", c.Transport, TransportHTTP, TransportStdio)
+}
+
+// ValidateIntradayDefaults checks that the configured default interval,
+// output sizes and adjusted flag, when set, are values the intraday tool
+// accepts.
+func (c *Config) ValidateIntradayDefaults() error {
+	if c.DefaultInterval != "" {
+		if err := validation.ValidateInterval(c.DefaultInterval); err != nil {
+			return fmt.Errorf("DEFAULT_INTERVAL: %w", err)
+		}
 	}
+
+	if c.DefaultOutputSize != "" {
+		if err := validation.ValidateOutputSize(c.DefaultOutputSize); err != nil {
+			return fmt.Errorf("DEFAULT_OUTPUT_SIZE: %w", err)
+		}
+	}
+
+	for interval, outputSize := range c.IntervalOutputSizes {
+		if err := validation.ValidateInterval(interval); err != nil {
+			return fmt.Errorf("INTERVAL_OUTPUT_SIZES: %w", err)
+		}
+		if err := validation.ValidateOutputSize(outputSize); err != nil {
+			return fmt.Errorf("INTERVAL_OUTPUT_SIZES: %s: %w", interval, err)
+		}
+	}
+
+	if c.DefaultAdjusted != "" && c.IntradayAdjusted() == nil {
+		return fmt.Errorf("DEFAULT_ADJUSTED: invalid value '%s': must be true or false", c.DefaultAdjusted)
+	}
+
+	return nil
+}
+
+// IntradayAdjusted returns DefaultAdjusted as a flag, or nil when it is
+// empty or not a valid boolean
+func (c *Config) IntradayAdjusted() *bool {
+	if c.DefaultAdjusted == "" {
+		return nil
+	}
+
+	adjusted, err := strconv.ParseBool(c.DefaultAdjusted)
+	if err != nil {
+		return nil
+	}
+
+	return &adjusted
+}
+
+// parseIntervalOutputSizes parses "interval:outputSize" entries such as
+// "1min:full" into a map. Malformed entries are kept with an empty output
+// size for ValidateIntradayDefaults to report.
+func parseIntervalOutputSizes(entries []string) map[string]string {
+	if entries == nil {
+		return nil
+	}
+
+	sizes := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		interval, outputSize, _ := strings.Cut(entry, ":")
+		sizes[strings.TrimSpace(interval)] = strings.TrimSpace(outputSize)
+	}
+
+	return sizes
+}
+
+// ValidateCORSOrigins checks that CORSAllowedOrigins is "*" or a list of
+// origins made of a scheme and host, optionally with a "*." subdomain
+// wildcard such as "https://*.example.com".
+func (c *Config) ValidateCORSOrigins() error {
+	if c.CORSAllowedOrigins == "*" {
+		return nil
+	}
+
+	for origin := range strings.SplitSeq(c.CORSAllowedOrigins, ",") {
+		origin = strings.TrimSpace(origin)
+		host := strings.Replace(origin, "://*.", "://", 1)
+
+		parsed, err := url.Parse(host)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" || strings.Contains(parsed.Host, "*") ||
+			(parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" {
+			return fmt.Errorf("CORS_ALLOWED_ORIGINS: invalid origin '%s': must be '*' or a scheme and host such as https://app.example.com", origin)
+		}
+	}
+
+	return nil
+}
+
+// AllowsOrigin reports whether origin matches CORSAllowedOrigins, either
+// exactly or as a subdomain of a "*." wildcard entry, the way CORS does
+func (c *Config) AllowsOrigin(origin string) bool {
+	if c.CORSAllowedOrigins == "*" {
+		return true
+	}
+
+	origin = strings.ToLower(strings.TrimSpace(origin))
+	for allowed := range strings.SplitSeq(c.CORSAllowedOrigins, ",") {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if origin == allowed {
+			return true
+		}
+
+		scheme, suffix, ok := strings.Cut(allowed, "://*.")
+		if ok && strings.HasPrefix(origin, scheme+"://") && strings.HasSuffix(origin, "."+suffix) {
+			return true
+		}
+	}
+
+	return false
 }
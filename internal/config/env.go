@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/yeferson59/finance-mcp/pkg/file"
@@ -34,3 +37,77 @@ func (e *Env) GetEnv(key string, defaultValue string) string {
 	log.Println("[ENV] Environment variable not found:", key)
 	return defaultValue
 }
+
+// GetEnvDuration reads a duration such as "30s" or "2m" from the environment,
+// falling back to defaultValue when the variable is unset or invalid.
+func (e *Env) GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		log.Println("[ENV] Environment variable not found:", key)
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("[ENV] Invalid duration for %s: %q, using %s", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return duration
+}
+
+// GetEnvInt reads an integer from the environment, falling back to
+// defaultValue when the variable is unset or invalid.
+func (e *Env) GetEnvInt(key string, defaultValue int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		log.Println("[ENV] Environment variable not found:", key)
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("[ENV] Invalid integer for %s: %q, using %d", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// GetEnvBool reads a boolean such as "true" or "1" from the environment,
+// falling back to defaultValue when the variable is unset or invalid.
+func (e *Env) GetEnvBool(key string, defaultValue bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		log.Println("[ENV] Environment variable not found:", key)
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("[ENV] Invalid boolean for %s: %q, using %t", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// GetEnvList reads a comma-separated list from the environment, trimming
+// spaces and dropping empty entries. It returns nil when the variable is
+// unset or empty.
+func (e *Env) GetEnvList(key string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		log.Println("[ENV] Environment variable not found:", key)
+		return nil
+	}
+
+	var list []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -0,0 +1,125 @@
+package filter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// DateRange is an inclusive window over bar timestamps.
+//
+// Bounds given as a date (YYYY-MM-DD) cover whole days in each bar's own
+// time zone, so "2024-01-15" selects that trading day's bars wherever the
+// exchange is. RFC3339 bounds compare exact instants. A nil bound leaves
+// that side of the window open.
+type DateRange struct {
+	from *dateBound
+	to   *dateBound
+}
+
+// dateBound is one side of a DateRange
+type dateBound struct {
+	// instant is the parsed bound; midnight UTC for date bounds
+	instant time.Time
+
+	// date is set for YYYY-MM-DD bounds
+	date string
+}
+
+// NewDateRange parses the from and to bounds, either of which may be nil.
+//
+// Returns an error if a bound is neither YYYY-MM-DD nor RFC3339, or if from
+// is after to.
+func NewDateRange(from, to *string) (DateRange, error) {
+	var (
+		r   DateRange
+		err error
+	)
+
+	if from != nil {
+		if r.from, err = parseDateBound(*from); err != nil {
+			return DateRange{}, err
+		}
+	}
+
+	if to != nil {
+		if r.to, err = parseDateBound(*to); err != nil {
+			return DateRange{}, err
+		}
+	}
+
+	if r.from != nil && r.to != nil && r.from.start().After(r.to.end()) {
+		return DateRange{}, fmt.Errorf("invalid date range: from '%s' is after to '%s'", *from, *to)
+	}
+
+	return r, nil
+}
+
+// IsZero reports whether the range is open on both sides
+func (r DateRange) IsZero() bool {
+	return r.from == nil && r.to == nil
+}
+
+// Apply returns the bars whose timestamp falls within the range
+func (r DateRange) Apply(series []models.OHLCVFloat) []models.OHLCVFloat {
+	if r.IsZero() {
+		return series
+	}
+
+	filtered := make([]models.OHLCVFloat, 0, len(series))
+	for _, bar := range series {
+		if r.from != nil && r.from.afterBar(bar.Timestamp) {
+			continue
+		}
+		if r.to != nil && r.to.beforeBar(bar.Timestamp) {
+			continue
+		}
+		filtered = append(filtered, bar)
+	}
+
+	return filtered
+}
+
+// parseDateBound parses a YYYY-MM-DD or RFC3339 bound
+func parseDateBound(value string) (*dateBound, error) {
+	if instant, err := time.Parse(time.DateOnly, value); err == nil {
+		return &dateBound{instant: instant, date: value}, nil
+	}
+
+	instant, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s'. Expected format: YYYY-MM-DD or RFC3339", value)
+	}
+
+	return &dateBound{instant: instant}, nil
+}
+
+// start returns the earliest instant covered by the bound
+func (b *dateBound) start() time.Time {
+	return b.instant
+}
+
+// end returns the latest instant covered by the bound
+func (b *dateBound) end() time.Time {
+	if b.date != "" {
+		return b.instant.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return b.instant
+}
+
+// afterBar reports whether a from bound excludes the bar
+func (b *dateBound) afterBar(timestamp time.Time) bool {
+	if b.date != "" {
+		return timestamp.Format(time.DateOnly) < b.date
+	}
+	return timestamp.Before(b.instant)
+}
+
+// beforeBar reports whether a to bound excludes the bar
+func (b *dateBound) beforeBar(timestamp time.Time) bool {
+	if b.date != "" {
+		return timestamp.Format(time.DateOnly) > b.date
+	}
+	return timestamp.After(b.instant)
+}
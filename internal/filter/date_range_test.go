@@ -0,0 +1,97 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestNewDateRange_Validation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from, to *string
+		errorMsg string
+	}{
+		{name: "open range"},
+		{name: "dates", from: stringPtr("2024-01-15"), to: stringPtr("2024-01-16")},
+		{name: "same date", from: stringPtr("2024-01-15"), to: stringPtr("2024-01-15")},
+		{name: "RFC3339", from: stringPtr("2024-01-15T09:30:00-05:00"), to: stringPtr("2024-01-15T16:00:00-05:00")},
+		{name: "instant within to date", from: stringPtr("2024-01-15T12:00:00Z"), to: stringPtr("2024-01-15")},
+		{name: "only to", to: stringPtr("2024-01-15")},
+		{name: "invalid from", from: stringPtr("15/01/2024"), errorMsg: "invalid date '15/01/2024'"},
+		{name: "invalid to", to: stringPtr("2024-13-01"), errorMsg: "invalid date '2024-13-01'"},
+		{name: "from after to", from: stringPtr("2024-01-16"), to: stringPtr("2024-01-15"), errorMsg: "from '2024-01-16' is after to '2024-01-15'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewDateRange(tc.from, tc.to)
+			if tc.errorMsg != "" {
+				assert.ErrorContains(t, err, tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDateRange_Apply(t *testing.T) {
+	series := bars(
+		time.Date(2024, 1, 14, 19, 55, 0, 0, eastern),
+		time.Date(2024, 1, 15, 0, 0, 0, 0, eastern),
+		time.Date(2024, 1, 15, 9, 30, 0, 0, eastern),
+		time.Date(2024, 1, 16, 23, 59, 0, 0, eastern),
+		time.Date(2024, 1, 17, 0, 0, 0, 0, eastern),
+	)
+
+	t.Run("inclusive dates", func(t *testing.T) {
+		r, err := NewDateRange(stringPtr("2024-01-15"), stringPtr("2024-01-16"))
+		require.NoError(t, err)
+		assert.Equal(t, series[1:4], r.Apply(series))
+	})
+
+	t.Run("inclusive instants", func(t *testing.T) {
+		// 14:30Z is 09:30 EST
+		r, err := NewDateRange(stringPtr("2024-01-15T14:30:00Z"), stringPtr("2024-01-16T23:59:00-05:00"))
+		require.NoError(t, err)
+		assert.Equal(t, series[2:4], r.Apply(series))
+	})
+
+	t.Run("open from", func(t *testing.T) {
+		r, err := NewDateRange(nil, stringPtr("2024-01-14"))
+		require.NoError(t, err)
+		assert.Equal(t, series[:1], r.Apply(series))
+	})
+
+	t.Run("empty window", func(t *testing.T) {
+		r, err := NewDateRange(stringPtr("2024-02-01"), stringPtr("2024-02-29"))
+		require.NoError(t, err)
+		assert.Empty(t, r.Apply(series))
+	})
+
+	t.Run("zero range", func(t *testing.T) {
+		r, err := NewDateRange(nil, nil)
+		require.NoError(t, err)
+		assert.True(t, r.IsZero())
+		assert.Equal(t, series, r.Apply(series))
+	})
+}
+
+func TestDateRange_ApplyDates(t *testing.T) {
+	// Date-keyed series are parsed at midnight in the metadata time zone
+	series := bars(
+		time.Date(2023, 12, 29, 0, 0, 0, 0, eastern),
+		time.Date(2024, 1, 5, 0, 0, 0, 0, eastern),
+		time.Date(2024, 1, 12, 0, 0, 0, 0, eastern),
+	)
+
+	r, err := NewDateRange(stringPtr("2024-01-05"), stringPtr("2024-01-12"))
+	require.NoError(t, err)
+	assert.Equal(t, series[1:], r.Apply(series))
+}
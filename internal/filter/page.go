@@ -0,0 +1,25 @@
+package filter
+
+import "slices"
+
+// Page returns up to limit items starting at offset. When latest is set the
+// items are reversed first, so the page counts back from the most recent
+// item and is ordered newest first. A negative limit keeps every item from
+// offset on, and an offset past the end yields an empty page.
+func Page[T any](items []T, offset, limit int, latest bool) []T {
+	if latest {
+		items = slices.Clone(items)
+		slices.Reverse(items)
+	}
+
+	if offset >= len(items) {
+		return []T{}
+	}
+	items = items[offset:]
+
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
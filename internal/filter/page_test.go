@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	testCases := []struct {
+		name   string
+		offset int
+		limit  int
+		latest bool
+		want   []int
+	}{
+		{name: "no limit", offset: 0, limit: -1, want: []int{1, 2, 3, 4, 5}},
+		{name: "first page", offset: 0, limit: 2, want: []int{1, 2}},
+		{name: "middle page", offset: 2, limit: 2, want: []int{3, 4}},
+		{name: "last partial page", offset: 4, limit: 2, want: []int{5}},
+		{name: "limit equals length", offset: 0, limit: 5, want: []int{1, 2, 3, 4, 5}},
+		{name: "limit exceeds length", offset: 0, limit: 10, want: []int{1, 2, 3, 4, 5}},
+		{name: "offset at end", offset: 5, limit: 2, want: []int{}},
+		{name: "offset past end", offset: 9, limit: -1, want: []int{}},
+		{name: "latest first page", offset: 0, limit: 2, latest: true, want: []int{5, 4}},
+		{name: "latest with offset", offset: 3, limit: 5, latest: true, want: []int{2, 1}},
+		{name: "latest no limit", offset: 0, limit: -1, latest: true, want: []int{5, 4, 3, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Page(items, tc.offset, tc.limit, tc.latest))
+		})
+	}
+
+	// The input order is left untouched
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+}
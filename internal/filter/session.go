@@ -0,0 +1,56 @@
+// Package filter narrows price series that have already been fetched, so a
+// full response can be cached once and different views of it returned.
+//
+// All functions expect the series sorted oldest first, which is the order the
+// parser produces, and preserve that order.
+package filter
+
+import (
+	"time"
+
+	// US/Eastern must resolve even on hosts without a zoneinfo database
+	_ "time/tzdata"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// Regular US equity session bounds in seconds after midnight Eastern time
+const (
+	regularOpen  = (9*60 + 30) * 60
+	regularClose = 16 * 60 * 60
+)
+
+// eastern is the exchange time zone of US equities
+var eastern = mustLoadLocation("America/New_York")
+
+// RegularHours returns the bars whose timestamp falls within the regular
+// trading session, from 09:30 up to but excluding 16:00 US/Eastern,
+// dropping pre-market and post-market bars. Alpha Vantage stamps bars with
+// their start time, so the 16:00 bar is the first post-market one.
+//
+// Timestamps are converted to Eastern time before comparison, so bars are
+// classified correctly across daylight saving changes whatever zone they
+// were parsed in.
+func RegularHours(series []models.OHLCVFloat) []models.OHLCVFloat {
+	filtered := make([]models.OHLCVFloat, 0, len(series))
+
+	for _, bar := range series {
+		local := bar.Timestamp.In(eastern)
+		seconds := (local.Hour()*60+local.Minute())*60 + local.Second()
+		if seconds < regularOpen || seconds >= regularClose {
+			continue
+		}
+		filtered = append(filtered, bar)
+	}
+
+	return filtered
+}
+
+// mustLoadLocation loads an IANA time zone bundled with the binary
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
@@ -0,0 +1,55 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+func bars(timestamps ...time.Time) []models.OHLCVFloat {
+	series := make([]models.OHLCVFloat, len(timestamps))
+	for i, timestamp := range timestamps {
+		series[i] = models.OHLCVFloat{Timestamp: timestamp, Close: float64(i)}
+	}
+	return series
+}
+
+func TestRegularHours_Boundaries(t *testing.T) {
+	series := bars(
+		time.Date(2024, 1, 16, 9, 29, 0, 0, eastern),
+		time.Date(2024, 1, 16, 9, 30, 0, 0, eastern),
+		time.Date(2024, 1, 16, 12, 0, 0, 0, eastern),
+		time.Date(2024, 1, 16, 15, 55, 0, 0, eastern),
+		time.Date(2024, 1, 16, 16, 0, 0, 0, eastern),
+		time.Date(2024, 1, 16, 16, 1, 0, 0, eastern),
+		time.Date(2024, 1, 16, 19, 55, 0, 0, eastern),
+	)
+
+	// Bars are stamped with their start time, so 16:00 opens the post-market
+	filtered := RegularHours(series)
+	assert.Equal(t, []models.OHLCVFloat{series[1], series[2], series[3]}, filtered)
+}
+
+func TestRegularHours_DaylightSaving(t *testing.T) {
+	// The session opens at 14:30 UTC under EST and 13:30 UTC under EDT
+	series := bars(
+		time.Date(2024, 1, 16, 14, 29, 0, 0, time.UTC), // 09:29 EST
+		time.Date(2024, 1, 16, 14, 30, 0, 0, time.UTC), // 09:30 EST
+		time.Date(2024, 1, 16, 20, 59, 0, 0, time.UTC), // 15:59 EST
+		time.Date(2024, 1, 16, 21, 0, 0, 0, time.UTC),  // 16:00 EST
+		time.Date(2024, 7, 16, 13, 30, 0, 0, time.UTC), // 09:30 EDT
+		time.Date(2024, 7, 16, 14, 30, 0, 0, time.UTC), // 10:30 EDT
+		time.Date(2024, 7, 16, 19, 59, 0, 0, time.UTC), // 15:59 EDT
+		time.Date(2024, 7, 16, 20, 0, 0, 0, time.UTC),  // 16:00 EDT
+	)
+
+	filtered := RegularHours(series)
+	assert.Equal(t, []models.OHLCVFloat{series[1], series[2], series[4], series[5], series[6]}, filtered)
+}
+
+func TestRegularHours_Empty(t *testing.T) {
+	assert.Empty(t, RegularHours(nil))
+	assert.Empty(t, RegularHours(bars(time.Date(2024, 1, 16, 4, 0, 0, 0, eastern))))
+}
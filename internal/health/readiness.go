@@ -0,0 +1,155 @@
+// Package health implements the readiness check behind the /health/ready
+// endpoint, which verifies that Alpha Vantage is reachable and accepts the
+// configured API key.
+package health
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// CheckSymbol is the symbol quoted by the readiness check
+const CheckSymbol = request.ValidateSymbol
+
+// checkTimeout bounds a single upstream check so a hung backend does not
+// stall the probe past the load balancer's own timeout
+const checkTimeout = 5 * time.Second
+
+// KeyValidationTTL is how long a successful API key validation is trusted
+// before the checker spends another request to validate the key again
+const KeyValidationTTL = 6 * time.Hour
+
+// Upstream states reported by a readiness check
+const (
+	// StatusOK means Alpha Vantage answered the check
+	StatusOK = "ok"
+	// StatusRateLimited means Alpha Vantage is reachable and accepted the
+	// key but the quota is used up; the server is still considered ready
+	// since routing elsewhere would not help
+	StatusRateLimited = "rate_limited"
+	// StatusInvalidAPIKey means Alpha Vantage rejected the API key
+	StatusInvalidAPIKey = "invalid_api_key"
+	// StatusUnreachable means the request failed or returned an error
+	StatusUnreachable = "unreachable"
+)
+
+// Result is the outcome of a readiness check.
+type Result struct {
+	Ready     bool      `json:"ready"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+// Checker checks upstream connectivity and the API key. The key is
+// validated with AlphaVantageClient.Validate, which spends one GLOBAL_QUOTE
+// request, at most once per KeyValidationTTL; in between, checks only ping
+// Alpha Vantage, which spends no quota. Results are also reused for a TTL,
+// so frequent load balancer probes cost at most one ping per TTL.
+//
+// Checker is safe for concurrent use; concurrent probes share one request.
+type Checker struct {
+	alphaClient *request.AlphaVantageClient
+	ttl         time.Duration
+	clock       clock.Clock
+
+	mu          sync.Mutex
+	result      Result
+	validatedAt time.Time
+}
+
+// NewCheckerWithClient creates a readiness checker using the given client.
+// Pass a client on the shared rate limiter, such as one from
+// Toolset.AlphaVantageClient, so key validations count against the quota.
+//
+// Parameters:
+//   - alphaClient: Client used to reach Alpha Vantage
+//   - ttl: How long a check result is reused before querying upstream again
+func NewCheckerWithClient(alphaClient *request.AlphaVantageClient, ttl time.Duration) *Checker {
+	return &Checker{
+		alphaClient: alphaClient,
+		ttl:         ttl,
+		clock:       clock.Real,
+	}
+}
+
+// WithClock sets the clock that ages check results, for tests. It returns
+// the checker for chaining.
+func (c *Checker) WithClock(clk clock.Clock) *Checker {
+	c.clock = clock.OrReal(clk)
+	return c
+}
+
+// Check returns the cached result when it is younger than the TTL and
+// otherwise queries Alpha Vantage.
+func (c *Checker) Check(ctx context.Context) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if !c.result.CheckedAt.IsZero() && now.Sub(c.result.CheckedAt) < c.ttl {
+		return c.result
+	}
+
+	if c.validatedAt.IsZero() || now.Sub(c.validatedAt) >= KeyValidationTTL {
+		c.result = c.validate(ctx, now)
+	} else {
+		c.result = c.ping(ctx)
+	}
+	c.result.CheckedAt = now
+	return c.result
+}
+
+// validate validates the API key. Once the key has been accepted, a
+// revalidation that fails for any reason other than a rejected key, such as
+// a timeout while waiting for the rate limiter, falls back to a ping and is
+// retried by the next check.
+func (c *Checker) validate(ctx context.Context, now time.Time) Result {
+	validateCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	result := classify(c.alphaClient.Validate(validateCtx))
+	switch {
+	case result.Ready:
+		c.validatedAt = now
+	case result.Status == StatusInvalidAPIKey:
+		c.validatedAt = time.Time{}
+	case !c.validatedAt.IsZero():
+		return c.ping(ctx)
+	}
+
+	return result
+}
+
+// ping checks connectivity without spending API quota
+func (c *Checker) ping(ctx context.Context) Result {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	return classify(c.alphaClient.Ping(ctx))
+}
+
+// classify maps the error of an upstream check to a readiness result
+func classify(err error) Result {
+	switch {
+	case err == nil:
+		return Result{Ready: true, Status: StatusOK}
+	case stderrors.Is(err, errors.ErrRateLimited):
+		return Result{Ready: true, Status: StatusRateLimited, Error: err.Error()}
+	case stderrors.Is(err, errors.ErrInvalidAPIKey):
+		return Result{Ready: false, Status: StatusInvalidAPIKey, Error: err.Error()}
+	default:
+		return Result{Ready: false, Status: StatusUnreachable, Error: err.Error()}
+	}
+}
+
+// Close cleans up resources used by the checker
+func (c *Checker) Close() error {
+	return c.alphaClient.Close()
+}
@@ -0,0 +1,167 @@
+package health
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+const (
+	checkURL = "https://www.alphavantage.co/query?apikey=test-key&function=GLOBAL_QUOTE&symbol=IBM"
+	pingURL  = "https://www.alphavantage.co/query"
+)
+
+func newMockChecker(ttl time.Duration) (*Checker, *client.MockClient) {
+	mockClient := client.NewMockClient()
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 5 * time.Second,
+	}
+
+	return NewCheckerWithClient(request.NewAlphaVantageClient(mockClient, config), ttl), mockClient
+}
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   *client.Response
+		err        error
+		wantReady  bool
+		wantStatus string
+	}{
+		{
+			name:       "ok",
+			response:   &client.Response{StatusCode: 200, Body: []byte(`{"Global Quote": {"01. symbol": "IBM"}}`)},
+			wantReady:  true,
+			wantStatus: StatusOK,
+		},
+		{
+			name:       "invalid key",
+			response:   &client.Response{StatusCode: 200, Body: []byte(`{"Error Message": "the parameter apikey is invalid or missing."}`)},
+			wantReady:  false,
+			wantStatus: StatusInvalidAPIKey,
+		},
+		{
+			name:       "unauthorized",
+			response:   &client.Response{StatusCode: 401},
+			wantReady:  false,
+			wantStatus: StatusInvalidAPIKey,
+		},
+		{
+			name:       "rate limited",
+			response:   &client.Response{StatusCode: 200, Body: []byte(`{"Information": "We have detected your API key as ... standard API rate limit is 25 requests per day."}`)},
+			wantReady:  true,
+			wantStatus: StatusRateLimited,
+		},
+		{
+			name:       "unreachable",
+			err:        stderrors.New("dial tcp: no such host"),
+			wantReady:  false,
+			wantStatus: StatusUnreachable,
+		},
+		{
+			name:       "server error",
+			response:   &client.Response{StatusCode: 502},
+			wantReady:  false,
+			wantStatus: StatusUnreachable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, mockClient := newMockChecker(time.Minute)
+			if tt.err != nil {
+				mockClient.SetError(checkURL, tt.err)
+			} else {
+				mockClient.SetResponse(checkURL, tt.response)
+			}
+
+			result := checker.Check(context.Background())
+			assert.Equal(t, tt.wantReady, result.Ready)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.False(t, result.CheckedAt.IsZero())
+			if tt.wantStatus == StatusOK {
+				assert.Empty(t, result.Error)
+			} else {
+				assert.NotEmpty(t, result.Error)
+			}
+		})
+	}
+}
+
+func TestChecker_CachesResult(t *testing.T) {
+	checker, mockClient := newMockChecker(time.Minute)
+	mockClient.SetResponse(checkURL, &client.Response{StatusCode: 200, Body: []byte(`{"Global Quote": {}}`)})
+
+	fake := clock.NewFake(time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC))
+	checker.WithClock(fake)
+
+	require.True(t, checker.Check(context.Background()).Ready)
+	require.True(t, checker.Check(context.Background()).Ready)
+	assert.Equal(t, 1, mockClient.GetCallCount(checkURL), "Checks within the TTL should reuse the result")
+
+	// The upstream breaks, but the cached result is served until it expires
+	mockClient.SetError(pingURL, stderrors.New("connection refused"))
+	fake.Advance(30 * time.Second)
+	assert.True(t, checker.Check(context.Background()).Ready)
+
+	fake.Advance(31 * time.Second)
+	result := checker.Check(context.Background())
+	assert.False(t, result.Ready)
+	assert.Equal(t, StatusUnreachable, result.Status)
+	assert.Equal(t, fake.Now(), result.CheckedAt)
+	assert.Equal(t, 1, mockClient.GetCallCount(checkURL), "Checks after the TTL should only ping")
+}
+
+func TestChecker_RevalidatesKey(t *testing.T) {
+	checker, mockClient := newMockChecker(time.Minute)
+	mockClient.SetResponse(checkURL, &client.Response{StatusCode: 200, Body: []byte(`{"Global Quote": {}}`)})
+	mockClient.SetResponse(pingURL, &client.Response{StatusCode: 200, Body: []byte(`{"Error Message": "the parameter apikey is invalid or missing."}`)})
+
+	fake := clock.NewFake(time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC))
+	checker.WithClock(fake)
+
+	// A day of probes every minute validates the key once per KeyValidationTTL
+	for range 24 * 60 {
+		require.Equal(t, StatusOK, checker.Check(context.Background()).Status)
+		fake.Advance(time.Minute)
+	}
+	assert.Equal(t, int(24*time.Hour/KeyValidationTTL), mockClient.GetCallCount(checkURL))
+	assert.Equal(t, 24*60-4, mockClient.GetCallCount(pingURL))
+
+	// A revoked key is noticed at the next validation
+	mockClient.SetResponse(checkURL, &client.Response{StatusCode: 401})
+	fake.Advance(KeyValidationTTL)
+	assert.Equal(t, StatusInvalidAPIKey, checker.Check(context.Background()).Status)
+}
+
+func TestChecker_RevalidationFailureFallsBackToPing(t *testing.T) {
+	checker, mockClient := newMockChecker(time.Minute)
+	mockClient.SetResponseSequence(checkURL, []*client.Response{
+		{StatusCode: 200, Body: []byte(`{"Global Quote": {}}`)},
+		{StatusCode: 502},
+		{StatusCode: 401},
+	})
+
+	fake := clock.NewFake(time.Date(2024, 1, 12, 10, 0, 0, 0, time.UTC))
+	checker.WithClock(fake)
+	require.Equal(t, StatusOK, checker.Check(context.Background()).Status)
+
+	// The key was accepted before, so a failed revalidation only checks
+	// connectivity and is retried by the next check
+	fake.Advance(KeyValidationTTL)
+	assert.Equal(t, StatusOK, checker.Check(context.Background()).Status)
+	assert.Equal(t, 1, mockClient.GetCallCount(pingURL))
+
+	fake.Advance(time.Minute)
+	assert.Equal(t, StatusInvalidAPIKey, checker.Check(context.Background()).Status)
+	assert.Equal(t, 3, mockClient.GetCallCount(checkURL))
+}
@@ -0,0 +1,43 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// BollingerBands computes Bollinger Bands over close prices: the middle band
+// is the SMA over period bars and the upper and lower bands lie stddev
+// population standard deviations of the same closes above and below it.
+//
+// Bars before the first full window are omitted, so each band is aligned
+// with SMA: value i corresponds to series[i+period-1].
+//
+// Returns an error if period is not positive or exceeds the series length,
+// or if stddev is not positive.
+func BollingerBands(series []models.OHLCVFloat, period int, stddev float64) (upper, middle, lower []float64, err error) {
+	if !(stddev > 0) {
+		return nil, nil, nil, fmt.Errorf("invalid standard deviation multiplier %g. It must be positive", stddev)
+	}
+
+	middle, err = SMA(series, period)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	upper = make([]float64, len(middle))
+	lower = make([]float64, len(middle))
+	for i, mean := range middle {
+		var sumSquares float64
+		for _, bar := range series[i : i+period] {
+			sumSquares += (bar.Close - mean) * (bar.Close - mean)
+		}
+
+		width := stddev * math.Sqrt(sumSquares/float64(period))
+		upper[i] = mean + width
+		lower[i] = mean - width
+	}
+
+	return upper, middle, lower, nil
+}
@@ -0,0 +1,51 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBollingerBands(t *testing.T) {
+	// Windows {2, 4, 4, 4} and {4, 4, 4, 5}: means 3.5 and 4.25,
+	// population standard deviations sqrt(0.75) and sqrt(0.1875)
+	series := closes(2, 4, 4, 4, 5)
+
+	upper, middle, lower, err := BollingerBands(series, 4, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, []float64{3.5, 4.25}, middle)
+	require.Len(t, upper, 2)
+	require.Len(t, lower, 2)
+	assert.InDelta(t, 3.5+2*0.8660254038, upper[0], 1e-9)
+	assert.InDelta(t, 3.5-2*0.8660254038, lower[0], 1e-9)
+	assert.InDelta(t, 4.25+2*0.4330127019, upper[1], 1e-9)
+	assert.InDelta(t, 4.25-2*0.4330127019, lower[1], 1e-9)
+
+	// Leading bars without a full window are omitted from every band
+	points := Points(series, upper)
+	assert.Equal(t, series[3].Timestamp, points[0].Timestamp)
+}
+
+func TestBollingerBands_FlatSeries(t *testing.T) {
+	upper, middle, lower, err := BollingerBands(closes(10, 10, 10), 3, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{10}, upper)
+	assert.Equal(t, []float64{10}, middle)
+	assert.Equal(t, []float64{10}, lower)
+}
+
+func TestBollingerBands_InvalidParameters(t *testing.T) {
+	_, _, _, err := BollingerBands(closes(1, 2, 3), 0, 2)
+	assert.ErrorContains(t, err, "invalid period 0")
+
+	_, _, _, err = BollingerBands(closes(1, 2, 3), 4, 2)
+	assert.ErrorContains(t, err, "period 4 exceeds series length 3")
+
+	_, _, _, err = BollingerBands(closes(1, 2, 3), 2, 0)
+	assert.ErrorContains(t, err, "invalid standard deviation multiplier 0")
+
+	_, _, _, err = BollingerBands(closes(1, 2, 3), 2, -1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+// Package indicators computes technical indicators locally from price series
+// that have already been fetched, so no additional Alpha Vantage requests are
+// spent on them.
+//
+// All functions expect the series sorted oldest first, which is the order the
+// parser produces.
+package indicators
+
+import (
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// SMA computes the simple moving average of close prices over period bars.
+//
+// The result has len(series)-period+1 values; value i is the average of
+// series[i:i+period] and corresponds to series[i+period-1].
+//
+// Returns an error if period is not positive or exceeds the series length.
+func SMA(series []models.OHLCVFloat, period int) ([]float64, error) {
+	if err := validatePeriod(len(series), period); err != nil {
+		return nil, err
+	}
+
+	values := make([]float64, 0, len(series)-period+1)
+
+	var sum float64
+	for i, bar := range series {
+		sum += bar.Close
+		if i >= period {
+			sum -= series[i-period].Close
+		}
+		if i >= period-1 {
+			values = append(values, sum/float64(period))
+		}
+	}
+
+	return values, nil
+}
+
+// EMA computes the exponential moving average of close prices over period bars
+// using the smoothing factor 2/(period+1).
+//
+// The first value is seeded with the SMA of the first period bars, so the
+// result is aligned with SMA: value i corresponds to series[i+period-1].
+//
+// Returns an error if period is not positive or exceeds the series length.
+func EMA(series []models.OHLCVFloat, period int) ([]float64, error) {
+	if err := validatePeriod(len(series), period); err != nil {
+		return nil, err
+	}
+
+	var seed float64
+	for _, bar := range series[:period] {
+		seed += bar.Close
+	}
+	seed /= float64(period)
+
+	k := 2 / float64(period+1)
+
+	values := make([]float64, 0, len(series)-period+1)
+	values = append(values, seed)
+
+	prev := seed
+	for _, bar := range series[period:] {
+		prev = (bar.Close-prev)*k + prev
+		values = append(values, prev)
+	}
+
+	return values, nil
+}
+
+// Points pairs values produced by SMA or EMA with the timestamps of the bars
+// they correspond to.
+func Points(series []models.OHLCVFloat, values []float64) []models.IndicatorPoint {
+	offset := len(series) - len(values)
+	if offset < 0 {
+		return nil
+	}
+
+	points := make([]models.IndicatorPoint, len(values))
+	for i, value := range values {
+		points[i] = models.IndicatorPoint{
+			Timestamp: series[offset+i].Timestamp,
+			Value:     value,
+		}
+	}
+
+	return points
+}
+
+// validatePeriod checks that period can be applied to a series of length n
+func validatePeriod(n, period int) error {
+	if period <= 0 {
+		return fmt.Errorf("invalid period %d. Period must be a positive integer", period)
+	}
+
+	if period > n {
+		return fmt.Errorf("period %d exceeds series length %d", period, n)
+	}
+
+	return nil
+}
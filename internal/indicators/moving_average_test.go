@@ -0,0 +1,60 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+func closes(values ...float64) []models.OHLCVFloat {
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	series := make([]models.OHLCVFloat, len(values))
+	for i, value := range values {
+		series[i] = models.OHLCVFloat{Timestamp: start.Add(time.Duration(i) * time.Minute), Close: value}
+	}
+	return series
+}
+
+func TestSMA(t *testing.T) {
+	values, err := SMA(closes(1, 2, 3, 4, 5), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{2, 3, 4}, values)
+}
+
+func TestEMA(t *testing.T) {
+	series := closes(2, 4, 6, 8, 10)
+
+	values, err := EMA(series, 3)
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+
+	// Seeded from the SMA of the first three closes
+	assert.Equal(t, 4.0, values[0])
+
+	// Smoothing factor 2/(3+1) = 0.5
+	assert.InDelta(t, (8-4.0)*0.5+4.0, values[1], 1e-9)
+	assert.InDelta(t, (10-values[1])*0.5+values[1], values[2], 1e-9)
+}
+
+func TestMovingAverage_InvalidPeriod(t *testing.T) {
+	_, err := SMA(closes(1, 2), 3)
+	assert.ErrorContains(t, err, "period 3 exceeds series length 2")
+
+	_, err = EMA(closes(1, 2), 0)
+	assert.ErrorContains(t, err, "invalid period 0")
+}
+
+func TestPoints(t *testing.T) {
+	series := closes(1, 2, 3, 4)
+	values, err := SMA(series, 2)
+	require.NoError(t, err)
+
+	points := Points(series, values)
+	require.Len(t, points, 3)
+	assert.Equal(t, series[1].Timestamp, points[0].Timestamp)
+	assert.Equal(t, 1.5, points[0].Value)
+	assert.Equal(t, series[3].Timestamp, points[2].Timestamp)
+}
@@ -0,0 +1,102 @@
+package indicators
+
+import (
+	"math"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// Candlestick patterns reported by DetectPatterns
+const (
+	PatternDoji             = "doji"
+	PatternHammer           = "hammer"
+	PatternBullishEngulfing = "bullish_engulfing"
+	PatternBearishEngulfing = "bearish_engulfing"
+)
+
+// Thresholds of DetectPatterns, as fractions of a bar's high-low range
+const (
+	// dojiMaxBody is the largest body of a doji: open and close nearly equal
+	dojiMaxBody = 0.1
+
+	// hammerMaxUpperShadow is the largest upper shadow of a hammer, whose
+	// lower shadow must also be at least hammerMinShadowToBody times its body
+	hammerMaxUpperShadow  = 0.1
+	hammerMinShadowToBody = 2.0
+)
+
+// DetectPatterns flags basic candlestick patterns in the series:
+//   - doji: the body is under 10% of the bar's range
+//   - hammer: not a doji, the lower shadow is at least twice the body and
+//     the upper shadow at most 10% of the range
+//   - bullish_engulfing: a rising bar whose body covers the whole body of
+//     the falling bar before it
+//   - bearish_engulfing: a falling bar whose body covers the whole body of
+//     the rising bar before it
+//
+// Bars without a range are skipped. A bar may match several patterns; hits
+// are reported in series order.
+func DetectPatterns(series []models.OHLCVFloat) []models.PatternHit {
+	var hits []models.PatternHit
+
+	for i, bar := range series {
+		hit := func(pattern string) {
+			hits = append(hits, models.PatternHit{Timestamp: bar.Timestamp, Pattern: pattern})
+		}
+
+		if isDoji(bar) {
+			hit(PatternDoji)
+		} else if isHammer(bar) {
+			hit(PatternHammer)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		prev := series[i-1]
+		switch {
+		case isFalling(prev) && isRising(bar) && bar.Open <= prev.Close && bar.Close >= prev.Open && body(bar) > body(prev):
+			hit(PatternBullishEngulfing)
+		case isRising(prev) && isFalling(bar) && bar.Open >= prev.Close && bar.Close <= prev.Open && body(bar) > body(prev):
+			hit(PatternBearishEngulfing)
+		}
+	}
+
+	return hits
+}
+
+// isDoji reports whether the bar's body is negligible relative to its range
+func isDoji(bar models.OHLCVFloat) bool {
+	barRange := bar.High - bar.Low
+	return barRange > 0 && body(bar) < dojiMaxBody*barRange
+}
+
+// isHammer reports whether the bar has a small body at the top of its range
+// and a long lower shadow
+func isHammer(bar models.OHLCVFloat) bool {
+	barRange := bar.High - bar.Low
+	if barRange <= 0 {
+		return false
+	}
+
+	upperShadow := bar.High - max(bar.Open, bar.Close)
+	lowerShadow := min(bar.Open, bar.Close) - bar.Low
+
+	return lowerShadow >= hammerMinShadowToBody*body(bar) && upperShadow <= hammerMaxUpperShadow*barRange
+}
+
+// body returns the size of the bar's body
+func body(bar models.OHLCVFloat) float64 {
+	return math.Abs(bar.Close - bar.Open)
+}
+
+// isRising reports whether the bar closed above its open
+func isRising(bar models.OHLCVFloat) bool {
+	return bar.Close > bar.Open
+}
+
+// isFalling reports whether the bar closed below its open
+func isFalling(bar models.OHLCVFloat) bool {
+	return bar.Close < bar.Open
+}
@@ -0,0 +1,95 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+func candle(minute int, open, high, low, close float64) models.OHLCVFloat {
+	return models.OHLCVFloat{
+		Timestamp: time.Date(2024, 1, 2, 9, 30+minute, 0, 0, time.UTC),
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Close:     close,
+	}
+}
+
+func TestDetectPatterns(t *testing.T) {
+	testCases := []struct {
+		name   string
+		series []models.OHLCVFloat
+		want   []string
+	}{
+		{
+			name:   "doji",
+			series: []models.OHLCVFloat{candle(0, 100, 105, 95, 100.5)}, // body 0.5 of range 10
+			want:   []string{PatternDoji},
+		},
+		{
+			name:   "hammer",
+			series: []models.OHLCVFloat{candle(0, 100, 102.5, 95, 102)}, // body 2, lower shadow 5, upper 0.5
+			want:   []string{PatternHammer},
+		},
+		{
+			name: "bullish engulfing",
+			series: []models.OHLCVFloat{
+				candle(0, 102, 103, 100, 101),
+				candle(1, 100.5, 104, 100, 103.5),
+			},
+			want: []string{PatternBullishEngulfing},
+		},
+		{
+			name: "bearish engulfing",
+			series: []models.OHLCVFloat{
+				candle(0, 101, 103, 100, 102),
+				candle(1, 102.5, 103, 99, 100),
+			},
+			want: []string{PatternBearishEngulfing},
+		},
+		{
+			name:   "plain bar",
+			series: []models.OHLCVFloat{candle(0, 100, 106, 99, 105)},
+		},
+		{
+			name:   "no range",
+			series: []models.OHLCVFloat{candle(0, 100, 100, 100, 100)},
+		},
+		{
+			name: "rising bar not covering the falling body",
+			series: []models.OHLCVFloat{
+				candle(0, 102, 103, 99, 100),
+				candle(1, 100.5, 104, 100, 101.5),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hits := DetectPatterns(tc.series)
+
+			var patterns []string
+			for _, hit := range hits {
+				patterns = append(patterns, hit.Pattern)
+				assert.Equal(t, tc.series[len(tc.series)-1].Timestamp, hit.Timestamp)
+			}
+			assert.Equal(t, tc.want, patterns)
+		})
+	}
+}
+
+func TestDetectPatterns_Timestamps(t *testing.T) {
+	series := []models.OHLCVFloat{
+		candle(0, 100, 105, 95, 100.5), // doji
+		candle(1, 100, 106, 99, 105),
+		candle(2, 100, 102.5, 95, 102), // hammer
+	}
+
+	assert.Equal(t, []models.PatternHit{
+		{Timestamp: series[0].Timestamp, Pattern: PatternDoji},
+		{Timestamp: series[2].Timestamp, Pattern: PatternHammer},
+	}, DetectPatterns(series))
+}
@@ -0,0 +1,46 @@
+package indicators
+
+import (
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// Returns computes the simple close-to-close return of each bar over the bar
+// before it, as a fraction (0.01 is 1%).
+//
+// The first bar has no prior close, so the result has len(series)-1 values
+// and value i corresponds to series[i+1], aligned for Points. A return from a
+// zero close is undefined and reported as 0.
+func Returns(series []models.OHLCVFloat) []float64 {
+	if len(series) < 2 {
+		return nil
+	}
+
+	values := make([]float64, len(series)-1)
+	for i := 1; i < len(series); i++ {
+		values[i-1] = simpleReturn(series[i-1].Close, series[i].Close)
+	}
+
+	return values
+}
+
+// CumulativeReturn computes the close-to-close return from the first bar of
+// the series to the last, as a fraction.
+//
+// Returns 0 for fewer than two bars or when the first close is zero.
+func CumulativeReturn(series []models.OHLCVFloat) float64 {
+	if len(series) < 2 {
+		return 0
+	}
+
+	return simpleReturn(series[0].Close, series[len(series)-1].Close)
+}
+
+// simpleReturn returns the change from prev to next as a fraction of prev,
+// or 0 when prev is zero
+func simpleReturn(prev, next float64) float64 {
+	if prev == 0 {
+		return 0
+	}
+
+	return next/prev - 1
+}
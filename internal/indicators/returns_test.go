@@ -0,0 +1,40 @@
+package indicators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReturns(t *testing.T) {
+	series := closes(100, 110, 99, 99)
+
+	values := Returns(series)
+	require.Len(t, values, 3)
+	assert.InDelta(t, 0.10, values[0], 1e-9)  // 100 -> 110
+	assert.InDelta(t, -0.10, values[1], 1e-9) // 110 -> 99
+	assert.Zero(t, values[2])                 // 99 -> 99
+
+	// Aligned with the bars after the first
+	points := Points(series, values)
+	assert.Equal(t, series[1].Timestamp, points[0].Timestamp)
+	assert.Equal(t, series[3].Timestamp, points[2].Timestamp)
+
+	assert.InDelta(t, -0.01, CumulativeReturn(series), 1e-9)
+}
+
+func TestReturns_ZeroPrice(t *testing.T) {
+	series := closes(0, 50, 0, 25)
+
+	values := Returns(series)
+	assert.Equal(t, []float64{0, -1, 0}, values, "Returns from a zero close must be 0, not infinite")
+	assert.Zero(t, CumulativeReturn(series))
+	assert.InDelta(t, -0.5, CumulativeReturn(series[1:]), 1e-9)
+}
+
+func TestReturns_ShortSeries(t *testing.T) {
+	assert.Empty(t, Returns(nil))
+	assert.Empty(t, Returns(closes(100)))
+	assert.Zero(t, CumulativeReturn(closes(100)))
+}
@@ -0,0 +1,56 @@
+package indicators
+
+import (
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// Summarize computes summary statistics over the bars, which may be in any
+// order. The percent change runs from the open of the oldest bar to the close
+// of the newest, and VWAP weights the typical price (high+low+close)/3 of
+// every bar by its volume.
+//
+// Returns nil for an empty series.
+func Summarize(series []models.OHLCVFloat) *models.Summary {
+	if len(series) == 0 {
+		return nil
+	}
+
+	oldest, newest := series[0], series[0]
+	summary := &models.Summary{
+		Bars:     len(series),
+		MinClose: series[0].Close,
+		MaxClose: series[0].Close,
+	}
+
+	var sumClose, sumPV float64
+	for _, bar := range series {
+		if bar.Timestamp.Before(oldest.Timestamp) {
+			oldest = bar
+		}
+		if bar.Timestamp.After(newest.Timestamp) {
+			newest = bar
+		}
+
+		summary.MinClose = min(summary.MinClose, bar.Close)
+		summary.MaxClose = max(summary.MaxClose, bar.Close)
+		sumClose += bar.Close
+
+		summary.TotalVolume += bar.Volume
+		sumPV += (bar.High + bar.Low + bar.Close) / 3 * float64(bar.Volume)
+	}
+
+	summary.Start = oldest.Timestamp
+	summary.End = newest.Timestamp
+	summary.MeanClose = sumClose / float64(len(series))
+
+	if summary.TotalVolume > 0 {
+		vwap := sumPV / float64(summary.TotalVolume)
+		summary.VWAP = &vwap
+	}
+
+	if oldest.Open != 0 {
+		summary.PercentChange = (newest.Close - oldest.Open) / oldest.Open * 100
+	}
+
+	return summary
+}
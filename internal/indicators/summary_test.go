@@ -0,0 +1,49 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+func TestSummarize(t *testing.T) {
+	start := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	series := []models.OHLCVFloat{
+		{Timestamp: start, Open: 100, High: 12, Low: 9, Close: 9, Volume: 100},                      // typical 10
+		{Timestamp: start.Add(time.Minute), Open: 101, High: 22, Low: 19, Close: 19, Volume: 300},   // typical 20
+		{Timestamp: start.Add(2 * time.Minute), Open: 99, High: 32, Low: 29, Close: 110, Volume: 0}, // no volume
+	}
+
+	summary := Summarize(series)
+	require.NotNil(t, summary)
+	assert.Equal(t, 3, summary.Bars)
+	assert.Equal(t, start, summary.Start)
+	assert.Equal(t, start.Add(2*time.Minute), summary.End)
+	assert.Equal(t, 9.0, summary.MinClose)
+	assert.Equal(t, 110.0, summary.MaxClose)
+	assert.Equal(t, 46.0, summary.MeanClose)
+	assert.Equal(t, int64(400), summary.TotalVolume)
+	require.NotNil(t, summary.VWAP)
+	assert.Equal(t, (10*100+20*300)/400.0, *summary.VWAP)
+	assert.InDelta(t, 10.0, summary.PercentChange, 1e-9) // 100 -> 110
+
+	// Newest-first input yields the same summary
+	reversed := []models.OHLCVFloat{series[2], series[1], series[0]}
+	assert.Equal(t, summary, Summarize(reversed))
+}
+
+func TestSummarize_NoVolume(t *testing.T) {
+	summary := Summarize([]models.OHLCVFloat{
+		{Timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Open: 1.1, High: 1.2, Low: 1.0, Close: 1.1},
+	})
+	require.NotNil(t, summary)
+	assert.Nil(t, summary.VWAP)
+	assert.Zero(t, summary.PercentChange)
+}
+
+func TestSummarize_Empty(t *testing.T) {
+	assert.Nil(t, Summarize(nil))
+}
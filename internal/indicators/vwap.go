@@ -0,0 +1,55 @@
+package indicators
+
+import (
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// VWAP computes the running volume-weighted average price of intraday bars,
+// using the typical price (high+low+close)/3 weighted by volume.
+//
+// The running totals reset whenever the trading date changes. Intraday
+// timestamps are parsed as the exchange's wall-clock time in the metadata
+// time zone (US/Eastern for US equities), so the date portion of each
+// timestamp is the trading date.
+//
+// The result has one value per bar. While no volume has traded yet in the
+// current day, the bar's typical price is used instead of dividing by zero.
+func VWAP(series []models.OHLCVFloat) []float64 {
+	values := make([]float64, len(series))
+
+	var (
+		day           time.Time
+		cumulativePV  float64
+		cumulativeVol float64
+	)
+
+	for i, bar := range series {
+		if barDay := tradingDate(bar.Timestamp); !barDay.Equal(day) {
+			day = barDay
+			cumulativePV, cumulativeVol = 0, 0
+		}
+
+		typical := (bar.High + bar.Low + bar.Close) / 3
+		volume := float64(bar.Volume)
+
+		cumulativePV += typical * volume
+		cumulativeVol += volume
+
+		if cumulativeVol == 0 {
+			values[i] = typical
+			continue
+		}
+
+		values[i] = cumulativePV / cumulativeVol
+	}
+
+	return values
+}
+
+// tradingDate truncates a timestamp to its calendar date in its own location
+func tradingDate(timestamp time.Time) time.Time {
+	year, month, day := timestamp.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, timestamp.Location())
+}
@@ -0,0 +1,46 @@
+package indicators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+func bar(timestamp time.Time, high, low, close float64, volume int64) models.OHLCVFloat {
+	return models.OHLCVFloat{Timestamp: timestamp, High: high, Low: low, Close: close, Volume: volume}
+}
+
+func TestVWAP(t *testing.T) {
+	series := []models.OHLCVFloat{
+		bar(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC), 12, 9, 9, 100),   // typical 10
+		bar(time.Date(2024, 1, 2, 9, 31, 0, 0, time.UTC), 22, 19, 19, 300), // typical 20
+	}
+
+	values := VWAP(series)
+	assert.Equal(t, []float64{10, (10*100 + 20*300) / 400.0}, values)
+}
+
+func TestVWAP_ResetsDaily(t *testing.T) {
+	series := []models.OHLCVFloat{
+		bar(time.Date(2024, 1, 2, 19, 59, 0, 0, time.UTC), 12, 9, 9, 100),
+		bar(time.Date(2024, 1, 3, 4, 0, 0, 0, time.UTC), 32, 29, 29, 50),
+	}
+
+	values := VWAP(series)
+	assert.Equal(t, 10.0, values[0])
+	assert.Equal(t, 30.0, values[1])
+}
+
+func TestVWAP_ZeroVolume(t *testing.T) {
+	series := []models.OHLCVFloat{
+		bar(time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC), 12, 9, 9, 0),
+		bar(time.Date(2024, 1, 2, 4, 1, 0, 0, time.UTC), 22, 19, 19, 0),
+		bar(time.Date(2024, 1, 2, 4, 2, 0, 0, time.UTC), 32, 29, 29, 10),
+	}
+
+	values := VWAP(series)
+	assert.Equal(t, []float64{10, 20, 30}, values)
+	assert.Empty(t, VWAP(nil))
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// csvHeader is the header row written by IntradayStockOutput.CSV
+var csvHeader = []string{"timestamp", "open", "high", "low", "close", "volume"}
+
+// CSV serializes the processed time series as CSV with a
+// "timestamp,open,high,low,close,volume" header, one row per bar in
+// chronological order, for spreadsheet consumers.
+//
+// Timestamps are rendered as RFC3339 in the metadata time zone, falling back
+// to each bar's own zone when the metadata zone is missing or unknown.
+func (o IntradayStockOutput) CSV() ([]byte, error) {
+	loc := o.location()
+
+	bars := slices.Clone(o.TimeSeries)
+	slices.SortStableFunc(bars, func(a, b OHLCVFloat) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, bar := range bars {
+		timestamp := bar.Timestamp
+		if loc != nil {
+			timestamp = timestamp.In(loc)
+		}
+
+		record := []string{
+			timestamp.Format(time.RFC3339),
+			csvFloat(bar.Open),
+			csvFloat(bar.High),
+			csvFloat(bar.Low),
+			csvFloat(bar.Close),
+			strconv.FormatInt(bar.Volume, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// location returns the metadata time zone, or nil when it is missing or unknown
+func (o IntradayStockOutput) location() *time.Location {
+	if o.MetaData.TimeZone == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(o.MetaData.TimeZone)
+	if err != nil {
+		return nil
+	}
+
+	return loc
+}
+
+// csvFloat formats a price with the fewest digits that represent it exactly
+func csvFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
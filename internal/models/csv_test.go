@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntradayStockOutput_CSV(t *testing.T) {
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+
+	output := IntradayStockOutput{
+		MetaData: MetaData{Symbol: "IBM", TimeZone: "US/Eastern"},
+		TimeSeries: []OHLCVFloat{
+			{Timestamp: time.Date(2024, 1, 15, 20, 0, 0, 0, eastern), Open: 185.5, High: 185.75, Low: 185.25, Close: 185.6, Volume: 125000},
+			// Given in UTC; rendered in the metadata zone
+			{Timestamp: time.Date(2024, 1, 16, 0, 55, 0, 0, time.UTC), Open: 185.2, High: 185.55, Low: 185.15, Close: 185.5, Volume: 98000},
+		},
+	}
+
+	data, err := output.CSV()
+	require.NoError(t, err)
+	assert.Equal(t, "timestamp,open,high,low,close,volume\n"+
+		"2024-01-15T19:55:00-05:00,185.2,185.55,185.15,185.5,98000\n"+
+		"2024-01-15T20:00:00-05:00,185.5,185.75,185.25,185.6,125000\n", string(data))
+
+	assert.Equal(t, time.UTC, output.TimeSeries[1].Timestamp.Location(), "CSV should not reorder or modify the series")
+}
+
+func TestIntradayStockOutput_CSVWithoutTimeZone(t *testing.T) {
+	output := IntradayStockOutput{
+		MetaData: MetaData{TimeZone: "Not/AZone"},
+		TimeSeries: []OHLCVFloat{
+			{Timestamp: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10},
+		},
+	}
+
+	data, err := output.CSV()
+	require.NoError(t, err)
+	assert.Equal(t, "timestamp,open,high,low,close,volume\n2024-01-12T00:00:00Z,1,2,0.5,1.5,10\n", string(data))
+}
+
+func TestIntradayStockOutput_CSVEmpty(t *testing.T) {
+	data, err := IntradayStockOutput{}.CSV()
+	require.NoError(t, err)
+	assert.Equal(t, "timestamp,open,high,low,close,volume\n", string(data))
+}
@@ -0,0 +1,26 @@
+package models
+
+// AnnualEarnings is the reported EPS for a single fiscal year.
+type AnnualEarnings struct {
+	FiscalDateEnding string   `json:"fiscalDateEnding"`
+	ReportedEPS      *float64 `json:"reportedEPS"`
+}
+
+// QuarterlyEarnings is the reported and estimated EPS for a single fiscal quarter.
+// Estimate-derived fields are nil when Alpha Vantage reports "None".
+type QuarterlyEarnings struct {
+	FiscalDateEnding   string   `json:"fiscalDateEnding"`
+	ReportedDate       string   `json:"reportedDate"`
+	ReportedEPS        *float64 `json:"reportedEPS"`
+	EstimatedEPS       *float64 `json:"estimatedEPS"`
+	Surprise           *float64 `json:"surprise"`
+	SurprisePercentage *float64 `json:"surprisePercentage"`
+	ReportTime         string   `json:"reportTime,omitempty"` // "pre-market" or "post-market"
+}
+
+// EarningsOutput is returned by the get_earnings MCP tool.
+type EarningsOutput struct {
+	Symbol            string              `json:"symbol"`
+	AnnualEarnings    []AnnualEarnings    `json:"annualEarnings"`
+	QuarterlyEarnings []QuarterlyEarnings `json:"quarterlyEarnings"`
+}
@@ -0,0 +1,136 @@
+package models
+
+// Financial statement reports returned by the INCOME_STATEMENT, BALANCE_SHEET
+// and CASH_FLOW functions. JSON names match the Alpha Vantage keys.
+//
+// Alpha Vantage reports amounts as numeric strings and uses "None" for values
+// that were not reported; amounts are converted to float64 and "None" becomes nil.
+
+// IncomeStatementReport is a single annual or quarterly income statement.
+type IncomeStatementReport struct {
+	FiscalDateEnding string `json:"fiscalDateEnding"`
+	ReportedCurrency string `json:"reportedCurrency"`
+
+	GrossProfit                       *float64 `json:"grossProfit"`
+	TotalRevenue                      *float64 `json:"totalRevenue"`
+	CostOfRevenue                     *float64 `json:"costOfRevenue"`
+	CostofGoodsAndServicesSold        *float64 `json:"costofGoodsAndServicesSold"`
+	OperatingIncome                   *float64 `json:"operatingIncome"`
+	SellingGeneralAndAdministrative   *float64 `json:"sellingGeneralAndAdministrative"`
+	ResearchAndDevelopment            *float64 `json:"researchAndDevelopment"`
+	OperatingExpenses                 *float64 `json:"operatingExpenses"`
+	InvestmentIncomeNet               *float64 `json:"investmentIncomeNet"`
+	NetInterestIncome                 *float64 `json:"netInterestIncome"`
+	InterestIncome                    *float64 `json:"interestIncome"`
+	InterestExpense                   *float64 `json:"interestExpense"`
+	NonInterestIncome                 *float64 `json:"nonInterestIncome"`
+	OtherNonOperatingIncome           *float64 `json:"otherNonOperatingIncome"`
+	Depreciation                      *float64 `json:"depreciation"`
+	DepreciationAndAmortization       *float64 `json:"depreciationAndAmortization"`
+	IncomeBeforeTax                   *float64 `json:"incomeBeforeTax"`
+	IncomeTaxExpense                  *float64 `json:"incomeTaxExpense"`
+	InterestAndDebtExpense            *float64 `json:"interestAndDebtExpense"`
+	NetIncomeFromContinuingOperations *float64 `json:"netIncomeFromContinuingOperations"`
+	ComprehensiveIncomeNetOfTax       *float64 `json:"comprehensiveIncomeNetOfTax"`
+	EBIT                              *float64 `json:"ebit"`
+	EBITDA                            *float64 `json:"ebitda"`
+	NetIncome                         *float64 `json:"netIncome"`
+}
+
+// BalanceSheetReport is a single annual or quarterly balance sheet.
+type BalanceSheetReport struct {
+	FiscalDateEnding string `json:"fiscalDateEnding"`
+	ReportedCurrency string `json:"reportedCurrency"`
+
+	TotalAssets                            *float64 `json:"totalAssets"`
+	TotalCurrentAssets                     *float64 `json:"totalCurrentAssets"`
+	CashAndCashEquivalentsAtCarryingValue  *float64 `json:"cashAndCashEquivalentsAtCarryingValue"`
+	CashAndShortTermInvestments            *float64 `json:"cashAndShortTermInvestments"`
+	Inventory                              *float64 `json:"inventory"`
+	CurrentNetReceivables                  *float64 `json:"currentNetReceivables"`
+	TotalNonCurrentAssets                  *float64 `json:"totalNonCurrentAssets"`
+	PropertyPlantEquipment                 *float64 `json:"propertyPlantEquipment"`
+	AccumulatedDepreciationAmortizationPPE *float64 `json:"accumulatedDepreciationAmortizationPPE"`
+	IntangibleAssets                       *float64 `json:"intangibleAssets"`
+	IntangibleAssetsExcludingGoodwill      *float64 `json:"intangibleAssetsExcludingGoodwill"`
+	Goodwill                               *float64 `json:"goodwill"`
+	Investments                            *float64 `json:"investments"`
+	LongTermInvestments                    *float64 `json:"longTermInvestments"`
+	ShortTermInvestments                   *float64 `json:"shortTermInvestments"`
+	OtherCurrentAssets                     *float64 `json:"otherCurrentAssets"`
+	OtherNonCurrentAssets                  *float64 `json:"otherNonCurrentAssets"`
+	TotalLiabilities                       *float64 `json:"totalLiabilities"`
+	TotalCurrentLiabilities                *float64 `json:"totalCurrentLiabilities"`
+	CurrentAccountsPayable                 *float64 `json:"currentAccountsPayable"`
+	DeferredRevenue                        *float64 `json:"deferredRevenue"`
+	CurrentDebt                            *float64 `json:"currentDebt"`
+	ShortTermDebt                          *float64 `json:"shortTermDebt"`
+	TotalNonCurrentLiabilities             *float64 `json:"totalNonCurrentLiabilities"`
+	CapitalLeaseObligations                *float64 `json:"capitalLeaseObligations"`
+	LongTermDebt                           *float64 `json:"longTermDebt"`
+	CurrentLongTermDebt                    *float64 `json:"currentLongTermDebt"`
+	LongTermDebtNoncurrent                 *float64 `json:"longTermDebtNoncurrent"`
+	ShortLongTermDebtTotal                 *float64 `json:"shortLongTermDebtTotal"`
+	OtherCurrentLiabilities                *float64 `json:"otherCurrentLiabilities"`
+	OtherNonCurrentLiabilities             *float64 `json:"otherNonCurrentLiabilities"`
+	TotalShareholderEquity                 *float64 `json:"totalShareholderEquity"`
+	TreasuryStock                          *float64 `json:"treasuryStock"`
+	RetainedEarnings                       *float64 `json:"retainedEarnings"`
+	CommonStock                            *float64 `json:"commonStock"`
+	CommonStockSharesOutstanding           *float64 `json:"commonStockSharesOutstanding"`
+}
+
+// CashFlowReport is a single annual or quarterly cash flow statement.
+type CashFlowReport struct {
+	FiscalDateEnding string `json:"fiscalDateEnding"`
+	ReportedCurrency string `json:"reportedCurrency"`
+
+	OperatingCashflow                                         *float64 `json:"operatingCashflow"`
+	PaymentsForOperatingActivities                            *float64 `json:"paymentsForOperatingActivities"`
+	ProceedsFromOperatingActivities                           *float64 `json:"proceedsFromOperatingActivities"`
+	ChangeInOperatingLiabilities                              *float64 `json:"changeInOperatingLiabilities"`
+	ChangeInOperatingAssets                                   *float64 `json:"changeInOperatingAssets"`
+	DepreciationDepletionAndAmortization                      *float64 `json:"depreciationDepletionAndAmortization"`
+	CapitalExpenditures                                       *float64 `json:"capitalExpenditures"`
+	ChangeInReceivables                                       *float64 `json:"changeInReceivables"`
+	ChangeInInventory                                         *float64 `json:"changeInInventory"`
+	ProfitLoss                                                *float64 `json:"profitLoss"`
+	CashflowFromInvestment                                    *float64 `json:"cashflowFromInvestment"`
+	CashflowFromFinancing                                     *float64 `json:"cashflowFromFinancing"`
+	ProceedsFromRepaymentsOfShortTermDebt                     *float64 `json:"proceedsFromRepaymentsOfShortTermDebt"`
+	PaymentsForRepurchaseOfCommonStock                        *float64 `json:"paymentsForRepurchaseOfCommonStock"`
+	PaymentsForRepurchaseOfEquity                             *float64 `json:"paymentsForRepurchaseOfEquity"`
+	PaymentsForRepurchaseOfPreferredStock                     *float64 `json:"paymentsForRepurchaseOfPreferredStock"`
+	DividendPayout                                            *float64 `json:"dividendPayout"`
+	DividendPayoutCommonStock                                 *float64 `json:"dividendPayoutCommonStock"`
+	DividendPayoutPreferredStock                              *float64 `json:"dividendPayoutPreferredStock"`
+	ProceedsFromIssuanceOfCommonStock                         *float64 `json:"proceedsFromIssuanceOfCommonStock"`
+	ProceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet *float64 `json:"proceedsFromIssuanceOfLongTermDebtAndCapitalSecuritiesNet"`
+	ProceedsFromIssuanceOfPreferredStock                      *float64 `json:"proceedsFromIssuanceOfPreferredStock"`
+	ProceedsFromRepurchaseOfEquity                            *float64 `json:"proceedsFromRepurchaseOfEquity"`
+	ProceedsFromSaleOfTreasuryStock                           *float64 `json:"proceedsFromSaleOfTreasuryStock"`
+	ChangeInCashAndCashEquivalents                            *float64 `json:"changeInCashAndCashEquivalents"`
+	ChangeInExchangeRate                                      *float64 `json:"changeInExchangeRate"`
+	NetIncome                                                 *float64 `json:"netIncome"`
+}
+
+// IncomeStatementOutput is returned by the get_income_statement MCP tool.
+type IncomeStatementOutput struct {
+	Symbol           string                  `json:"symbol"`
+	AnnualReports    []IncomeStatementReport `json:"annualReports"`
+	QuarterlyReports []IncomeStatementReport `json:"quarterlyReports"`
+}
+
+// BalanceSheetOutput is returned by the get_balance_sheet MCP tool.
+type BalanceSheetOutput struct {
+	Symbol           string               `json:"symbol"`
+	AnnualReports    []BalanceSheetReport `json:"annualReports"`
+	QuarterlyReports []BalanceSheetReport `json:"quarterlyReports"`
+}
+
+// CashFlowOutput is returned by the get_cash_flow MCP tool.
+type CashFlowOutput struct {
+	Symbol           string           `json:"symbol"`
+	AnnualReports    []CashFlowReport `json:"annualReports"`
+	QuarterlyReports []CashFlowReport `json:"quarterlyReports"`
+}
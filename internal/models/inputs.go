@@ -6,6 +6,19 @@
 // All structures include validation tags to ensure proper data types and formats.
 package models
 
+// CallOptions holds the per-call options accepted by every tool. It is
+// embedded in each input so its fields appear at the top level of the call
+// arguments.
+type CallOptions struct {
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty" jsonschema:"Optional time budget for this call in seconds, at most 120. Overrides the default 30 second timeout, e.g. timeoutSeconds=5 to fail fast or timeoutSeconds=90 to allow retries on a full historical pull."`
+}
+
+// CallTimeout returns the per-call timeout in seconds requested by the
+// caller, or nil when the default timeout applies.
+func (o CallOptions) CallTimeout() *int {
+	return o.TimeoutSeconds
+}
+
 // SymbolInput represents the input parameters for stock-related MCP tools.
 //
 // This struct is used by MCP clients to specify which stock they want to query.
@@ -32,13 +45,160 @@ type SymbolInput struct {
 	// JSON Schema validation ensures this field is provided and is a string.
 	// The description helps AI models understand what kind of input is expected.
 	Symbol string `json:"symbol" jsonschema:"the symbol of the stock to get"`
+
+	CallOptions
+}
+
+// OverviewInput represents the input parameters for the stock overview tool.
+type OverviewInput struct {
+	Symbol  string `json:"symbol" jsonschema:"the symbol of the stock to get"`
+	Numeric *bool  `json:"numeric,omitempty" jsonschema:"Set numeric=true to also return the numeric fields (market capitalization, ratios, margins, prices) parsed into numbers under numeric, with unreported values omitted."`
+
+	CallOptions
+}
+
+// SymbolsInput represents the input parameters for tools that query a basket
+// of stocks in one call.
+type SymbolsInput struct {
+	Symbols []string `json:"symbols" jsonschema:"the symbols of the stocks to get, e.g. ['AAPL', 'MSFT']. At most 20 symbols per call; duplicates are fetched once."`
+
+	CallOptions
 }
 
 type IntradayPriceInput struct {
-	Symbol        string  `json:"symbol" jsonschema:"the symbol of the stock to get"`
-	Interval      string  `json:"interval" jsonschema:"the interval of the intraday price data e.g. '1min', '5min', '15min', '30min', '60min'"`
-	Adjusted      *bool   `json:"adjusted" jsonschema:"By default, adjusted=true and the output time series is adjusted by historical split and dividend events. Set adjusted=false to query raw (as-traded) intraday values."`
-	ExtendedHours *bool   `json:"extendedHours" jsonschema:"By default, extended_hours=true and the output time series will include both the regular trading hours and the extended (pre-market and post-market) trading hours (4:00am to 8:00pm Eastern Time for the US market). Set extended_hours=false to query regular trading hours (9:30am to 4:00pm US Eastern Time) only."`
-	Month         *string `json:"month" jsonschema:"By default, this parameter is not set and the API will return intraday data for the most recent days of trading. You can use the month parameter (in YYYY-MM format) to query a specific month in history. For example, month=2009-01. Any month in the last 20+ years since 2000-01 (January 2000) is supported."`
-	OutputSize    *string `json:"outputSize" jsonschema:"By default, output_size=compact and the API will return a compact set of data points. You can use the output_size parameter to query a full set of data points. For example, output_size=full. Any month in the last 20+ years since 2000-01 (January 2000) is supported."`
+	Symbol           string   `json:"symbol" jsonschema:"the symbol of the stock to get"`
+	Interval         string   `json:"interval,omitempty" jsonschema:"the interval of the intraday price data e.g. '1min', '5min', '15min', '30min', '60min'. May be omitted when the server configures a default interval."`
+	Adjusted         *bool    `json:"adjusted" jsonschema:"By default, adjusted=true (unless the server configures another default) and the output time series is adjusted by historical split and dividend events. Set adjusted=false to query raw (as-traded) intraday values."`
+	ExtendedHours    *bool    `json:"extendedHours" jsonschema:"By default, extended_hours=true and the output time series will include both the regular trading hours and the extended (pre-market and post-market) trading hours (4:00am to 8:00pm Eastern Time for the US market). Set extended_hours=false to query regular trading hours (9:30am to 4:00pm US Eastern Time) only."`
+	Month            *string  `json:"month" jsonschema:"By default, this parameter is not set and the API will return intraday data for the most recent days of trading. You can use the month parameter (in YYYY-MM format) to query a specific month in history. For example, month=2009-01. Any month in the last 20+ years since 2000-01 (January 2000) is supported."`
+	OutputSize       *string  `json:"outputSize" jsonschema:"By default, output_size=full for the 1min and 5min intervals and output_size=compact (the latest 100 data points) for 15min, 30min and 60min, unless the server configures other defaults per interval or for all intervals. Set output_size=compact or output_size=full to choose the size regardless of interval."`
+	IncludeSMA       *bool    `json:"includeSMA" jsonschema:"Set includeSMA=true to attach a simple moving average of close prices, computed locally from the returned bars without an extra API call."`
+	IncludeEMA       *bool    `json:"includeEMA" jsonschema:"Set includeEMA=true to attach an exponential moving average of close prices, computed locally from the returned bars without an extra API call."`
+	IndicatorPeriod  *int     `json:"indicatorPeriod" jsonschema:"The number of bars used for includeSMA, includeEMA and includeBollinger. Defaults to 20 and must not exceed the number of returned bars."`
+	IncludeBollinger *bool    `json:"includeBollinger" jsonschema:"Set includeBollinger=true to attach Bollinger Bands of close prices over indicatorPeriod bars, computed locally from the returned bars without an extra API call."`
+	BollingerStdDev  *float64 `json:"bollingerStdDev" jsonschema:"The number of standard deviations between the middle Bollinger Band and the upper and lower bands. Defaults to 2 and must be positive."`
+	IncludeVWAP      *bool    `json:"includeVWAP" jsonschema:"Set includeVWAP=true to attach the running volume-weighted average price, computed locally from the returned bars and reset at the start of each trading day."`
+	Datatype         *string  `json:"datatype" jsonschema:"By default, datatype=json. Set datatype=csv to fetch the bars from Alpha Vantage as CSV, which is much smaller for large pulls. The tool output has the same shape either way."`
+	OnlyRegularHours *bool    `json:"onlyRegularHours" jsonschema:"Set onlyRegularHours=true to return only bars from the regular session (09:30 up to 16:00 US/Eastern, excluding the 16:00 bar). Unlike extendedHours this filters locally, so the full response stays cached."`
+	IncludeSummary   *bool    `json:"includeSummary" jsonschema:"Set includeSummary=true to attach min, max and mean close, total volume, VWAP and percent change computed over the returned bars, after any filtering and pagination."`
+	IncludePatterns  *bool    `json:"includePatterns" jsonschema:"Set includePatterns=true to attach the doji, hammer and bullish or bearish engulfing candlestick patterns detected in the returned bars."`
+	IncludeReturns   *bool    `json:"includeReturns" jsonschema:"Set includeReturns=true to attach the close-to-close return of each bar over the previous one and the cumulative return over the returned bars, as fractions (0.01 is 1%). Returns from a zero close are reported as 0."`
+	Resample         *string  `json:"resample" jsonschema:"Set resample to a coarser interval that is a multiple of interval, e.g. resample=15min with interval=1min, to aggregate the fetched bars locally without another API call. Buckets are aligned to the 09:30 session open and buckets without bars are omitted. Indicators, returns and patterns are computed on the resampled bars."`
+	From             *string  `json:"from" jsonschema:"Only return bars at or after this time, as YYYY-MM-DD (whole day, exchange time) or RFC3339, e.g. 2024-01-15 or 2024-01-15T09:30:00-05:00."`
+	To               *string  `json:"to" jsonschema:"Only return bars at or before this time, as YYYY-MM-DD (whole day, exchange time) or RFC3339. Must not be before from."`
+	Limit            *int     `json:"limit" jsonschema:"The maximum number of bars to return, at most 5000. By default all bars are returned; totalBars reports the count before limit and offset are applied."`
+	Offset           *int     `json:"offset" jsonschema:"The number of bars to skip before applying limit. Defaults to 0."`
+	Latest           *bool    `json:"latest" jsonschema:"Set latest=true to order bars most recent first, so limit and offset page back from the newest bar."`
+	StrictParsing    *bool    `json:"strictParsing" jsonschema:"By default, strictParsing=true and the request fails if any bar cannot be parsed. Set strictParsing=false to drop unparseable bars and report them in skippedBars and parseErrors instead."`
+	Entitlement      *string  `json:"entitlement,omitempty" jsonschema:"Premium API keys only: set entitlement=realtime for live bars or entitlement=delayed for 15-minute delayed bars. By default the key's standard (delayed or end-of-day) data is returned."`
+
+	CallOptions
+}
+
+// PeriodicPriceInput represents the input parameters for the weekly and
+// monthly price tools.
+type PeriodicPriceInput struct {
+	Symbol     string  `json:"symbol" jsonschema:"the symbol of the stock to get"`
+	Adjusted   *bool   `json:"adjusted" jsonschema:"By default, adjusted=false and the raw (as-traded) series is returned. Set adjusted=true to query the adjusted series, which includes adjusted close and dividend amount for each period."`
+	OutputSize *string `json:"outputSize" jsonschema:"By default, output_size=compact and only the latest 100 data points are returned. Set output_size=full to return the full 20+ year history."`
+	Datatype   *string `json:"datatype" jsonschema:"By default, datatype=json. Set datatype=csv to fetch the series from Alpha Vantage as CSV, which is much smaller for the full history. The tool output has the same shape either way."`
+	From       *string `json:"from" jsonschema:"Only return data points at or after this time, as YYYY-MM-DD (whole day, exchange time) or RFC3339, e.g. 2024-01-15 or 2024-01-15T09:30:00-05:00."`
+	To         *string `json:"to" jsonschema:"Only return data points at or before this time, as YYYY-MM-DD (whole day, exchange time) or RFC3339. Must not be before from."`
+
+	CallOptions
+}
+
+// NewsSentimentInput represents the input parameters for the news sentiment tool.
+// All fields are optional; without filters the latest market news is returned.
+type NewsSentimentInput struct {
+	Tickers  *string `json:"tickers" jsonschema:"Comma-separated list of symbols to filter articles by, e.g. 'AAPL' or 'COIN,CRYPTO:BTC,FOREX:USD'."`
+	Topics   *string `json:"topics" jsonschema:"Comma-separated list of news topics, e.g. 'technology' or 'technology,ipo'. Supported topics: blockchain, earnings, ipo, mergers_and_acquisitions, financial_markets, economy_fiscal, economy_monetary, economy_macro, energy_transportation, finance, life_sciences, manufacturing, real_estate, retail_wholesale, technology."`
+	TimeFrom *string `json:"timeFrom" jsonschema:"Only return articles published at or after this time, in YYYYMMDDTHHMM format, e.g. 20220410T0130."`
+	TimeTo   *string `json:"timeTo" jsonschema:"Only return articles published at or before this time, in YYYYMMDDTHHMM format. Requires timeFrom to be meaningful."`
+	Sort     *string `json:"sort" jsonschema:"Sort order of the articles: LATEST (default), EARLIEST or RELEVANCE."`
+	Limit    *int    `json:"limit" jsonschema:"Maximum number of articles to return, between 1 and 1000. Defaults to 50."`
+
+	CallOptions
+}
+
+// ListingStatusInput represents the input parameters for the listing status tool.
+// Without parameters the symbols actively listed as of the latest trading day are returned.
+type ListingStatusInput struct {
+	Date  *string `json:"date,omitempty" jsonschema:"Return the listings as of this date, in YYYY-MM-DD format, e.g. 2013-08-03. Any date later than 2010-01-01 is supported. Defaults to the latest trading day."`
+	State *string `json:"state,omitempty" jsonschema:"active (default) returns actively traded stocks and ETFs; delisted returns delisted assets."`
+
+	CallOptions
+}
+
+// FunctionInput represents the input parameters for the generic Alpha Vantage
+// function tool, for endpoints without a dedicated tool.
+type FunctionInput struct {
+	Function string            `json:"function" jsonschema:"the Alpha Vantage function to call, in uppercase e.g. 'SYMBOL_SEARCH', 'TOP_GAINERS_LOSERS' or 'TIME_SERIES_DAILY'"`
+	Params   map[string]string `json:"params,omitempty" jsonschema:"the other query parameters of the function by name, e.g. {\"keywords\": \"tesla\"}. apikey, function and datatype are set by the server and ignored here."`
+
+	CallOptions
+}
+
+// ExchangeRateInput represents the input parameters for the exchange rate tool.
+type ExchangeRateInput struct {
+	FromCurrency string `json:"fromCurrency" jsonschema:"the currency to convert from, as a 3-letter uppercase code e.g. 'USD' or 'BTC'"`
+	ToCurrency   string `json:"toCurrency" jsonschema:"the currency to convert to, as a 3-letter uppercase code e.g. 'JPY' or 'EUR'"`
+
+	CallOptions
+}
+
+// FXIntradayInput represents the input parameters for the FX intraday tool.
+type FXIntradayInput struct {
+	FromSymbol string  `json:"fromSymbol" jsonschema:"the base currency as a 3-letter uppercase code e.g. 'EUR'"`
+	ToSymbol   string  `json:"toSymbol" jsonschema:"the quote currency as a 3-letter uppercase code e.g. 'USD'"`
+	Interval   string  `json:"interval" jsonschema:"the interval of the intraday rate data e.g. '1min', '5min', '15min', '30min', '60min'"`
+	OutputSize *string `json:"outputSize" jsonschema:"By default, output_size=compact and only the latest 100 data points are returned. Set output_size=full to return the full-length intraday series."`
+
+	CallOptions
+}
+
+// FXDailyInput represents the input parameters for the FX daily tool.
+type FXDailyInput struct {
+	FromSymbol string  `json:"fromSymbol" jsonschema:"the base currency as a 3-letter uppercase code e.g. 'EUR'"`
+	ToSymbol   string  `json:"toSymbol" jsonschema:"the quote currency as a 3-letter uppercase code e.g. 'USD'"`
+	OutputSize *string `json:"outputSize" jsonschema:"By default, output_size=compact and only the latest 100 data points are returned. Set output_size=full to return the full 20+ year history."`
+	Datatype   *string `json:"datatype" jsonschema:"By default, datatype=json. Set datatype=csv to fetch the series from Alpha Vantage as CSV, which is much smaller for the full history. The tool output has the same shape either way."`
+
+	CallOptions
+}
+
+// CryptoDailyInput represents the input parameters for the crypto daily tool.
+type CryptoDailyInput struct {
+	Symbol string `json:"symbol" jsonschema:"the digital currency symbol e.g. 'BTC' or 'ETH'"`
+	Market string `json:"market" jsonschema:"the exchange market to price the digital currency in, as a 3-letter uppercase code e.g. 'USD' or 'EUR'"`
+
+	CallOptions
+}
+
+// CryptoIntradayInput represents the input parameters for the crypto intraday tool.
+type CryptoIntradayInput struct {
+	Symbol     string  `json:"symbol" jsonschema:"the digital currency symbol e.g. 'BTC' or 'ETH'"`
+	Market     string  `json:"market" jsonschema:"the exchange market to price the digital currency in, as a 3-letter uppercase code e.g. 'USD' or 'EUR'"`
+	Interval   string  `json:"interval" jsonschema:"the interval of the intraday price data e.g. '1min', '5min', '15min', '30min', '60min'"`
+	OutputSize *string `json:"outputSize" jsonschema:"By default, output_size=compact and only the latest 100 data points are returned. Set output_size=full to return the full-length intraday series."`
+
+	CallOptions
+}
+
+// IndicatorInput represents the input parameters for the technical indicator tools.
+type IndicatorInput struct {
+	Symbol     string `json:"symbol" jsonschema:"the symbol of the stock to get"`
+	Interval   string `json:"interval" jsonschema:"the interval between data points: '1min', '5min', '15min', '30min', '60min', 'daily', 'weekly' or 'monthly'"`
+	TimePeriod int    `json:"timePeriod" jsonschema:"the number of data points used to calculate each value, e.g. 60 or 200. Ignored by MACD, which uses the standard 12/26/9 periods."`
+	SeriesType string `json:"seriesType" jsonschema:"the price type used in the calculation: 'open', 'high', 'low' or 'close'"`
+
+	CallOptions
+}
+
+// TranscriptInput represents the input parameters for the earnings call transcript tool.
+type TranscriptInput struct {
+	Symbol  string `json:"symbol" jsonschema:"the symbol of the stock to get"`
+	Quarter string `json:"quarter" jsonschema:"the fiscal quarter of the earnings call in YYYYQn format, e.g. 2024Q1. Quarters since 2010Q1 are supported."`
+
+	CallOptions
 }
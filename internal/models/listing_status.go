@@ -0,0 +1,18 @@
+package models
+
+// ListingStatus is a single security from the LISTING_STATUS listing.
+type ListingStatus struct {
+	Symbol        string `json:"symbol"`
+	Name          string `json:"name"`
+	Exchange      string `json:"exchange"`
+	AssetType     string `json:"assetType"` // "Stock" or "ETF"
+	IPODate       string `json:"ipoDate"`
+	DelistingDate string `json:"delistingDate,omitempty"` // empty for active listings
+	Status        string `json:"status"`                  // "Active" or "Delisted"
+}
+
+// ListingStatusOutput is returned by the get_listing_status MCP tool.
+type ListingStatusOutput struct {
+	Count    int             `json:"count"`
+	Listings []ListingStatus `json:"listings"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// NDJSON serializes the processed time series as newline-delimited JSON, one
+// OHLCVFloat object per line in chronological order, for streaming ingestion
+// and line-oriented tools such as jq.
+func (o IntradayStockOutput) NDJSON() ([]byte, error) {
+	bars := slices.Clone(o.TimeSeries)
+	slices.SortStableFunc(bars, func(a, b OHLCVFloat) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	// encoding/json keeps models buildable where sonic is not; the encoder
+	// ends each value with a newline and matches parser.Default's output
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	for _, bar := range bars {
+		if err := encoder.Encode(bar); err != nil {
+			return nil, fmt.Errorf("failed to encode bar at %s: %w", bar.Timestamp, err)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
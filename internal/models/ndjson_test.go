@@ -0,0 +1,51 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntradayStockOutput_NDJSON(t *testing.T) {
+	output := IntradayStockOutput{
+		MetaData: MetaData{Symbol: "IBM", TimeZone: "US/Eastern"},
+		TimeSeries: []OHLCVFloat{
+			{Timestamp: time.Date(2024, 1, 16, 1, 0, 0, 0, time.UTC), Open: 185.5, High: 185.75, Low: 185.25, Close: 185.6, Volume: 125000},
+			{Timestamp: time.Date(2024, 1, 16, 0, 55, 0, 0, time.UTC), Open: 185.2, High: 185.55, Low: 185.15, Close: 185.5, Volume: 98000},
+			{Timestamp: time.Date(2024, 1, 16, 1, 5, 0, 0, time.UTC), Open: 185.6, High: 186, Low: 185.6, Close: 185.9, Volume: 101000},
+		},
+	}
+
+	data, err := output.NDJSON()
+	require.NoError(t, err)
+
+	var bars []OHLCVFloat
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var bar OHLCVFloat
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &bar), "Each line must be a JSON object on its own")
+		bars = append(bars, bar)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, bars, len(output.TimeSeries))
+	assert.True(t, bars[0].Timestamp.Equal(output.TimeSeries[1].Timestamp), "Bars should be sorted oldest first")
+	assert.True(t, bars[1].Timestamp.Equal(output.TimeSeries[0].Timestamp))
+	assert.True(t, bars[2].Timestamp.Equal(output.TimeSeries[2].Timestamp))
+	assert.Equal(t, 185.5, bars[0].Close)
+	assert.Equal(t, int64(101000), bars[2].Volume)
+
+	assert.True(t, bytes.HasSuffix(data, []byte("}\n")))
+	assert.Equal(t, time.Date(2024, 1, 16, 1, 0, 0, 0, time.UTC), output.TimeSeries[0].Timestamp, "NDJSON should not reorder the series")
+}
+
+func TestIntradayStockOutput_NDJSONEmpty(t *testing.T) {
+	data, err := IntradayStockOutput{}.NDJSON()
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
@@ -4,6 +4,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/yeferson59/finance-mcp/pkg/errors"
 )
 
 // OverviewOutput represents comprehensive stock and company information
@@ -71,15 +73,32 @@ type OverviewOutput struct {
 	EBITDA        string `json:"EBITDA,omitempty"`        // Earnings before interest, taxes, depreciation, and amortization
 	AssetType     string `json:"AssetType,omitempty"`     // Type of asset (usually "Common Stock")
 	CIK           string `json:"CIK,omitempty"`           // Central Index Key (SEC identifier)
+
+	// Numeric holds the numeric fields parsed into numbers; only set when numeric is requested
+	Numeric *OverviewNumeric `json:"numeric,omitempty"`
+}
+
+// OverviewBatchOutput is returned by the batch overview tool. Each requested
+// symbol appears in exactly one of the two maps.
+type OverviewBatchOutput struct {
+	Overviews map[string]OverviewOutput  `json:"overviews"`        // Keyed by uppercase symbol
+	Errors    map[string]errors.Envelope `json:"errors,omitempty"` // Symbols that could not be fetched
 }
 
+// OHLCVFloat is a single parsed price bar.
+//
+// Timestamp is in the zone reported by the response's metadata (e.g.
+// US/Eastern for stock series), or UTC when the zone is missing or unknown,
+// so it marshals with the correct offset.
 type OHLCVFloat struct {
-	Timestamp time.Time `json:"timestamp"`
-	Open      float64   `json:"open"`
-	High      float64   `json:"high"`
-	Low       float64   `json:"low"`
-	Close     float64   `json:"close"`
-	Volume    int64     `json:"volume"`
+	Timestamp      time.Time `json:"timestamp"`
+	Open           float64   `json:"open"`
+	High           float64   `json:"high"`
+	Low            float64   `json:"low"`
+	Close          float64   `json:"close"`
+	Volume         int64     `json:"volume"`
+	AdjustedClose  float64   `json:"adjustedClose,omitempty"`  // Only set for adjusted series
+	DividendAmount float64   `json:"dividendAmount,omitempty"` // Only set for adjusted series
 }
 
 type MetaData struct {
@@ -89,9 +108,228 @@ type MetaData struct {
 	Interval      string `json:"4. Interval"`
 	OutputSize    string `json:"5. Output Size"`
 	TimeZone      string `json:"6. Time Zone"`
+
+	// LastRefreshedTime is LastRefreshed parsed in TimeZone; a date without
+	// a time of day is the start of that day. Omitted when LastRefreshed
+	// cannot be parsed.
+	LastRefreshedTime *time.Time `json:"lastRefreshedTime,omitempty"`
 }
 
 type IntradayStockOutput struct {
+	MetaData   MetaData         `json:"metaData"`
+	TimeSeries []OHLCVFloat     `json:"timeSeries"`
+	SMA        []IndicatorPoint `json:"sma,omitempty"`     // Only set when includeSMA is requested
+	EMA        []IndicatorPoint `json:"ema,omitempty"`     // Only set when includeEMA is requested
+	VWAP       []IndicatorPoint `json:"vwap,omitempty"`    // Only set when includeVWAP is requested
+	Summary    *Summary         `json:"summary,omitempty"` // Only set when includeSummary is requested
+	Returns    []IndicatorPoint `json:"returns,omitempty"` // Only set when includeReturns is requested
+
+	// BollingerBands is only set when includeBollinger is requested
+	BollingerBands *BollingerBands `json:"bollingerBands,omitempty"`
+
+	// Patterns lists the candlestick patterns detected in the returned bars;
+	// only set when includePatterns is requested
+	Patterns []PatternHit `json:"patterns,omitempty"`
+
+	// CumulativeReturn is the close-to-close return from the oldest to the
+	// newest returned bar; only set when includeReturns is requested
+	CumulativeReturn *float64 `json:"cumulativeReturn,omitempty"`
+
+	// TotalBars is the number of bars before limit and offset were applied
+	TotalBars int `json:"totalBars,omitempty"`
+
+	// SkippedBars and ParseErrors report bars dropped because they could not be
+	// parsed; only set when strictParsing is disabled
+	SkippedBars int      `json:"skippedBars,omitempty"`
+	ParseErrors []string `json:"parseErrors,omitempty"`
+
+	// AgeSeconds is how long ago metaData.lastRefreshed was and IsStale
+	// whether that exceeds the server's staleness threshold; both are
+	// omitted when the refresh time cannot be parsed
+	AgeSeconds *int64 `json:"ageSeconds,omitempty"`
+	IsStale    *bool  `json:"isStale,omitempty"`
+}
+
+// BollingerBands holds the bands computed over close prices. Bars before the
+// first full window have no points.
+type BollingerBands struct {
+	Upper  []IndicatorPoint `json:"upper"`
+	Middle []IndicatorPoint `json:"middle"` // The simple moving average
+	Lower  []IndicatorPoint `json:"lower"`
+}
+
+// PatternHit is a candlestick pattern detected at a bar, e.g. "doji" or
+// "bullish_engulfing".
+type PatternHit struct {
+	Timestamp time.Time `json:"timestamp"`
+	Pattern   string    `json:"pattern"`
+}
+
+// Summary holds statistics over the bars returned by a request, so clients
+// do not need to aggregate the series themselves.
+type Summary struct {
+	Bars          int       `json:"bars"`
+	Start         time.Time `json:"start"` // Timestamp of the oldest bar
+	End           time.Time `json:"end"`   // Timestamp of the newest bar
+	MinClose      float64   `json:"minClose"`
+	MaxClose      float64   `json:"maxClose"`
+	MeanClose     float64   `json:"meanClose"`
+	TotalVolume   int64     `json:"totalVolume"`
+	VWAP          *float64  `json:"vwap,omitempty"` // Volume-weighted typical price; nil when no volume traded
+	PercentChange float64   `json:"percentChange"`  // From the oldest bar's open to the newest bar's close
+}
+
+// PeriodicStockOutput is returned by the weekly and monthly price tools.
+// Interval and output size metadata are not reported by these endpoints.
+type PeriodicStockOutput struct {
 	MetaData   MetaData     `json:"metaData"`
 	TimeSeries []OHLCVFloat `json:"timeSeries"`
 }
+
+// TickerSentiment is the sentiment of a news article towards a single ticker.
+type TickerSentiment struct {
+	Ticker         string  `json:"ticker"`
+	RelevanceScore float64 `json:"relevanceScore"` // 0 to 1, higher is more relevant
+	SentimentScore float64 `json:"sentimentScore"` // -1 (bearish) to 1 (bullish)
+	SentimentLabel string  `json:"sentimentLabel"` // e.g. "Somewhat-Bullish"
+}
+
+// NewsArticle is a single article returned by the news sentiment tool.
+type NewsArticle struct {
+	Title                 string            `json:"title"`
+	URL                   string            `json:"url"`
+	TimePublished         time.Time         `json:"timePublished"`
+	Summary               string            `json:"summary,omitempty"`
+	Source                string            `json:"source,omitempty"`
+	OverallSentimentScore float64           `json:"overallSentimentScore"`
+	OverallSentimentLabel string            `json:"overallSentimentLabel"`
+	TickerSentiment       []TickerSentiment `json:"tickerSentiment,omitempty"`
+}
+
+// NewsSentimentOutput is returned by the get_news_sentiment MCP tool.
+type NewsSentimentOutput struct {
+	Items int           `json:"items"`
+	Feed  []NewsArticle `json:"feed"`
+}
+
+// GlobalQuoteOutput is returned by the get_global_quote MCP tool: the latest
+// price and volume of a stock.
+type GlobalQuoteOutput struct {
+	Symbol           string  `json:"symbol"`
+	Open             float64 `json:"open"`
+	High             float64 `json:"high"`
+	Low              float64 `json:"low"`
+	Price            float64 `json:"price"`
+	Volume           int64   `json:"volume"`
+	LatestTradingDay string  `json:"latestTradingDay"` // YYYY-MM-DD
+	PreviousClose    float64 `json:"previousClose"`
+	Change           float64 `json:"change"`
+	ChangePercent    float64 `json:"changePercent"` // e.g. 1.25 for a 1.25% gain
+
+	// AgeSeconds is how long ago latestTradingDay ended and IsStale whether
+	// that exceeds the server's staleness threshold; both are omitted when
+	// the date cannot be parsed
+	AgeSeconds *int64 `json:"ageSeconds,omitempty"`
+	IsStale    *bool  `json:"isStale,omitempty"`
+}
+
+// GlobalQuoteBatchOutput is returned by the get_global_quotes MCP tool. Each
+// requested symbol appears in exactly one of the two maps.
+type GlobalQuoteBatchOutput struct {
+	Quotes map[string]GlobalQuoteOutput `json:"quotes"`           // Keyed by uppercase symbol
+	Errors map[string]errors.Envelope   `json:"errors,omitempty"` // Symbols that could not be fetched
+}
+
+// ExchangeRateOutput is returned by the get_exchange_rate MCP tool.
+// Bid and ask prices are nil when Alpha Vantage does not quote them.
+type ExchangeRateOutput struct {
+	FromCurrencyCode string   `json:"fromCurrencyCode"`
+	FromCurrencyName string   `json:"fromCurrencyName"`
+	ToCurrencyCode   string   `json:"toCurrencyCode"`
+	ToCurrencyName   string   `json:"toCurrencyName"`
+	ExchangeRate     float64  `json:"exchangeRate"`
+	LastRefreshed    string   `json:"lastRefreshed"`
+	TimeZone         string   `json:"timeZone"`
+	BidPrice         *float64 `json:"bidPrice"`
+	AskPrice         *float64 `json:"askPrice"`
+
+	// AgeSeconds is how long ago lastRefreshed was and IsStale whether that
+	// exceeds the server's staleness threshold; both are omitted when the
+	// refresh time cannot be parsed
+	AgeSeconds *int64 `json:"ageSeconds,omitempty"`
+	IsStale    *bool  `json:"isStale,omitempty"`
+}
+
+// FXMetaData describes an FX time series. Interval is only set for intraday series.
+type FXMetaData struct {
+	Information   string `json:"information"`
+	FromSymbol    string `json:"fromSymbol"`
+	ToSymbol      string `json:"toSymbol"`
+	LastRefreshed string `json:"lastRefreshed"`
+	Interval      string `json:"interval,omitempty"`
+	OutputSize    string `json:"outputSize"`
+	TimeZone      string `json:"timeZone"`
+}
+
+// FXTimeSeriesOutput is returned by the get_fx_intraday and get_fx_daily MCP tools.
+// FX series do not report volume, so Volume is always zero.
+type FXTimeSeriesOutput struct {
+	MetaData   FXMetaData   `json:"metaData"`
+	TimeSeries []OHLCVFloat `json:"timeSeries"`
+}
+
+// CryptoBar is a single digital currency price bar in the requested market.
+// Volume is fractional because digital currencies trade in fractional units.
+type CryptoBar struct {
+	Timestamp time.Time `json:"timestamp"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+}
+
+// CryptoMetaData describes a digital currency time series.
+// Interval is only set for intraday series.
+type CryptoMetaData struct {
+	Information         string `json:"information"`
+	DigitalCurrencyCode string `json:"digitalCurrencyCode"`
+	DigitalCurrencyName string `json:"digitalCurrencyName"`
+	MarketCode          string `json:"marketCode"`
+	MarketName          string `json:"marketName"`
+	LastRefreshed       string `json:"lastRefreshed"`
+	Interval            string `json:"interval,omitempty"`
+	TimeZone            string `json:"timeZone"`
+}
+
+// CryptoOutput is returned by the get_crypto_daily and get_crypto_intraday MCP tools.
+type CryptoOutput struct {
+	MetaData   CryptoMetaData `json:"metaData"`
+	TimeSeries []CryptoBar    `json:"timeSeries"`
+}
+
+// IndicatorPoint is a single technical indicator value.
+// For MACD, Value is the MACD line and Signal and Histogram are also set.
+type IndicatorPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     float64   `json:"value"`
+	Signal    *float64  `json:"signal,omitempty"`
+	Histogram *float64  `json:"histogram,omitempty"`
+}
+
+// IndicatorOutput is returned by the technical indicator MCP tools.
+type IndicatorOutput struct {
+	Symbol        string           `json:"symbol"`
+	Indicator     string           `json:"indicator"`
+	Interval      string           `json:"interval"`
+	LastRefreshed string           `json:"lastRefreshed"`
+	TimeZone      string           `json:"timeZone"`
+	Values        []IndicatorPoint `json:"values"`
+}
+
+// FunctionOutput is returned by the get_alpha_function MCP tool: the JSON
+// response of an Alpha Vantage function, unchanged.
+type FunctionOutput struct {
+	Function string         `json:"function"`
+	Data     map[string]any `json:"data"`
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// OverviewNumeric holds the numeric fields of an OverviewOutput parsed into
+// numbers. A field is nil when Alpha Vantage did not report it, either by
+// omitting it or with the "None" or "-" sentinels.
+type OverviewNumeric struct {
+	// Market Data
+	MarketCapitalization       *int64   `json:"marketCapitalization,omitempty"`
+	SharesOutstanding          *int64   `json:"sharesOutstanding,omitempty"`
+	BookValue                  *float64 `json:"bookValue,omitempty"`
+	DividendPerShare           *float64 `json:"dividendPerShare,omitempty"`
+	DividendYield              *float64 `json:"dividendYield,omitempty"`
+	EPS                        *float64 `json:"eps,omitempty"`
+	RevenuePerShareTTM         *float64 `json:"revenuePerShareTTM,omitempty"`
+	ProfitMargin               *float64 `json:"profitMargin,omitempty"`
+	OperatingMarginTTM         *float64 `json:"operatingMarginTTM,omitempty"`
+	ReturnOnAssetsTTM          *float64 `json:"returnOnAssetsTTM,omitempty"`
+	ReturnOnEquityTTM          *float64 `json:"returnOnEquityTTM,omitempty"`
+	RevenueTTM                 *int64   `json:"revenueTTM,omitempty"`
+	GrossProfitTTM             *int64   `json:"grossProfitTTM,omitempty"`
+	DilutedEPSTTM              *float64 `json:"dilutedEPSTTM,omitempty"`
+	QuarterlyEarningsGrowthYOY *float64 `json:"quarterlyEarningsGrowthYOY,omitempty"`
+	QuarterlyRevenueGrowthYOY  *float64 `json:"quarterlyRevenueGrowthYOY,omitempty"`
+
+	// Financial Ratios
+	PERatio              *float64 `json:"peRatio,omitempty"`
+	PEGRatio             *float64 `json:"pegRatio,omitempty"`
+	PriceToBookRatio     *float64 `json:"priceToBookRatio,omitempty"`
+	PriceToSalesRatioTTM *float64 `json:"priceToSalesRatioTTM,omitempty"`
+	EVToRevenue          *float64 `json:"evToRevenue,omitempty"`
+	EVToEBITDA           *float64 `json:"evToEBITDA,omitempty"`
+	Beta                 *float64 `json:"beta,omitempty"`
+	ForwardPE            *float64 `json:"forwardPE,omitempty"`
+	AnalystTargetPrice   *float64 `json:"analystTargetPrice,omitempty"`
+
+	// Trading Data
+	Week52High          *float64 `json:"week52High,omitempty"`
+	Week52Low           *float64 `json:"week52Low,omitempty"`
+	Day50MovingAverage  *float64 `json:"day50MovingAverage,omitempty"`
+	Day200MovingAverage *float64 `json:"day200MovingAverage,omitempty"`
+
+	// Additional Company Data
+	EBITDA *int64 `json:"ebitda,omitempty"`
+}
+
+// ToNumeric parses the numeric fields of the overview. Values that are
+// missing, sentinels or not numbers become nil rather than an error.
+func (o OverviewOutput) ToNumeric() OverviewNumeric {
+	return OverviewNumeric{
+		MarketCapitalization:       overviewInt(o.MarketCapitalization),
+		SharesOutstanding:          overviewInt(o.SharesOutstanding),
+		BookValue:                  overviewFloat(o.BookValue),
+		DividendPerShare:           overviewFloat(o.DividendPerShare),
+		DividendYield:              overviewFloat(o.DividendYield),
+		EPS:                        overviewFloat(o.EPS),
+		RevenuePerShareTTM:         overviewFloat(o.RevenuePerShareTTM),
+		ProfitMargin:               overviewFloat(o.ProfitMargin),
+		OperatingMarginTTM:         overviewFloat(o.OperatingMarginTTM),
+		ReturnOnAssetsTTM:          overviewFloat(o.ReturnOnAssetsTTM),
+		ReturnOnEquityTTM:          overviewFloat(o.ReturnOnEquityTTM),
+		RevenueTTM:                 overviewInt(o.RevenueTTM),
+		GrossProfitTTM:             overviewInt(o.GrossProfitTTM),
+		DilutedEPSTTM:              overviewFloat(o.DilutedEPSTTM),
+		QuarterlyEarningsGrowthYOY: overviewFloat(o.QuarterlyEarningsGrowthYOY),
+		QuarterlyRevenueGrowthYOY:  overviewFloat(o.QuarterlyRevenueGrowthYOY),
+
+		PERatio:              overviewFloat(o.PERatio),
+		PEGRatio:             overviewFloat(o.PEGRatio),
+		PriceToBookRatio:     overviewFloat(o.PriceToBookRatio),
+		PriceToSalesRatioTTM: overviewFloat(o.PriceToSalesRatioTTM),
+		EVToRevenue:          overviewFloat(o.EVToRevenue),
+		EVToEBITDA:           overviewFloat(o.EVToEBITDA),
+		Beta:                 overviewFloat(o.Beta),
+		ForwardPE:            overviewFloat(o.ForwardPE),
+		AnalystTargetPrice:   overviewFloat(o.AnalystTargetPrice),
+
+		Week52High:          overviewFloat(o.Week52High),
+		Week52Low:           overviewFloat(o.Week52Low),
+		Day50MovingAverage:  overviewFloat(o.Day50MovingAverage),
+		Day200MovingAverage: overviewFloat(o.Day200MovingAverage),
+
+		EBITDA: overviewInt(o.EBITDA),
+	}
+}
+
+// isOverviewSentinel reports whether value marks a field Alpha Vantage did not report
+func isOverviewSentinel(value string) bool {
+	return value == "" || value == "None" || value == "-"
+}
+
+// overviewFloat parses an overview value into a float, or nil if it is not reported
+func overviewFloat(value string) *float64 {
+	value = strings.TrimSpace(value)
+	if isOverviewSentinel(value) {
+		return nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
+
+// overviewInt parses an overview value into an integer, or nil if it is not reported
+func overviewInt(value string) *int64 {
+	value = strings.TrimSpace(value)
+	if isOverviewSentinel(value) {
+		return nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverviewOutput_ToNumeric(t *testing.T) {
+	overview := OverviewOutput{
+		MarketCapitalization: "2913562624000",
+		PERatio:              "29.95",
+		DividendYield:        "0.0054",
+		Beta:                 " 1.29 ",
+		EPS:                  "-0.58",
+		PEGRatio:             "None",
+		ForwardPE:            "-",
+		EVToEBITDA:           "",
+		EBITDA:               "not-a-number",
+		Week52High:           "199.62",
+	}
+
+	numeric := overview.ToNumeric()
+
+	require.NotNil(t, numeric.MarketCapitalization)
+	assert.Equal(t, int64(2913562624000), *numeric.MarketCapitalization)
+	require.NotNil(t, numeric.PERatio)
+	assert.Equal(t, 29.95, *numeric.PERatio)
+	require.NotNil(t, numeric.DividendYield)
+	assert.Equal(t, 0.0054, *numeric.DividendYield)
+	require.NotNil(t, numeric.Beta)
+	assert.Equal(t, 1.29, *numeric.Beta)
+	require.NotNil(t, numeric.EPS)
+	assert.Equal(t, -0.58, *numeric.EPS)
+	require.NotNil(t, numeric.Week52High)
+	assert.Equal(t, 199.62, *numeric.Week52High)
+
+	assert.Nil(t, numeric.PEGRatio, "None sentinel")
+	assert.Nil(t, numeric.ForwardPE, "dash sentinel")
+	assert.Nil(t, numeric.EVToEBITDA, "empty string")
+	assert.Nil(t, numeric.EBITDA, "malformed value")
+	assert.Nil(t, numeric.SharesOutstanding, "missing field")
+}
+
+func TestOverviewOutput_ToNumericEmpty(t *testing.T) {
+	assert.Equal(t, OverviewNumeric{}, OverviewOutput{}.ToNumeric())
+}
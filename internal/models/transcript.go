@@ -0,0 +1,17 @@
+package models
+
+// TranscriptSegment is a single speaker turn in an earnings call transcript.
+// Sentiment is nil when Alpha Vantage does not score the segment.
+type TranscriptSegment struct {
+	Speaker   string   `json:"speaker"`
+	Title     string   `json:"title"`
+	Text      string   `json:"text"`
+	Sentiment *float64 `json:"sentiment,omitempty"`
+}
+
+// TranscriptOutput is returned by the get_earnings_transcript MCP tool.
+type TranscriptOutput struct {
+	Symbol   string              `json:"symbol"`
+	Quarter  string              `json:"quarter"`
+	Segments []TranscriptSegment `json:"segments"`
+}
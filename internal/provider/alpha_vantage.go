@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// AlphaVantage is the DataProvider backed by the Alpha Vantage API.
+type AlphaVantage struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+
+	// parser is a reusable JSON parser instance to avoid allocation overhead
+	parser *parser.JSON
+}
+
+// NewAlphaVantage creates an Alpha Vantage provider using the given client.
+func NewAlphaVantage(alphaClient *request.AlphaVantageClient) *AlphaVantage {
+	return &AlphaVantage{
+		alphaClient: alphaClient,
+		parser:      parser.NewJSON(),
+	}
+}
+
+// Overview retrieves the OVERVIEW function for symbol.
+func (av *AlphaVantage) Overview(ctx context.Context, symbol string) (*models.OverviewOutput, error) {
+	res, err := av.overviewRequest(symbol).GetWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stock data for symbol '%s': %w", symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Error bodies would otherwise parse into an empty overview
+	if err := parser.CheckAPIMessages(res); err != nil {
+		return nil, fmt.Errorf("failed to fetch stock data for symbol '%s': %w", symbol, err)
+	}
+
+	var data models.OverviewOutput
+	if err := av.parser.ParseBytes(&data, res); err != nil {
+		return nil, fmt.Errorf("failed to parse stock data for symbol '%s': %w", symbol, err)
+	}
+
+	return &data, nil
+}
+
+// Intraday retrieves the TIME_SERIES_INTRADAY function for the input.
+//
+// Full-size JSON responses can span tens of megabytes, so they are decoded
+// as they stream in instead of being buffered first.
+func (av *AlphaVantage) Intraday(ctx context.Context, input models.IntradayPriceInput) (*models.IntradayStockOutput, error) {
+	var (
+		rawData *parser.AlphaVantageResponse
+		err     error
+	)
+	if isFullSize(input.OutputSize) && !isCSV(input.Datatype) {
+		rawData, err = av.streamIntraday(ctx, input)
+	} else {
+		rawData, err = av.fetchIntraday(ctx, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rawData.CheckInterval(input.Interval); err != nil {
+		return nil, fmt.Errorf("invalid intraday data for symbol '%s': %w", input.Symbol, err)
+	}
+
+	opts := parser.DefaultProcessOptions()
+	opts.StaleAfter = av.alphaClient.StaleAfter()
+	opts.Clock = av.alphaClient.Clock()
+	if input.StrictParsing != nil {
+		opts.StrictParsing = *input.StrictParsing
+	}
+
+	data, err := rawData.ProcessTimeSeriesWithOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process time series data for symbol '%s': %w", input.Symbol, err)
+	}
+
+	return data, nil
+}
+
+// fetchIntraday requests and parses a buffered TIME_SERIES_INTRADAY response
+func (av *AlphaVantage) fetchIntraday(ctx context.Context, input models.IntradayPriceInput) (*parser.AlphaVantageResponse, error) {
+	res, err := av.intradayRequest(input).GetWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch intraday data for symbol '%s': %w", input.Symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	var rawData *parser.AlphaVantageResponse
+	if isCSV(input.Datatype) {
+		rawData, err = parser.IntradayPricesCSV(res, intradayCSVMetaData(input))
+	} else {
+		rawData, err = parser.IntradayPrices(res)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse intraday data for symbol '%s': %w", input.Symbol, err)
+	}
+
+	return rawData, nil
+}
+
+// streamIntraday requests a JSON TIME_SERIES_INTRADAY response and parses it
+// while the body streams in
+func (av *AlphaVantage) streamIntraday(ctx context.Context, input models.IntradayPriceInput) (*parser.AlphaVantageResponse, error) {
+	body, err := av.intradayRequest(input).StreamWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch intraday data for symbol '%s': %w", input.Symbol, err)
+	}
+	defer body.Close()
+
+	rawData, err := parser.IntradayPricesFrom(body)
+	if err != nil {
+		// A cancelled request surfaces as a failed read mid-body
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, fmt.Errorf("failed to parse intraday data for symbol '%s': %w", input.Symbol, err)
+	}
+
+	return rawData, nil
+}
+
+// OverviewURL returns the redacted URL Overview would request for symbol.
+func (av *AlphaVantage) OverviewURL(symbol string) (string, error) {
+	return av.overviewRequest(symbol).RedactedURL()
+}
+
+// IntradayURL returns the redacted URL Intraday would request for input.
+func (av *AlphaVantage) IntradayURL(input models.IntradayPriceInput) (string, error) {
+	return av.intradayRequest(input).RedactedURL()
+}
+
+// overviewRequest creates the OVERVIEW request for symbol
+func (av *AlphaVantage) overviewRequest(symbol string) *request.RequestAlpha {
+	return request.NewAlphaWithClient(av.alphaClient, symbol, []request.Query{
+		request.NewQuery("function", "OVERVIEW"),
+	})
+}
+
+// intradayRequest creates the TIME_SERIES_INTRADAY request for input
+func (av *AlphaVantage) intradayRequest(input models.IntradayPriceInput) *request.RequestAlpha {
+	return request.NewAlphaWithClient(av.alphaClient, input.Symbol, intradayQueries(input))
+}
+
+// intradayQueries constructs the query parameters for a TIME_SERIES_INTRADAY request
+func intradayQueries(input models.IntradayPriceInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", "TIME_SERIES_INTRADAY"),
+		request.NewQuery("interval", input.Interval),
+	}
+
+	if input.Adjusted != nil {
+		queries = append(queries, request.NewQuery("adjusted", strconv.FormatBool(*input.Adjusted)))
+	}
+
+	if input.ExtendedHours != nil {
+		queries = append(queries, request.NewQuery("extended_hours", strconv.FormatBool(*input.ExtendedHours)))
+	}
+
+	if input.Month != nil {
+		queries = append(queries, request.NewQuery("month", *input.Month))
+	}
+
+	if input.OutputSize != nil {
+		queries = append(queries, request.NewQuery("outputsize", *input.OutputSize))
+	}
+
+	if isCSV(input.Datatype) {
+		queries = append(queries, request.NewQuery("datatype", "csv"))
+	}
+
+	if input.Entitlement != nil {
+		queries = append(queries, request.NewQuery("entitlement", *input.Entitlement))
+	}
+
+	return queries
+}
+
+// intradayCSVMetaData builds the metadata a JSON intraday response would
+// carry, since CSV responses only contain the bars
+func intradayCSVMetaData(input models.IntradayPriceInput) parser.MetaData {
+	outputSize := "Compact"
+	if isFullSize(input.OutputSize) {
+		outputSize = "Full size"
+	}
+
+	return parser.MetaData{
+		Information: fmt.Sprintf("Intraday (%s) open, high, low, close prices and volume", input.Interval),
+		Symbol:      strings.ToUpper(input.Symbol),
+		Interval:    input.Interval,
+		OutputSize:  outputSize,
+		TimeZone:    "US/Eastern",
+	}
+}
+
+// isFullSize reports whether an output size input requests the full series
+func isFullSize(outputSize *string) bool {
+	return outputSize != nil && *outputSize == "full"
+}
+
+// isCSV reports whether a datatype input requests CSV responses
+func isCSV(datatype *string) bool {
+	return datatype != nil && *datatype == "csv"
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (av *AlphaVantage) GetStats() client.ClientStats {
+	return av.alphaClient.GetStats()
+}
+
+// IntradayDefaults returns the default interval and output size of the underlying client
+func (av *AlphaVantage) IntradayDefaults() (interval, outputSize string) {
+	return av.alphaClient.IntradayDefaults()
+}
+
+// IntradayOutputSize returns the default output size for interval of the underlying client
+func (av *AlphaVantage) IntradayOutputSize(interval string) string {
+	return av.alphaClient.IntradayOutputSize(interval)
+}
+
+// IntradayAdjusted returns the default adjusted flag of the underlying client
+func (av *AlphaVantage) IntradayAdjusted() *bool {
+	return av.alphaClient.IntradayAdjusted()
+}
+
+// SetTimeout configures the request timeout of the underlying client
+func (av *AlphaVantage) SetTimeout(timeout time.Duration) {
+	av.alphaClient.SetTimeout(timeout)
+}
+
+// Close cleans up resources used by the provider
+func (av *AlphaVantage) Close() error {
+	return av.alphaClient.Close()
+}
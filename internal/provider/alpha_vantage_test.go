@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// Compile-time check that AlphaVantage satisfies the provider interfaces
+var (
+	_ DataProvider  = (*AlphaVantage)(nil)
+	_ StatsReporter = (*AlphaVantage)(nil)
+	_ TimeoutSetter = (*AlphaVantage)(nil)
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func newMockAlphaVantage(url, body string) *AlphaVantage {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config))
+}
+
+func TestAlphaVantage_Overview(t *testing.T) {
+	av := newMockAlphaVantage(
+		"https://www.alphavantage.co/query?apikey=test-key&function=OVERVIEW&symbol=IBM",
+		`{"Symbol": "IBM", "Name": "International Business Machines", "Sector": "TECHNOLOGY"}`,
+	)
+
+	data, err := av.Overview(context.Background(), "IBM")
+	require.NoError(t, err)
+	assert.Equal(t, "IBM", data.Symbol)
+	assert.Equal(t, "International Business Machines", data.Name)
+}
+
+func TestAlphaVantage_Intraday(t *testing.T) {
+	av := newMockAlphaVantage(
+		"https://www.alphavantage.co/query?apikey=test-key&function=TIME_SERIES_INTRADAY&interval=5min&outputsize=compact&symbol=IBM",
+		`{
+			"Meta Data": {
+				"1. Information": "Intraday (5min) open, high, low, close prices and volume",
+				"2. Symbol": "IBM",
+				"3. Last Refreshed": "2023-12-08 19:55:00",
+				"4. Interval": "5min",
+				"5. Output Size": "Compact",
+				"6. Time Zone": "US/Eastern"
+			},
+			"Time Series (5min)": {
+				"2023-12-08 19:55:00": {"1. open": "161.00", "2. high": "161.20", "3. low": "160.90", "4. close": "161.10", "5. volume": "150"},
+				"2023-12-08 19:50:00": {"1. open": "160.80", "2. high": "161.05", "3. low": "160.70", "4. close": "161.00", "5. volume": "200"}
+			}
+		}`,
+	)
+
+	data, err := av.Intraday(context.Background(), models.IntradayPriceInput{
+		Symbol:     "IBM",
+		Interval:   "5min",
+		OutputSize: stringPtr("compact"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "IBM", data.MetaData.Symbol)
+	require.Len(t, data.TimeSeries, 2)
+	assert.Equal(t, 161.00, data.TimeSeries[0].Close)
+	assert.Equal(t, 161.10, data.TimeSeries[1].Close)
+}
+
+func TestAlphaVantage_IntradayCSV(t *testing.T) {
+	av := newMockAlphaVantage(
+		"https://www.alphavantage.co/query?apikey=test-key&datatype=csv&function=TIME_SERIES_INTRADAY&interval=5min&symbol=IBM",
+		"timestamp,open,high,low,close,volume\r\n"+
+			"2023-12-08 19:55:00,161.00,161.20,160.90,161.10,150\r\n"+
+			"2023-12-08 19:50:00,160.80,161.05,160.70,161.00,200\r\n"+
+			"\r\n",
+	)
+
+	data, err := av.Intraday(context.Background(), models.IntradayPriceInput{
+		Symbol:   "ibm",
+		Interval: "5min",
+		Datatype: stringPtr("csv"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "IBM", data.MetaData.Symbol)
+	assert.Equal(t, "5min", data.MetaData.Interval)
+	assert.Equal(t, "2023-12-08 19:55:00", data.MetaData.LastRefreshed)
+	require.Len(t, data.TimeSeries, 2)
+	assert.Equal(t, 161.00, data.TimeSeries[0].Close)
+	assert.Equal(t, int64(150), data.TimeSeries[1].Volume)
+}
+
+func TestAlphaVantage_IntradayStrictParsing(t *testing.T) {
+	body := "timestamp,open,high,low,close,volume\r\n" +
+		"2023-12-08 19:55:00,161.00,161.20,160.90,161.10,150\r\n" +
+		"2023-12-08 19:50:00,160.80,161.05,160.70,n/a,200\r\n"
+	url := "https://www.alphavantage.co/query?apikey=test-key&datatype=csv&function=TIME_SERIES_INTRADAY&interval=5min&symbol=IBM"
+
+	av := newMockAlphaVantage(url, body)
+	_, err := av.Intraday(context.Background(), models.IntradayPriceInput{
+		Symbol:   "IBM",
+		Interval: "5min",
+		Datatype: stringPtr("csv"),
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to process time series data")
+
+	av = newMockAlphaVantage(url, body)
+	data, err := av.Intraday(context.Background(), models.IntradayPriceInput{
+		Symbol:        "IBM",
+		Interval:      "5min",
+		Datatype:      stringPtr("csv"),
+		StrictParsing: boolPtr(false),
+	})
+	require.NoError(t, err)
+	require.Len(t, data.TimeSeries, 1)
+	assert.Equal(t, 161.10, data.TimeSeries[0].Close)
+	assert.Equal(t, 1, data.SkippedBars)
+	assert.Len(t, data.ParseErrors, 1)
+}
+
+// streamCountingClient records how many requests were streamed
+type streamCountingClient struct {
+	*client.MockClient
+	streams int
+}
+
+func (c *streamCountingClient) DoStream(ctx context.Context, method, url string, body []byte, headers map[string]string) (*client.StreamResponse, error) {
+	c.streams++
+	return c.MockClient.DoStream(ctx, method, url, body, headers)
+}
+
+func TestAlphaVantage_IntradayFullStreams(t *testing.T) {
+	url := "https://www.alphavantage.co/query?apikey=test-key&function=TIME_SERIES_INTRADAY&interval=5min&outputsize=full&symbol=IBM"
+	httpClient := &streamCountingClient{MockClient: client.NewMockClient()}
+	httpClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(`{
+		"Meta Data": {"2. Symbol": "IBM", "4. Interval": "5min", "6. Time Zone": "US/Eastern"},
+		"Time Series (5min)": {
+			"2023-12-08 19:55:00": {"1. open": "161.00", "2. high": "161.20", "3. low": "160.90", "4. close": "161.10", "5. volume": "150"}
+		}
+	}`)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	av := NewAlphaVantage(request.NewAlphaVantageClient(httpClient, config))
+
+	data, err := av.Intraday(context.Background(), models.IntradayPriceInput{
+		Symbol:     "IBM",
+		Interval:   "5min",
+		OutputSize: stringPtr("full"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, httpClient.streams, "Full-size responses should be streamed")
+	require.Len(t, data.TimeSeries, 1)
+	assert.Equal(t, 161.10, data.TimeSeries[0].Close)
+
+	_, err = av.Intraday(context.Background(), models.IntradayPriceInput{Symbol: "IBM", Interval: "5min"})
+	assert.Error(t, err, "The compact request has no mock response")
+	assert.Equal(t, 1, httpClient.streams, "Compact responses should be buffered")
+}
+
+func TestAlphaVantage_IntradayFetchError(t *testing.T) {
+	url := "https://www.alphavantage.co/query?apikey=test-key&function=TIME_SERIES_INTRADAY&interval=5min&symbol=IBM"
+	mockClient := client.NewMockClient()
+	mockClient.SetError(url, errors.New("connection refused"))
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	av := NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config))
+
+	_, err := av.Intraday(context.Background(), models.IntradayPriceInput{Symbol: "IBM", Interval: "5min"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to fetch intraday data for symbol 'IBM'")
+}
+
+func TestIntradayQueries(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          models.IntradayPriceInput
+		expectedParams map[string]string
+	}{
+		{
+			name: "basic parameters",
+			input: models.IntradayPriceInput{
+				Symbol:   "AAPL",
+				Interval: "1min",
+			},
+			expectedParams: map[string]string{
+				"function": "TIME_SERIES_INTRADAY",
+				"interval": "1min",
+			},
+		},
+		{
+			name: "with all optional parameters",
+			input: models.IntradayPriceInput{
+				Symbol:        "MSFT",
+				Interval:      "5min",
+				Adjusted:      boolPtr(true),
+				ExtendedHours: boolPtr(false),
+				Month:         stringPtr("2023-01"),
+				OutputSize:    stringPtr("full"),
+			},
+			expectedParams: map[string]string{
+				"function":       "TIME_SERIES_INTRADAY",
+				"interval":       "5min",
+				"adjusted":       "true",
+				"extended_hours": "false",
+				"month":          "2023-01",
+				"outputsize":     "full",
+			},
+		},
+		{
+			name: "csv datatype",
+			input: models.IntradayPriceInput{
+				Symbol:   "AAPL",
+				Interval: "1min",
+				Datatype: stringPtr("csv"),
+			},
+			expectedParams: map[string]string{
+				"function": "TIME_SERIES_INTRADAY",
+				"interval": "1min",
+				"datatype": "csv",
+			},
+		},
+		{
+			name: "realtime entitlement",
+			input: models.IntradayPriceInput{
+				Symbol:      "AAPL",
+				Interval:    "1min",
+				Entitlement: stringPtr("realtime"),
+			},
+			expectedParams: map[string]string{
+				"function":    "TIME_SERIES_INTRADAY",
+				"interval":    "1min",
+				"entitlement": "realtime",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queries := intradayQueries(tc.input)
+
+			paramMap := make(map[string]string)
+			for _, query := range queries {
+				paramMap[query.Name] = query.Value
+			}
+
+			assert.Equal(t, tc.expectedParams, paramMap)
+		})
+	}
+}
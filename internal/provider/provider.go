@@ -0,0 +1,66 @@
+// Package provider defines the market data backends used by the MCP tools.
+//
+// Tools depend on the DataProvider interface rather than on a concrete API
+// client, so Alpha Vantage can be swapped for another vendor, a local file or
+// a fake in tests without touching the MCP layer.
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+)
+
+// DataProvider fetches market data and returns it as the shared models types.
+//
+// Implementations are responsible for talking to their backend and decoding
+// its responses; input validation and response sanity checks stay in the
+// tools so every provider is held to the same contract.
+type DataProvider interface {
+	// Overview returns company information and key financial metrics for symbol
+	Overview(ctx context.Context, symbol string) (*models.OverviewOutput, error)
+
+	// Intraday returns intraday bars for the symbol and interval in input,
+	// sorted oldest first
+	Intraday(ctx context.Context, input models.IntradayPriceInput) (*models.IntradayStockOutput, error)
+
+	// Close releases the resources held by the provider
+	Close() error
+}
+
+// StatsReporter is implemented by providers backed by an HTTP client that
+// exposes connection statistics.
+type StatsReporter interface {
+	GetStats() client.ClientStats
+}
+
+// TimeoutSetter is implemented by providers whose request timeout can be
+// changed at runtime.
+type TimeoutSetter interface {
+	SetTimeout(timeout time.Duration)
+}
+
+// IntradayDefaulter is implemented by providers configured with defaults for
+// the intraday interval and output size that inputs may leave empty.
+type IntradayDefaulter interface {
+	IntradayDefaults() (interval, outputSize string)
+
+	// IntradayOutputSize returns the default output size for requests of
+	// interval, which may differ between intervals; empty sets none
+	IntradayOutputSize(interval string) string
+
+	// IntradayAdjusted returns the default adjusted flag; nil sets none
+	IntradayAdjusted() *bool
+}
+
+// RequestURLBuilder is implemented by providers that can show the upstream
+// request of a call without performing it. URLs have credentials redacted.
+type RequestURLBuilder interface {
+	// OverviewURL returns the URL Overview would request for symbol
+	OverviewURL(symbol string) (string, error)
+
+	// IntradayURL returns the URL Intraday would request for input
+	IntradayURL(input models.IntradayPriceInput) (string, error)
+}
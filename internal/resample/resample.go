@@ -0,0 +1,73 @@
+// Package resample aggregates price bars that have already been fetched into
+// a coarser interval, so e.g. 15min bars can be derived from a cached 1min
+// response without another Alpha Vantage request.
+package resample
+
+import (
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// sessionOpen is the start of the regular US equity session, 09:30, in
+// seconds after midnight; bucket boundaries are aligned to it
+const sessionOpen = (9*60 + 30) * 60
+
+// Resample aggregates the bars of series, sorted oldest first, into buckets
+// of target length. Each bucket takes the open of its first bar, the close
+// and adjusted close of its last bar, the highest high and lowest low, and
+// the sum of the volumes and dividends; it is timestamped with the bucket
+// start.
+//
+// Buckets are aligned to 09:30 wall-clock time in each bar's location, so
+// 15min buckets start at 09:30, 09:45 and so on, and pre-market bars fall in
+// buckets counted back from the open. Buckets never span two dates. Gaps in
+// the series yield no bars: a bucket without bars is omitted rather than
+// filled in, and a bucket with missing bars aggregates the bars present.
+//
+// Returns the series unchanged when target is shorter than a second.
+func Resample(series []models.OHLCVFloat, target time.Duration) []models.OHLCVFloat {
+	if target < time.Second {
+		return series
+	}
+
+	resampled := make([]models.OHLCVFloat, 0, len(series))
+	for _, bar := range series {
+		start := bucketStart(bar.Timestamp, target)
+
+		if n := len(resampled); n > 0 && resampled[n-1].Timestamp.Equal(start) {
+			bucket := &resampled[n-1]
+			bucket.High = max(bucket.High, bar.High)
+			bucket.Low = min(bucket.Low, bar.Low)
+			bucket.Close = bar.Close
+			bucket.Volume += bar.Volume
+			bucket.AdjustedClose = bar.AdjustedClose
+			bucket.DividendAmount += bar.DividendAmount
+			continue
+		}
+
+		bar.Timestamp = start
+		resampled = append(resampled, bar)
+	}
+
+	return resampled
+}
+
+// bucketStart returns the start of the bucket of length target containing
+// timestamp, counting from the session open of its date in its own location
+func bucketStart(timestamp time.Time, target time.Duration) time.Time {
+	year, month, day := timestamp.Date()
+	seconds := (timestamp.Hour()*60+timestamp.Minute())*60 + timestamp.Second()
+
+	width := int(target / time.Second)
+	offset := seconds - sessionOpen
+	index := offset / width
+	if offset < 0 && offset%width != 0 {
+		index--
+	}
+
+	// A pre-market bucket never starts before midnight
+	start := max(sessionOpen+index*width, 0)
+
+	return time.Date(year, month, day, 0, 0, start, 0, timestamp.Location())
+}
@@ -0,0 +1,102 @@
+package resample
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+var eastern = mustLoadLocation("America/New_York")
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+func minuteBar(hour, minute int, open, high, low, close float64, volume int64) models.OHLCVFloat {
+	return models.OHLCVFloat{
+		Timestamp: time.Date(2024, 1, 16, hour, minute, 0, 0, eastern),
+		Open:      open,
+		High:      high,
+		Low:       low,
+		Close:     close,
+		Volume:    volume,
+	}
+}
+
+func TestResample_OneToFiveMinutes(t *testing.T) {
+	series := []models.OHLCVFloat{
+		minuteBar(9, 30, 100, 101, 99, 100.5, 10),
+		minuteBar(9, 31, 100.5, 102, 100, 101, 20),
+		minuteBar(9, 32, 101, 101.5, 98, 99, 30),
+		minuteBar(9, 33, 99, 100, 98.5, 99.5, 40),
+		minuteBar(9, 34, 99.5, 100, 99, 100, 50),
+		// No bars from 09:35 to 09:43
+		minuteBar(9, 44, 103, 104, 102.5, 103.5, 60),
+		minuteBar(9, 45, 103.5, 105, 103, 104, 70),
+		minuteBar(9, 46, 104, 104.5, 103.5, 104.2, 80),
+	}
+
+	resampled := Resample(series, 5*time.Minute)
+	require.Len(t, resampled, 3, "The empty 09:35 bucket is omitted")
+
+	assert.Equal(t, models.OHLCVFloat{
+		Timestamp: time.Date(2024, 1, 16, 9, 30, 0, 0, eastern),
+		Open:      100, High: 102, Low: 98, Close: 100, Volume: 150,
+	}, resampled[0])
+
+	// A bucket partly covered by the gap aggregates the bar it has
+	assert.Equal(t, models.OHLCVFloat{
+		Timestamp: time.Date(2024, 1, 16, 9, 40, 0, 0, eastern),
+		Open:      103, High: 104, Low: 102.5, Close: 103.5, Volume: 60,
+	}, resampled[1])
+
+	assert.Equal(t, models.OHLCVFloat{
+		Timestamp: time.Date(2024, 1, 16, 9, 45, 0, 0, eastern),
+		Open:      103.5, High: 105, Low: 103, Close: 104.2, Volume: 150,
+	}, resampled[2])
+}
+
+func TestResample_AlignsToSessionOpen(t *testing.T) {
+	series := []models.OHLCVFloat{
+		minuteBar(4, 0, 1, 1, 1, 1, 1),
+		minuteBar(9, 29, 2, 2, 2, 2, 1),
+		minuteBar(9, 30, 3, 3, 3, 3, 1),
+		minuteBar(10, 29, 4, 4, 4, 4, 1),
+		minuteBar(10, 30, 5, 5, 5, 5, 1),
+	}
+
+	var starts []time.Time
+	for _, bar := range Resample(series, time.Hour) {
+		starts = append(starts, bar.Timestamp)
+	}
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 16, 3, 30, 0, 0, eastern),
+		time.Date(2024, 1, 16, 8, 30, 0, 0, eastern),
+		time.Date(2024, 1, 16, 9, 30, 0, 0, eastern),
+		time.Date(2024, 1, 16, 10, 30, 0, 0, eastern),
+	}, starts)
+}
+
+func TestResample_SplitsDates(t *testing.T) {
+	series := []models.OHLCVFloat{
+		{Timestamp: time.Date(2024, 1, 16, 19, 59, 0, 0, eastern), Close: 1, Volume: 1},
+		{Timestamp: time.Date(2024, 1, 17, 4, 0, 0, 0, eastern), Close: 2, Volume: 1},
+	}
+
+	assert.Len(t, Resample(series, 24*time.Hour), 2)
+}
+
+func TestResample_InvalidTarget(t *testing.T) {
+	series := []models.OHLCVFloat{minuteBar(9, 30, 1, 1, 1, 1, 1)}
+	assert.Equal(t, series, Resample(series, 0))
+	assert.Empty(t, Resample(nil, time.Minute))
+}
@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// reservedFunctionParams are the query parameters the server sets itself, so
+// callers of AlphaFunction cannot override them; apikey in particular never
+// comes from the caller.
+var reservedFunctionParams = []string{"apikey", "function", "datatype"}
+
+// AlphaFunction implements the "get_alpha_function" MCP tool, an escape
+// hatch that calls any Alpha Vantage function in validation.ValidFunctions,
+// so new endpoints are usable before they get a dedicated tool.
+//
+// The response is returned as a generic JSON object, unchanged.
+type AlphaFunction struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewAlphaFunction creates a new AlphaFunction tool instance with the
+// provided Alpha Vantage API configuration using dependency injection.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewAlphaFunction(apiURL, apiKey string) *AlphaFunction {
+	return newStandaloneToolset(apiURL, apiKey).AlphaFunction()
+}
+
+// validateInput checks the function against the allowlist and the names of
+// the parameters
+func (f *AlphaFunction) validateInput(input models.FunctionInput) error {
+	if err := validation.ValidateFunction(input.Function); err != nil {
+		return err
+	}
+
+	for name := range input.Params {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("parameter names cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API
+// request, sorted by name and without the reserved parameters
+func (f *AlphaFunction) buildQueries(input models.FunctionInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", input.Function),
+	}
+
+	names := make([]string, 0, len(input.Params))
+	for name := range input.Params {
+		if !slices.Contains(reservedFunctionParams, strings.ToLower(strings.TrimSpace(name))) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		queries = append(queries, request.NewQuery(name, input.Params[name]))
+	}
+
+	return queries
+}
+
+// Get calls the requested Alpha Vantage function with the given parameters.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - req: MCP tool request metadata (unused but required by interface)
+//   - input: The function name and its query parameters
+//
+// Returns:
+//   - *mcp.CallToolResult: Always nil (result data is in second return value)
+//   - models.FunctionOutput: The function name and its JSON response
+//   - error: Any error encountered, including a function outside the allowlist
+func (f *AlphaFunction) Get(ctx context.Context, req *mcp.CallToolRequest, input models.FunctionInput) (*mcp.CallToolResult, models.FunctionOutput, error) {
+	if err := f.validateInput(input); err != nil {
+		return nil, models.FunctionOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.FunctionOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := request.NewAlphaQueryWithClient(f.alphaClient, f.buildQueries(input)).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.FunctionOutput{}, fmt.Errorf("failed to call function '%s': %w", input.Function, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.FunctionOutput{}, ctx.Err()
+	default:
+	}
+
+	data, err := parser.Function(res)
+	if err != nil {
+		return nil, models.FunctionOutput{}, fmt.Errorf("failed to parse response of function '%s': %w", input.Function, err)
+	}
+
+	if len(data) == 0 {
+		return nil, models.FunctionOutput{}, fmt.Errorf("%w: no data returned by function '%s'", errors.ErrNotFound, input.Function)
+	}
+
+	return nil, models.FunctionOutput{
+		Function: input.Function,
+		Data:     data,
+	}, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (f *AlphaFunction) BuildURL(input models.FunctionInput) (string, error) {
+	if err := f.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaQueryWithClient(f.alphaClient, f.buildQueries(input)).RedactedURL()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (f *AlphaFunction) GetStats() client.ClientStats {
+	return f.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (f *AlphaFunction) Close() error {
+	return f.alphaClient.Close()
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+const symbolSearchURL = "https://www.alphavantage.co/query?apikey=test-key&function=SYMBOL_SEARCH&keywords=tesla"
+
+func newMockAlphaFunction(url, body string) (*AlphaFunction, *client.MockClient) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return &AlphaFunction{alphaClient: request.NewAlphaVantageClient(mockClient, config)}, mockClient
+}
+
+func TestAlphaFunction_Get(t *testing.T) {
+	tool, mockClient := newMockAlphaFunction(symbolSearchURL, `{"bestMatches": [{"1. symbol": "TSLA", "9. matchScore": "0.8889"}]}`)
+
+	_, res, err := tool.Get(context.Background(), nil, models.FunctionInput{
+		Function: "SYMBOL_SEARCH",
+		Params: map[string]string{
+			"keywords": "tesla",
+			// The caller cannot swap the key, function or format
+			"APIKEY":   "stolen-key",
+			"function": "LISTING_STATUS",
+			"datatype": "csv",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockClient.GetCallCount(symbolSearchURL))
+
+	assert.Equal(t, "SYMBOL_SEARCH", res.Function)
+	matches, ok := res.Data["bestMatches"].([]any)
+	require.True(t, ok)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "TSLA", matches[0].(map[string]any)["1. symbol"])
+}
+
+func TestAlphaFunction_KeepsNumbers(t *testing.T) {
+	const url = "https://www.alphavantage.co/query?apikey=test-key&function=MARKET_STATUS"
+	tool, _ := newMockAlphaFunction(url, `{"endpoint": "Global Market Open & Close Status", "markets": 12345678901234567890}`)
+
+	_, res, err := tool.Get(context.Background(), nil, models.FunctionInput{Function: "MARKET_STATUS"})
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("12345678901234567890"), res.Data["markets"])
+}
+
+func TestAlphaFunction_APIError(t *testing.T) {
+	tool, _ := newMockAlphaFunction(symbolSearchURL, `{"Information": "Thank you for using Alpha Vantage! Our standard API rate limit is 25 requests per day."}`)
+
+	_, _, err := tool.Get(context.Background(), nil, models.FunctionInput{
+		Function: "SYMBOL_SEARCH",
+		Params:   map[string]string{"keywords": "tesla"},
+	})
+	assert.ErrorIs(t, err, errors.ErrRateLimited)
+}
+
+func TestAlphaFunction_InputValidation(t *testing.T) {
+	tool, mockClient := newMockAlphaFunction(symbolSearchURL, `{}`)
+
+	testCases := map[string]models.FunctionInput{
+		"empty function":       {},
+		"unknown function":     {Function: "DROP_TABLE"},
+		"lowercase function":   {Function: "symbol_search"},
+		"CSV only function":    {Function: "LISTING_STATUS"},
+		"empty parameter name": {Function: "SYMBOL_SEARCH", Params: map[string]string{" ": "tesla"}},
+	}
+
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := tool.Get(context.Background(), nil, input)
+			assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		})
+	}
+
+	assert.Empty(t, mockClient.Requests())
+}
+
+func TestAlphaFunction_BuildURL(t *testing.T) {
+	tool, _ := newMockAlphaFunction(symbolSearchURL, `{}`)
+
+	url, err := tool.BuildURL(models.FunctionInput{
+		Function: "SYMBOL_SEARCH",
+		Params:   map[string]string{"keywords": "tesla", "apikey": "stolen-key"},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, url, "stolen-key")
+	assert.NotContains(t, url, "test-key")
+	assert.Contains(t, url, "keywords=tesla")
+}
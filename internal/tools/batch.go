@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+const (
+	// maxBatchSymbols is the most symbols a batch request may ask for
+	maxBatchSymbols = 20
+
+	// batchConcurrency bounds the requests of a batch in flight at once
+	batchConcurrency = 4
+
+	// batchRequestsPerMinute keeps batch tools built on their own within the
+	// free tier's per-minute quota
+	batchRequestsPerMinute = 5
+)
+
+// batchSymbols checks the batch size and returns the uppercase symbols to
+// fetch with duplicates removed. Individual symbols are validated per fetch.
+func batchSymbols(input []string) ([]string, error) {
+	if len(input) == 0 {
+		return nil, fmt.Errorf("symbols cannot be empty")
+	}
+
+	if len(input) > maxBatchSymbols {
+		return nil, fmt.Errorf("too many symbols: got %d, at most %d are allowed per request", len(input), maxBatchSymbols)
+	}
+
+	seen := make(map[string]bool, len(input))
+	symbols := make([]string, 0, len(input))
+	for _, symbol := range input {
+		symbol = strings.ToUpper(strings.TrimSpace(symbol))
+		if seen[symbol] {
+			continue
+		}
+		seen[symbol] = true
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols, nil
+}
+
+// fetchBatch calls fetch for every symbol with at most concurrency calls in
+// flight. Results are keyed by symbol and failures reported as the envelope
+// a failed single-symbol call returns; the map of failures is nil when none
+// failed. Once ctx is done no further symbols are fetched and ctx's error is
+// returned, so a deadline on ctx bounds how long the batch waits for rate
+// limiter quota.
+func fetchBatch[T any](ctx context.Context, symbols []string, concurrency int, fetch func(context.Context, string) (T, error)) (map[string]T, map[string]errors.Envelope, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	type result struct {
+		symbol string
+		data   T
+		err    error
+	}
+
+	jobs := make(chan string)
+	results := make(chan result)
+
+	go func() {
+		defer close(jobs)
+		for _, symbol := range symbols {
+			select {
+			case jobs <- symbol:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range min(concurrency, len(symbols)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for symbol := range jobs {
+				data, err := fetch(ctx, symbol)
+				results <- result{symbol: symbol, data: data, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	data := make(map[string]T, len(symbols))
+	var failures map[string]errors.Envelope
+	for r := range results {
+		if r.err != nil {
+			if failures == nil {
+				failures = make(map[string]errors.Envelope)
+			}
+			failures[r.symbol] = errors.NewEnvelope(r.err)
+			continue
+		}
+		data[r.symbol] = r.data
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return data, failures, nil
+}
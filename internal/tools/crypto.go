@@ -0,0 +1,208 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// validateCryptoPair validates the digital currency symbol and its market
+func validateCryptoPair(symbol, market string) error {
+	if err := validation.ValidateSymbol(symbol); err != nil {
+		return err
+	}
+
+	if market == "" {
+		return fmt.Errorf("market is required")
+	}
+
+	return validation.ValidateCurrencyCode(market)
+}
+
+// CryptoDaily implements the "get-crypto-daily" MCP tool for retrieving daily
+// digital currency prices using Alpha Vantage's DIGITAL_CURRENCY_DAILY function.
+type CryptoDaily struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewCryptoDaily creates a new CryptoDaily tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewCryptoDaily(apiURL, apiKey string) *CryptoDaily {
+	return newStandaloneToolset(apiURL, apiKey).CryptoDaily()
+}
+
+// Get retrieves daily prices of the digital currency quoted in the requested market.
+func (c *CryptoDaily) Get(ctx context.Context, req *mcp.CallToolRequest, input models.CryptoDailyInput) (*mcp.CallToolResult, models.CryptoOutput, error) {
+	if err := validateCryptoPair(input.Symbol, input.Market); err != nil {
+		return nil, models.CryptoOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return fetchCryptoPrices(ctx, c.alphaClient, input.Symbol, input.Market, c.buildQueries(input), false)
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (c *CryptoDaily) buildQueries(input models.CryptoDailyInput) []request.Query {
+	return []request.Query{
+		request.NewQuery("function", "DIGITAL_CURRENCY_DAILY"),
+		request.NewQuery("market", input.Market),
+	}
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (c *CryptoDaily) BuildURL(input models.CryptoDailyInput) (string, error) {
+	if err := validateCryptoPair(input.Symbol, input.Market); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaWithClient(c.alphaClient, input.Symbol, c.buildQueries(input)).RedactedURL()
+}
+
+// CryptoIntraday implements the "get-crypto-intraday" MCP tool for retrieving
+// intraday digital currency prices using Alpha Vantage's CRYPTO_INTRADAY function.
+type CryptoIntraday struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewCryptoIntraday creates a new CryptoIntraday tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewCryptoIntraday(apiURL, apiKey string) *CryptoIntraday {
+	return newStandaloneToolset(apiURL, apiKey).CryptoIntraday()
+}
+
+// validateInput performs input validation on the crypto intraday input
+func (c *CryptoIntraday) validateInput(input models.CryptoIntradayInput) error {
+	if err := validateCryptoPair(input.Symbol, input.Market); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateInterval(input.Interval); err != nil {
+		return err
+	}
+
+	if input.OutputSize != nil {
+		if err := validation.ValidateOutputSize(*input.OutputSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (c *CryptoIntraday) buildQueries(input models.CryptoIntradayInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", "CRYPTO_INTRADAY"),
+		request.NewQuery("market", input.Market),
+		request.NewQuery("interval", input.Interval),
+	}
+
+	if input.OutputSize != nil {
+		queries = append(queries, request.NewQuery("outputsize", *input.OutputSize))
+	}
+
+	return queries
+}
+
+// Get retrieves intraday prices of the digital currency quoted in the requested market.
+func (c *CryptoIntraday) Get(ctx context.Context, req *mcp.CallToolRequest, input models.CryptoIntradayInput) (*mcp.CallToolResult, models.CryptoOutput, error) {
+	if err := c.validateInput(input); err != nil {
+		return nil, models.CryptoOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return fetchCryptoPrices(ctx, c.alphaClient, input.Symbol, input.Market, c.buildQueries(input), true)
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (c *CryptoIntraday) BuildURL(input models.CryptoIntradayInput) (string, error) {
+	if err := c.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaWithClient(c.alphaClient, input.Symbol, c.buildQueries(input)).RedactedURL()
+}
+
+// InputSchema returns the JSON schema of the tool input, restricting
+// interval and outputSize to the values Alpha Vantage accepts so the MCP SDK
+// rejects others before Get is called.
+func (c *CryptoIntraday) InputSchema() *jsonschema.Schema {
+	schema := inputSchema[models.CryptoIntradayInput]("symbol", "market", "interval")
+	setEnum(schema, "interval", validation.ValidIntervals)
+	setEnum(schema, "outputSize", validation.ValidOutputSizes)
+
+	return schema
+}
+
+// fetchCryptoPrices performs a crypto time series request and parses the response
+func fetchCryptoPrices(ctx context.Context, alphaClient *request.AlphaVantageClient, symbol, market string, queries []request.Query, intraday bool) (*mcp.CallToolResult, models.CryptoOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, models.CryptoOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := request.NewAlphaWithClient(alphaClient, symbol, queries).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.CryptoOutput{}, fmt.Errorf("failed to fetch crypto data for %s/%s: %w", symbol, market, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.CryptoOutput{}, ctx.Err()
+	default:
+	}
+
+	data, err := parser.CryptoPrices(res, market, intraday)
+	if err != nil {
+		return nil, models.CryptoOutput{}, fmt.Errorf("failed to parse crypto data for %s/%s: %w", symbol, market, err)
+	}
+
+	if len(data.TimeSeries) == 0 {
+		return nil, models.CryptoOutput{}, fmt.Errorf("%w: no time series data returned for %s/%s", errors.ErrNotFound, symbol, market)
+	}
+
+	return nil, *data, nil
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (c *CryptoDaily) GetStats() client.ClientStats {
+	return c.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (c *CryptoDaily) Close() error {
+	return c.alphaClient.Close()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (c *CryptoIntraday) GetStats() client.ClientStats {
+	return c.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (c *CryptoIntraday) Close() error {
+	return c.alphaClient.Close()
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func TestCryptoDaily_MarketRequired(t *testing.T) {
+	tool := NewCryptoDaily("https://www.alphavantage.co", "test-key")
+
+	_, _, err := tool.Get(context.Background(), nil, models.CryptoDailyInput{Symbol: "BTC"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "market is required")
+}
+
+func TestCryptoIntraday_InputValidation(t *testing.T) {
+	tool := NewCryptoIntraday("https://www.alphavantage.co", "test-key")
+
+	assert.NoError(t, tool.validateInput(models.CryptoIntradayInput{Symbol: "ETH", Market: "USD", Interval: "5min"}))
+	assert.ErrorContains(t, tool.validateInput(models.CryptoIntradayInput{Symbol: "ETH", Market: "usd", Interval: "5min"}), "uppercase letters")
+	assert.ErrorContains(t, tool.validateInput(models.CryptoIntradayInput{Symbol: "ETH", Market: "USD", Interval: "1h"}), "invalid interval")
+}
+
+func TestCryptoIntraday_InputSchema(t *testing.T) {
+	schema := NewCryptoIntraday("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Equal(t, []string{"symbol", "market", "interval"}, schema.Required)
+	assert.Equal(t, []any{"compact", "full", nil}, schema.Properties["outputSize"].Enum)
+
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]any{"symbol": "ETH", "market": "USD", "interval": "5min"}), "outputSize may be omitted")
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "ETH", "market": "USD", "interval": "1h"}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "ETH", "interval": "5min"}))
+}
+
+func TestCryptoDaily_Get(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(
+		"https://www.alphavantage.co/query?apikey=test-key&function=DIGITAL_CURRENCY_DAILY&market=EUR&symbol=BTC",
+		&client.Response{StatusCode: 200, Body: []byte(`{
+			"Meta Data": {"2. Digital Currency Code": "BTC", "4. Market Code": "EUR"},
+			"Time Series (Digital Currency Daily)": {
+				"2024-01-12": {"1a. open (EUR)": "1", "1b. open (USD)": "2", "2a. high (EUR)": "3", "2b. high (USD)": "4", "3a. low (EUR)": "0.5", "3b. low (USD)": "1", "4a. close (EUR)": "2", "4b. close (USD)": "3", "5. volume": "10.5"}
+			}
+		}`)},
+	)
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	tool := &CryptoDaily{alphaClient: request.NewAlphaVantageClient(mockClient, config)}
+
+	_, res, err := tool.Get(context.Background(), nil, models.CryptoDailyInput{Symbol: "BTC", Market: "EUR"})
+	require.NoError(t, err)
+
+	require.Len(t, res.TimeSeries, 1)
+	assert.Equal(t, 1.0, res.TimeSeries[0].Open)
+	assert.Equal(t, 3.0, res.TimeSeries[0].High)
+	assert.Equal(t, 10.5, res.TimeSeries[0].Volume)
+}
@@ -0,0 +1,129 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Earnings implements the "get-earnings" MCP tool for retrieving a company's
+// EPS history.
+//
+// This tool integrates with Alpha Vantage's EARNINGS function to provide:
+//   - Annual reported EPS
+//   - Quarterly reported and estimated EPS with report dates
+//   - Earnings surprise and surprise percentage
+//
+// Missing estimates, reported by Alpha Vantage as "None", are returned as nil.
+type Earnings struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewEarnings creates a new Earnings tool instance with the provided
+// Alpha Vantage API configuration using dependency injection.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewEarnings(apiURL, apiKey string) *Earnings {
+	return newStandaloneToolset(apiURL, apiKey).Earnings()
+}
+
+// validateResponse checks that the response contains quarterly earnings
+func (e *Earnings) validateResponse(data models.EarningsOutput, symbol string) error {
+	if len(data.QuarterlyEarnings) == 0 {
+		return fmt.Errorf("%w: no earnings data returned for symbol '%s' - symbol may not exist or API limit reached", errors.ErrNotFound, symbol)
+	}
+
+	return nil
+}
+
+// newRequest builds the EARNINGS request for the input's symbol
+func (e *Earnings) newRequest(input models.SymbolInput) *request.RequestAlpha {
+	return request.NewAlphaWithClient(
+		e.alphaClient,
+		input.Symbol,
+		[]request.Query{
+			request.NewQuery("function", "EARNINGS"),
+		},
+	)
+}
+
+// Get retrieves the annual and quarterly earnings history for the given symbol.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - req: MCP tool request metadata (unused but required by interface)
+//   - input: Stock symbol input containing the ticker to query
+//
+// Returns:
+//   - *mcp.CallToolResult: Always nil (result data is in second return value)
+//   - models.EarningsOutput: Annual and quarterly EPS history, most recent first
+//   - error: Any error encountered, including an empty quarterly history
+func (e *Earnings) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolInput) (*mcp.CallToolResult, models.EarningsOutput, error) {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return nil, models.EarningsOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.EarningsOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := e.newRequest(input).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.EarningsOutput{}, fmt.Errorf("failed to fetch earnings for symbol '%s': %w", input.Symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.EarningsOutput{}, ctx.Err()
+	default:
+	}
+
+	data, err := parser.Earnings(res)
+	if err != nil {
+		return nil, models.EarningsOutput{}, fmt.Errorf("failed to parse earnings for symbol '%s': %w", input.Symbol, err)
+	}
+
+	if err := e.validateResponse(*data, input.Symbol); err != nil {
+		return nil, models.EarningsOutput{}, err
+	}
+
+	return nil, *data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (e *Earnings) BuildURL(input models.SymbolInput) (string, error) {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return e.newRequest(input).RedactedURL()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (e *Earnings) GetStats() client.ClientStats {
+	return e.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (e *Earnings) Close() error {
+	return e.alphaClient.Close()
+}
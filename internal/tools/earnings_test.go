@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func newMockEarnings(url, body string) *Earnings {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return &Earnings{alphaClient: request.NewAlphaVantageClient(mockClient, config)}
+}
+
+func TestEarnings_Get(t *testing.T) {
+	tool := newMockEarnings(
+		"https://www.alphavantage.co/query?apikey=test-key&function=EARNINGS&symbol=IBM",
+		`{"symbol": "IBM", "annualEarnings": [], "quarterlyEarnings": [{"fiscalDateEnding": "2023-12-31", "reportedEPS": "3.87", "estimatedEPS": "None"}]}`,
+	)
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolInput{Symbol: "IBM"})
+	require.NoError(t, err)
+
+	require.Len(t, res.QuarterlyEarnings, 1)
+	assert.Equal(t, 3.87, *res.QuarterlyEarnings[0].ReportedEPS)
+	assert.Nil(t, res.QuarterlyEarnings[0].EstimatedEPS)
+}
+
+func TestEarnings_EmptyQuarterlyEarnings(t *testing.T) {
+	tool := newMockEarnings(
+		"https://www.alphavantage.co/query?apikey=test-key&function=EARNINGS&symbol=XXXX",
+		`{"symbol": "XXXX", "annualEarnings": [], "quarterlyEarnings": []}`,
+	)
+
+	_, _, err := tool.Get(context.Background(), nil, models.SymbolInput{Symbol: "XXXX"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no earnings data returned for symbol 'XXXX'")
+}
+
+func TestEarnings_BuildURL(t *testing.T) {
+	tool := newMockEarnings("", "")
+
+	url, err := tool.BuildURL(models.SymbolInput{Symbol: "IBM"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.alphavantage.co/query?apikey=***&function=EARNINGS&symbol=IBM", url)
+	assert.NotContains(t, url, "test-key")
+
+	_, err = tool.BuildURL(models.SymbolInput{Symbol: ""})
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// WithErrorEnvelope wraps a tool handler so that failures reach MCP clients
+// as a JSON errors.Envelope with a machine-readable code, instead of a bare
+// Go error string.
+//
+// The SDK reports handler errors as a tool result with IsError set and the
+// error text as its content, so the envelope is carried as that text.
+func WithErrorEnvelope[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		result, output, err := handler(ctx, req, input)
+		if err != nil {
+			return nil, output, newEnvelopeError(err)
+		}
+
+		return result, output, nil
+	}
+}
+
+// envelopeError is a tool error whose text is its JSON error envelope
+type envelopeError struct {
+	err  error
+	text string
+}
+
+// newEnvelopeError classifies err and renders its envelope
+func newEnvelopeError(err error) *envelopeError {
+	text, marshalErr := parser.MarshalString(errors.NewEnvelope(err))
+	if marshalErr != nil {
+		text = err.Error()
+	}
+
+	return &envelopeError{err: err, text: text}
+}
+
+// Error returns the JSON error envelope
+func (e *envelopeError) Error() string {
+	return e.text
+}
+
+// Unwrap returns the original tool error
+func (e *envelopeError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestWithErrorEnvelope(t *testing.T) {
+	testCases := []struct {
+		name      string
+		toolErr   error
+		code      errors.Code
+		retryable bool
+	}{
+		{
+			name:    "invalid symbol",
+			toolErr: NewOverviewStockWithProvider(&fakeProvider{}).validateInput(models.OverviewInput{Symbol: "AAPL!"}),
+			code:    errors.CodeInvalidSymbol,
+		},
+		{
+			name:    "invalid input",
+			toolErr: fmt.Errorf("%w: invalid interval '2min'", errors.ErrInvalidInput),
+			code:    errors.CodeInvalidInput,
+		},
+		{
+			name:    "not found",
+			toolErr: fmt.Errorf("%w: no data returned for symbol 'XXXX'", errors.ErrNotFound),
+			code:    errors.CodeNotFound,
+		},
+		{
+			name:      "rate limited",
+			toolErr:   fmt.Errorf("failed to fetch stock data: %w", errors.ErrRateLimitWouldExceedDaily),
+			code:      errors.CodeRateLimited,
+			retryable: true,
+		},
+		{
+			name:      "rate limit note",
+			toolErr:   fmt.Errorf("failed to fetch stock data: API error: %w: API call frequency limit reached", errors.ErrRateLimited),
+			code:      errors.CodeRateLimited,
+			retryable: true,
+		},
+		{
+			name:    "premium required",
+			toolErr: fmt.Errorf("failed to fetch intraday data: API error: %w", errors.ErrPremiumRequired),
+			code:    errors.CodePremiumRequired,
+		},
+		{
+			name:    "invalid API key",
+			toolErr: fmt.Errorf("failed to fetch stock data: API error: %w", errors.ErrInvalidAPIKey),
+			code:    errors.CodeInvalidAPIKey,
+		},
+		{
+			name:      "timeout",
+			toolErr:   context.DeadlineExceeded,
+			code:      errors.CodeTimeout,
+			retryable: true,
+		},
+		{
+			name:      "upstream",
+			toolErr:   stderrors.New("failed to parse stock data: unexpected end of JSON input"),
+			code:      errors.CodeUpstreamError,
+			retryable: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, tc.toolErr)
+			handler := WithErrorEnvelope(func(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolInput) (*mcp.CallToolResult, models.OverviewOutput, error) {
+				return nil, models.OverviewOutput{}, tc.toolErr
+			})
+
+			_, _, err := handler(context.Background(), nil, models.SymbolInput{})
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.toolErr)
+
+			var envelope errors.Envelope
+			require.NoError(t, parser.ParseString(&envelope, err.Error()))
+			assert.Equal(t, tc.code, envelope.Code)
+			assert.Equal(t, tc.toolErr.Error(), envelope.Message)
+			assert.Equal(t, tc.retryable, envelope.Retryable)
+		})
+	}
+}
+
+func TestWithErrorEnvelope_Success(t *testing.T) {
+	handler := WithErrorEnvelope(NewOverviewStockWithProvider(&fakeProvider{
+		overview: &models.OverviewOutput{Symbol: "AAPL", Name: "Apple Inc"},
+	}).Get)
+
+	_, res, err := handler(context.Background(), nil, models.OverviewInput{Symbol: "AAPL"})
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", res.Symbol)
+}
+
+func TestWithErrorEnvelope_ToolResult(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_overview_stock"},
+		WithErrorEnvelope(NewOverviewStockWithProvider(&fakeProvider{overview: &models.OverviewOutput{}}).Get))
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	ctx := context.Background()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	session, err := client.Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_overview_stock",
+		Arguments: map[string]any{"symbol": "XXXX"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+
+	var envelope errors.Envelope
+	require.NoError(t, parser.ParseString(&envelope, result.Content[0].(*mcp.TextContent).Text))
+	assert.Equal(t, errors.CodeNotFound, envelope.Code)
+	assert.Contains(t, envelope.Message, "no data returned for symbol 'XXXX'")
+	assert.False(t, envelope.Retryable)
+}
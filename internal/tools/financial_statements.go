@@ -0,0 +1,193 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// financialStatement holds the state shared by the income statement,
+// balance sheet and cash flow tools, which differ only in the Alpha Vantage
+// function they call and the report type they decode.
+type financialStatement struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+
+	// function is the Alpha Vantage function, e.g. "INCOME_STATEMENT"
+	function string
+}
+
+// newFinancialStatement creates the shared state for a financial statement tool
+func newFinancialStatement(alphaClient *request.AlphaVantageClient, function string) financialStatement {
+	return financialStatement{
+		alphaClient: alphaClient,
+		function:    function,
+	}
+}
+
+// newRequest builds the statement's request for the input's symbol
+func (fs financialStatement) newRequest(input models.SymbolInput) *request.RequestAlpha {
+	return request.NewAlphaWithClient(
+		fs.alphaClient,
+		input.Symbol,
+		[]request.Query{
+			request.NewQuery("function", fs.function),
+		},
+	)
+}
+
+// fetchReports validates the input, calls the statement's function and decodes
+// the annual and quarterly reports into T
+func fetchReports[T any](ctx context.Context, fs financialStatement, input models.SymbolInput) (*parser.Reports[T], error) {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	res, err := fs.newRequest(input).GetWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s for symbol '%s': %w", fs.function, input.Symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	reports, err := parser.FinancialReports[T](res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s for symbol '%s': %w", fs.function, input.Symbol, err)
+	}
+
+	if reports.Symbol == "" && len(reports.AnnualReports) == 0 && len(reports.QuarterlyReports) == 0 {
+		return nil, fmt.Errorf("%w: no data returned for symbol '%s' - symbol may not exist or API limit reached", errors.ErrNotFound, input.Symbol)
+	}
+
+	return reports, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (fs financialStatement) BuildURL(input models.SymbolInput) (string, error) {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return fs.newRequest(input).RedactedURL()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (fs financialStatement) GetStats() client.ClientStats {
+	return fs.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (fs financialStatement) Close() error {
+	return fs.alphaClient.Close()
+}
+
+// IncomeStatement implements the "get-income-statement" MCP tool, returning
+// annual and quarterly income statements from Alpha Vantage's INCOME_STATEMENT function.
+type IncomeStatement struct {
+	financialStatement
+}
+
+// NewIncomeStatement creates a new IncomeStatement tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewIncomeStatement(apiURL, apiKey string) *IncomeStatement {
+	return newStandaloneToolset(apiURL, apiKey).IncomeStatement()
+}
+
+// Get retrieves the annual and quarterly income statements for the given symbol.
+func (s *IncomeStatement) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolInput) (*mcp.CallToolResult, models.IncomeStatementOutput, error) {
+	reports, err := fetchReports[models.IncomeStatementReport](ctx, s.financialStatement, input)
+	if err != nil {
+		return nil, models.IncomeStatementOutput{}, err
+	}
+
+	return nil, models.IncomeStatementOutput{
+		Symbol:           reports.Symbol,
+		AnnualReports:    reports.AnnualReports,
+		QuarterlyReports: reports.QuarterlyReports,
+	}, nil
+}
+
+// BalanceSheet implements the "get-balance-sheet" MCP tool, returning
+// annual and quarterly balance sheets from Alpha Vantage's BALANCE_SHEET function.
+type BalanceSheet struct {
+	financialStatement
+}
+
+// NewBalanceSheet creates a new BalanceSheet tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewBalanceSheet(apiURL, apiKey string) *BalanceSheet {
+	return newStandaloneToolset(apiURL, apiKey).BalanceSheet()
+}
+
+// Get retrieves the annual and quarterly balance sheets for the given symbol.
+func (s *BalanceSheet) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolInput) (*mcp.CallToolResult, models.BalanceSheetOutput, error) {
+	reports, err := fetchReports[models.BalanceSheetReport](ctx, s.financialStatement, input)
+	if err != nil {
+		return nil, models.BalanceSheetOutput{}, err
+	}
+
+	return nil, models.BalanceSheetOutput{
+		Symbol:           reports.Symbol,
+		AnnualReports:    reports.AnnualReports,
+		QuarterlyReports: reports.QuarterlyReports,
+	}, nil
+}
+
+// CashFlow implements the "get-cash-flow" MCP tool, returning annual and
+// quarterly cash flow statements from Alpha Vantage's CASH_FLOW function.
+type CashFlow struct {
+	financialStatement
+}
+
+// NewCashFlow creates a new CashFlow tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewCashFlow(apiURL, apiKey string) *CashFlow {
+	return newStandaloneToolset(apiURL, apiKey).CashFlow()
+}
+
+// Get retrieves the annual and quarterly cash flow statements for the given symbol.
+func (s *CashFlow) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolInput) (*mcp.CallToolResult, models.CashFlowOutput, error) {
+	reports, err := fetchReports[models.CashFlowReport](ctx, s.financialStatement, input)
+	if err != nil {
+		return nil, models.CashFlowOutput{}, err
+	}
+
+	return nil, models.CashFlowOutput{
+		Symbol:           reports.Symbol,
+		AnnualReports:    reports.AnnualReports,
+		QuarterlyReports: reports.QuarterlyReports,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func newMockFinancialStatement(function, url, body string) financialStatement {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return financialStatement{
+		alphaClient: request.NewAlphaVantageClient(mockClient, config),
+		function:    function,
+	}
+}
+
+func TestFinancialStatements_Constructors(t *testing.T) {
+	assert.Equal(t, "INCOME_STATEMENT", NewIncomeStatement("https://www.alphavantage.co", "test-key").function)
+	assert.Equal(t, "BALANCE_SHEET", NewBalanceSheet("https://www.alphavantage.co", "test-key").function)
+	assert.Equal(t, "CASH_FLOW", NewCashFlow("https://www.alphavantage.co", "test-key").function)
+}
+
+func TestBalanceSheet_Get(t *testing.T) {
+	tool := &BalanceSheet{newMockFinancialStatement(
+		"BALANCE_SHEET",
+		"https://www.alphavantage.co/query?apikey=test-key&function=BALANCE_SHEET&symbol=IBM",
+		`{"symbol": "IBM", "annualReports": [{"fiscalDateEnding": "2023-12-31", "totalAssets": "135241000000", "goodwill": "None"}], "quarterlyReports": []}`,
+	)}
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolInput{Symbol: "IBM"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "IBM", res.Symbol)
+	require.Len(t, res.AnnualReports, 1)
+	require.NotNil(t, res.AnnualReports[0].TotalAssets)
+	assert.Equal(t, 135241000000.0, *res.AnnualReports[0].TotalAssets)
+	assert.Nil(t, res.AnnualReports[0].Goodwill)
+	assert.Empty(t, res.QuarterlyReports)
+}
+
+func TestCashFlow_EmptyResponse(t *testing.T) {
+	tool := &CashFlow{newMockFinancialStatement(
+		"CASH_FLOW",
+		"https://www.alphavantage.co/query?apikey=test-key&function=CASH_FLOW&symbol=XXXX",
+		`{}`,
+	)}
+
+	_, _, err := tool.Get(context.Background(), nil, models.SymbolInput{Symbol: "XXXX"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no data returned for symbol 'XXXX'")
+}
+
+func TestIncomeStatement_InvalidSymbol(t *testing.T) {
+	tool := NewIncomeStatement("https://www.alphavantage.co", "test-key")
+
+	_, _, err := tool.Get(context.Background(), nil, models.SymbolInput{Symbol: ""})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "symbol cannot be empty")
+}
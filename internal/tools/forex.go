@@ -0,0 +1,316 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// validateCurrencyPair validates both sides of a currency pair
+func validateCurrencyPair(from, to string) error {
+	if err := validation.ValidateCurrencyCode(from); err != nil {
+		return err
+	}
+
+	return validation.ValidateCurrencyCode(to)
+}
+
+// CurrencyExchangeRate implements the "get-exchange-rate" MCP tool for retrieving
+// the realtime exchange rate between two physical or digital currencies using
+// Alpha Vantage's CURRENCY_EXCHANGE_RATE function.
+type CurrencyExchangeRate struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewCurrencyExchangeRate creates a new CurrencyExchangeRate tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewCurrencyExchangeRate(apiURL, apiKey string) *CurrencyExchangeRate {
+	return newStandaloneToolset(apiURL, apiKey).CurrencyExchangeRate()
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (c *CurrencyExchangeRate) buildQueries(input models.ExchangeRateInput) []request.Query {
+	return []request.Query{
+		request.NewQuery("function", "CURRENCY_EXCHANGE_RATE"),
+		request.NewQuery("from_currency", input.FromCurrency),
+		request.NewQuery("to_currency", input.ToCurrency),
+	}
+}
+
+// Get retrieves the realtime exchange rate, bid and ask for the currency pair.
+func (c *CurrencyExchangeRate) Get(ctx context.Context, req *mcp.CallToolRequest, input models.ExchangeRateInput) (*mcp.CallToolResult, models.ExchangeRateOutput, error) {
+	if err := validateCurrencyPair(input.FromCurrency, input.ToCurrency); err != nil {
+		return nil, models.ExchangeRateOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.ExchangeRateOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := request.NewAlphaQueryWithClient(c.alphaClient, c.buildQueries(input)).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.ExchangeRateOutput{}, fmt.Errorf("failed to fetch exchange rate %s/%s: %w", input.FromCurrency, input.ToCurrency, err)
+	}
+
+	data, err := parser.ExchangeRate(res)
+	if err != nil {
+		return nil, models.ExchangeRateOutput{}, fmt.Errorf("failed to parse exchange rate %s/%s: %w", input.FromCurrency, input.ToCurrency, err)
+	}
+
+	data.AgeSeconds, data.IsStale = parser.Freshness(data.LastRefreshed, data.TimeZone, c.alphaClient.Clock().Now(), c.alphaClient.StaleAfter())
+
+	return nil, *data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (c *CurrencyExchangeRate) BuildURL(input models.ExchangeRateInput) (string, error) {
+	if err := validateCurrencyPair(input.FromCurrency, input.ToCurrency); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaQueryWithClient(c.alphaClient, c.buildQueries(input)).RedactedURL()
+}
+
+// FXIntraday implements the "get-fx-intraday" MCP tool for retrieving intraday
+// OHLC exchange rates using Alpha Vantage's FX_INTRADAY function.
+type FXIntraday struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewFXIntraday creates a new FXIntraday tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewFXIntraday(apiURL, apiKey string) *FXIntraday {
+	return newStandaloneToolset(apiURL, apiKey).FXIntraday()
+}
+
+// validateInput performs input validation on the FX intraday input
+func (f *FXIntraday) validateInput(input models.FXIntradayInput) error {
+	if err := validateCurrencyPair(input.FromSymbol, input.ToSymbol); err != nil {
+		return err
+	}
+
+	if err := validation.ValidateInterval(input.Interval); err != nil {
+		return err
+	}
+
+	if input.OutputSize != nil {
+		if err := validation.ValidateOutputSize(*input.OutputSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (f *FXIntraday) buildQueries(input models.FXIntradayInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", "FX_INTRADAY"),
+		request.NewQuery("from_symbol", input.FromSymbol),
+		request.NewQuery("to_symbol", input.ToSymbol),
+		request.NewQuery("interval", input.Interval),
+	}
+
+	if input.OutputSize != nil {
+		queries = append(queries, request.NewQuery("outputsize", *input.OutputSize))
+	}
+
+	return queries
+}
+
+// Get retrieves intraday OHLC exchange rates for the currency pair.
+func (f *FXIntraday) Get(ctx context.Context, req *mcp.CallToolRequest, input models.FXIntradayInput) (*mcp.CallToolResult, models.FXTimeSeriesOutput, error) {
+	if err := f.validateInput(input); err != nil {
+		return nil, models.FXTimeSeriesOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return fetchFXTimeSeries(ctx, f.alphaClient, f.buildQueries(input), true)
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (f *FXIntraday) BuildURL(input models.FXIntradayInput) (string, error) {
+	if err := f.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaQueryWithClient(f.alphaClient, f.buildQueries(input)).RedactedURL()
+}
+
+// InputSchema returns the JSON schema of the tool input, restricting
+// interval and outputSize to the values Alpha Vantage accepts so the MCP SDK
+// rejects others before Get is called.
+func (f *FXIntraday) InputSchema() *jsonschema.Schema {
+	schema := inputSchema[models.FXIntradayInput]("fromSymbol", "toSymbol", "interval")
+	setEnum(schema, "interval", validation.ValidIntervals)
+	setEnum(schema, "outputSize", validation.ValidOutputSizes)
+
+	return schema
+}
+
+// FXDaily implements the "get-fx-daily" MCP tool for retrieving daily OHLC
+// exchange rates using Alpha Vantage's FX_DAILY function.
+type FXDaily struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewFXDaily creates a new FXDaily tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewFXDaily(apiURL, apiKey string) *FXDaily {
+	return newStandaloneToolset(apiURL, apiKey).FXDaily()
+}
+
+// validateInput performs input validation on the FX daily input
+func (f *FXDaily) validateInput(input models.FXDailyInput) error {
+	if err := validateCurrencyPair(input.FromSymbol, input.ToSymbol); err != nil {
+		return err
+	}
+
+	if input.OutputSize != nil {
+		if err := validation.ValidateOutputSize(*input.OutputSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (f *FXDaily) buildQueries(input models.FXDailyInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", "FX_DAILY"),
+		request.NewQuery("from_symbol", input.FromSymbol),
+		request.NewQuery("to_symbol", input.ToSymbol),
+	}
+
+	if input.OutputSize != nil {
+		queries = append(queries, request.NewQuery("outputsize", *input.OutputSize))
+	}
+
+	return queries
+}
+
+// Get retrieves daily OHLC exchange rates for the currency pair.
+func (f *FXDaily) Get(ctx context.Context, req *mcp.CallToolRequest, input models.FXDailyInput) (*mcp.CallToolResult, models.FXTimeSeriesOutput, error) {
+	if err := f.validateInput(input); err != nil {
+		return nil, models.FXTimeSeriesOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return fetchFXTimeSeries(ctx, f.alphaClient, f.buildQueries(input), false)
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (f *FXDaily) BuildURL(input models.FXDailyInput) (string, error) {
+	if err := f.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaQueryWithClient(f.alphaClient, f.buildQueries(input)).RedactedURL()
+}
+
+// InputSchema returns the JSON schema of the tool input, restricting
+// outputSize and datatype to the values Alpha Vantage accepts so the MCP SDK
+// rejects others before Get is called.
+func (f *FXDaily) InputSchema() *jsonschema.Schema {
+	schema := inputSchema[models.FXDailyInput]("fromSymbol", "toSymbol")
+	setEnum(schema, "outputSize", validation.ValidOutputSizes)
+	setEnum(schema, "datatype", validation.ValidDatatypes)
+
+	return schema
+}
+
+// fetchFXTimeSeries performs an FX time series request and parses the response
+func fetchFXTimeSeries(ctx context.Context, alphaClient *request.AlphaVantageClient, queries []request.Query, intraday bool) (*mcp.CallToolResult, models.FXTimeSeriesOutput, error) {
+	select {
+	case <-ctx.Done():
+		return nil, models.FXTimeSeriesOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := request.NewAlphaQueryWithClient(alphaClient, queries).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.FXTimeSeriesOutput{}, fmt.Errorf("failed to fetch FX data: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.FXTimeSeriesOutput{}, ctx.Err()
+	default:
+	}
+
+	rawData, err := parser.FXPrices(res, intraday)
+	if err != nil {
+		return nil, models.FXTimeSeriesOutput{}, fmt.Errorf("failed to parse FX data: %w", err)
+	}
+
+	data, err := rawData.ProcessFXTimeSeries()
+	if err != nil {
+		return nil, models.FXTimeSeriesOutput{}, fmt.Errorf("failed to process FX time series: %w", err)
+	}
+
+	if len(data.TimeSeries) == 0 {
+		return nil, models.FXTimeSeriesOutput{}, fmt.Errorf("%w: no FX time series data returned for %s/%s", errors.ErrNotFound, data.MetaData.FromSymbol, data.MetaData.ToSymbol)
+	}
+
+	return nil, *data, nil
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (c *CurrencyExchangeRate) GetStats() client.ClientStats {
+	return c.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (c *CurrencyExchangeRate) Close() error {
+	return c.alphaClient.Close()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (f *FXIntraday) GetStats() client.ClientStats {
+	return f.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (f *FXIntraday) Close() error {
+	return f.alphaClient.Close()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (f *FXDaily) GetStats() client.ClientStats {
+	return f.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (f *FXDaily) Close() error {
+	return f.alphaClient.Close()
+}
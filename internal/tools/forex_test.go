@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func newMockForexClient(url, body string) *request.AlphaVantageClient {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return request.NewAlphaVantageClient(mockClient, config)
+}
+
+func TestCurrencyExchangeRate_Get(t *testing.T) {
+	tool := &CurrencyExchangeRate{alphaClient: newMockForexClient(
+		"https://www.alphavantage.co/query?apikey=test-key&from_currency=USD&function=CURRENCY_EXCHANGE_RATE&to_currency=JPY",
+		`{"Realtime Currency Exchange Rate": {"1. From_Currency Code": "USD", "3. To_Currency Code": "JPY", "5. Exchange Rate": "144.86", "8. Bid Price": "144.85", "9. Ask Price": "144.87"}}`,
+	)}
+
+	_, res, err := tool.Get(context.Background(), nil, models.ExchangeRateInput{FromCurrency: "USD", ToCurrency: "JPY"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 144.86, res.ExchangeRate)
+	assert.Equal(t, 144.85, *res.BidPrice)
+	assert.Equal(t, 144.87, *res.AskPrice)
+}
+
+func TestCurrencyExchangeRate_InvalidCurrency(t *testing.T) {
+	tool := NewCurrencyExchangeRate("https://www.alphavantage.co", "test-key")
+
+	_, _, err := tool.Get(context.Background(), nil, models.ExchangeRateInput{FromCurrency: "usd", ToCurrency: "JPY"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "currency code 'usd'")
+}
+
+func TestFXIntraday_InputValidation(t *testing.T) {
+	tool := NewFXIntraday("https://www.alphavantage.co", "test-key")
+
+	assert.NoError(t, tool.validateInput(models.FXIntradayInput{FromSymbol: "EUR", ToSymbol: "USD", Interval: "5min"}))
+	assert.ErrorContains(t, tool.validateInput(models.FXIntradayInput{FromSymbol: "EUR", ToSymbol: "US", Interval: "5min"}), "exactly 3 letters")
+	assert.ErrorContains(t, tool.validateInput(models.FXIntradayInput{FromSymbol: "EUR", ToSymbol: "USD", Interval: "2min"}), "invalid interval")
+	assert.ErrorContains(t, tool.validateInput(models.FXIntradayInput{FromSymbol: "EUR", ToSymbol: "USD", Interval: "5min", OutputSize: stringPtr("huge")}), "invalid output size")
+}
+
+func TestFXIntraday_InputSchema(t *testing.T) {
+	schema := NewFXIntraday("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Equal(t, []string{"fromSymbol", "toSymbol", "interval"}, schema.Required)
+	assert.Equal(t, []any{"compact", "full", nil}, schema.Properties["outputSize"].Enum)
+
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]any{"fromSymbol": "EUR", "toSymbol": "USD", "interval": "5min"}), "outputSize may be omitted")
+	assert.Error(t, resolved.Validate(map[string]any{"fromSymbol": "EUR", "toSymbol": "USD", "interval": "2min"}))
+	assert.Error(t, resolved.Validate(map[string]any{"fromSymbol": "EUR", "toSymbol": "USD", "interval": "5min", "outputSize": "large"}))
+	assert.Error(t, resolved.Validate(map[string]any{"fromSymbol": "EUR", "toSymbol": "USD"}))
+}
+
+func TestFXDaily_InputSchema(t *testing.T) {
+	schema := NewFXDaily("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Equal(t, []string{"fromSymbol", "toSymbol"}, schema.Required)
+	assert.Equal(t, []any{"json", "csv", nil}, schema.Properties["datatype"].Enum)
+
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]any{"fromSymbol": "EUR", "toSymbol": "USD"}), "outputSize and datatype may be omitted")
+	assert.NoError(t, resolved.Validate(map[string]any{"fromSymbol": "EUR", "toSymbol": "USD", "outputSize": "full", "datatype": "csv"}))
+	assert.Error(t, resolved.Validate(map[string]any{"fromSymbol": "EUR", "toSymbol": "USD", "datatype": "xml"}))
+}
+
+func TestFXDaily_Get(t *testing.T) {
+	tool := &FXDaily{alphaClient: newMockForexClient(
+		"https://www.alphavantage.co/query?apikey=test-key&from_symbol=EUR&function=FX_DAILY&to_symbol=USD",
+		`{
+			"Meta Data": {
+				"1. Information": "Forex Daily Prices (open, high, low, close)",
+				"2. From Symbol": "EUR",
+				"3. To Symbol": "USD",
+				"4. Output Size": "Compact",
+				"5. Last Refreshed": "2024-01-12 21:55:00",
+				"6. Time Zone": "UTC"
+			},
+			"Time Series FX (Daily)": {
+				"2024-01-12": {"1. open": "1.0970", "2. high": "1.0998", "3. low": "1.0936", "4. close": "1.0950"},
+				"2024-01-11": {"1. open": "1.0974", "2. high": "1.1000", "3. low": "1.0930", "4. close": "1.0970"}
+			}
+		}`,
+	)}
+
+	_, res, err := tool.Get(context.Background(), nil, models.FXDailyInput{FromSymbol: "EUR", ToSymbol: "USD"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", res.MetaData.FromSymbol)
+	assert.Equal(t, "Compact", res.MetaData.OutputSize)
+	assert.Empty(t, res.MetaData.Interval)
+	require.Len(t, res.TimeSeries, 2)
+	assert.Equal(t, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), res.TimeSeries[0].Timestamp)
+}
@@ -0,0 +1,133 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// quoteTimeZone is the time zone of a quote's latest trading day
+const quoteTimeZone = "US/Eastern"
+
+// GlobalQuote implements the "get-global-quote" MCP tool for retrieving the
+// latest price of a stock.
+//
+// This tool integrates with Alpha Vantage's GLOBAL_QUOTE function, the
+// lightest way to get a stock's current state:
+//   - Latest price, open, high and low of the trading day
+//   - Volume and the latest trading day
+//   - Change and change percent from the previous close
+type GlobalQuote struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewGlobalQuote creates a new GlobalQuote tool instance with the provided
+// Alpha Vantage API configuration using dependency injection.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewGlobalQuote(apiURL, apiKey string) *GlobalQuote {
+	return newStandaloneToolset(apiURL, apiKey).GlobalQuote()
+}
+
+// validateResponse checks that the response quotes a symbol
+func (g *GlobalQuote) validateResponse(data models.GlobalQuoteOutput, symbol string) error {
+	if data.Symbol == "" {
+		return fmt.Errorf("%w: no quote returned for symbol '%s' - symbol may not exist", errors.ErrNotFound, symbol)
+	}
+
+	return nil
+}
+
+// newRequest builds the GLOBAL_QUOTE request for the input's symbol
+func (g *GlobalQuote) newRequest(input models.SymbolInput) *request.RequestAlpha {
+	return request.NewAlphaWithClient(
+		g.alphaClient,
+		input.Symbol,
+		[]request.Query{
+			request.NewQuery("function", "GLOBAL_QUOTE"),
+		},
+	)
+}
+
+// Get retrieves the latest quote for the given symbol.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - req: MCP tool request metadata (unused but required by interface)
+//   - input: Stock symbol input containing the ticker to query
+//
+// Returns:
+//   - *mcp.CallToolResult: Always nil (result data is in second return value)
+//   - models.GlobalQuoteOutput: Latest price, volume and change of the stock
+//   - error: Any error encountered, including an unknown symbol
+func (g *GlobalQuote) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolInput) (*mcp.CallToolResult, models.GlobalQuoteOutput, error) {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return nil, models.GlobalQuoteOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.GlobalQuoteOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := g.newRequest(input).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.GlobalQuoteOutput{}, fmt.Errorf("failed to fetch quote for symbol '%s': %w", input.Symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.GlobalQuoteOutput{}, ctx.Err()
+	default:
+	}
+
+	data, err := parser.GlobalQuote(res)
+	if err != nil {
+		return nil, models.GlobalQuoteOutput{}, fmt.Errorf("failed to parse quote for symbol '%s': %w", input.Symbol, err)
+	}
+
+	if err := g.validateResponse(*data, input.Symbol); err != nil {
+		return nil, models.GlobalQuoteOutput{}, err
+	}
+
+	data.AgeSeconds, data.IsStale = parser.Freshness(data.LatestTradingDay, quoteTimeZone, g.alphaClient.Clock().Now(), g.alphaClient.StaleAfter())
+
+	return nil, *data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (g *GlobalQuote) BuildURL(input models.SymbolInput) (string, error) {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return g.newRequest(input).RedactedURL()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (g *GlobalQuote) GetStats() client.ClientStats {
+	return g.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (g *GlobalQuote) Close() error {
+	return g.alphaClient.Close()
+}
@@ -0,0 +1,77 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GlobalQuotes implements the "get-global-quotes" MCP tool, fetching the
+// latest quote of several symbols concurrently, e.g. to refresh a watchlist.
+//
+// Each symbol goes through the same validation and fetch as the
+// "get-global-quote" tool, so requests draw on the same HTTP client, request
+// slots and rate limiter. Symbols that fail are reported in the output's
+// error map while the rest succeed.
+type GlobalQuotes struct {
+	// quote fetches a single symbol
+	quote *GlobalQuote
+
+	// concurrency is the number of symbols fetched at once
+	concurrency int
+}
+
+// NewGlobalQuotes creates a new GlobalQuotes tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+//
+// Requests are held to the free tier's per-minute quota. Waiting for quota
+// is bounded by the caller's context, not the per-request timeout, so a
+// large batch waits for quota instead of being rejected upstream.
+func NewGlobalQuotes(apiURL, apiKey string) *GlobalQuotes {
+	return newStandaloneBatchToolset(apiURL, apiKey).GlobalQuotes()
+}
+
+// Get retrieves the latest quote of every requested symbol.
+//
+// Per-symbol failures, including invalid symbols, are collected in the
+// output's error map rather than failing the call. The call itself fails
+// only for an invalid batch or when ctx is cancelled.
+func (g *GlobalQuotes) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolsInput) (*mcp.CallToolResult, models.GlobalQuoteBatchOutput, error) {
+	symbols, err := batchSymbols(input.Symbols)
+	if err != nil {
+		return nil, models.GlobalQuoteBatchOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	quotes, failures, err := fetchBatch(ctx, symbols, g.concurrency, func(ctx context.Context, symbol string) (models.GlobalQuoteOutput, error) {
+		_, data, err := g.quote.Get(ctx, req, models.SymbolInput{Symbol: symbol})
+		return data, err
+	})
+	if err != nil {
+		return nil, models.GlobalQuoteBatchOutput{}, err
+	}
+
+	return nil, models.GlobalQuoteBatchOutput{Quotes: quotes, Errors: failures}, nil
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (g *GlobalQuotes) GetStats() client.ClientStats {
+	return g.quote.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (g *GlobalQuotes) Close() error {
+	return g.quote.Close()
+}
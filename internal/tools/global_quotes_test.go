@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	pkgerrors "github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func quoteURL(symbol string) string {
+	return "https://www.alphavantage.co/query?apikey=test-key&function=GLOBAL_QUOTE&symbol=" + symbol
+}
+
+func quoteResponse(symbol string, price float64) *client.Response {
+	return &client.Response{
+		StatusCode: 200,
+		Body: []byte(fmt.Sprintf(`{"Global Quote": {"01. symbol": "%s", "02. open": "100.0000", "03. high": "110.0000", "04. low": "95.0000", "05. price": "%.4f", "06. volume": "1000", "07. latest trading day": "2024-01-12", "08. previous close": "100.0000", "09. change": "%.4f", "10. change percent": "%.4f%%"}}`,
+			symbol, price, price-100, price-100)),
+	}
+}
+
+func newMockGlobalQuotes(mockClient *client.MockClient) *GlobalQuotes {
+	return NewToolsetWithClient(mockClient, &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}).GlobalQuotes()
+}
+
+func TestGlobalQuotes_PartialSuccess(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(quoteURL("AAPL"), quoteResponse("AAPL", 185.5))
+	mockClient.SetResponse(quoteURL("MSFT"), quoteResponse("MSFT", 390.25))
+	mockClient.SetError(quoteURL("IBM"), errors.New("connection refused"))
+	mockClient.SetResponse(quoteURL("NOPE"), &client.Response{StatusCode: 200, Body: []byte(`{"Global Quote": {}}`)})
+
+	tool := newMockGlobalQuotes(mockClient)
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolsInput{
+		Symbols: []string{"aapl", "MSFT", "IBM", "NOPE", " AAPL "},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, res.Quotes, 2)
+	assert.Equal(t, 185.5, res.Quotes["AAPL"].Price)
+	assert.Equal(t, 390.25, res.Quotes["MSFT"].Price)
+	assert.Equal(t, 290.25, res.Quotes["MSFT"].ChangePercent)
+
+	// The quotes are of a trading day long past
+	require.NotNil(t, res.Quotes["AAPL"].IsStale)
+	assert.True(t, *res.Quotes["AAPL"].IsStale)
+
+	require.Len(t, res.Errors, 2)
+	assert.Equal(t, pkgerrors.CodeUpstreamError, res.Errors["IBM"].Code)
+	assert.Contains(t, res.Errors["IBM"].Message, "connection refused")
+	assert.Equal(t, pkgerrors.CodeNotFound, res.Errors["NOPE"].Code)
+
+	// Duplicate symbols are fetched once
+	assert.Equal(t, 1, mockClient.GetCallCount(quoteURL("AAPL")))
+}
+
+func TestGlobalQuotes_InputValidation(t *testing.T) {
+	tool := newMockGlobalQuotes(client.NewMockClient())
+
+	_, _, err := tool.Get(context.Background(), nil, models.SymbolsInput{})
+	assert.ErrorIs(t, err, pkgerrors.ErrInvalidInput)
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolsInput{Symbols: []string{"AAPL!"}})
+	require.NoError(t, err)
+	assert.Equal(t, pkgerrors.CodeInvalidSymbol, res.Errors["AAPL!"].Code)
+}
+
+func TestGlobalQuotes_ContextCancellation(t *testing.T) {
+	mockClient := client.NewMockClient()
+	tool := newMockGlobalQuotes(mockClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := tool.Get(ctx, nil, models.SymbolsInput{Symbols: []string{"AAPL", "MSFT"}})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, mockClient.Requests())
+}
+
+func TestGlobalQuotes_WaitsForQuota(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	config := &request.AlphaVantageConfig{RequestsPerMinute: 5, Clock: fake}
+	toolset, mockClient := newMockToolset(config)
+	mockClient.SetResponseContains("function=GLOBAL_QUOTE", quoteResponse("S", 101))
+
+	// The limiter frees a token every 12s, so most symbols wait far longer
+	// than the request timeout
+	config.Timeout = 5 * time.Millisecond
+	tool := toolset.GlobalQuotes()
+	defer driveClock(fake, 12*time.Second, config.Timeout)()
+
+	symbols := make([]string, maxBatchSymbols)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%d", i)
+	}
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolsInput{Symbols: symbols})
+	require.NoError(t, err)
+	assert.Empty(t, res.Errors)
+	assert.Len(t, res.Quotes, maxBatchSymbols)
+}
+
+func TestGlobalQuotes_NoLimiterOfItsOwn(t *testing.T) {
+	// Without configured limits the batch is not throttled: a private
+	// limiter would let the batch tools exceed a quota the shared one keeps
+	fake := clock.NewFake(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	toolset, mockClient := newMockToolset(&request.AlphaVantageConfig{Clock: fake})
+	mockClient.SetResponseContains("function=GLOBAL_QUOTE", quoteResponse("S", 101))
+	mockClient.SetResponseContains("function=OVERVIEW", &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "S"}`)})
+
+	symbols := make([]string, maxBatchSymbols)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%d", i)
+	}
+
+	// The fake clock never advances, so a throttled batch would time out
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, quotes, err := toolset.GlobalQuotes().Get(ctx, nil, models.SymbolsInput{Symbols: symbols})
+	require.NoError(t, err)
+	assert.Len(t, quotes.Quotes, maxBatchSymbols)
+
+	_, overviews, err := toolset.OverviewStocks().Get(ctx, nil, models.SymbolsInput{Symbols: symbols})
+	require.NoError(t, err)
+	assert.Len(t, overviews.Overviews, maxBatchSymbols)
+	assert.Zero(t, fake.Waiters())
+}
+
+func TestGlobalQuote_NotFound(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(quoteURL("NOPE"), &client.Response{StatusCode: 200, Body: []byte(`{"Global Quote": {}}`)})
+
+	tool := newMockGlobalQuotes(mockClient).quote
+	_, _, err := tool.Get(context.Background(), nil, models.SymbolInput{Symbol: "NOPE"})
+	assert.ErrorIs(t, err, pkgerrors.ErrNotFound)
+
+	url, err := tool.BuildURL(models.SymbolInput{Symbol: "IBM"})
+	require.NoError(t, err)
+	assert.Contains(t, url, "function=GLOBAL_QUOTE")
+	assert.NotContains(t, url, "test-key")
+}
@@ -9,19 +9,35 @@ import (
 	"context"
 	"fmt"
 	"slices"
-	"strings"
 	"sync"
 	"time"
 
+	"github.com/yeferson59/finance-mcp/internal/filter"
+	"github.com/yeferson59/finance-mcp/internal/indicators"
 	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/provider"
+	"github.com/yeferson59/finance-mcp/internal/resample"
 	"github.com/yeferson59/finance-mcp/internal/validation"
 	"github.com/yeferson59/finance-mcp/pkg/client"
-	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
 	"github.com/yeferson59/finance-mcp/pkg/request"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// defaultIndicatorPeriod is the number of bars used for locally computed
+// moving averages when no indicator period is provided
+const defaultIndicatorPeriod = 20
+
+// defaultBollingerStdDev is the width of locally computed Bollinger Bands in
+// standard deviations when none is provided
+const defaultBollingerStdDev = 2.0
+
+// maxIntradayLimit caps the number of bars a single response can return, so a
+// full pull does not overflow the client's context window
+const maxIntradayLimit = 5000
+
 // IntradayPriceStock implements the "get-intraday-price-stock" MCP tool for retrieving
 // intraday stock price data with time series information.
 //
@@ -37,8 +53,8 @@ import (
 // data validation automatically with proper context support for timeouts
 // and cancellation.
 type IntradayPriceStock struct {
-	// alphaClient is the injected Alpha Vantage client
-	alphaClient *request.AlphaVantageClient
+	// dataProvider is the injected market data backend
+	dataProvider provider.DataProvider
 
 	// mu protects concurrent access for thread safety
 	mu sync.RWMutex
@@ -58,27 +74,55 @@ type IntradayPriceStock struct {
 // settings for intraday data retrieval that are reused across requests.
 func NewIntradayPriceStock(apiURL, apiKey string) *IntradayPriceStock {
 	// Create Alpha Vantage client configuration
-	config := &request.AlphaVantageConfig{
+	return NewIntradayPriceStockWithConfig(&request.AlphaVantageConfig{
 		BaseURL: apiURL,
 		APIKey:  apiKey,
 		Timeout: 30 * time.Second,
-	}
+	})
+}
 
-	// Create HTTP client with optimized settings for intraday data
-	httpConfig := client.DefaultConfig()
-	httpConfig.UserAgent = "Finance-MCP-Server/1.0"
-	httpConfig.ReadTimeout = 30 * time.Second
-	httpConfig.WriteTimeout = 30 * time.Second
-	// Intraday data can be large, so we may need higher limits
-	httpConfig.MaxResponseBodySize = 20 * 1024 * 1024 // 20MB for large datasets
-	httpClient := client.NewFastHTTPClient(httpConfig)
+// NewIntradayPriceStockWithConfig creates an IntradayPriceStock tool backed by
+// Alpha Vantage with the given configuration, including the DefaultInterval
+// and DefaultOutputSize applied to inputs that leave those fields empty.
+func NewIntradayPriceStockWithConfig(config *request.AlphaVantageConfig) *IntradayPriceStock {
+	toolset := NewToolset(config)
+	toolset.standalone = true
 
-	// Create Alpha Vantage client with dependency injection
-	alphaClient := request.NewAlphaVantageClient(httpClient, config)
+	return toolset.IntradayPriceStock()
+}
 
+// NewIntradayPriceStockWithProvider creates an IntradayPriceStock tool that
+// fetches bars from the given provider instead of the default Alpha Vantage backend.
+func NewIntradayPriceStockWithProvider(dataProvider provider.DataProvider) *IntradayPriceStock {
 	return &IntradayPriceStock{
-		alphaClient: alphaClient,
+		dataProvider: dataProvider,
+	}
+}
+
+// applyDefaults fills an empty interval from the provider's configured
+// default, then an empty output size from the default for that interval and
+// an unset adjusted flag from its default. Values set in the input always
+// take precedence.
+func (s *IntradayPriceStock) applyDefaults(input models.IntradayPriceInput) models.IntradayPriceInput {
+	defaulter, ok := s.dataProvider.(provider.IntradayDefaulter)
+	if !ok {
+		return input
+	}
+
+	interval, _ := defaulter.IntradayDefaults()
+	if input.Interval == "" {
+		input.Interval = interval
+	}
+
+	if outputSize := defaulter.IntradayOutputSize(input.Interval); input.OutputSize == nil && outputSize != "" {
+		input.OutputSize = &outputSize
+	}
+
+	if input.Adjusted == nil {
+		input.Adjusted = defaulter.IntradayAdjusted()
 	}
+
+	return input
 }
 
 // validateInput performs comprehensive input validation on the intraday price input
@@ -89,24 +133,14 @@ func (s *IntradayPriceStock) validateInput(input models.IntradayPriceInput) erro
 	}
 
 	// Validate interval
-	validIntervals := []string{"1min", "5min", "15min", "30min", "60min"}
-	if !slices.Contains(validIntervals, input.Interval) {
-		return fmt.Errorf("invalid interval '%s'. Valid intervals are: %s",
-			input.Interval, strings.Join(validIntervals, ", "))
+	if err := validation.ValidateInterval(input.Interval); err != nil {
+		return err
 	}
 
 	// Validate output size if provided
 	if input.OutputSize != nil {
-		validOutputSizes := []string{"compact", "full"}
-		outputSizeValid := false
-
-		if slices.Contains(validOutputSizes, *input.OutputSize) {
-			outputSizeValid = true
-		}
-
-		if !outputSizeValid {
-			return fmt.Errorf("invalid output size '%s'. Valid sizes are: %s",
-				*input.OutputSize, strings.Join(validOutputSizes, ", "))
+		if err := validation.ValidateOutputSize(*input.OutputSize); err != nil {
+			return err
 		}
 	}
 
@@ -119,34 +153,58 @@ func (s *IntradayPriceStock) validateInput(input models.IntradayPriceInput) erro
 		// Additional validation could check if it's a valid date
 	}
 
-	return nil
-}
+	// Validate datatype if provided
+	if input.Datatype != nil {
+		if err := validation.ValidateDatatype(*input.Datatype); err != nil {
+			return err
+		}
+	}
 
-// buildQueries constructs the query parameters for the Alpha Vantage API request
-func (s *IntradayPriceStock) buildQueries(input models.IntradayPriceInput) []request.Query {
-	queries := []request.Query{
-		request.NewQuery("function", "TIME_SERIES_INTRADAY"),
-		request.NewQuery("interval", input.Interval),
+	// Validate entitlement if provided
+	if input.Entitlement != nil {
+		if err := validation.ValidateEntitlement(*input.Entitlement); err != nil {
+			return err
+		}
 	}
 
-	// Add optional parameters
-	if input.Adjusted != nil {
-		queries = append(queries, request.NewQuery("adjusted", fmt.Sprintf("%t", *input.Adjusted)))
+	// Validate indicator period if provided
+	if input.IndicatorPeriod != nil && *input.IndicatorPeriod <= 0 {
+		return fmt.Errorf("invalid indicator period %d. Period must be a positive integer", *input.IndicatorPeriod)
 	}
 
-	if input.ExtendedHours != nil {
-		queries = append(queries, request.NewQuery("extended_hours", fmt.Sprintf("%t", *input.ExtendedHours)))
+	// Validate Bollinger Band width if provided
+	if input.BollingerStdDev != nil && !(*input.BollingerStdDev > 0) {
+		return fmt.Errorf("invalid Bollinger standard deviation %g. It must be positive", *input.BollingerStdDev)
 	}
 
-	if input.Month != nil {
-		queries = append(queries, request.NewQuery("month", *input.Month))
+	// Validate the resample interval if provided
+	if input.Resample != nil {
+		target, err := validation.IntervalDuration(*input.Resample)
+		if err != nil {
+			return fmt.Errorf("invalid resample interval: %w", err)
+		}
+
+		fetched, _ := validation.IntervalDuration(input.Interval)
+		if target%fetched != 0 {
+			return fmt.Errorf("invalid resample interval '%s'. It must be a multiple of interval '%s'", *input.Resample, input.Interval)
+		}
 	}
 
-	if input.OutputSize != nil {
-		queries = append(queries, request.NewQuery("outputsize", *input.OutputSize))
+	// Validate the date range if provided
+	if _, err := filter.NewDateRange(input.From, input.To); err != nil {
+		return err
 	}
 
-	return queries
+	// Validate pagination if provided
+	if input.Limit != nil && *input.Limit <= 0 {
+		return fmt.Errorf("invalid limit %d. Limit must be a positive integer", *input.Limit)
+	}
+
+	if input.Offset != nil && *input.Offset < 0 {
+		return fmt.Errorf("invalid offset %d. Offset must not be negative", *input.Offset)
+	}
+
+	return nil
 }
 
 // Get retrieves intraday stock price data for the specified stock symbol and parameters.
@@ -177,9 +235,12 @@ func (s *IntradayPriceStock) buildQueries(input models.IntradayPriceInput) []req
 // various Alpha Vantage response formats including error responses.
 // It respects the context for cancellation and timeout control.
 func (s *IntradayPriceStock) Get(ctx context.Context, req *mcp.CallToolRequest, input models.IntradayPriceInput) (*mcp.CallToolResult, models.IntradayStockOutput, error) {
+	// Fall back to the configured interval and output size
+	input = s.applyDefaults(input)
+
 	// Validate input before making any external requests
 	if err := s.validateInput(input); err != nil {
-		return nil, models.IntradayStockOutput{}, fmt.Errorf("input validation failed: %w", err)
+		return nil, models.IntradayStockOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
 	}
 
 	// Check if context is already cancelled
@@ -189,55 +250,234 @@ func (s *IntradayPriceStock) Get(ctx context.Context, req *mcp.CallToolRequest,
 	default:
 	}
 
-	// Build query parameters
-	queries := s.buildQueries(input)
+	// Fetch the processed bars from the provider
+	data, err := s.dataProvider.Intraday(ctx, input)
+	if err != nil {
+		return nil, models.IntradayStockOutput{}, err
+	}
 
-	// Create request with proper query parameters using injected client
-	requestClient := request.NewAlphaWithClient(
-		s.alphaClient,
-		input.Symbol,
-		queries,
-	)
+	// Validate that we received data
+	if err := s.validateResponse(*data, input.Symbol); err != nil {
+		return nil, models.IntradayStockOutput{}, err
+	}
 
-	// Make API request with context support
-	res, err := requestClient.GetWithContext(ctx)
-	if err != nil {
-		return nil, models.IntradayStockOutput{}, fmt.Errorf("failed to fetch intraday data for symbol '%s': %w", input.Symbol, err)
+	// Narrow the cached full fetch to the regular session when requested
+	if input.OnlyRegularHours != nil && *input.OnlyRegularHours {
+		data.TimeSeries = filter.RegularHours(data.TimeSeries)
 	}
 
-	// Check context again before parsing (in case request took a long time)
-	select {
-	case <-ctx.Done():
-		return nil, models.IntradayStockOutput{}, ctx.Err()
-	default:
+	// Narrow to the requested date range; already validated above
+	if dateRange, _ := filter.NewDateRange(input.From, input.To); !dateRange.IsZero() {
+		data.TimeSeries = dateRange.Apply(data.TimeSeries)
 	}
 
-	// Parse the raw intraday data using the specialized parser
-	rawData, err := parser.IntradayPrices(res)
-	if err != nil {
-		return nil, models.IntradayStockOutput{}, fmt.Errorf("failed to parse intraday data for symbol '%s': %w", input.Symbol, err)
+	// Aggregate into the requested coarser interval; already validated above
+	if input.Resample != nil {
+		target, _ := validation.IntervalDuration(*input.Resample)
+		data.TimeSeries = resample.Resample(data.TimeSeries, target)
+		data.MetaData.Interval = *input.Resample
 	}
 
-	// Process the time series data into the final output format
-	data, err := rawData.ProcessTimeSeries()
-	if err != nil {
-		return nil, models.IntradayStockOutput{}, fmt.Errorf("failed to process time series data for symbol '%s': %w", input.Symbol, err)
+	// Attach locally computed indicators
+	if err := s.attachIndicators(data, input); err != nil {
+		return nil, models.IntradayStockOutput{}, fmt.Errorf("failed to compute indicators for symbol '%s': %w", input.Symbol, err)
 	}
 
-	// Validate that we received data
-	if err := s.validateResponse(*data, input.Symbol); err != nil {
-		return nil, models.IntradayStockOutput{}, err
+	// Return only the requested page
+	s.paginate(data, input)
+
+	// Summarize the window actually returned
+	if input.IncludeSummary != nil && *input.IncludeSummary {
+		data.Summary = indicators.Summarize(data.TimeSeries)
+	}
+
+	// Compute the cumulative return over the same window, oldest bar first
+	if input.IncludeReturns != nil && *input.IncludeReturns {
+		window := data.TimeSeries
+		if input.Latest != nil && *input.Latest {
+			window = slices.Clone(window)
+			slices.Reverse(window)
+		}
+
+		cumulative := indicators.CumulativeReturn(window)
+		data.CumulativeReturn = &cumulative
 	}
 
 	// Return successful result
 	return nil, *data, nil
 }
 
+// BuildURL applies the configured defaults, validates the input and returns
+// the Alpha Vantage URL Get would request, with the API key redacted,
+// without calling the API.
+func (s *IntradayPriceStock) BuildURL(input models.IntradayPriceInput) (string, error) {
+	input = s.applyDefaults(input)
+	if err := s.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	builder, ok := s.dataProvider.(provider.RequestURLBuilder)
+	if !ok {
+		return "", fmt.Errorf("provider %T cannot build request URLs", s.dataProvider)
+	}
+
+	return builder.IntradayURL(input)
+}
+
+// InputSchema returns the JSON schema of the tool input, restricting
+// interval, outputSize, datatype, entitlement and resample to the values
+// Alpha Vantage accepts so the MCP SDK rejects others before Get is called.
+// The interval is only required when the provider configures no default
+// interval.
+func (s *IntradayPriceStock) InputSchema() *jsonschema.Schema {
+	required := []string{"symbol", "interval"}
+	if defaulter, ok := s.dataProvider.(provider.IntradayDefaulter); ok {
+		if interval, _ := defaulter.IntradayDefaults(); interval != "" {
+			required = required[:1]
+		}
+	}
+
+	schema := inputSchema[models.IntradayPriceInput](required...)
+	setEnum(schema, "interval", validation.ValidIntervals)
+	setEnum(schema, "outputSize", validation.ValidOutputSizes)
+	setEnum(schema, "datatype", validation.ValidDatatypes)
+	setEnum(schema, "entitlement", validation.ValidEntitlements)
+	setEnum(schema, "resample", validation.ValidIntervals)
+
+	return schema
+}
+
+// paginate slices the bars and attached indicators to the page selected by
+// the input's limit, offset and latest fields, recording the total beforehand.
+// Limits above maxIntradayLimit are capped.
+func (s *IntradayPriceStock) paginate(data *models.IntradayStockOutput, input models.IntradayPriceInput) {
+	data.TotalBars = len(data.TimeSeries)
+
+	latest := input.Latest != nil && *input.Latest
+	if input.Limit == nil && input.Offset == nil && !latest {
+		return
+	}
+
+	offset := 0
+	if input.Offset != nil {
+		offset = *input.Offset
+	}
+
+	limit := -1
+	if input.Limit != nil {
+		limit = min(*input.Limit, maxIntradayLimit)
+	}
+
+	data.TimeSeries = filter.Page(data.TimeSeries, offset, limit, latest)
+	data.SMA = pagePoints(data.SMA, data.TimeSeries, latest)
+	data.EMA = pagePoints(data.EMA, data.TimeSeries, latest)
+	data.VWAP = pagePoints(data.VWAP, data.TimeSeries, latest)
+	data.Returns = pagePoints(data.Returns, data.TimeSeries, latest)
+	if data.BollingerBands != nil {
+		data.BollingerBands.Upper = pagePoints(data.BollingerBands.Upper, data.TimeSeries, latest)
+		data.BollingerBands.Middle = pagePoints(data.BollingerBands.Middle, data.TimeSeries, latest)
+		data.BollingerBands.Lower = pagePoints(data.BollingerBands.Lower, data.TimeSeries, latest)
+	}
+	data.Patterns = pageByTime(data.Patterns, func(hit models.PatternHit) time.Time { return hit.Timestamp }, data.TimeSeries, latest)
+}
+
+// pagePoints keeps the indicator points within the page's time span, in the
+// same order as the page
+func pagePoints(points []models.IndicatorPoint, page []models.OHLCVFloat, latest bool) []models.IndicatorPoint {
+	return pageByTime(points, func(point models.IndicatorPoint) time.Time { return point.Timestamp }, page, latest)
+}
+
+// pageByTime keeps the items whose timestamp is within the page's time span,
+// in the same order as the page
+func pageByTime[T any](items []T, timestamp func(T) time.Time, page []models.OHLCVFloat, latest bool) []T {
+	if len(items) == 0 {
+		return items
+	}
+
+	if len(page) == 0 {
+		return nil
+	}
+
+	first, last := page[0].Timestamp, page[len(page)-1].Timestamp
+	if latest {
+		first, last = last, first
+	}
+
+	kept := make([]T, 0, len(page))
+	for _, item := range items {
+		if at := timestamp(item); !at.Before(first) && !at.After(last) {
+			kept = append(kept, item)
+		}
+	}
+
+	if latest {
+		slices.Reverse(kept)
+	}
+
+	return kept
+}
+
+// attachIndicators computes the indicators requested in the input from
+// the fetched bars, avoiding additional rate-limited indicator API calls
+func (s *IntradayPriceStock) attachIndicators(data *models.IntradayStockOutput, input models.IntradayPriceInput) error {
+	period := defaultIndicatorPeriod
+	if input.IndicatorPeriod != nil {
+		period = *input.IndicatorPeriod
+	}
+
+	if input.IncludeSMA != nil && *input.IncludeSMA {
+		values, err := indicators.SMA(data.TimeSeries, period)
+		if err != nil {
+			return fmt.Errorf("SMA: %w", err)
+		}
+		data.SMA = indicators.Points(data.TimeSeries, values)
+	}
+
+	if input.IncludeEMA != nil && *input.IncludeEMA {
+		values, err := indicators.EMA(data.TimeSeries, period)
+		if err != nil {
+			return fmt.Errorf("EMA: %w", err)
+		}
+		data.EMA = indicators.Points(data.TimeSeries, values)
+	}
+
+	if input.IncludeVWAP != nil && *input.IncludeVWAP {
+		data.VWAP = indicators.Points(data.TimeSeries, indicators.VWAP(data.TimeSeries))
+	}
+
+	if input.IncludeReturns != nil && *input.IncludeReturns {
+		data.Returns = indicators.Points(data.TimeSeries, indicators.Returns(data.TimeSeries))
+	}
+
+	if input.IncludeBollinger != nil && *input.IncludeBollinger {
+		stddev := defaultBollingerStdDev
+		if input.BollingerStdDev != nil {
+			stddev = *input.BollingerStdDev
+		}
+
+		upper, middle, lower, err := indicators.BollingerBands(data.TimeSeries, period, stddev)
+		if err != nil {
+			return fmt.Errorf("Bollinger Bands: %w", err)
+		}
+		data.BollingerBands = &models.BollingerBands{
+			Upper:  indicators.Points(data.TimeSeries, upper),
+			Middle: indicators.Points(data.TimeSeries, middle),
+			Lower:  indicators.Points(data.TimeSeries, lower),
+		}
+	}
+
+	if input.IncludePatterns != nil && *input.IncludePatterns {
+		data.Patterns = indicators.DetectPatterns(data.TimeSeries)
+	}
+
+	return nil
+}
+
 // validateResponse checks if the API response contains valid data
 func (s *IntradayPriceStock) validateResponse(data models.IntradayStockOutput, symbol string) error {
 	// Check if response contains basic required fields
 	if data.MetaData.Symbol == "" {
-		return fmt.Errorf("no data returned for symbol '%s' - symbol may not exist or API limit reached", symbol)
+		return fmt.Errorf("%w: no data returned for symbol '%s' - symbol may not exist or API limit reached", errors.ErrNotFound, symbol)
 	}
 
 	if data.MetaData.Interval == "" {
@@ -245,29 +485,36 @@ func (s *IntradayPriceStock) validateResponse(data models.IntradayStockOutput, s
 	}
 
 	if len(data.TimeSeries) == 0 {
-		return fmt.Errorf("no time series data returned for symbol '%s' - check if market is open or try a different time period", symbol)
+		return fmt.Errorf("%w: no time series data returned for symbol '%s' - check if market is open or try a different time period", errors.ErrNotFound, symbol)
 	}
 
 	return nil
 }
 
 // GetStats returns HTTP client statistics for monitoring
+// when the provider reports them, and empty statistics otherwise
 func (s *IntradayPriceStock) GetStats() client.ClientStats {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.alphaClient.GetStats()
+	if reporter, ok := s.dataProvider.(provider.StatsReporter); ok {
+		return reporter.GetStats()
+	}
+	return client.ClientStats{}
 }
 
 // Close cleans up resources used by the tool
 func (s *IntradayPriceStock) Close() error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	return s.alphaClient.Close()
+	return s.dataProvider.Close()
 }
 
-// SetTimeout configures the request timeout for this tool instance
+// SetTimeout configures the request timeout for this tool instance when the
+// provider supports it
 func (s *IntradayPriceStock) SetTimeout(timeout time.Duration) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.alphaClient.SetTimeout(timeout)
+	if setter, ok := s.dataProvider.(provider.TimeoutSetter); ok {
+		setter.SetTimeout(timeout)
+	}
 }
@@ -2,12 +2,19 @@ package tools
 
 import (
 	"context"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/provider"
+	"github.com/yeferson59/finance-mcp/internal/validation"
 	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
 	"github.com/yeferson59/finance-mcp/pkg/request"
 )
 
@@ -50,13 +57,10 @@ func TestIntradayPriceStock_DependencyInjection(t *testing.T) {
 
 	alphaClient := request.NewAlphaVantageClient(mockClient, config)
 
-	// This would be how we'd create it with DI (not exposed yet, but demonstrates the pattern)
-	tool := &IntradayPriceStock{
-		alphaClient: alphaClient,
-	}
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(alphaClient))
 
 	assert.NotNil(t, tool)
-	assert.NotNil(t, tool.alphaClient)
+	assert.NotNil(t, tool.dataProvider)
 }
 
 func TestIntradayPriceStock_NewIntradayPriceStock(t *testing.T) {
@@ -67,7 +71,7 @@ func TestIntradayPriceStock_NewIntradayPriceStock(t *testing.T) {
 	tool := NewIntradayPriceStock(apiURL, apiKey)
 
 	assert.NotNil(t, tool)
-	assert.NotNil(t, tool.alphaClient)
+	assert.NotNil(t, tool.dataProvider)
 }
 
 func TestIntradayPriceStock_InputValidation(t *testing.T) {
@@ -172,11 +176,11 @@ func TestIntradayPriceStock_InputValidation(t *testing.T) {
 		{
 			name: "symbol too long",
 			input: models.IntradayPriceInput{
-				Symbol:   "VERYLONGSYMBOL",
+				Symbol:   "AVERYVERYLONGSYMBOL.XX",
 				Interval: "1min",
 			},
 			expectError: true,
-			errorMsg:    "symbol 'VERYLONGSYMBOL' appears to be invalid (too long)",
+			errorMsg:    "symbol 'AVERYVERYLONGSYMBOL.XX' appears to be invalid (too long, max 20 characters)",
 		},
 		{
 			name: "invalid characters in symbol",
@@ -187,6 +191,55 @@ func TestIntradayPriceStock_InputValidation(t *testing.T) {
 			expectError: true,
 			errorMsg:    "symbol 'AAPL!@#' contains invalid characters",
 		},
+		{
+			name: "zero limit",
+			input: models.IntradayPriceInput{
+				Symbol:   "AAPL",
+				Interval: "1min",
+				Limit:    intPtr(0),
+			},
+			expectError: true,
+			errorMsg:    "invalid limit 0",
+		},
+		{
+			name: "negative offset",
+			input: models.IntradayPriceInput{
+				Symbol:   "AAPL",
+				Interval: "1min",
+				Offset:   intPtr(-1),
+			},
+			expectError: true,
+			errorMsg:    "invalid offset -1",
+		},
+		{
+			name: "valid pagination",
+			input: models.IntradayPriceInput{
+				Symbol:   "AAPL",
+				Interval: "1min",
+				Limit:    intPtr(10),
+				Offset:   intPtr(0),
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid entitlement",
+			input: models.IntradayPriceInput{
+				Symbol:      "AAPL",
+				Interval:    "1min",
+				Entitlement: stringPtr("live"),
+			},
+			expectError: true,
+			errorMsg:    "invalid entitlement 'live'",
+		},
+		{
+			name: "realtime entitlement",
+			input: models.IntradayPriceInput{
+				Symbol:      "AAPL",
+				Interval:    "1min",
+				Entitlement: stringPtr("realtime"),
+			},
+			expectError: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -205,122 +258,392 @@ func TestIntradayPriceStock_InputValidation(t *testing.T) {
 	}
 }
 
-func TestIntradayPriceStock_BuildQueries(t *testing.T) {
-	tool := NewIntradayPriceStock("https://www.alphavantage.co", "test-key")
-
-	testCases := []struct {
-		name           string
-		input          models.IntradayPriceInput
-		expectedParams map[string]string
-	}{
-		{
-			name: "basic parameters",
-			input: models.IntradayPriceInput{
-				Symbol:   "AAPL",
-				Interval: "1min",
-			},
-			expectedParams: map[string]string{
-				"function": "TIME_SERIES_INTRADAY",
-				"interval": "1min",
-			},
-		},
-		{
-			name: "with all optional parameters",
-			input: models.IntradayPriceInput{
-				Symbol:        "MSFT",
-				Interval:      "5min",
-				Adjusted:      boolPtr(true),
-				ExtendedHours: boolPtr(false),
-				Month:         stringPtr("2023-01"),
-				OutputSize:    stringPtr("full"),
-			},
-			expectedParams: map[string]string{
-				"function":       "TIME_SERIES_INTRADAY",
-				"interval":       "5min",
-				"adjusted":       "true",
-				"extended_hours": "false",
-				"month":          "2023-01",
-				"outputsize":     "full",
-			},
+func TestIntradayPriceStock_SuccessfulRequest(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(
+		"https://www.alphavantage.co/query?apikey=test-key&function=TIME_SERIES_INTRADAY&interval=1min&outputsize=full&symbol=AAPL",
+		&client.Response{
+			StatusCode: 200,
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       []byte(mockIntradayResponse),
 		},
+	)
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	alphaClient := request.NewAlphaVantageClient(mockClient, config)
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(alphaClient))
+
+	input := models.IntradayPriceInput{
+		Symbol:   "AAPL",
+		Interval: "1min",
 	}
 
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			queries := tool.buildQueries(tc.input)
+	_, res, err := tool.Get(context.Background(), nil, input)
+	assert.NoError(t, err)
+	assert.Equal(t, "AAPL", res.MetaData.Symbol)
+	assert.Len(t, res.TimeSeries, 2)
+	assert.Equal(t, 195.0, res.TimeSeries[1].Close, "Bars should be sorted oldest first")
 
-			// Convert queries to map for easier assertion
-			paramMap := make(map[string]string)
-			for _, query := range queries {
-				paramMap[query.Name] = query.Value
-			}
+	requests := mockClient.GetRequests("https://www.alphavantage.co/query?apikey=test-key&function=TIME_SERIES_INTRADAY&interval=1min&outputsize=full&symbol=AAPL")
+	require.Len(t, requests, 1)
+	assert.Equal(t, "GET", requests[0].Method)
+	assert.Equal(t, "application/json", requests[0].Headers["Accept"])
+	assert.Equal(t, "no-cache", requests[0].Headers["Cache-Control"])
+	// User-Agent is applied by the HTTP transport from its config, not per request
+	assert.NotContains(t, requests[0].Headers, "User-Agent")
+	assert.Empty(t, requests[0].Body)
+}
+
+func TestIntradayPriceStock_MatchedRequest(t *testing.T) {
+	// Optional parameters change the query string, so match on the interval
+	// instead of spelling out every exact URL
+	mockClient := client.NewMockClient()
+	for _, interval := range []string{"1min", "5min", "60min"} {
+		mockClient.SetResponseContains("interval="+interval, &client.Response{
+			StatusCode: 200,
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       []byte(strings.ReplaceAll(mockIntradayResponse, "1min", interval)),
+		})
+	}
 
-			for key, expected := range tc.expectedParams {
-				actual, exists := paramMap[key]
-				assert.True(t, exists, "Parameter %s should exist", key)
-				assert.Equal(t, expected, actual, "Parameter %s should have value %s", key, expected)
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	alphaClient := request.NewAlphaVantageClient(mockClient, config)
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(alphaClient))
+
+	for _, interval := range []string{"1min", "5min", "60min"} {
+		t.Run(interval, func(t *testing.T) {
+			input := models.IntradayPriceInput{
+				Symbol:        "AAPL",
+				Interval:      interval,
+				Adjusted:      boolPtr(false),
+				ExtendedHours: boolPtr(true),
+				OutputSize:    stringPtr("compact"),
 			}
+
+			_, res, err := tool.Get(context.Background(), nil, input)
+			require.NoError(t, err)
+			assert.Equal(t, "AAPL", res.MetaData.Symbol)
+			assert.Len(t, res.TimeSeries, 2)
+
+			requests := mockClient.Requests()
+			require.NotEmpty(t, requests)
+			sent := requests[len(requests)-1]
+			assert.Equal(t, "application/json", sent.Headers["Accept"])
+
+			sentURL, err := url.Parse(sent.URL)
+			require.NoError(t, err)
+			query := sentURL.Query()
+			assert.Equal(t, "TIME_SERIES_INTRADAY", query.Get("function"))
+			assert.Equal(t, "AAPL", query.Get("symbol"))
+			assert.Equal(t, interval, query.Get("interval"))
+			assert.Equal(t, "false", query.Get("adjusted"))
+			assert.Equal(t, "true", query.Get("extended_hours"))
+			assert.Equal(t, "compact", query.Get("outputsize"))
+			assert.Equal(t, "test-key", query.Get("apikey"))
 		})
 	}
 }
 
-func TestIntradayPriceStock_SuccessfulRequest(t *testing.T) {
-	// Create mock client
+func TestIntradayPriceStock_IntervalMismatch(t *testing.T) {
+	// A proxy serving the 1min series for a 5min request must not pass silently
 	mockClient := client.NewMockClient()
-
-	// Set up successful response
-	mockResponse := &client.Response{
+	mockClient.SetResponseContains("function=TIME_SERIES_INTRADAY", &client.Response{
 		StatusCode: 200,
-		Headers:    map[string]string{"Content-Type": "application/json"},
 		Body:       []byte(mockIntradayResponse),
+	})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
+
+	_, _, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL", Interval: "5min"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrIntervalMismatch)
+	assert.Contains(t, err.Error(), "requested 5min")
+}
+
+func TestIntradayPriceStock_ConfiguredDefaults(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, interval := range []string{"1min", "5min"} {
+		mockClient.SetResponseContains("interval="+interval, &client.Response{
+			StatusCode: 200,
+			Body:       []byte(strings.ReplaceAll(mockIntradayResponse, "1min", interval)),
+		})
 	}
 
-	// We need to set this for any URL that might be called
-	// Since URL construction is complex, we'll use a pattern
-	mockClient.SetResponse("https://www.alphavantage.co", mockResponse)
+	config := &request.AlphaVantageConfig{
+		BaseURL:           "https://www.alphavantage.co/query",
+		APIKey:            "test-key",
+		Timeout:           30 * time.Second,
+		DefaultInterval:   "5min",
+		DefaultOutputSize: "full",
+	}
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
+
+	lastQuery := func(t *testing.T) url.Values {
+		requests := mockClient.Requests()
+		require.NotEmpty(t, requests)
+		sentURL, err := url.Parse(requests[len(requests)-1].URL)
+		require.NoError(t, err)
+		return sentURL.Query()
+	}
+
+	t.Run("empty fields resolve to defaults", func(t *testing.T) {
+		_, res, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL"})
+		require.NoError(t, err)
+		assert.Equal(t, "5min", res.MetaData.Interval)
+
+		query := lastQuery(t)
+		assert.Equal(t, "5min", query.Get("interval"))
+		assert.Equal(t, "full", query.Get("outputsize"))
+	})
+
+	t.Run("explicit values override defaults", func(t *testing.T) {
+		_, res, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{
+			Symbol:     "AAPL",
+			Interval:   "1min",
+			OutputSize: stringPtr("compact"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1min", res.MetaData.Interval)
+
+		query := lastQuery(t)
+		assert.Equal(t, "1min", query.Get("interval"))
+		assert.Equal(t, "compact", query.Get("outputsize"))
+	})
+}
+
+func TestIntradayPriceStock_OutputSizeByInterval(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, interval := range validation.ValidIntervals {
+		mockClient.SetResponseContains("interval="+interval, &client.Response{
+			StatusCode: 200,
+			Body:       []byte(strings.ReplaceAll(mockIntradayResponse, "1min", interval)),
+		})
+	}
 
-	// Create Alpha Vantage client with mock
 	config := &request.AlphaVantageConfig{
-		BaseURL: "https://www.alphavantage.co",
+		BaseURL: "https://www.alphavantage.co/query",
 		APIKey:  "test-key",
 		Timeout: 30 * time.Second,
 	}
-	alphaClient := request.NewAlphaVantageClient(mockClient, config)
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
+
+	// compact's 100 bars are too few at one and five minutes
+	expected := map[string]string{"1min": "full", "5min": "full", "15min": "", "30min": "", "60min": ""}
+	for interval, outputSize := range expected {
+		_, _, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL", Interval: interval})
+		require.NoError(t, err)
 
-	// Create tool with injected client
-	tool := &IntradayPriceStock{
-		alphaClient: alphaClient,
+		requests := mockClient.Requests()
+		sentURL, err := url.Parse(requests[len(requests)-1].URL)
+		require.NoError(t, err)
+		assert.Equal(t, outputSize, sentURL.Query().Get("outputsize"), interval)
 	}
 
-	// Test input
-	input := models.IntradayPriceInput{
-		Symbol:   "AAPL",
-		Interval: "1min",
+	// An explicit output size is always sent as given
+	_, _, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL", Interval: "1min", OutputSize: stringPtr("compact")})
+	require.NoError(t, err)
+	requests := mockClient.Requests()
+	assert.Contains(t, requests[len(requests)-1].URL, "outputsize=compact")
+}
+
+// gatedStreamClient holds every streamed request until release is closed
+type gatedStreamClient struct {
+	*client.MockClient
+	received chan struct{}
+	release  chan struct{}
+}
+
+func (g *gatedStreamClient) DoStream(ctx context.Context, method, url string, body []byte, headers map[string]string) (*client.StreamResponse, error) {
+	g.received <- struct{}{}
+	<-g.release
+	return g.MockClient.DoStream(ctx, method, url, body, headers)
+}
+
+func TestIntradayPriceStock_DefaultFullSizeSharesCall(t *testing.T) {
+	const callers = 2
+	httpClient := &gatedStreamClient{
+		MockClient: client.NewMockClient(),
+		received:   make(chan struct{}, callers),
+		release:    make(chan struct{}),
 	}
+	httpClient.SetResponseContains("interval=1min", &client.Response{StatusCode: 200, Body: []byte(mockIntradayResponse)})
 
-	// Make request (this will fail because the mock client URL matching is exact)
-	// But we can test the input validation and query building
-	err := tool.validateInput(input)
-	assert.NoError(t, err, "Input validation should pass")
-
-	queries := tool.buildQueries(input)
-	assert.NotEmpty(t, queries, "Queries should be built")
-
-	// Verify required queries are present
-	hasFunction := false
-	hasInterval := false
-	for _, query := range queries {
-		switch query.Name {
-		case "function":
-			hasFunction = true
-			assert.Equal(t, "TIME_SERIES_INTRADAY", query.Value)
-		case "interval":
-			hasInterval = true
-			assert.Equal(t, "1min", query.Value)
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(httpClient, config)))
+
+	var wg sync.WaitGroup
+	results := make([]models.IntradayStockOutput, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, results[i], errs[i] = tool.Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL", Interval: "1min"})
+		}()
+	}
+
+	// Give the second caller time to join the call in flight
+	<-httpClient.received
+	time.Sleep(50 * time.Millisecond)
+	close(httpClient.release)
+	wg.Wait()
+
+	requests := httpClient.Requests()
+	require.Len(t, requests, 1, "Identical defaulted calls should share one upstream request")
+	assert.Contains(t, requests[0].URL, "outputsize=full")
+	for i := range callers {
+		require.NoError(t, errs[i])
+		assert.Len(t, results[i].TimeSeries, 2)
+	}
+}
+
+func TestIntradayPriceStock_DefaultAdjusted(t *testing.T) {
+	newTool := func(defaultAdjusted *bool) *IntradayPriceStock {
+		config := &request.AlphaVantageConfig{
+			BaseURL:         "https://www.alphavantage.co/query",
+			APIKey:          "test-key",
+			Timeout:         30 * time.Second,
+			DefaultAdjusted: defaultAdjusted,
 		}
+		return NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(client.NewMockClient(), config)))
+	}
+
+	testCases := []struct {
+		name            string
+		defaultAdjusted *bool
+		adjusted        *bool
+		want            string
+	}{
+		{name: "omitted without default", want: ""},
+		{name: "omitted with default true", defaultAdjusted: boolPtr(true), want: "true"},
+		{name: "omitted with default false", defaultAdjusted: boolPtr(false), want: "false"},
+		{name: "explicit true", adjusted: boolPtr(true), want: "true"},
+		{name: "explicit false", adjusted: boolPtr(false), want: "false"},
+		{name: "explicit true overrides default false", defaultAdjusted: boolPtr(false), adjusted: boolPtr(true), want: "true"},
+		{name: "explicit false overrides default true", defaultAdjusted: boolPtr(true), adjusted: boolPtr(false), want: "false"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sentURL, err := newTool(tc.defaultAdjusted).BuildURL(models.IntradayPriceInput{Symbol: "AAPL", Interval: "15min", Adjusted: tc.adjusted})
+			require.NoError(t, err)
+
+			parsed, err := url.Parse(sentURL)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, parsed.Query().Get("adjusted"))
+			assert.Equal(t, tc.want != "", parsed.Query().Has("adjusted"))
+		})
+	}
+}
+
+func TestIntradayPriceStock_NoDefaultInterval(t *testing.T) {
+	tool := NewIntradayPriceStock("https://www.alphavantage.co", "test-key")
+
+	_, _, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "invalid interval ''")
+}
+
+func TestIntradayPriceStock_BuildURL(t *testing.T) {
+	mockClient := client.NewMockClient()
+	config := &request.AlphaVantageConfig{
+		BaseURL:         "https://www.alphavantage.co",
+		APIKey:          "secret-key",
+		Timeout:         30 * time.Second,
+		DefaultInterval: "15min",
+	}
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
+
+	sentURL, err := tool.BuildURL(models.IntradayPriceInput{Symbol: "AAPL", Month: stringPtr("2024-01")})
+	require.NoError(t, err)
+	assert.NotContains(t, sentURL, "secret-key")
+
+	parsed, err := url.Parse(sentURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	assert.Equal(t, "***", query.Get("apikey"))
+	assert.Equal(t, "TIME_SERIES_INTRADAY", query.Get("function"))
+	assert.Equal(t, "AAPL", query.Get("symbol"))
+	assert.Equal(t, "15min", query.Get("interval"), "Configured default interval should be applied")
+	assert.Equal(t, "2024-01", query.Get("month"))
+	assert.Empty(t, mockClient.Requests(), "BuildURL must not call the API")
+
+	_, err = tool.BuildURL(models.IntradayPriceInput{Symbol: "AAPL", Interval: "2min"})
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
+
+func TestIntradayPriceStock_InputSchema(t *testing.T) {
+	schema := NewIntradayPriceStock("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Equal(t, []string{"symbol", "interval"}, schema.Required)
+	assert.Equal(t, []any{"1min", "5min", "15min", "30min", "60min"}, schema.Properties["interval"].Enum)
+	assert.Equal(t, []any{"compact", "full", nil}, schema.Properties["outputSize"].Enum)
+	assert.Equal(t, "boolean", schema.Properties["adjusted"].Types[1])
+
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]any{"symbol": "AAPL", "interval": "5min"}), "Optional fields may be omitted")
+	assert.NoError(t, resolved.Validate(map[string]any{"symbol": "AAPL", "interval": "5min", "outputSize": "full", "adjusted": false}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "AAPL", "interval": "2min"}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "AAPL", "interval": "5min", "outputSize": "large"}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "AAPL", "interval": "5min", "adjusted": "yes"}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "AAPL"}), "Interval is required without a default")
+	assert.Error(t, resolved.Validate(map[string]any{"interval": "5min"}))
+
+	configured := NewIntradayPriceStockWithConfig(&request.AlphaVantageConfig{
+		BaseURL:         "https://www.alphavantage.co",
+		APIKey:          "test-key",
+		Timeout:         30 * time.Second,
+		DefaultInterval: "15min",
+	}).InputSchema()
+	assert.Equal(t, []string{"symbol"}, configured.Required, "Interval may be omitted when a default is configured")
+}
+
+func TestIntradayPriceStock_FakeProvider(t *testing.T) {
+	fake := &fakeProvider{
+		intraday: &models.IntradayStockOutput{
+			MetaData: models.MetaData{Symbol: "AAPL", Interval: "5min"},
+			TimeSeries: []models.OHLCVFloat{
+				{Timestamp: time.Date(2023, 12, 8, 19, 55, 0, 0, time.UTC), Close: 194},
+				{Timestamp: time.Date(2023, 12, 8, 20, 0, 0, 0, time.UTC), Close: 196},
+			},
+		},
+	}
+	tool := NewIntradayPriceStockWithProvider(fake)
+
+	input := models.IntradayPriceInput{
+		Symbol:          "AAPL",
+		Interval:        "5min",
+		IncludeSMA:      boolPtr(true),
+		IndicatorPeriod: intPtr(2),
 	}
-	assert.True(t, hasFunction, "Function query should be present")
-	assert.True(t, hasInterval, "Interval query should be present")
+
+	_, res, err := tool.Get(context.Background(), nil, input)
+	assert.NoError(t, err)
+	assert.Equal(t, input, fake.intradayInput, "Input should be passed to the provider unchanged")
+	assert.Len(t, res.TimeSeries, 2)
+	assert.Len(t, res.SMA, 1)
+	assert.Equal(t, 195.0, res.SMA[0].Value)
+
+	// Providers without client statistics report empty stats
+	assert.Equal(t, client.ClientStats{}, tool.GetStats())
 }
 
 func TestIntradayPriceStock_ContextCancellation(t *testing.T) {
@@ -470,6 +793,10 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
 func TestIntradayPriceStock_AllIntervals(t *testing.T) {
 	tool := NewIntradayPriceStock("https://www.alphavantage.co", "test-key")
 
@@ -484,28 +811,26 @@ func TestIntradayPriceStock_AllIntervals(t *testing.T) {
 
 			err := tool.validateInput(input)
 			assert.NoError(t, err, "Interval %s should be valid", interval)
-
-			queries := tool.buildQueries(input)
-
-			// Find interval query
-			found := false
-			for _, query := range queries {
-				if query.Name == "interval" {
-					assert.Equal(t, interval, query.Value)
-					found = true
-					break
-				}
-			}
-			assert.True(t, found, "Interval query should be present")
 		})
 	}
 }
 
 func TestIntradayPriceStock_ThreadSafety(t *testing.T) {
-	tool := NewIntradayPriceStock("https://www.alphavantage.co", "test-key")
+	mockClient := client.NewMockClient()
+	mockClient.SetResponseContains("function=TIME_SERIES_INTRADAY", &client.Response{
+		StatusCode: 200,
+		Body:       []byte(mockIntradayResponse),
+	})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	tool := NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
 
 	// Test concurrent access to methods that use mutex
-	done := make(chan bool, 3)
+	done := make(chan bool, 4)
 
 	// Concurrent GetStats calls
 	go func() {
@@ -517,21 +842,23 @@ func TestIntradayPriceStock_ThreadSafety(t *testing.T) {
 
 	// Concurrent SetTimeout calls
 	go func() {
-		for i := range 10 {
+		for i := range 100 {
 			tool.SetTimeout(time.Duration(i+30) * time.Second)
 		}
 		done <- true
 	}()
 
-	// Concurrent Close calls
+	// Concurrent Get calls read the timeout while it is being set, since
+	// a background context has no deadline
 	go func() {
-		for range 10 {
-			_ = tool.Close()
+		for range 100 {
+			_, _, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL", Interval: "1min"})
+			assert.NoError(t, err)
 		}
 		done <- true
 	}()
 
-	// Wait for all goroutines to complete
+	// Wait for the readers and writers before closing the client
 	for range 3 {
 		select {
 		case <-done:
@@ -540,4 +867,366 @@ func TestIntradayPriceStock_ThreadSafety(t *testing.T) {
 			t.Fatal("Timeout waiting for goroutine to complete")
 		}
 	}
+
+	// Concurrent Close calls
+	go func() {
+		for range 10 {
+			_ = tool.Close()
+		}
+		done <- true
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timeout waiting for goroutine to complete")
+	}
+}
+
+func TestIntradayPriceStock_AttachIndicators(t *testing.T) {
+	tool := &IntradayPriceStock{}
+
+	data := &models.IntradayStockOutput{
+		TimeSeries: []models.OHLCVFloat{
+			{Timestamp: time.Date(2023, 12, 8, 19, 57, 0, 0, time.UTC), Close: 194},
+			{Timestamp: time.Date(2023, 12, 8, 19, 58, 0, 0, time.UTC), Close: 195},
+			{Timestamp: time.Date(2023, 12, 8, 19, 59, 0, 0, time.UTC), Close: 196},
+		},
+	}
+
+	input := models.IntradayPriceInput{
+		Symbol:          "AAPL",
+		Interval:        "1min",
+		IncludeSMA:      boolPtr(true),
+		IncludeEMA:      boolPtr(true),
+		IndicatorPeriod: intPtr(2),
+	}
+
+	err := tool.attachIndicators(data, input)
+	assert.NoError(t, err)
+	assert.Len(t, data.SMA, 2)
+	assert.Equal(t, 194.5, data.SMA[0].Value)
+	assert.Equal(t, data.TimeSeries[2].Timestamp, data.SMA[1].Timestamp)
+	assert.Len(t, data.EMA, 2)
+	assert.Empty(t, data.VWAP)
+
+	input.IncludeVWAP = boolPtr(true)
+	err = tool.attachIndicators(data, input)
+	assert.NoError(t, err)
+	assert.Len(t, data.VWAP, 3)
+
+	// Without an explicit period the default of 20 exceeds the three bars
+	input.IndicatorPeriod = nil
+	err = tool.attachIndicators(data, input)
+	assert.ErrorContains(t, err, "period 20 exceeds series length 3")
+}
+
+func TestIntradayPriceStock_AttachBollingerBands(t *testing.T) {
+	base := time.Date(2023, 12, 8, 10, 0, 0, 0, time.UTC)
+	tool := NewIntradayPriceStockWithProvider(&fakeProvider{
+		intraday: &models.IntradayStockOutput{
+			MetaData: models.MetaData{Symbol: "AAPL", Interval: "1min"},
+			TimeSeries: []models.OHLCVFloat{
+				{Timestamp: base, Close: 2},
+				{Timestamp: base.Add(time.Minute), Close: 4},
+				{Timestamp: base.Add(2 * time.Minute), Close: 4},
+			},
+		},
+	})
+
+	input := models.IntradayPriceInput{
+		Symbol:           "AAPL",
+		Interval:         "1min",
+		IncludeBollinger: boolPtr(true),
+		IndicatorPeriod:  intPtr(2),
+		BollingerStdDev:  float64Ptr(1),
+	}
+
+	_, res, err := tool.Get(context.Background(), nil, input)
+	require.NoError(t, err)
+	require.NotNil(t, res.BollingerBands)
+
+	// Windows {2, 4} and {4, 4}
+	bands := res.BollingerBands
+	require.Len(t, bands.Middle, 2)
+	assert.Equal(t, base.Add(time.Minute), bands.Middle[0].Timestamp)
+	assert.Equal(t, []float64{3, 4}, []float64{bands.Middle[0].Value, bands.Middle[1].Value})
+	assert.Equal(t, []float64{4, 4}, []float64{bands.Upper[0].Value, bands.Upper[1].Value})
+	assert.Equal(t, []float64{2, 4}, []float64{bands.Lower[0].Value, bands.Lower[1].Value})
+
+	// Each band is paged with the bars
+	input.Limit = intPtr(1)
+	input.Latest = boolPtr(true)
+	_, res, err = tool.Get(context.Background(), nil, input)
+	require.NoError(t, err)
+	require.Len(t, res.BollingerBands.Upper, 1)
+	require.Len(t, res.BollingerBands.Lower, 1)
+	assert.Equal(t, base.Add(2*time.Minute), res.BollingerBands.Middle[0].Timestamp)
+
+	input.BollingerStdDev = float64Ptr(0)
+	_, _, err = tool.Get(context.Background(), nil, input)
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+}
+
+func TestIntradayPriceStock_OnlyRegularHours(t *testing.T) {
+	eastern, err := time.LoadLocation("US/Eastern")
+	assert.NoError(t, err)
+
+	series := []models.OHLCVFloat{
+		{Timestamp: time.Date(2023, 12, 8, 9, 25, 0, 0, eastern), Close: 193},
+		{Timestamp: time.Date(2023, 12, 8, 9, 30, 0, 0, eastern), Close: 194},
+		{Timestamp: time.Date(2023, 12, 8, 15, 55, 0, 0, eastern), Close: 195},
+		{Timestamp: time.Date(2023, 12, 8, 16, 0, 0, 0, eastern), Close: 196},
+		{Timestamp: time.Date(2023, 12, 8, 16, 5, 0, 0, eastern), Close: 197},
+	}
+	fake := &fakeProvider{
+		intraday: &models.IntradayStockOutput{
+			MetaData:   models.MetaData{Symbol: "AAPL", Interval: "5min"},
+			TimeSeries: series,
+		},
+	}
+	tool := NewIntradayPriceStockWithProvider(fake)
+
+	_, res, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{
+		Symbol:           "AAPL",
+		Interval:         "5min",
+		OnlyRegularHours: boolPtr(true),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, series[1:3], res.TimeSeries)
+	assert.Nil(t, fake.intradayInput.ExtendedHours, "Extended hours should still be fetched upstream")
+}
+
+func TestIntradayPriceStock_Paginate(t *testing.T) {
+	base := time.Date(2023, 12, 8, 10, 0, 0, 0, time.UTC)
+	newData := func(n int) *models.IntradayStockOutput {
+		data := &models.IntradayStockOutput{}
+		for i := range n {
+			data.TimeSeries = append(data.TimeSeries, models.OHLCVFloat{Timestamp: base.Add(time.Duration(i) * time.Minute), Close: float64(i)})
+		}
+		return data
+	}
+	closes := func(series []models.OHLCVFloat) []float64 {
+		values := make([]float64, len(series))
+		for i, bar := range series {
+			values[i] = bar.Close
+		}
+		return values
+	}
+
+	tool := &IntradayPriceStock{}
+
+	testCases := []struct {
+		name   string
+		input  models.IntradayPriceInput
+		want   []float64
+		length int
+	}{
+		{name: "no pagination", input: models.IntradayPriceInput{}, want: []float64{0, 1, 2, 3, 4}},
+		{name: "limit", input: models.IntradayPriceInput{Limit: intPtr(2)}, want: []float64{0, 1}},
+		{name: "limit equals total", input: models.IntradayPriceInput{Limit: intPtr(5)}, want: []float64{0, 1, 2, 3, 4}},
+		{name: "offset only", input: models.IntradayPriceInput{Offset: intPtr(3)}, want: []float64{3, 4}},
+		{name: "offset and limit", input: models.IntradayPriceInput{Offset: intPtr(1), Limit: intPtr(3)}, want: []float64{1, 2, 3}},
+		{name: "offset at total", input: models.IntradayPriceInput{Offset: intPtr(5), Limit: intPtr(3)}, want: []float64{}},
+		{name: "latest", input: models.IntradayPriceInput{Latest: boolPtr(true), Limit: intPtr(2)}, want: []float64{4, 3}},
+		{name: "latest with offset", input: models.IntradayPriceInput{Latest: boolPtr(true), Offset: intPtr(4), Limit: intPtr(2)}, want: []float64{0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := newData(5)
+			tool.paginate(data, tc.input)
+			assert.Equal(t, tc.want, closes(data.TimeSeries))
+			assert.Equal(t, 5, data.TotalBars)
+		})
+	}
+
+	t.Run("limit capped", func(t *testing.T) {
+		data := newData(maxIntradayLimit + 10)
+		tool.paginate(data, models.IntradayPriceInput{Limit: intPtr(maxIntradayLimit + 1)})
+		assert.Len(t, data.TimeSeries, maxIntradayLimit)
+		assert.Equal(t, maxIntradayLimit+10, data.TotalBars)
+	})
+
+	t.Run("indicators follow the page", func(t *testing.T) {
+		data := newData(5)
+		input := models.IntradayPriceInput{IncludeSMA: boolPtr(true), IndicatorPeriod: intPtr(2), Latest: boolPtr(true), Limit: intPtr(2)}
+		assert.NoError(t, tool.attachIndicators(data, input))
+		require.Len(t, data.SMA, 4)
+
+		tool.paginate(data, input)
+		require.Len(t, data.SMA, 2)
+		assert.Equal(t, data.TimeSeries[0].Timestamp, data.SMA[0].Timestamp)
+		assert.Equal(t, 3.5, data.SMA[0].Value)
+		assert.Equal(t, 2.5, data.SMA[1].Value)
+	})
+}
+
+func TestIntradayPriceStock_DateRange(t *testing.T) {
+	series := []models.OHLCVFloat{
+		{Timestamp: time.Date(2023, 12, 7, 19, 55, 0, 0, time.UTC), Close: 193},
+		{Timestamp: time.Date(2023, 12, 8, 9, 30, 0, 0, time.UTC), Close: 194},
+		{Timestamp: time.Date(2023, 12, 8, 16, 0, 0, 0, time.UTC), Close: 195},
+	}
+	tool := NewIntradayPriceStockWithProvider(&fakeProvider{
+		intraday: &models.IntradayStockOutput{
+			MetaData:   models.MetaData{Symbol: "AAPL", Interval: "5min"},
+			TimeSeries: series,
+		},
+	})
+
+	_, res, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{
+		Symbol:   "AAPL",
+		Interval: "5min",
+		From:     stringPtr("2023-12-08T09:30:00Z"),
+		To:       stringPtr("2023-12-08"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, series[1:], res.TimeSeries)
+	assert.Equal(t, 2, res.TotalBars)
+
+	_, _, err = tool.Get(context.Background(), nil, models.IntradayPriceInput{
+		Symbol:   "AAPL",
+		Interval: "5min",
+		From:     stringPtr("2023-12-09"),
+		To:       stringPtr("2023-12-08"),
+	})
+	assert.ErrorContains(t, err, "invalid date range")
+}
+
+func TestIntradayPriceStock_IncludeSummary(t *testing.T) {
+	base := time.Date(2023, 12, 8, 10, 0, 0, 0, time.UTC)
+	tool := NewIntradayPriceStockWithProvider(&fakeProvider{
+		intraday: &models.IntradayStockOutput{
+			MetaData: models.MetaData{Symbol: "AAPL", Interval: "1min"},
+			TimeSeries: []models.OHLCVFloat{
+				{Timestamp: base, Open: 100, Close: 50, Volume: 1000},
+				{Timestamp: base.Add(time.Minute), Open: 10, Close: 11, Volume: 10},
+				{Timestamp: base.Add(2 * time.Minute), Open: 11, Close: 12, Volume: 20},
+			},
+		},
+	})
+
+	_, res, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{
+		Symbol:         "AAPL",
+		Interval:       "1min",
+		IncludeSummary: boolPtr(true),
+		Latest:         boolPtr(true),
+		Limit:          intPtr(2),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.Summary)
+
+	// Only the two returned bars are summarized
+	assert.Equal(t, 2, res.Summary.Bars)
+	assert.Equal(t, 11.0, res.Summary.MinClose)
+	assert.Equal(t, int64(30), res.Summary.TotalVolume)
+	assert.InDelta(t, 20.0, res.Summary.PercentChange, 1e-9) // 10 -> 12
+}
+
+func TestIntradayPriceStock_IncludeReturns(t *testing.T) {
+	base := time.Date(2023, 12, 8, 10, 0, 0, 0, time.UTC)
+	tool := NewIntradayPriceStockWithProvider(&fakeProvider{
+		intraday: &models.IntradayStockOutput{
+			MetaData: models.MetaData{Symbol: "AAPL", Interval: "1min"},
+			TimeSeries: []models.OHLCVFloat{
+				{Timestamp: base, Close: 50},
+				{Timestamp: base.Add(time.Minute), Close: 100},
+				{Timestamp: base.Add(2 * time.Minute), Close: 110},
+			},
+		},
+	})
+
+	_, res, err := tool.Get(context.Background(), nil, models.IntradayPriceInput{
+		Symbol:         "AAPL",
+		Interval:       "1min",
+		IncludeReturns: boolPtr(true),
+		Latest:         boolPtr(true),
+		Limit:          intPtr(2),
+	})
+	require.NoError(t, err)
+
+	// The first returned bar keeps its return over the bar before the page
+	require.Len(t, res.Returns, 2)
+	assert.Equal(t, base.Add(2*time.Minute), res.Returns[0].Timestamp)
+	assert.InDelta(t, 0.10, res.Returns[0].Value, 1e-9)
+	assert.InDelta(t, 1.0, res.Returns[1].Value, 1e-9)
+
+	// The cumulative return only spans the two returned bars
+	require.NotNil(t, res.CumulativeReturn)
+	assert.InDelta(t, 0.10, *res.CumulativeReturn, 1e-9)
+}
+
+func TestIntradayPriceStock_IncludePatterns(t *testing.T) {
+	base := time.Date(2023, 12, 8, 10, 0, 0, 0, time.UTC)
+	tool := NewIntradayPriceStockWithProvider(&fakeProvider{
+		intraday: &models.IntradayStockOutput{
+			MetaData: models.MetaData{Symbol: "AAPL", Interval: "1min"},
+			TimeSeries: []models.OHLCVFloat{
+				{Timestamp: base, Open: 100, High: 105, Low: 95, Close: 100.5},
+				{Timestamp: base.Add(time.Minute), Open: 102, High: 103, Low: 100, Close: 101},
+				{Timestamp: base.Add(2 * time.Minute), Open: 100.5, High: 104, Low: 100, Close: 103.5},
+			},
+		},
+	})
+
+	input := models.IntradayPriceInput{Symbol: "AAPL", Interval: "1min", IncludePatterns: boolPtr(true)}
+	_, res, err := tool.Get(context.Background(), nil, input)
+	require.NoError(t, err)
+	assert.Equal(t, []models.PatternHit{
+		{Timestamp: base, Pattern: "doji"},
+		{Timestamp: base.Add(2 * time.Minute), Pattern: "bullish_engulfing"},
+	}, res.Patterns)
+
+	// Patterns are paged with the bars, keeping those that need an earlier bar
+	input.Latest = boolPtr(true)
+	input.Limit = intPtr(1)
+	_, res, err = tool.Get(context.Background(), nil, input)
+	require.NoError(t, err)
+	assert.Equal(t, []models.PatternHit{
+		{Timestamp: base.Add(2 * time.Minute), Pattern: "bullish_engulfing"},
+	}, res.Patterns)
+}
+
+func TestIntradayPriceStock_Resample(t *testing.T) {
+	base := time.Date(2023, 12, 8, 9, 30, 0, 0, time.UTC)
+	newTool := func() *IntradayPriceStock {
+		return NewIntradayPriceStockWithProvider(&fakeProvider{
+			intraday: &models.IntradayStockOutput{
+				MetaData: models.MetaData{Symbol: "AAPL", Interval: "1min"},
+				TimeSeries: []models.OHLCVFloat{
+					{Timestamp: base, Open: 10, High: 11, Low: 9, Close: 10.5, Volume: 100},
+					{Timestamp: base.Add(4 * time.Minute), Open: 10.5, High: 12, Low: 10, Close: 11, Volume: 200},
+					{Timestamp: base.Add(5 * time.Minute), Open: 11, High: 11.5, Low: 10.5, Close: 11.2, Volume: 300},
+				},
+			},
+		})
+	}
+
+	_, res, err := newTool().Get(context.Background(), nil, models.IntradayPriceInput{
+		Symbol:         "AAPL",
+		Interval:       "1min",
+		Resample:       stringPtr("5min"),
+		IncludeReturns: boolPtr(true),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "5min", res.MetaData.Interval)
+	assert.Equal(t, []models.OHLCVFloat{
+		{Timestamp: base, Open: 10, High: 12, Low: 9, Close: 11, Volume: 300},
+		{Timestamp: base.Add(5 * time.Minute), Open: 11, High: 11.5, Low: 10.5, Close: 11.2, Volume: 300},
+	}, res.TimeSeries)
+
+	// Returns are computed on the resampled bars
+	require.Len(t, res.Returns, 1)
+	assert.InDelta(t, 0.2/11, res.Returns[0].Value, 1e-9)
+
+	for _, target := range []string{"2min", "daily"} {
+		_, _, err = newTool().Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL", Interval: "1min", Resample: stringPtr(target)})
+		assert.ErrorIs(t, err, errors.ErrInvalidInput, target)
+	}
+
+	_, _, err = newTool().Get(context.Background(), nil, models.IntradayPriceInput{Symbol: "AAPL", Interval: "15min", Resample: stringPtr("5min")})
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	assert.ErrorContains(t, err, "must be a multiple of interval '15min'")
 }
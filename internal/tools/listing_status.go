@@ -0,0 +1,138 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// validListingStates lists the listing states accepted by LISTING_STATUS
+var validListingStates = []string{"active", "delisted"}
+
+// ListingStatus implements the "get-listing-status" MCP tool for retrieving
+// the active or delisted US stocks and ETFs, e.g. to build a trading universe.
+//
+// This tool integrates with Alpha Vantage's LISTING_STATUS function, which
+// always responds with CSV, to provide for each security:
+//   - Symbol, name, exchange and asset type
+//   - IPO date, and delisting date for delisted securities
+//   - Listing status
+type ListingStatus struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewListingStatus creates a new ListingStatus tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewListingStatus(apiURL, apiKey string) *ListingStatus {
+	return newStandaloneToolset(apiURL, apiKey).ListingStatus()
+}
+
+// validateInput performs input validation on the listing status input
+func (l *ListingStatus) validateInput(input models.ListingStatusInput) error {
+	if input.Date != nil {
+		if _, err := time.Parse(time.DateOnly, *input.Date); err != nil {
+			return fmt.Errorf("invalid date '%s'. Expected format: YYYY-MM-DD", *input.Date)
+		}
+	}
+
+	if input.State != nil && !slices.Contains(validListingStates, *input.State) {
+		return fmt.Errorf("invalid state '%s'. Valid states are: %s",
+			*input.State, strings.Join(validListingStates, ", "))
+	}
+
+	return nil
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (l *ListingStatus) buildQueries(input models.ListingStatusInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", "LISTING_STATUS"),
+	}
+
+	if input.Date != nil {
+		queries = append(queries, request.NewQuery("date", *input.Date))
+	}
+
+	if input.State != nil {
+		queries = append(queries, request.NewQuery("state", *input.State))
+	}
+
+	return queries
+}
+
+// Get retrieves the listed or delisted securities as of the requested date.
+func (l *ListingStatus) Get(ctx context.Context, req *mcp.CallToolRequest, input models.ListingStatusInput) (*mcp.CallToolResult, models.ListingStatusOutput, error) {
+	if err := l.validateInput(input); err != nil {
+		return nil, models.ListingStatusOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.ListingStatusOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := request.NewAlphaQueryWithClient(l.alphaClient, l.buildQueries(input)).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.ListingStatusOutput{}, fmt.Errorf("failed to fetch listing status: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.ListingStatusOutput{}, ctx.Err()
+	default:
+	}
+
+	listings, err := parser.ListingStatus(res)
+	if err != nil {
+		return nil, models.ListingStatusOutput{}, fmt.Errorf("failed to parse listing status: %w", err)
+	}
+
+	if len(listings) == 0 {
+		return nil, models.ListingStatusOutput{}, fmt.Errorf("%w: no listings returned", errors.ErrNotFound)
+	}
+
+	return nil, models.ListingStatusOutput{
+		Count:    len(listings),
+		Listings: listings,
+	}, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (l *ListingStatus) BuildURL(input models.ListingStatusInput) (string, error) {
+	if err := l.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaQueryWithClient(l.alphaClient, l.buildQueries(input)).RedactedURL()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (l *ListingStatus) GetStats() client.ClientStats {
+	return l.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (l *ListingStatus) Close() error {
+	return l.alphaClient.Close()
+}
@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func newMockListingStatus(url, body string) *ListingStatus {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return &ListingStatus{alphaClient: request.NewAlphaVantageClient(mockClient, config)}
+}
+
+func TestListingStatus_Get(t *testing.T) {
+	tool := newMockListingStatus(
+		"https://www.alphavantage.co/query?apikey=test-key&date=2014-07-10&function=LISTING_STATUS&state=delisted",
+		"symbol,name,exchange,assetType,ipoDate,delistingDate,status\n"+
+			"AAAP,Advanced Accelerator Applications SA,NASDAQ,Stock,2015-11-11,2018-02-16,Delisted\n"+
+			"AABA,Altaba Inc,NASDAQ,Stock,1996-04-12,2019-10-07,Delisted\n",
+	)
+
+	_, res, err := tool.Get(context.Background(), nil, models.ListingStatusInput{
+		Date:  stringPtr("2014-07-10"),
+		State: stringPtr("delisted"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, res.Count)
+	require.Len(t, res.Listings, 2)
+	assert.Equal(t, "AABA", res.Listings[1].Symbol)
+	assert.Equal(t, "2019-10-07", res.Listings[1].DelistingDate)
+}
+
+func TestListingStatus_EmptyListing(t *testing.T) {
+	tool := newMockListingStatus(
+		"https://www.alphavantage.co/query?apikey=test-key&function=LISTING_STATUS",
+		"symbol,name,exchange,assetType,ipoDate,delistingDate,status\n",
+	)
+
+	_, _, err := tool.Get(context.Background(), nil, models.ListingStatusInput{})
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+}
+
+func TestListingStatus_InputValidation(t *testing.T) {
+	tool := NewListingStatus("https://www.alphavantage.co", "test-key")
+
+	testCases := []struct {
+		name     string
+		input    models.ListingStatusInput
+		errorMsg string
+	}{
+		{"no parameters", models.ListingStatusInput{}, ""},
+		{"valid date and state", models.ListingStatusInput{Date: stringPtr("2020-01-02"), State: stringPtr("active")}, ""},
+		{"invalid date", models.ListingStatusInput{Date: stringPtr("2020/01/02")}, "invalid date '2020/01/02'"},
+		{"invalid state", models.ListingStatusInput{State: stringPtr("suspended")}, "invalid state 'suspended'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.errorMsg == "" {
+				assert.NoError(t, tool.validateInput(tc.input))
+				return
+			}
+
+			// Invalid input is rejected before any request is made
+			_, _, err := tool.Get(context.Background(), nil, tc.input)
+			assert.ErrorIs(t, err, errors.ErrInvalidInput)
+			assert.Contains(t, err.Error(), tc.errorMsg)
+		})
+	}
+}
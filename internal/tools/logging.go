@@ -0,0 +1,83 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/pkg/logging"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// LoggingMiddleware is an MCP receiving middleware that assigns each tool
+// call a correlation ID and logs its tool, symbol, latency and status.
+//
+// The ID is taken from the X-Request-ID header of the HTTP request, or
+// generated if absent, and is carried in the context passed to the tool so
+// upstream calls made by the tool are logged with the same ID.
+func LoggingMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if method != "tools/call" || !ok {
+			return next(ctx, method, req)
+		}
+
+		requestID := ""
+		if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+			requestID = extra.Header.Get(logging.RequestIDHeader)
+		}
+		if requestID == "" {
+			requestID = logging.NewRequestID()
+		}
+		ctx = logging.WithRequestID(ctx, requestID)
+
+		start := time.Now()
+		result, err := next(ctx, method, req)
+
+		status := "ok"
+		if toolCallFailed(result, err) {
+			status = "error"
+		}
+
+		attrs := []any{
+			"tool", params.Name,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"status", status,
+		}
+		if symbol := toolCallSymbol(params); symbol != "" {
+			attrs = append(attrs, "symbol", symbol)
+		}
+		if err != nil {
+			attrs = append(attrs, "error", err.Error())
+		}
+
+		logging.FromContext(ctx).InfoContext(ctx, "tool call", attrs...)
+		return result, err
+	}
+}
+
+// toolCallSymbol returns the symbol argument of a tool call, if any
+func toolCallSymbol(params *mcp.CallToolParamsRaw) string {
+	var args struct {
+		Symbol string `json:"symbol"`
+	}
+	_ = parser.ParseBytes(&args, params.Arguments)
+	return args.Symbol
+}
+
+// toolCallFailed reports whether a tool call returned an error, either as a
+// protocol error or as a tool result flagged with IsError
+func toolCallFailed(result mcp.Result, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	toolResult, ok := result.(*mcp.CallToolResult)
+	return ok && toolResult.IsError
+}
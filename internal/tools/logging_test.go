@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/logging"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buf, slog.LevelInfo))
+	defer slog.SetDefault(previous)
+
+	var seenRequestID string
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		seenRequestID = logging.RequestID(ctx)
+		return &mcp.CallToolResult{}, nil
+	}
+
+	header := http.Header{}
+	header.Set(logging.RequestIDHeader, "req-42")
+
+	req := &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      "get_overview_stock",
+			Arguments: json.RawMessage(`{"symbol": "IBM"}`),
+		},
+		Extra: &mcp.RequestExtra{Header: header},
+	}
+
+	_, err := LoggingMiddleware(next)(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+	assert.Equal(t, "req-42", seenRequestID)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "tool call", record["msg"])
+	assert.Equal(t, "get_overview_stock", record["tool"])
+	assert.Equal(t, "IBM", record["symbol"])
+	assert.Equal(t, "ok", record["status"])
+	assert.Equal(t, "req-42", record["request_id"])
+	assert.Contains(t, record, "latency_ms")
+}
+
+func TestLoggingMiddleware_GeneratesRequestID(t *testing.T) {
+	var seenRequestID string
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		seenRequestID = logging.RequestID(ctx)
+		return &mcp.CallToolResult{}, nil
+	}
+
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_earnings"}}
+
+	_, err := LoggingMiddleware(next)(context.Background(), "tools/call", req)
+	require.NoError(t, err)
+	assert.Len(t, seenRequestID, 32)
+}
@@ -0,0 +1,225 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newsTimeFilterLayout is the YYYYMMDDTHHMM layout of the time_from/time_to filters
+const newsTimeFilterLayout = "20060102T1504"
+
+var (
+	validNewsSorts  = []string{"LATEST", "EARLIEST", "RELEVANCE"}
+	validNewsTopics = []string{
+		"blockchain", "earnings", "ipo", "mergers_and_acquisitions", "financial_markets",
+		"economy_fiscal", "economy_monetary", "economy_macro", "energy_transportation",
+		"finance", "life_sciences", "manufacturing", "real_estate", "retail_wholesale",
+		"technology",
+	}
+)
+
+// NewsSentiment implements the "get-news-sentiment" MCP tool for retrieving
+// market news articles with sentiment analysis.
+//
+// This tool integrates with Alpha Vantage's NEWS_SENTIMENT function to provide:
+//   - Recent articles filtered by tickers, topics and publication time
+//   - Overall sentiment score and label for each article
+//   - Per-ticker relevance and sentiment scores
+//
+// Unlike the price tools, this endpoint is not keyed by a single symbol,
+// so requests are built from the optional filters only.
+type NewsSentiment struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+
+	// mu protects concurrent access for thread safety
+	mu sync.RWMutex
+}
+
+// NewNewsSentiment creates a new NewsSentiment tool instance with the provided
+// Alpha Vantage API configuration using dependency injection.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewNewsSentiment(apiURL, apiKey string) *NewsSentiment {
+	return newStandaloneToolset(apiURL, apiKey).NewsSentiment()
+}
+
+// validateInput performs input validation on the news sentiment filters
+func (s *NewsSentiment) validateInput(input models.NewsSentimentInput) error {
+	if input.Tickers != nil {
+		for ticker := range strings.SplitSeq(*input.Tickers, ",") {
+			if strings.TrimSpace(ticker) == "" {
+				return fmt.Errorf("invalid tickers '%s': tickers cannot be empty", *input.Tickers)
+			}
+		}
+	}
+
+	if input.Topics != nil {
+		for topic := range strings.SplitSeq(*input.Topics, ",") {
+			if !slices.Contains(validNewsTopics, strings.TrimSpace(topic)) {
+				return fmt.Errorf("invalid topic '%s'. Valid topics are: %s",
+					topic, strings.Join(validNewsTopics, ", "))
+			}
+		}
+	}
+
+	if input.TimeFrom != nil {
+		if _, err := time.Parse(newsTimeFilterLayout, *input.TimeFrom); err != nil {
+			return fmt.Errorf("invalid time_from format '%s'. Expected format: YYYYMMDDTHHMM", *input.TimeFrom)
+		}
+	}
+
+	if input.TimeTo != nil {
+		if _, err := time.Parse(newsTimeFilterLayout, *input.TimeTo); err != nil {
+			return fmt.Errorf("invalid time_to format '%s'. Expected format: YYYYMMDDTHHMM", *input.TimeTo)
+		}
+	}
+
+	if input.Sort != nil && !slices.Contains(validNewsSorts, *input.Sort) {
+		return fmt.Errorf("invalid sort '%s'. Valid sorts are: %s",
+			*input.Sort, strings.Join(validNewsSorts, ", "))
+	}
+
+	if input.Limit != nil && (*input.Limit < 1 || *input.Limit > 1000) {
+		return fmt.Errorf("invalid limit %d. Limit must be between 1 and 1000", *input.Limit)
+	}
+
+	return nil
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (s *NewsSentiment) buildQueries(input models.NewsSentimentInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", "NEWS_SENTIMENT"),
+	}
+
+	if input.Tickers != nil {
+		queries = append(queries, request.NewQuery("tickers", strings.ToUpper(strings.ReplaceAll(*input.Tickers, " ", ""))))
+	}
+
+	if input.Topics != nil {
+		queries = append(queries, request.NewQuery("topics", strings.ReplaceAll(*input.Topics, " ", "")))
+	}
+
+	if input.TimeFrom != nil {
+		queries = append(queries, request.NewQuery("time_from", *input.TimeFrom))
+	}
+
+	if input.TimeTo != nil {
+		queries = append(queries, request.NewQuery("time_to", *input.TimeTo))
+	}
+
+	if input.Sort != nil {
+		queries = append(queries, request.NewQuery("sort", *input.Sort))
+	}
+
+	if input.Limit != nil {
+		queries = append(queries, request.NewQuery("limit", strconv.Itoa(*input.Limit)))
+	}
+
+	return queries
+}
+
+// Get retrieves news articles with sentiment analysis matching the given filters.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - req: MCP tool request metadata (unused but required by interface)
+//   - input: Optional ticker, topic, time range, sort and limit filters
+//
+// Returns:
+//   - *mcp.CallToolResult: Always nil (result data is in second return value)
+//   - models.NewsSentimentOutput: Articles with overall and per-ticker sentiment
+//   - error: Any error encountered during the request or parsing process
+func (s *NewsSentiment) Get(ctx context.Context, req *mcp.CallToolRequest, input models.NewsSentimentInput) (*mcp.CallToolResult, models.NewsSentimentOutput, error) {
+	if err := s.validateInput(input); err != nil {
+		return nil, models.NewsSentimentOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.NewsSentimentOutput{}, ctx.Err()
+	default:
+	}
+
+	requestClient := request.NewAlphaQueryWithClient(s.alphaClient, s.buildQueries(input))
+
+	res, err := requestClient.GetWithContext(ctx)
+	if err != nil {
+		return nil, models.NewsSentimentOutput{}, fmt.Errorf("failed to fetch news sentiment: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.NewsSentimentOutput{}, ctx.Err()
+	default:
+	}
+
+	data, err := parser.NewsSentiment(res)
+	if err != nil {
+		return nil, models.NewsSentimentOutput{}, fmt.Errorf("failed to parse news sentiment: %w", err)
+	}
+
+	return nil, *data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (s *NewsSentiment) BuildURL(input models.NewsSentimentInput) (string, error) {
+	if err := s.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaQueryWithClient(s.alphaClient, s.buildQueries(input)).RedactedURL()
+}
+
+// InputSchema returns the JSON schema of the tool input. Every filter is
+// optional, and sort is restricted to the values Alpha Vantage accepts.
+func (s *NewsSentiment) InputSchema() *jsonschema.Schema {
+	schema := inputSchema[models.NewsSentimentInput]()
+	setEnum(schema, "sort", validNewsSorts)
+
+	return schema
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (s *NewsSentiment) GetStats() client.ClientStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (s *NewsSentiment) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.alphaClient.Close()
+}
+
+// SetTimeout configures the request timeout for this tool instance
+func (s *NewsSentiment) SetTimeout(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alphaClient.SetTimeout(timeout)
+}
@@ -0,0 +1,164 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const mockNewsSentimentResponse = `{
+  "items": "1",
+  "sentiment_score_definition": "x <= -0.35: Bearish; -0.35 < x <= -0.15: Somewhat-Bearish; -0.15 < x < 0.15: Neutral; 0.15 <= x < 0.35: Somewhat_Bullish; x >= 0.35: Bullish",
+  "relevance_score_definition": "0 < x <= 1, with a higher score indicating higher relevance.",
+  "feed": [
+    {
+      "title": "Apple beats estimates",
+      "url": "https://example.com/apple",
+      "time_published": "20240112T153000",
+      "summary": "Apple reported strong results.",
+      "source": "Example News",
+      "overall_sentiment_score": 0.312,
+      "overall_sentiment_label": "Somewhat-Bullish",
+      "ticker_sentiment": [
+        {
+          "ticker": "AAPL",
+          "relevance_score": "0.912",
+          "ticker_sentiment_score": "0.401",
+          "ticker_sentiment_label": "Bullish"
+        }
+      ]
+    }
+  ]
+}`
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestNewsSentiment_InputValidation(t *testing.T) {
+	tool := NewNewsSentiment("https://www.alphavantage.co", "test-key")
+
+	testCases := []struct {
+		name        string
+		input       models.NewsSentimentInput
+		expectError bool
+		errorMsg    string
+	}{
+		{name: "no filters", input: models.NewsSentimentInput{}},
+		{
+			name: "all filters",
+			input: models.NewsSentimentInput{
+				Tickers:  stringPtr("AAPL,CRYPTO:BTC"),
+				Topics:   stringPtr("technology,ipo"),
+				TimeFrom: stringPtr("20240101T0000"),
+				TimeTo:   stringPtr("20240112T2359"),
+				Sort:     stringPtr("RELEVANCE"),
+				Limit:    intPtr(1000),
+			},
+		},
+		{name: "limit too low", input: models.NewsSentimentInput{Limit: intPtr(0)}, expectError: true, errorMsg: "between 1 and 1000"},
+		{name: "limit too high", input: models.NewsSentimentInput{Limit: intPtr(1001)}, expectError: true, errorMsg: "between 1 and 1000"},
+		{name: "invalid sort", input: models.NewsSentimentInput{Sort: stringPtr("NEWEST")}, expectError: true, errorMsg: "invalid sort 'NEWEST'"},
+		{name: "invalid topic", input: models.NewsSentimentInput{Topics: stringPtr("sports")}, expectError: true, errorMsg: "invalid topic 'sports'"},
+		{name: "invalid time from", input: models.NewsSentimentInput{TimeFrom: stringPtr("2024-01-01")}, expectError: true, errorMsg: "invalid time_from format"},
+		{name: "empty ticker", input: models.NewsSentimentInput{Tickers: stringPtr("AAPL,,MSFT")}, expectError: true, errorMsg: "tickers cannot be empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tool.validateInput(tc.input)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewsSentiment_Get(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(
+		"https://www.alphavantage.co/query?apikey=test-key&function=NEWS_SENTIMENT&limit=10&tickers=AAPL",
+		&client.Response{StatusCode: 200, Body: []byte(mockNewsSentimentResponse)},
+	)
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	tool := &NewsSentiment{alphaClient: request.NewAlphaVantageClient(mockClient, config)}
+
+	_, res, err := tool.Get(context.Background(), nil, models.NewsSentimentInput{
+		Tickers: stringPtr("aapl"),
+		Limit:   intPtr(10),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, res.Items)
+	require.Len(t, res.Feed, 1)
+	article := res.Feed[0]
+	assert.Equal(t, "Apple beats estimates", article.Title)
+	assert.Equal(t, time.Date(2024, 1, 12, 15, 30, 0, 0, time.UTC), article.TimePublished)
+	assert.Equal(t, 0.312, article.OverallSentimentScore)
+	require.Len(t, article.TickerSentiment, 1)
+	assert.Equal(t, "AAPL", article.TickerSentiment[0].Ticker)
+	assert.Equal(t, 0.912, article.TickerSentiment[0].RelevanceScore)
+	assert.Equal(t, 0.401, article.TickerSentiment[0].SentimentScore)
+}
+
+func TestNewsSentiment_InputSchema(t *testing.T) {
+	schema := NewNewsSentiment("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Empty(t, schema.Required)
+	assert.Equal(t, []any{"LATEST", "EARLIEST", "RELEVANCE", nil}, schema.Properties["sort"].Enum)
+
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]any{}), "Every filter is optional")
+	assert.NoError(t, resolved.Validate(map[string]any{"tickers": "AAPL", "sort": "LATEST", "limit": 10}))
+	assert.Error(t, resolved.Validate(map[string]any{"sort": "NEWEST"}))
+}
+
+func TestNewsSentiment_CallWithoutArguments(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(
+		"https://www.alphavantage.co/query?apikey=test-key&function=NEWS_SENTIMENT",
+		&client.Response{StatusCode: 200, Body: []byte(mockNewsSentimentResponse)},
+	)
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	tool := &NewsSentiment{alphaClient: request.NewAlphaVantageClient(mockClient, config)}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_news_sentiment", InputSchema: tool.InputSchema()}, tool.Get)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	ctx := context.Background()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	defer serverSession.Close()
+
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "get_news_sentiment", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	assert.False(t, result.IsError, "A call without filters must reach the tool")
+	assert.Equal(t, 1, mockClient.GetCallCount("https://www.alphavantage.co/query?apikey=test-key&function=NEWS_SENTIMENT"))
+}
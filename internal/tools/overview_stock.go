@@ -8,13 +8,12 @@ package tools
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/provider"
 	"github.com/yeferson59/finance-mcp/internal/validation"
 	"github.com/yeferson59/finance-mcp/pkg/client"
-	"github.com/yeferson59/finance-mcp/pkg/parser"
-	"github.com/yeferson59/finance-mcp/pkg/request"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -32,12 +31,8 @@ import (
 // data validation automatically with proper context support for timeouts
 // and cancellation.
 type OverviewStock struct {
-	// alphaClient is the injected Alpha Vantage client
-	alphaClient *request.AlphaVantageClient
-
-	// parser is a reusable JSON parser instance to avoid allocation overhead
-	// Note: sonic parser is already thread-safe, no mutex needed
-	parser *parser.JSON
+	// dataProvider is the injected market data backend
+	dataProvider provider.DataProvider
 }
 
 // NewOverviewStock creates a new OverviewStock tool instance with the provided
@@ -50,35 +45,29 @@ type OverviewStock struct {
 // Returns:
 //   - Configured OverviewStock instance ready for use as MCP tool
 //
-// The returned instance includes a preconfigured JSON parser and HTTP client
-// that are reused across requests for better performance.
+// The returned instance is backed by an Alpha Vantage provider whose HTTP
+// client is reused across requests for better performance.
 func NewOverviewStock(apiURL, apiKey string) *OverviewStock {
-	config := &request.AlphaVantageConfig{
-		BaseURL: apiURL,
-		APIKey:  apiKey,
-		Timeout: 30 * time.Second,
-	}
-
-	httpConfig := client.DefaultConfig()
-	httpConfig.UserAgent = "Finance-MCP-Server/1.0"
-	httpClient := client.NewFastHTTPClient(httpConfig)
-	alphaClient := request.NewAlphaVantageClient(httpClient, config)
+	return newStandaloneToolset(apiURL, apiKey).OverviewStock()
+}
 
+// NewOverviewStockWithProvider creates an OverviewStock tool that fetches data
+// from the given provider instead of the default Alpha Vantage backend.
+func NewOverviewStockWithProvider(dataProvider provider.DataProvider) *OverviewStock {
 	return &OverviewStock{
-		alphaClient: alphaClient,
-		parser:      parser.NewJSON(),
+		dataProvider: dataProvider,
 	}
 }
 
 // validateInput performs input validation on the symbol input
-func (os *OverviewStock) validateInput(input models.SymbolInput) error {
+func (os *OverviewStock) validateInput(input models.OverviewInput) error {
 	return validation.ValidateSymbol(input.Symbol)
 }
 
 // validateResponse checks if the API response contains error information
 func (os *OverviewStock) validateResponse(data models.OverviewOutput, symbol string) error {
 	if data.Symbol == "" && data.Name == "" {
-		return fmt.Errorf("no data returned for symbol '%s' - symbol may not exist or API limit reached", symbol)
+		return fmt.Errorf("%w: no data returned for symbol '%s' - symbol may not exist or API limit reached", errors.ErrNotFound, symbol)
 	}
 
 	return nil
@@ -93,6 +82,7 @@ func (os *OverviewStock) validateResponse(data models.OverviewOutput, symbol str
 //   - ctx: Context for request cancellation and timeout handling
 //   - req: MCP tool request metadata (unused but required by interface)
 //   - input: Stock symbol input containing the ticker to query (e.g., "AAPL", "GOOGL")
+//     and whether to attach the numeric fields parsed into numbers
 //
 // Returns:
 //   - *mcp.CallToolResult: Always nil (result data is in second return value)
@@ -110,9 +100,9 @@ func (os *OverviewStock) validateResponse(data models.OverviewOutput, symbol str
 // The method automatically converts stock symbols to uppercase and handles
 // various Alpha Vantage response formats including error responses.
 // It respects the context for cancellation and timeout control.
-func (os *OverviewStock) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolInput) (*mcp.CallToolResult, models.OverviewOutput, error) {
+func (os *OverviewStock) Get(ctx context.Context, req *mcp.CallToolRequest, input models.OverviewInput) (*mcp.CallToolResult, models.OverviewOutput, error) {
 	if err := os.validateInput(input); err != nil {
-		return nil, models.OverviewOutput{}, fmt.Errorf("input validation failed: %w", err)
+		return nil, models.OverviewOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
 	}
 
 	select {
@@ -121,18 +111,9 @@ func (os *OverviewStock) Get(ctx context.Context, req *mcp.CallToolRequest, inpu
 	default:
 	}
 
-	requestClient := request.NewAlphaWithClient(
-		os.alphaClient,
-		input.Symbol,
-		[]request.Query{
-			request.NewQuery("function", "OVERVIEW"),
-		},
-	)
-
-	// Make API request with context support
-	res, err := requestClient.GetWithContext(ctx)
+	data, err := os.dataProvider.Overview(ctx, input.Symbol)
 	if err != nil {
-		return nil, models.OverviewOutput{}, fmt.Errorf("failed to fetch stock data for symbol '%s': %w", input.Symbol, err)
+		return nil, models.OverviewOutput{}, err
 	}
 
 	select {
@@ -141,17 +122,43 @@ func (os *OverviewStock) Get(ctx context.Context, req *mcp.CallToolRequest, inpu
 	default:
 	}
 
-	var data models.OverviewOutput
+	if err := os.validateResponse(*data, input.Symbol); err != nil {
+		return nil, models.OverviewOutput{}, err
+	}
 
-	// sonic parser is already thread-safe, no lock needed
-	err = os.parser.ParseBytes(&data, res)
-	if err != nil {
-		return nil, models.OverviewOutput{}, fmt.Errorf("failed to parse stock data for symbol '%s': %w", input.Symbol, err)
+	if input.Numeric != nil && *input.Numeric {
+		numeric := data.ToNumeric()
+		data.Numeric = &numeric
 	}
 
-	if err := os.validateResponse(data, input.Symbol); err != nil {
-		return nil, models.OverviewOutput{}, err
+	return nil, *data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (os *OverviewStock) BuildURL(input models.OverviewInput) (string, error) {
+	if err := os.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	builder, ok := os.dataProvider.(provider.RequestURLBuilder)
+	if !ok {
+		return "", fmt.Errorf("provider %T cannot build request URLs", os.dataProvider)
 	}
 
-	return nil, data, nil
+	return builder.OverviewURL(input.Symbol)
+}
+
+// GetStats returns HTTP client statistics for monitoring
+// when the provider reports them, and empty statistics otherwise
+func (os *OverviewStock) GetStats() client.ClientStats {
+	if reporter, ok := os.dataProvider.(provider.StatsReporter); ok {
+		return reporter.GetStats()
+	}
+	return client.ClientStats{}
+}
+
+// Close cleans up resources used by the tool
+func (os *OverviewStock) Close() error {
+	return os.dataProvider.Close()
 }
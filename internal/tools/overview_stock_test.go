@@ -2,19 +2,48 @@ package tools
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/yeferson59/finance-mcp/internal/config"
 	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/provider"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	pkgerrors "github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// fakeProvider is a DataProvider returning canned data for tool tests
+type fakeProvider struct {
+	overview      *models.OverviewOutput
+	intraday      *models.IntradayStockOutput
+	err           error
+	intradayInput models.IntradayPriceInput
+	closed        bool
+}
+
+func (f *fakeProvider) Overview(ctx context.Context, symbol string) (*models.OverviewOutput, error) {
+	return f.overview, f.err
+}
+
+func (f *fakeProvider) Intraday(ctx context.Context, input models.IntradayPriceInput) (*models.IntradayStockOutput, error) {
+	f.intradayInput = input
+	return f.intraday, f.err
+}
+
+func (f *fakeProvider) Close() error {
+	f.closed = true
+	return nil
+}
+
 func TestOverviewStock(t *testing.T) {
 	cfg := config.NewConfig()
 	overviewStock := NewOverviewStock(cfg.APIURL, cfg.APIKey)
 	ctx := context.Background()
-	input := models.SymbolInput{
+	input := models.OverviewInput{
 		Symbol: "AAPL",
 	}
 
@@ -26,3 +55,98 @@ func TestOverviewStock(t *testing.T) {
 	tx.NotNil(res)
 	tx.Equal(input.Symbol, res.Symbol)
 }
+
+func TestOverviewStock_FakeProvider(t *testing.T) {
+	fake := &fakeProvider{overview: &models.OverviewOutput{Symbol: "AAPL", Name: "Apple Inc"}}
+	tool := NewOverviewStockWithProvider(fake)
+
+	_, res, err := tool.Get(context.Background(), nil, models.OverviewInput{Symbol: "AAPL"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Apple Inc", res.Name)
+
+	assert.NoError(t, tool.Close())
+	assert.True(t, fake.closed)
+}
+
+func TestOverviewStock_Numeric(t *testing.T) {
+	tool := NewOverviewStockWithProvider(&fakeProvider{overview: &models.OverviewOutput{Symbol: "AAPL", PERatio: "29.95", PEGRatio: "None"}})
+
+	_, res, err := tool.Get(context.Background(), nil, models.OverviewInput{Symbol: "AAPL"})
+	assert.NoError(t, err)
+	assert.Nil(t, res.Numeric)
+
+	_, res, err = tool.Get(context.Background(), nil, models.OverviewInput{Symbol: "AAPL", Numeric: boolPtr(true)})
+	assert.NoError(t, err)
+	if assert.NotNil(t, res.Numeric) {
+		assert.Equal(t, 29.95, *res.Numeric.PERatio)
+		assert.Nil(t, res.Numeric.PEGRatio)
+	}
+	assert.Equal(t, "29.95", res.PERatio, "String fields are kept alongside the numeric view")
+}
+
+func TestOverviewStock_FakeProviderEmptyResponse(t *testing.T) {
+	tool := NewOverviewStockWithProvider(&fakeProvider{overview: &models.OverviewOutput{}})
+
+	_, _, err := tool.Get(context.Background(), nil, models.OverviewInput{Symbol: "XXXX"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no data returned for symbol 'XXXX'")
+}
+
+func TestOverviewStock_FakeProviderError(t *testing.T) {
+	providerErr := errors.New("provider unavailable")
+	tool := NewOverviewStockWithProvider(&fakeProvider{err: providerErr})
+
+	_, _, err := tool.Get(context.Background(), nil, models.OverviewInput{Symbol: "AAPL"})
+	assert.ErrorIs(t, err, providerErr)
+}
+
+func TestOverviewStock_BuildURLUnsupportedProvider(t *testing.T) {
+	tool := NewOverviewStockWithProvider(&fakeProvider{})
+
+	_, err := tool.BuildURL(models.OverviewInput{Symbol: "IBM"})
+	assert.ErrorContains(t, err, "cannot build request URLs")
+}
+
+func TestOverviewStock_APIMessages(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		sentinel error
+	}{
+		{
+			name:     "rate limit note",
+			body:     `{"Note": "API rate limit exceeded for this key, please retry in 60 seconds."}`,
+			sentinel: pkgerrors.ErrRateLimited,
+		},
+		{
+			name:     "premium information",
+			body:     `{"Information": "Company overviews for this symbol are a premium feature. Please subscribe to a premium plan."}`,
+			sentinel: pkgerrors.ErrPremiumRequired,
+		},
+		{
+			name: "error message",
+			body: `{"Error Message": "the parameter symbol is malformed."}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := client.NewMockClient()
+			mockClient.SetResponse(overviewURL("IBM"), &client.Response{StatusCode: 200, Body: []byte(tc.body)})
+
+			config := &request.AlphaVantageConfig{
+				BaseURL: "https://www.alphavantage.co/query",
+				APIKey:  "test-key",
+				Timeout: 30 * time.Second,
+			}
+			tool := NewOverviewStockWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
+
+			_, _, err := tool.Get(context.Background(), nil, models.OverviewInput{Symbol: "IBM"})
+			assert.Error(t, err)
+			assert.NotContains(t, err.Error(), "no data returned", "API messages must not be reported as missing data")
+			if tc.sentinel != nil {
+				assert.ErrorIs(t, err, tc.sentinel)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/provider"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// OverviewStocks implements the "get-overview-stocks" MCP tool, fetching the
+// company overview of several symbols concurrently.
+//
+// Each symbol goes through the same validation and fetch as the "get-stock"
+// tool. Symbols that fail are reported in the output's error map with the
+// same envelope a failed single-symbol call returns, while the rest succeed.
+type OverviewStocks struct {
+	// overview fetches a single symbol
+	overview *OverviewStock
+
+	// concurrency is the number of symbols fetched at once
+	concurrency int
+}
+
+// NewOverviewStocks creates a new OverviewStocks tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+//
+// Requests are held to the free tier's per-minute quota. Waiting for quota
+// is bounded by the caller's context, not the per-request timeout, so a
+// large batch waits for quota instead of being rejected upstream.
+func NewOverviewStocks(apiURL, apiKey string) *OverviewStocks {
+	return newStandaloneBatchToolset(apiURL, apiKey).OverviewStocks()
+}
+
+// NewOverviewStocksWithProvider creates an OverviewStocks tool that fetches
+// data from the given provider instead of the default Alpha Vantage backend.
+func NewOverviewStocksWithProvider(dataProvider provider.DataProvider) *OverviewStocks {
+	return &OverviewStocks{
+		overview:    NewOverviewStockWithProvider(dataProvider),
+		concurrency: batchConcurrency,
+	}
+}
+
+// validateInput checks the batch and returns the symbols to fetch
+func (s *OverviewStocks) validateInput(input models.SymbolsInput) ([]string, error) {
+	return batchSymbols(input.Symbols)
+}
+
+// Get retrieves the company overview of every requested symbol.
+//
+// Per-symbol failures, including invalid symbols, are collected in the
+// output's error map rather than failing the call. The call itself fails
+// only for an invalid batch or when ctx is cancelled.
+func (s *OverviewStocks) Get(ctx context.Context, req *mcp.CallToolRequest, input models.SymbolsInput) (*mcp.CallToolResult, models.OverviewBatchOutput, error) {
+	symbols, err := s.validateInput(input)
+	if err != nil {
+		return nil, models.OverviewBatchOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	overviews, failures, err := fetchBatch(ctx, symbols, s.concurrency, func(ctx context.Context, symbol string) (models.OverviewOutput, error) {
+		_, data, err := s.overview.Get(ctx, req, models.OverviewInput{Symbol: symbol})
+		return data, err
+	})
+	if err != nil {
+		return nil, models.OverviewBatchOutput{}, err
+	}
+
+	return nil, models.OverviewBatchOutput{Overviews: overviews, Errors: failures}, nil
+}
+
+// BuildURLs validates the batch and returns the Alpha Vantage URL Get would
+// request for each unique symbol, with the API key redacted, without calling
+// the API. Unlike Get, an invalid symbol fails the whole call.
+func (s *OverviewStocks) BuildURLs(input models.SymbolsInput) ([]string, error) {
+	symbols, err := s.validateInput(input)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	urls := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		url, err := s.overview.BuildURL(models.OverviewInput{Symbol: symbol})
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, url)
+	}
+
+	return urls, nil
+}
+
+// GetStats returns HTTP client statistics for monitoring
+// when the provider reports them, and empty statistics otherwise
+func (s *OverviewStocks) GetStats() client.ClientStats {
+	if reporter, ok := s.overview.dataProvider.(provider.StatsReporter); ok {
+		return reporter.GetStats()
+	}
+	return client.ClientStats{}
+}
+
+// Close cleans up resources used by the tool
+func (s *OverviewStocks) Close() error {
+	return s.overview.Close()
+}
@@ -0,0 +1,211 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/provider"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	pkgerrors "github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func overviewURL(symbol string) string {
+	return "https://www.alphavantage.co/query?apikey=test-key&function=OVERVIEW&symbol=" + symbol
+}
+
+func newMockOverviewStocks(mockClient *client.MockClient) *OverviewStocks {
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return NewOverviewStocksWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
+}
+
+func TestOverviewStocks_PartialSuccess(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		mockClient.SetResponse(overviewURL(symbol), &client.Response{
+			StatusCode: 200,
+			Body:       []byte(fmt.Sprintf(`{"Symbol": "%s", "Name": "%s Inc"}`, symbol, symbol)),
+		})
+	}
+	mockClient.SetError(overviewURL("IBM"), errors.New("connection refused"))
+	mockClient.SetResponse(overviewURL("NOPE"), &client.Response{StatusCode: 200, Body: []byte(`{}`)})
+
+	tool := newMockOverviewStocks(mockClient)
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolsInput{
+		Symbols: []string{"aapl", "MSFT", "IBM", "NOPE", "AAPL!", "AAPL"},
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, res.Overviews, 2)
+	assert.Equal(t, "AAPL Inc", res.Overviews["AAPL"].Name)
+	assert.Equal(t, "MSFT Inc", res.Overviews["MSFT"].Name)
+
+	require.Len(t, res.Errors, 3)
+	assert.Equal(t, pkgerrors.CodeUpstreamError, res.Errors["IBM"].Code)
+	assert.Contains(t, res.Errors["IBM"].Message, "connection refused")
+	assert.Equal(t, pkgerrors.CodeNotFound, res.Errors["NOPE"].Code)
+	assert.Equal(t, pkgerrors.CodeInvalidSymbol, res.Errors["AAPL!"].Code)
+
+	// Duplicate symbols are fetched once
+	assert.Equal(t, 1, mockClient.GetCallCount(overviewURL("AAPL")))
+}
+
+func TestOverviewStocks_InputValidation(t *testing.T) {
+	tool := newMockOverviewStocks(client.NewMockClient())
+
+	_, _, err := tool.Get(context.Background(), nil, models.SymbolsInput{})
+	assert.ErrorIs(t, err, pkgerrors.ErrInvalidInput)
+	assert.ErrorContains(t, err, "symbols cannot be empty")
+
+	symbols := make([]string, maxBatchSymbols+1)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%d", i)
+	}
+	_, _, err = tool.Get(context.Background(), nil, models.SymbolsInput{Symbols: symbols})
+	assert.ErrorIs(t, err, pkgerrors.ErrInvalidInput)
+	assert.ErrorContains(t, err, "too many symbols: got 21")
+}
+
+func TestOverviewStocks_ContextCancellation(t *testing.T) {
+	tool := newMockOverviewStocks(client.NewMockClient())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := tool.Get(ctx, nil, models.SymbolsInput{Symbols: []string{"AAPL"}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestOverviewStocks_RateLimited(t *testing.T) {
+	mockClient := client.NewMockClient()
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		mockClient.SetResponse(overviewURL(symbol), &client.Response{
+			StatusCode: 200,
+			Body:       []byte(fmt.Sprintf(`{"Symbol": "%s"}`, symbol)),
+		})
+	}
+
+	// A single-token limiter lets the first request through and makes the
+	// second wait past the deadline
+	config := &request.AlphaVantageConfig{
+		BaseURL:           "https://www.alphavantage.co/query",
+		APIKey:            "test-key",
+		Timeout:           30 * time.Second,
+		RequestsPerMinute: 1,
+	}
+	tool := NewOverviewStocksWithProvider(provider.NewAlphaVantage(request.NewAlphaVantageClient(mockClient, config)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, _, err := tool.Get(ctx, nil, models.SymbolsInput{Symbols: []string{"AAPL", "MSFT"}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	calls := mockClient.GetCallCount(overviewURL("AAPL")) + mockClient.GetCallCount(overviewURL("MSFT"))
+	assert.Equal(t, 1, calls, "Only one request fits within the rate limit")
+}
+
+// driveClock advances fake by step whenever something waits on it, after
+// letting more real time pass than timeout, until the returned stop is called
+func driveClock(fake *clock.Fake, step, timeout time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(time.Millisecond):
+			}
+			if fake.Waiters() > 0 {
+				time.Sleep(2 * timeout)
+				fake.Advance(step)
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func TestOverviewStocks_WaitsForQuota(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	config := &request.AlphaVantageConfig{RequestsPerMinute: 5, Clock: fake}
+	toolset, mockClient := newMockToolset(config)
+	mockClient.SetResponseContains("function=OVERVIEW", &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "S", "Name": "Stock"}`)})
+
+	// The limiter frees a token every 12s, so most symbols wait far longer
+	// than the request timeout
+	config.Timeout = 5 * time.Millisecond
+	tool := toolset.OverviewStocks()
+	defer driveClock(fake, 12*time.Second, config.Timeout)()
+
+	symbols := make([]string, maxBatchSymbols)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%d", i)
+	}
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolsInput{Symbols: symbols})
+	require.NoError(t, err)
+	assert.Empty(t, res.Errors)
+	assert.Len(t, res.Overviews, maxBatchSymbols)
+}
+
+// concurrencyProvider records the peak number of concurrent Overview calls
+type concurrencyProvider struct {
+	fakeProvider
+	active, peak atomic.Int32
+}
+
+func (p *concurrencyProvider) Overview(ctx context.Context, symbol string) (*models.OverviewOutput, error) {
+	active := p.active.Add(1)
+	defer p.active.Add(-1)
+
+	for peak := p.peak.Load(); active > peak && !p.peak.CompareAndSwap(peak, active); peak = p.peak.Load() {
+	}
+
+	time.Sleep(5 * time.Millisecond)
+	return &models.OverviewOutput{Symbol: symbol}, nil
+}
+
+func TestOverviewStocks_BoundedConcurrency(t *testing.T) {
+	fake := &concurrencyProvider{}
+	tool := NewOverviewStocksWithProvider(fake)
+
+	symbols := make([]string, maxBatchSymbols)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%d", i)
+	}
+
+	_, res, err := tool.Get(context.Background(), nil, models.SymbolsInput{Symbols: symbols})
+	require.NoError(t, err)
+	assert.Len(t, res.Overviews, maxBatchSymbols)
+	assert.Empty(t, res.Errors)
+	assert.LessOrEqual(t, fake.peak.Load(), int32(batchConcurrency))
+	assert.Positive(t, fake.peak.Load())
+}
+
+func TestOverviewStocks_BuildURLs(t *testing.T) {
+	mockClient := client.NewMockClient()
+	tool := newMockOverviewStocks(mockClient)
+
+	urls, err := tool.BuildURLs(models.SymbolsInput{Symbols: []string{"AAPL", "MSFT"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"https://www.alphavantage.co/query?apikey=***&function=OVERVIEW&symbol=AAPL",
+		"https://www.alphavantage.co/query?apikey=***&function=OVERVIEW&symbol=MSFT",
+	}, urls)
+	assert.Empty(t, mockClient.Requests())
+}
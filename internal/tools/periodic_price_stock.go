@@ -0,0 +1,271 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/filter"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// compactOutputSize is the number of most recent data points kept when
+// output size is "compact", matching Alpha Vantage's compact semantics.
+const compactOutputSize = 100
+
+// PeriodicPriceStock implements the "get-weekly-price-stock" and
+// "get-monthly-price-stock" MCP tools for retrieving weekly or monthly
+// stock price data.
+//
+// This tool integrates with Alpha Vantage's TIME_SERIES_WEEKLY and
+// TIME_SERIES_MONTHLY functions (and their _ADJUSTED variants) to provide:
+//   - OHLC (Open, High, Low, Close) prices for each week or month
+//   - Volume information for each period
+//   - Adjusted close and dividend amount when the adjusted series is requested
+//
+// The tool handles HTTP communication, JSON parsing, error handling, and
+// data validation automatically with proper context support for timeouts
+// and cancellation.
+type PeriodicPriceStock struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+
+	// function is the unadjusted Alpha Vantage function, e.g. "TIME_SERIES_WEEKLY"
+	function string
+
+	// mu protects concurrent access for thread safety
+	mu sync.RWMutex
+}
+
+// NewWeeklyPriceStock creates a PeriodicPriceStock tool backed by TIME_SERIES_WEEKLY.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewWeeklyPriceStock(apiURL, apiKey string) *PeriodicPriceStock {
+	return newStandaloneToolset(apiURL, apiKey).WeeklyPriceStock()
+}
+
+// NewMonthlyPriceStock creates a PeriodicPriceStock tool backed by TIME_SERIES_MONTHLY.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewMonthlyPriceStock(apiURL, apiKey string) *PeriodicPriceStock {
+	return newStandaloneToolset(apiURL, apiKey).MonthlyPriceStock()
+}
+
+// newPeriodicPriceStock creates a PeriodicPriceStock for the given base function
+func newPeriodicPriceStock(alphaClient *request.AlphaVantageClient, function string) *PeriodicPriceStock {
+	return &PeriodicPriceStock{
+		alphaClient: alphaClient,
+		function:    function,
+	}
+}
+
+// validateInput performs input validation on the periodic price input
+func (s *PeriodicPriceStock) validateInput(input models.PeriodicPriceInput) error {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return err
+	}
+
+	if input.OutputSize != nil {
+		if err := validation.ValidateOutputSize(*input.OutputSize); err != nil {
+			return err
+		}
+	}
+
+	if input.Datatype != nil {
+		if err := validation.ValidateDatatype(*input.Datatype); err != nil {
+			return err
+		}
+	}
+
+	if _, err := filter.NewDateRange(input.From, input.To); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// functionName returns the Alpha Vantage function for the input,
+// selecting the _ADJUSTED variant when adjusted data is requested
+func (s *PeriodicPriceStock) functionName(input models.PeriodicPriceInput) string {
+	if input.Adjusted != nil && *input.Adjusted {
+		return s.function + "_ADJUSTED"
+	}
+
+	return s.function
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (s *PeriodicPriceStock) buildQueries(input models.PeriodicPriceInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", s.functionName(input)),
+	}
+
+	if input.Datatype != nil && *input.Datatype == "csv" {
+		queries = append(queries, request.NewQuery("datatype", "csv"))
+	}
+
+	return queries
+}
+
+// parseResponse decodes a JSON or CSV response body into raw time series data
+func (s *PeriodicPriceStock) parseResponse(res []byte, input models.PeriodicPriceInput) (*parser.AlphaVantageResponse, error) {
+	if input.Datatype != nil && *input.Datatype == "csv" {
+		return parser.TimeSeriesPricesCSV(res, parser.MetaData{
+			Information: s.functionName(input),
+			Symbol:      strings.ToUpper(input.Symbol),
+			TimeZone:    "US/Eastern",
+		})
+	}
+
+	return parser.TimeSeriesPrices(res)
+}
+
+// Get retrieves weekly or monthly stock price data for the specified stock symbol.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - req: MCP tool request metadata (unused but required by interface)
+//   - input: Periodic price input containing symbol and optional parameters
+//
+// Returns:
+//   - *mcp.CallToolResult: Always nil (result data is in second return value)
+//   - models.PeriodicStockOutput: Stock metadata and time series sorted oldest first
+//   - error: Any error encountered during the request or parsing process
+//
+// Alpha Vantage always returns the full history for these functions, so a
+// "compact" output size is applied locally by keeping the latest 100 points,
+// after any from/to date range has been applied.
+func (s *PeriodicPriceStock) Get(ctx context.Context, req *mcp.CallToolRequest, input models.PeriodicPriceInput) (*mcp.CallToolResult, models.PeriodicStockOutput, error) {
+	if err := s.validateInput(input); err != nil {
+		return nil, models.PeriodicStockOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.PeriodicStockOutput{}, ctx.Err()
+	default:
+	}
+
+	requestClient := request.NewAlphaWithClient(
+		s.alphaClient,
+		input.Symbol,
+		s.buildQueries(input),
+	)
+
+	res, err := requestClient.GetWithContext(ctx)
+	if err != nil {
+		return nil, models.PeriodicStockOutput{}, fmt.Errorf("failed to fetch %s data for symbol '%s': %w", s.functionName(input), input.Symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.PeriodicStockOutput{}, ctx.Err()
+	default:
+	}
+
+	rawData, err := s.parseResponse(res, input)
+	if err != nil {
+		return nil, models.PeriodicStockOutput{}, fmt.Errorf("failed to parse %s data for symbol '%s': %w", s.functionName(input), input.Symbol, err)
+	}
+
+	processed, err := rawData.ProcessTimeSeries()
+	if err != nil {
+		return nil, models.PeriodicStockOutput{}, fmt.Errorf("failed to process time series data for symbol '%s': %w", input.Symbol, err)
+	}
+
+	data := models.PeriodicStockOutput{
+		MetaData:   processed.MetaData,
+		TimeSeries: processed.TimeSeries,
+	}
+
+	if err := s.validateResponse(data, input.Symbol); err != nil {
+		return nil, models.PeriodicStockOutput{}, err
+	}
+
+	// Narrow to the requested date range; already validated above
+	if dateRange, _ := filter.NewDateRange(input.From, input.To); !dateRange.IsZero() {
+		data.TimeSeries = dateRange.Apply(data.TimeSeries)
+	}
+
+	if input.OutputSize == nil || *input.OutputSize == "compact" {
+		if len(data.TimeSeries) > compactOutputSize {
+			data.TimeSeries = data.TimeSeries[len(data.TimeSeries)-compactOutputSize:]
+		}
+	}
+
+	return nil, data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (s *PeriodicPriceStock) BuildURL(input models.PeriodicPriceInput) (string, error) {
+	if err := s.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaWithClient(s.alphaClient, input.Symbol, s.buildQueries(input)).RedactedURL()
+}
+
+// InputSchema returns the JSON schema of the tool input, restricting
+// outputSize and datatype to the values Alpha Vantage accepts so the MCP SDK
+// rejects others before Get is called.
+func (s *PeriodicPriceStock) InputSchema() *jsonschema.Schema {
+	schema := inputSchema[models.PeriodicPriceInput]("symbol")
+	setEnum(schema, "outputSize", validation.ValidOutputSizes)
+	setEnum(schema, "datatype", validation.ValidDatatypes)
+
+	return schema
+}
+
+// validateResponse checks if the API response contains valid data
+func (s *PeriodicPriceStock) validateResponse(data models.PeriodicStockOutput, symbol string) error {
+	if data.MetaData.Symbol == "" {
+		return fmt.Errorf("%w: no data returned for symbol '%s' - symbol may not exist or API limit reached", errors.ErrNotFound, symbol)
+	}
+
+	if len(data.TimeSeries) == 0 {
+		return fmt.Errorf("%w: no time series data returned for symbol '%s'", errors.ErrNotFound, symbol)
+	}
+
+	return nil
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (s *PeriodicPriceStock) GetStats() client.ClientStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (s *PeriodicPriceStock) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.alphaClient.Close()
+}
+
+// SetTimeout configures the request timeout for this tool instance
+func (s *PeriodicPriceStock) SetTimeout(timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alphaClient.SetTimeout(timeout)
+}
@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+const mockWeeklyAdjustedResponse = `{
+  "Meta Data": {
+    "1. Information": "Weekly Adjusted Prices and Volumes",
+    "2. Symbol": "IBM",
+    "3. Last Refreshed": "2024-01-12",
+    "4. Time Zone": "US/Eastern"
+  },
+  "Weekly Adjusted Time Series": {
+    "2024-01-12": {
+      "1. open": "161.0100",
+      "2. high": "166.0000",
+      "3. low": "158.6100",
+      "4. close": "165.8000",
+      "5. adjusted close": "165.8000",
+      "6. volume": "20871633",
+      "7. dividend amount": "0.0000"
+    },
+    "2024-01-05": {
+      "1. open": "162.8300",
+      "2. high": "163.2900",
+      "3. low": "158.6700",
+      "4. close": "159.1600",
+      "5. adjusted close": "159.1600",
+      "6. volume": "17906924",
+      "7. dividend amount": "1.6600"
+    }
+  }
+}`
+
+func newMockPeriodicPriceStock(function string, responses map[string]string) *PeriodicPriceStock {
+	mockClient := client.NewMockClient()
+	for url, body := range responses {
+		mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+	}
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return &PeriodicPriceStock{
+		alphaClient: request.NewAlphaVantageClient(mockClient, config),
+		function:    function,
+	}
+}
+
+func TestPeriodicPriceStock_Constructors(t *testing.T) {
+	weekly := NewWeeklyPriceStock("https://www.alphavantage.co", "test-key")
+	monthly := NewMonthlyPriceStock("https://www.alphavantage.co", "test-key")
+
+	assert.Equal(t, "TIME_SERIES_WEEKLY", weekly.function)
+	assert.Equal(t, "TIME_SERIES_MONTHLY", monthly.function)
+	assert.NotNil(t, weekly.alphaClient)
+	assert.NotNil(t, monthly.alphaClient)
+}
+
+func TestPeriodicPriceStock_InputSchema(t *testing.T) {
+	schema := NewWeeklyPriceStock("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Equal(t, []string{"symbol"}, schema.Required)
+	assert.Equal(t, []any{"compact", "full", nil}, schema.Properties["outputSize"].Enum)
+	assert.Equal(t, []any{"json", "csv", nil}, schema.Properties["datatype"].Enum)
+
+	resolved, err := schema.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]any{"symbol": "IBM"}))
+	assert.NoError(t, resolved.Validate(map[string]any{"symbol": "IBM", "adjusted": true, "outputSize": "full"}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "IBM", "outputSize": "large"}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "IBM", "adjusted": "true"}))
+}
+
+func TestPeriodicPriceStock_FunctionName(t *testing.T) {
+	tool := NewMonthlyPriceStock("https://www.alphavantage.co", "test-key")
+
+	assert.Equal(t, "TIME_SERIES_MONTHLY", tool.functionName(models.PeriodicPriceInput{Symbol: "IBM"}))
+	assert.Equal(t, "TIME_SERIES_MONTHLY", tool.functionName(models.PeriodicPriceInput{Symbol: "IBM", Adjusted: boolPtr(false)}))
+	assert.Equal(t, "TIME_SERIES_MONTHLY_ADJUSTED", tool.functionName(models.PeriodicPriceInput{Symbol: "IBM", Adjusted: boolPtr(true)}))
+}
+
+func TestPeriodicPriceStock_InputValidation(t *testing.T) {
+	tool := NewWeeklyPriceStock("https://www.alphavantage.co", "test-key")
+
+	testCases := []struct {
+		name        string
+		input       models.PeriodicPriceInput
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "valid input",
+			input:       models.PeriodicPriceInput{Symbol: "IBM"},
+			expectError: false,
+		},
+		{
+			name:        "valid output size",
+			input:       models.PeriodicPriceInput{Symbol: "IBM", OutputSize: stringPtr("full")},
+			expectError: false,
+		},
+		{
+			name:        "empty symbol",
+			input:       models.PeriodicPriceInput{Symbol: ""},
+			expectError: true,
+			errorMsg:    "symbol cannot be empty",
+		},
+		{
+			name:        "invalid output size",
+			input:       models.PeriodicPriceInput{Symbol: "IBM", OutputSize: stringPtr("medium")},
+			expectError: true,
+			errorMsg:    "invalid output size 'medium'",
+		},
+		{
+			name:        "invalid datatype",
+			input:       models.PeriodicPriceInput{Symbol: "IBM", Datatype: stringPtr("xml")},
+			expectError: true,
+			errorMsg:    "invalid datatype 'xml'",
+		},
+		{
+			name:        "from after to",
+			input:       models.PeriodicPriceInput{Symbol: "IBM", From: stringPtr("2024-02-01"), To: stringPtr("2024-01-01")},
+			expectError: true,
+			errorMsg:    "invalid date range",
+		},
+		{
+			name:        "invalid from",
+			input:       models.PeriodicPriceInput{Symbol: "IBM", From: stringPtr("yesterday")},
+			expectError: true,
+			errorMsg:    "invalid date 'yesterday'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tool.validateInput(tc.input)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPeriodicPriceStock_GetAdjusted(t *testing.T) {
+	tool := newMockPeriodicPriceStock("TIME_SERIES_WEEKLY", map[string]string{
+		"https://www.alphavantage.co/query?apikey=test-key&function=TIME_SERIES_WEEKLY_ADJUSTED&symbol=IBM": mockWeeklyAdjustedResponse,
+	})
+
+	_, res, err := tool.Get(context.Background(), nil, models.PeriodicPriceInput{Symbol: "IBM", Adjusted: boolPtr(true)})
+	require.NoError(t, err)
+
+	assert.Equal(t, "IBM", res.MetaData.Symbol)
+	assert.Equal(t, "US/Eastern", res.MetaData.TimeZone)
+	require.Len(t, res.TimeSeries, 2)
+
+	// Sorted oldest first
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, eastern), res.TimeSeries[0].Timestamp)
+	assert.Equal(t, 159.16, res.TimeSeries[0].AdjustedClose)
+	assert.Equal(t, 1.66, res.TimeSeries[0].DividendAmount)
+	assert.Equal(t, int64(17906924), res.TimeSeries[0].Volume)
+}
+
+func TestPeriodicPriceStock_GetDateRange(t *testing.T) {
+	url := "https://www.alphavantage.co/query?apikey=test-key&function=TIME_SERIES_WEEKLY_ADJUSTED&symbol=IBM"
+
+	testCases := []struct {
+		name     string
+		from, to string
+		expected []string
+	}{
+		{
+			name:     "single week",
+			from:     "2024-01-12",
+			to:       "2024-01-12",
+			expected: []string{"2024-01-12"},
+		},
+		{
+			name:     "range without data",
+			from:     "2023-06-01",
+			to:       "2023-06-30",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := newMockPeriodicPriceStock("TIME_SERIES_WEEKLY", map[string]string{url: mockWeeklyAdjustedResponse})
+
+			_, res, err := tool.Get(context.Background(), nil, models.PeriodicPriceInput{
+				Symbol:   "IBM",
+				Adjusted: boolPtr(true),
+				From:     stringPtr(tc.from),
+				To:       stringPtr(tc.to),
+			})
+			require.NoError(t, err)
+
+			dates := make([]string, 0, len(res.TimeSeries))
+			for _, point := range res.TimeSeries {
+				dates = append(dates, point.Timestamp.Format(time.DateOnly))
+			}
+			assert.Equal(t, tc.expected, dates)
+		})
+	}
+}
+
+func TestPeriodicPriceStock_ValidateResponse(t *testing.T) {
+	tool := NewWeeklyPriceStock("https://www.alphavantage.co", "test-key")
+
+	err := tool.validateResponse(models.PeriodicStockOutput{}, "IBM")
+	assert.ErrorContains(t, err, "no data returned for symbol 'IBM'")
+
+	err = tool.validateResponse(models.PeriodicStockOutput{MetaData: models.MetaData{Symbol: "IBM"}}, "IBM")
+	assert.ErrorContains(t, err, "no time series data returned")
+}
+
+func TestPeriodicPriceStock_CSV(t *testing.T) {
+	tool := newMockPeriodicPriceStock("TIME_SERIES_WEEKLY", map[string]string{
+		"https://www.alphavantage.co/query?apikey=test-key&datatype=csv&function=TIME_SERIES_WEEKLY_ADJUSTED&symbol=IBM": "timestamp,open,high,low,close,adjusted close,volume,dividend amount\n" +
+			"2024-01-12,161.0100,166.0000,158.6100,165.8000,165.8000,20871633,0.0000\n" +
+			"2024-01-05,162.8300,163.2900,158.6700,159.1600,159.1600,17906924,1.6600\n",
+	})
+
+	_, res, err := tool.Get(context.Background(), nil, models.PeriodicPriceInput{
+		Symbol:   "IBM",
+		Adjusted: boolPtr(true),
+		Datatype: stringPtr("csv"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "IBM", res.MetaData.Symbol)
+	assert.Equal(t, "2024-01-12", res.MetaData.LastRefreshed)
+	require.Len(t, res.TimeSeries, 2)
+	assert.Equal(t, 159.16, res.TimeSeries[0].Close)
+	assert.Equal(t, 1.66, res.TimeSeries[0].DividendAmount)
+	assert.Equal(t, int64(20871633), res.TimeSeries[1].Volume)
+}
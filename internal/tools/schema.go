@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// inputSchema infers the JSON schema of the tool input In as the MCP SDK
+// does, but requires only the given properties: the inferred schema also
+// requires every field without omitempty, including optional pointers.
+//
+// Input types are fixed at compile time, so like mcp.AddTool it panics when
+// the schema cannot be inferred.
+func inputSchema[In any](required ...string) *jsonschema.Schema {
+	schema, err := jsonschema.For[In](nil)
+	if err != nil {
+		panic(fmt.Sprintf("inferring input schema: %v", err))
+	}
+
+	schema.Required = required
+	return schema
+}
+
+// setEnum restricts the property of schema to values. A property that may be
+// null keeps accepting null.
+func setEnum(schema *jsonschema.Schema, property string, values []string) {
+	propertySchema, ok := schema.Properties[property]
+	if !ok {
+		panic(fmt.Sprintf("input schema has no property %q", property))
+	}
+
+	enum := make([]any, 0, len(values)+1)
+	for _, value := range values {
+		enum = append(enum, value)
+	}
+	if slices.Contains(propertySchema.Types, "null") {
+		enum = append(enum, nil)
+	}
+
+	propertySchema.Enum = enum
+}
@@ -0,0 +1,197 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var (
+	validIndicatorIntervals = []string{"1min", "5min", "15min", "30min", "60min", "daily", "weekly", "monthly"}
+	validSeriesTypes        = []string{"open", "high", "low", "close"}
+)
+
+// TechnicalIndicator implements the technical indicator MCP tools
+// ("get-sma", "get-ema", "get-rsi", "get-macd") using Alpha Vantage's
+// built-in indicator functions.
+//
+// All indicators share the same inputs and response layout, so a single
+// implementation is parameterized by the Alpha Vantage function name.
+type TechnicalIndicator struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+
+	// function is the Alpha Vantage indicator function, e.g. "SMA"
+	function string
+
+	// usesTimePeriod reports whether the indicator accepts time_period
+	usesTimePeriod bool
+}
+
+// NewSMA creates a simple moving average indicator tool.
+func NewSMA(apiURL, apiKey string) *TechnicalIndicator {
+	return newStandaloneToolset(apiURL, apiKey).SMA()
+}
+
+// NewEMA creates an exponential moving average indicator tool.
+func NewEMA(apiURL, apiKey string) *TechnicalIndicator {
+	return newStandaloneToolset(apiURL, apiKey).EMA()
+}
+
+// NewRSI creates a relative strength index indicator tool.
+func NewRSI(apiURL, apiKey string) *TechnicalIndicator {
+	return newStandaloneToolset(apiURL, apiKey).RSI()
+}
+
+// NewMACD creates a moving average convergence/divergence indicator tool.
+// MACD uses the standard 12/26/9 periods, so the time period input is ignored.
+func NewMACD(apiURL, apiKey string) *TechnicalIndicator {
+	return newStandaloneToolset(apiURL, apiKey).MACD()
+}
+
+// newTechnicalIndicator creates a TechnicalIndicator for the given function
+func newTechnicalIndicator(alphaClient *request.AlphaVantageClient, function string, usesTimePeriod bool) *TechnicalIndicator {
+	return &TechnicalIndicator{
+		alphaClient:    alphaClient,
+		function:       function,
+		usesTimePeriod: usesTimePeriod,
+	}
+}
+
+// validateInput performs input validation on the indicator input
+func (ti *TechnicalIndicator) validateInput(input models.IndicatorInput) error {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return err
+	}
+
+	if !slices.Contains(validIndicatorIntervals, input.Interval) {
+		return fmt.Errorf("invalid interval '%s'. Valid intervals are: %s",
+			input.Interval, strings.Join(validIndicatorIntervals, ", "))
+	}
+
+	if ti.usesTimePeriod && input.TimePeriod <= 0 {
+		return fmt.Errorf("invalid time period %d. Time period must be a positive integer", input.TimePeriod)
+	}
+
+	if !slices.Contains(validSeriesTypes, input.SeriesType) {
+		return fmt.Errorf("invalid series type '%s'. Valid series types are: %s",
+			input.SeriesType, strings.Join(validSeriesTypes, ", "))
+	}
+
+	return nil
+}
+
+// InputSchema returns the JSON schema of the tool input, restricting
+// interval and seriesType to the values Alpha Vantage accepts. timePeriod is
+// required only by the indicators that use it, so MACD clients may omit it.
+func (ti *TechnicalIndicator) InputSchema() *jsonschema.Schema {
+	required := []string{"symbol", "interval", "seriesType"}
+	if ti.usesTimePeriod {
+		required = []string{"symbol", "interval", "timePeriod", "seriesType"}
+	}
+
+	schema := inputSchema[models.IndicatorInput](required...)
+	setEnum(schema, "interval", validIndicatorIntervals)
+	setEnum(schema, "seriesType", validSeriesTypes)
+
+	return schema
+}
+
+// buildQueries constructs the query parameters for the Alpha Vantage API request
+func (ti *TechnicalIndicator) buildQueries(input models.IndicatorInput) []request.Query {
+	queries := []request.Query{
+		request.NewQuery("function", ti.function),
+		request.NewQuery("interval", input.Interval),
+		request.NewQuery("series_type", input.SeriesType),
+	}
+
+	if ti.usesTimePeriod {
+		queries = append(queries, request.NewQuery("time_period", strconv.Itoa(input.TimePeriod)))
+	}
+
+	return queries
+}
+
+// Get retrieves the indicator values for the given symbol, sorted oldest first.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - req: MCP tool request metadata (unused but required by interface)
+//   - input: Symbol, interval, time period and series type
+//
+// Returns:
+//   - *mcp.CallToolResult: Always nil (result data is in second return value)
+//   - models.IndicatorOutput: Indicator metadata and values
+//   - error: Any error encountered during the request or parsing process
+func (ti *TechnicalIndicator) Get(ctx context.Context, req *mcp.CallToolRequest, input models.IndicatorInput) (*mcp.CallToolResult, models.IndicatorOutput, error) {
+	if err := ti.validateInput(input); err != nil {
+		return nil, models.IndicatorOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.IndicatorOutput{}, ctx.Err()
+	default:
+	}
+
+	requestClient := request.NewAlphaWithClient(ti.alphaClient, input.Symbol, ti.buildQueries(input))
+
+	res, err := requestClient.GetWithContext(ctx)
+	if err != nil {
+		return nil, models.IndicatorOutput{}, fmt.Errorf("failed to fetch %s for symbol '%s': %w", ti.function, input.Symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.IndicatorOutput{}, ctx.Err()
+	default:
+	}
+
+	data, err := parser.TechnicalIndicator(res, ti.function)
+	if err != nil {
+		return nil, models.IndicatorOutput{}, fmt.Errorf("failed to parse %s for symbol '%s': %w", ti.function, input.Symbol, err)
+	}
+
+	if len(data.Values) == 0 {
+		return nil, models.IndicatorOutput{}, fmt.Errorf("%w: no %s values returned for symbol '%s'", errors.ErrNotFound, ti.function, input.Symbol)
+	}
+
+	return nil, *data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (ti *TechnicalIndicator) BuildURL(input models.IndicatorInput) (string, error) {
+	if err := ti.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return request.NewAlphaWithClient(ti.alphaClient, input.Symbol, ti.buildQueries(input)).RedactedURL()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (ti *TechnicalIndicator) GetStats() client.ClientStats {
+	return ti.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (ti *TechnicalIndicator) Close() error {
+	return ti.alphaClient.Close()
+}
@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func TestTechnicalIndicator_InputValidation(t *testing.T) {
+	rsi := NewRSI("https://www.alphavantage.co", "test-key")
+	macd := NewMACD("https://www.alphavantage.co", "test-key")
+
+	valid := models.IndicatorInput{Symbol: "IBM", Interval: "daily", TimePeriod: 14, SeriesType: "close"}
+	assert.NoError(t, rsi.validateInput(valid))
+
+	noPeriod := valid
+	noPeriod.TimePeriod = 0
+	assert.ErrorContains(t, rsi.validateInput(noPeriod), "invalid time period 0")
+	assert.NoError(t, macd.validateInput(noPeriod))
+
+	badSeries := valid
+	badSeries.SeriesType = "median"
+	assert.ErrorContains(t, rsi.validateInput(badSeries), "invalid series type 'median'")
+
+	badInterval := valid
+	badInterval.Interval = "hourly"
+	assert.ErrorContains(t, rsi.validateInput(badInterval), "invalid interval 'hourly'")
+}
+
+func TestTechnicalIndicator_InputSchema(t *testing.T) {
+	rsi := NewRSI("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Equal(t, []string{"symbol", "interval", "timePeriod", "seriesType"}, rsi.Required)
+
+	macd := NewMACD("https://www.alphavantage.co", "test-key").InputSchema()
+	assert.Equal(t, []string{"symbol", "interval", "seriesType"}, macd.Required)
+	assert.Equal(t, []any{"open", "high", "low", "close"}, macd.Properties["seriesType"].Enum)
+
+	resolved, err := macd.Resolve(nil)
+	require.NoError(t, err)
+	assert.NoError(t, resolved.Validate(map[string]any{"symbol": "IBM", "interval": "daily", "seriesType": "close"}), "timePeriod may be omitted")
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "IBM", "interval": "hourly", "seriesType": "close"}))
+	assert.Error(t, resolved.Validate(map[string]any{"symbol": "IBM", "interval": "daily", "seriesType": "median"}))
+}
+
+func TestTechnicalIndicator_BuildQueries(t *testing.T) {
+	input := models.IndicatorInput{Symbol: "IBM", Interval: "weekly", TimePeriod: 10, SeriesType: "open"}
+
+	params := func(queries []request.Query) map[string]string {
+		paramMap := make(map[string]string)
+		for _, query := range queries {
+			paramMap[query.Name] = query.Value
+		}
+		return paramMap
+	}
+
+	sma := params(NewSMA("https://www.alphavantage.co", "test-key").buildQueries(input))
+	assert.Equal(t, "SMA", sma["function"])
+	assert.Equal(t, "10", sma["time_period"])
+	assert.Equal(t, "open", sma["series_type"])
+
+	macd := params(NewMACD("https://www.alphavantage.co", "test-key").buildQueries(input))
+	assert.Equal(t, "MACD", macd["function"])
+	assert.NotContains(t, macd, "time_period")
+}
+
+func TestTechnicalIndicator_Get(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(
+		"https://www.alphavantage.co/query?apikey=test-key&function=EMA&interval=daily&series_type=close&symbol=IBM&time_period=20",
+		&client.Response{StatusCode: 200, Body: []byte(`{
+			"Meta Data": {"1: Symbol": "IBM", "4: Interval": "daily"},
+			"Technical Analysis: EMA": {"2024-01-12": {"EMA": "160.5"}, "2024-01-11": {"EMA": "160.1"}}
+		}`)},
+	)
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	tool := &TechnicalIndicator{
+		alphaClient:    request.NewAlphaVantageClient(mockClient, config),
+		function:       "EMA",
+		usesTimePeriod: true,
+	}
+
+	_, res, err := tool.Get(context.Background(), nil, models.IndicatorInput{Symbol: "IBM", Interval: "daily", TimePeriod: 20, SeriesType: "close"})
+	require.NoError(t, err)
+
+	require.Len(t, res.Values, 2)
+	assert.Equal(t, 160.1, res.Values[0].Value)
+	assert.Equal(t, 160.5, res.Values[1].Value)
+}
+
+func TestTechnicalIndicator_BuildURL(t *testing.T) {
+	rsi := NewRSI("https://www.alphavantage.co", "secret-key")
+	defer rsi.Close()
+
+	url, err := rsi.BuildURL(models.IndicatorInput{Symbol: "IBM", Interval: "daily", TimePeriod: 14, SeriesType: "close"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.alphavantage.co/query?apikey=***&function=RSI&interval=daily&series_type=close&symbol=IBM&time_period=14", url)
+
+	_, err = rsi.BuildURL(models.IndicatorInput{Symbol: "IBM", Interval: "hourly", TimePeriod: 14, SeriesType: "close"})
+	assert.ErrorContains(t, err, "invalid interval 'hourly'")
+}
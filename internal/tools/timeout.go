@@ -0,0 +1,62 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MaxCallTimeout caps the per-call timeout a client may request with timeoutSeconds
+const MaxCallTimeout = 120 * time.Second
+
+// callTimeouter is implemented by tool inputs that embed models.CallOptions
+type callTimeouter interface {
+	CallTimeout() *int
+}
+
+// WithCallTimeout wraps a tool handler so that an input's timeoutSeconds,
+// when set, bounds the call with that deadline instead of the tool's default
+// timeout. Values that are not positive or exceed MaxCallTimeout are rejected
+// as invalid input.
+//
+// Each HTTP attempt remains bounded by the client's read timeout, so longer
+// budgets leave room for retries and rate limit waits rather than a single
+// slower response.
+func WithCallTimeout[In, Out any](handler mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		timeouter, ok := any(input).(callTimeouter)
+		if !ok || timeouter.CallTimeout() == nil {
+			return handler(ctx, req, input)
+		}
+
+		timeout, err := callTimeout(*timeouter.CallTimeout())
+		if err != nil {
+			var zero Out
+			return nil, zero, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return handler(ctx, req, input)
+	}
+}
+
+// callTimeout validates a timeoutSeconds value and converts it to a duration
+func callTimeout(seconds int) (time.Duration, error) {
+	maxSeconds := int(MaxCallTimeout / time.Second)
+	if seconds <= 0 || seconds > maxSeconds {
+		return 0, fmt.Errorf("invalid timeoutSeconds %d. Must be between 1 and %d", seconds, maxSeconds)
+	}
+
+	return time.Duration(seconds) * time.Second, nil
+}
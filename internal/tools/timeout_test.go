@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// slowClient is a mock HTTP client whose requests take delay unless the
+// context ends first
+type slowClient struct {
+	*client.MockClient
+	delay time.Duration
+}
+
+func (s *slowClient) Get(ctx context.Context, url string, headers map[string]string) (*client.Response, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(s.delay):
+		return s.MockClient.Get(ctx, url, headers)
+	}
+}
+
+func newSlowEarnings(delay time.Duration) *Earnings {
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return &Earnings{alphaClient: request.NewAlphaVantageClient(&slowClient{MockClient: client.NewMockClient(), delay: delay}, config)}
+}
+
+func TestWithCallTimeout_CancelsSlowCall(t *testing.T) {
+	handler := WithCallTimeout(newSlowEarnings(10 * time.Second).Get)
+
+	input := models.SymbolInput{Symbol: "IBM"}
+	input.TimeoutSeconds = intPtr(1)
+
+	start := time.Now()
+	_, _, err := handler(context.Background(), nil, input)
+	elapsed := time.Since(start)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, elapsed, 900*time.Millisecond)
+	assert.Less(t, elapsed, 5*time.Second, "The 1s override should cancel the 10s request")
+}
+
+func TestWithCallTimeout_NoOverride(t *testing.T) {
+	handler := WithCallTimeout(func(ctx context.Context, _ *mcp.CallToolRequest, _ models.SymbolInput) (*mcp.CallToolResult, models.EarningsOutput, error) {
+		_, ok := ctx.Deadline()
+		assert.False(t, ok, "Without timeoutSeconds the context should be left unchanged")
+		return nil, models.EarningsOutput{}, nil
+	})
+
+	_, _, err := handler(context.Background(), nil, models.SymbolInput{Symbol: "IBM"})
+	assert.NoError(t, err)
+}
+
+func TestWithCallTimeout_InvalidTimeout(t *testing.T) {
+	for _, seconds := range []int{0, -1, 121} {
+		handler := WithCallTimeout(newSlowEarnings(0).Get)
+
+		input := models.SymbolInput{Symbol: "IBM"}
+		input.TimeoutSeconds = intPtr(seconds)
+
+		_, _, err := handler(context.Background(), nil, input)
+		require.Error(t, err, seconds)
+		assert.ErrorIs(t, err, errors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "invalid timeoutSeconds")
+	}
+}
@@ -0,0 +1,244 @@
+package tools
+
+import (
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/provider"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+// maxToolResponseBodySize is the largest upstream response the tools accept.
+// Full price, FX and indicator histories, the listing and call transcripts
+// all run to several megabytes.
+const maxToolResponseBodySize = 20 * 1024 * 1024
+
+// Toolset builds the Alpha Vantage tools on one shared HTTP client, so the
+// tools draw from a single connection pool, report unified statistics and
+// share one rate limiter per API key instead of each tool keeping its own.
+//
+// Each tool still gets its own Alpha Vantage client, deliberately: tools
+// cache for different TTLs and SetTimeout adjusts a single tool. Response
+// caches and the merging of concurrent identical requests are therefore per
+// tool, so e.g. the overview and batch overview tools never share a cached
+// response.
+type Toolset struct {
+	config     *request.AlphaVantageConfig
+	httpClient client.HTTPClient
+
+	// limiter throttles the requests of every tool when the config sets
+	// RequestsPerMinute or RequestsPerDay
+	limiter *request.RateLimiter
+
+	// standalone is set when the set builds a single tool, which then owns
+	// the HTTP client and closes it with its own Close
+	standalone bool
+}
+
+// NewToolset creates a Toolset whose tools call Alpha Vantage with config
+// over a FastHTTP client built from config.HTTPConfig.
+func NewToolset(config *request.AlphaVantageConfig) *Toolset {
+	httpConfig := config.HTTPConfig()
+	httpConfig.MaxResponseBodySize = maxToolResponseBodySize
+
+	return NewToolsetWithClient(client.NewFastHTTPClient(httpConfig), config)
+}
+
+// NewToolsetWithClient creates a Toolset whose tools send their requests
+// through httpClient, e.g. a client.MockClient in tests.
+func NewToolsetWithClient(httpClient client.HTTPClient, config *request.AlphaVantageConfig) *Toolset {
+	toolset := &Toolset{
+		config:     config,
+		httpClient: httpClient,
+	}
+
+	if config.RequestsPerMinute > 0 || config.RequestsPerDay > 0 {
+		toolset.limiter = request.NewRateLimiter(config.RequestsPerMinute, config.RequestsPerDay).WithClock(config.Clock)
+	}
+
+	return toolset
+}
+
+// newClient creates the Alpha Vantage client of one tool on the shared HTTP
+// client and rate limiter, caching responses for cacheTTL when positive.
+// Closing the tool releases its cache but leaves the HTTP client to Close.
+func (ts *Toolset) newClient(cacheTTL time.Duration) *request.AlphaVantageClient {
+	alphaClient := request.NewAlphaVantageClient(ts.httpClient, ts.config).WithRateLimiter(ts.limiter)
+	if !ts.standalone {
+		alphaClient.WithSharedHTTPClient()
+	}
+	if cacheTTL > 0 {
+		alphaClient.WithCache(cacheTTL)
+	}
+
+	return alphaClient
+}
+
+// AlphaVantageClient creates an uncached Alpha Vantage client on the shared
+// HTTP client and rate limiter, for callers outside the tools such as the
+// readiness check, so their requests count against the same quota.
+func (ts *Toolset) AlphaVantageClient() *request.AlphaVantageClient {
+	return ts.newClient(0)
+}
+
+// OverviewStock creates the company overview tool
+func (ts *Toolset) OverviewStock() *OverviewStock {
+	return NewOverviewStockWithProvider(provider.NewAlphaVantage(ts.newClient(6 * time.Hour)))
+}
+
+// OverviewStocks creates the batch company overview tool. Its requests draw
+// on the shared rate limiter like those of every other tool.
+func (ts *Toolset) OverviewStocks() *OverviewStocks {
+	return NewOverviewStocksWithProvider(provider.NewAlphaVantage(ts.newClient(6 * time.Hour)))
+}
+
+// GlobalQuote creates the latest quote tool
+func (ts *Toolset) GlobalQuote() *GlobalQuote {
+	return &GlobalQuote{alphaClient: ts.newClient(0)}
+}
+
+// GlobalQuotes creates the batch quote tool. Its requests draw on the shared
+// rate limiter like those of every other tool.
+func (ts *Toolset) GlobalQuotes() *GlobalQuotes {
+	return &GlobalQuotes{
+		quote:       &GlobalQuote{alphaClient: ts.newClient(0)},
+		concurrency: batchConcurrency,
+	}
+}
+
+// IntradayPriceStock creates the intraday price tool, applying the
+// DefaultInterval and DefaultOutputSize of the config. Intraday bars go
+// stale quickly, so responses are only cached briefly.
+func (ts *Toolset) IntradayPriceStock() *IntradayPriceStock {
+	return NewIntradayPriceStockWithProvider(provider.NewAlphaVantage(ts.newClient(request.IntradayCacheTTL)))
+}
+
+// WeeklyPriceStock creates the weekly price tool
+func (ts *Toolset) WeeklyPriceStock() *PeriodicPriceStock {
+	return newPeriodicPriceStock(ts.newClient(0), "TIME_SERIES_WEEKLY")
+}
+
+// MonthlyPriceStock creates the monthly price tool
+func (ts *Toolset) MonthlyPriceStock() *PeriodicPriceStock {
+	return newPeriodicPriceStock(ts.newClient(0), "TIME_SERIES_MONTHLY")
+}
+
+// NewsSentiment creates the news sentiment tool
+func (ts *Toolset) NewsSentiment() *NewsSentiment {
+	return &NewsSentiment{alphaClient: ts.newClient(0)}
+}
+
+// IncomeStatement creates the income statement tool
+func (ts *Toolset) IncomeStatement() *IncomeStatement {
+	return &IncomeStatement{newFinancialStatement(ts.newClient(6*time.Hour), "INCOME_STATEMENT")}
+}
+
+// BalanceSheet creates the balance sheet tool
+func (ts *Toolset) BalanceSheet() *BalanceSheet {
+	return &BalanceSheet{newFinancialStatement(ts.newClient(6*time.Hour), "BALANCE_SHEET")}
+}
+
+// CashFlow creates the cash flow tool
+func (ts *Toolset) CashFlow() *CashFlow {
+	return &CashFlow{newFinancialStatement(ts.newClient(6*time.Hour), "CASH_FLOW")}
+}
+
+// Earnings creates the earnings history tool
+func (ts *Toolset) Earnings() *Earnings {
+	return &Earnings{alphaClient: ts.newClient(6 * time.Hour)}
+}
+
+// EarningsTranscript creates the earnings call transcript tool. Transcripts
+// of past calls never change, so they are cached for a day.
+func (ts *Toolset) EarningsTranscript() *EarningsTranscript {
+	return &EarningsTranscript{alphaClient: ts.newClient(24 * time.Hour)}
+}
+
+// CurrencyExchangeRate creates the realtime exchange rate tool
+func (ts *Toolset) CurrencyExchangeRate() *CurrencyExchangeRate {
+	return &CurrencyExchangeRate{alphaClient: ts.newClient(0)}
+}
+
+// FXIntraday creates the intraday FX rates tool
+func (ts *Toolset) FXIntraday() *FXIntraday {
+	return &FXIntraday{alphaClient: ts.newClient(0)}
+}
+
+// FXDaily creates the daily FX rates tool
+func (ts *Toolset) FXDaily() *FXDaily {
+	return &FXDaily{alphaClient: ts.newClient(0)}
+}
+
+// CryptoDaily creates the daily digital currency prices tool
+func (ts *Toolset) CryptoDaily() *CryptoDaily {
+	return &CryptoDaily{alphaClient: ts.newClient(0)}
+}
+
+// CryptoIntraday creates the intraday digital currency prices tool
+func (ts *Toolset) CryptoIntraday() *CryptoIntraday {
+	return &CryptoIntraday{alphaClient: ts.newClient(0)}
+}
+
+// SMA creates the simple moving average tool
+func (ts *Toolset) SMA() *TechnicalIndicator {
+	return newTechnicalIndicator(ts.newClient(0), "SMA", true)
+}
+
+// EMA creates the exponential moving average tool
+func (ts *Toolset) EMA() *TechnicalIndicator {
+	return newTechnicalIndicator(ts.newClient(0), "EMA", true)
+}
+
+// RSI creates the relative strength index tool
+func (ts *Toolset) RSI() *TechnicalIndicator {
+	return newTechnicalIndicator(ts.newClient(0), "RSI", true)
+}
+
+// MACD creates the moving average convergence/divergence tool
+func (ts *Toolset) MACD() *TechnicalIndicator {
+	return newTechnicalIndicator(ts.newClient(0), "MACD", false)
+}
+
+// ListingStatus creates the listing status tool
+func (ts *Toolset) ListingStatus() *ListingStatus {
+	return &ListingStatus{alphaClient: ts.newClient(6 * time.Hour)}
+}
+
+// AlphaFunction creates the generic Alpha Vantage function tool
+func (ts *Toolset) AlphaFunction() *AlphaFunction {
+	return &AlphaFunction{alphaClient: ts.newClient(0)}
+}
+
+// GetStats returns the statistics of the shared HTTP client
+func (ts *Toolset) GetStats() client.ClientStats {
+	return ts.httpClient.Stats()
+}
+
+// Close releases the shared HTTP client, after which no tool of the set can
+// make requests. Closing a single tool leaves the client open for the others.
+func (ts *Toolset) Close() error {
+	return ts.httpClient.Close()
+}
+
+// newStandaloneToolset creates the Toolset of a tool built on its own, with
+// an HTTP client that no other tool shares
+func newStandaloneToolset(apiURL, apiKey string) *Toolset {
+	toolset := NewToolset(&request.AlphaVantageConfig{
+		BaseURL: apiURL,
+		APIKey:  apiKey,
+		Timeout: 30 * time.Second,
+	})
+	toolset.standalone = true
+
+	return toolset
+}
+
+// newStandaloneBatchToolset creates the set of a batch tool built on its
+// own, holding its requests to the free tier's per-minute quota. Batch tools
+// built from a Toolset use the Toolset's rate limiter instead.
+func newStandaloneBatchToolset(apiURL, apiKey string) *Toolset {
+	toolset := newStandaloneToolset(apiURL, apiKey)
+	toolset.limiter = request.NewRateLimiter(batchRequestsPerMinute, 0)
+
+	return toolset
+}
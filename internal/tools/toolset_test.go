@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	pkgerrors "github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+const toolsetEarningsURL = "https://www.alphavantage.co/query?apikey=test-key&function=EARNINGS&symbol=IBM"
+
+func newMockToolset(config *request.AlphaVantageConfig) (*Toolset, *client.MockClient) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(overviewURL("IBM"), &client.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"Symbol": "IBM", "Name": "International Business Machines"}`),
+	})
+	mockClient.SetResponse(toolsetEarningsURL, &client.Response{
+		StatusCode: 200,
+		Body:       []byte(`{"symbol": "IBM", "annualEarnings": [], "quarterlyEarnings": [{"fiscalDateEnding": "2023-12-31", "reportedEPS": "3.87"}]}`),
+	})
+
+	config.BaseURL = "https://www.alphavantage.co/query"
+	config.APIKey = "test-key"
+	config.Timeout = 30 * time.Second
+
+	return NewToolsetWithClient(mockClient, config), mockClient
+}
+
+func TestToolset_SharesHTTPClient(t *testing.T) {
+	toolset, mockClient := newMockToolset(&request.AlphaVantageConfig{})
+	overview := toolset.OverviewStock()
+	earnings := toolset.Earnings()
+
+	_, _, err := overview.Get(context.Background(), nil, models.OverviewInput{Symbol: "IBM"})
+	require.NoError(t, err)
+	_, _, err = earnings.Get(context.Background(), nil, models.SymbolInput{Symbol: "IBM"})
+	require.NoError(t, err)
+
+	assert.Len(t, mockClient.Requests(), 2)
+
+	// Both tools report the statistics of the one client they share
+	assert.Equal(t, int64(2), toolset.GetStats().TotalRequests)
+	assert.Equal(t, toolset.GetStats(), overview.GetStats())
+	assert.Equal(t, toolset.GetStats(), earnings.GetStats())
+}
+
+func TestToolset_SharesRateLimiter(t *testing.T) {
+	toolset, mockClient := newMockToolset(&request.AlphaVantageConfig{RequestsPerDay: 1})
+
+	_, _, err := toolset.OverviewStock().Get(context.Background(), nil, models.OverviewInput{Symbol: "IBM"})
+	require.NoError(t, err)
+
+	// The daily budget spent by one tool is gone for the others
+	_, _, err = toolset.Earnings().Get(context.Background(), nil, models.SymbolInput{Symbol: "IBM"})
+	assert.ErrorIs(t, err, pkgerrors.ErrRateLimitWouldExceedDaily)
+	assert.Zero(t, mockClient.GetCallCount(toolsetEarningsURL))
+}
+
+func TestToolset_ToolCloseLeavesSharedClientOpen(t *testing.T) {
+	toolset, mockClient := newMockToolset(&request.AlphaVantageConfig{})
+	overview := toolset.OverviewStock()
+	earnings := toolset.Earnings()
+
+	require.NoError(t, overview.Close())
+	assert.Zero(t, mockClient.CloseCount(), "Closing one tool must not close the client the others share")
+
+	_, _, err := earnings.Get(context.Background(), nil, models.SymbolInput{Symbol: "IBM"})
+	require.NoError(t, err)
+
+	// The set closes the shared client once
+	require.NoError(t, toolset.Close())
+	assert.Equal(t, 1, mockClient.CloseCount())
+}
@@ -0,0 +1,65 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yeferson59/finance-mcp/pkg/tracing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TracingMiddleware is an MCP receiving middleware that wraps each tool call
+// in a span, continuing the trace context sent in the HTTP request headers.
+//
+// Spans are recorded with the tracer installed via tracing.SetTracer, which
+// is a no-op unless tracing is enabled.
+func TracingMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		params, ok := req.GetParams().(*mcp.CallToolParamsRaw)
+		if method != "tools/call" || !ok {
+			return next(ctx, method, req)
+		}
+
+		if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+			ctx = tracing.Extract(ctx, extra.Header)
+		}
+
+		attrs := []tracing.Attribute{tracing.String("mcp.tool", params.Name)}
+		if symbol := toolCallSymbol(params); symbol != "" {
+			attrs = append(attrs, tracing.String("finance.symbol", symbol))
+		}
+
+		ctx, span := tracing.Start(ctx, "tool "+params.Name, attrs...)
+		defer span.End()
+
+		result, err := next(ctx, method, req)
+
+		switch {
+		case err != nil:
+			span.RecordError(err)
+		case toolCallFailed(result, nil):
+			span.RecordError(errors.New(toolResultError(result)))
+		}
+
+		return result, err
+	}
+}
+
+// toolResultError returns the text of a tool result flagged with IsError
+func toolResultError(result mcp.Result) string {
+	if toolResult, ok := result.(*mcp.CallToolResult); ok {
+		for _, content := range toolResult.Content {
+			if text, ok := content.(*mcp.TextContent); ok {
+				return text.Text
+			}
+		}
+	}
+
+	return "tool returned an error result"
+}
@@ -0,0 +1,128 @@
+// Package tools implements MCP tools for financial data retrieval.
+//
+// This package provides implementations of MCP (Model Context Protocol) tools
+// that can be called by AI models and other MCP clients to fetch real-time
+// financial market data from external APIs like Alpha Vantage.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/internal/validation"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/parser"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// EarningsTranscript implements the "get-earnings-transcript" MCP tool for
+// retrieving the transcript of a company's earnings call for a given quarter.
+//
+// This tool integrates with Alpha Vantage's EARNINGS_CALL_TRANSCRIPT function
+// to provide, for each speaker turn:
+//   - Speaker name and title
+//   - Spoken text
+//   - Sentiment score, when available
+type EarningsTranscript struct {
+	// alphaClient is the injected Alpha Vantage client
+	alphaClient *request.AlphaVantageClient
+}
+
+// NewEarningsTranscript creates a new EarningsTranscript tool instance.
+//
+// Parameters:
+//   - apiURL: Base URL for Alpha Vantage API (e.g., "https://www.alphavantage.co")
+//   - apiKey: Valid Alpha Vantage API key for authentication
+func NewEarningsTranscript(apiURL, apiKey string) *EarningsTranscript {
+	return newStandaloneToolset(apiURL, apiKey).EarningsTranscript()
+}
+
+// validateInput performs input validation on the transcript input
+func (e *EarningsTranscript) validateInput(input models.TranscriptInput) error {
+	if err := validation.ValidateSymbol(input.Symbol); err != nil {
+		return err
+	}
+
+	return validation.ValidateQuarter(input.Quarter)
+}
+
+// newRequest builds the EARNINGS_CALL_TRANSCRIPT request for the input
+func (e *EarningsTranscript) newRequest(input models.TranscriptInput) *request.RequestAlpha {
+	return request.NewAlphaWithClient(
+		e.alphaClient,
+		input.Symbol,
+		[]request.Query{
+			request.NewQuery("function", "EARNINGS_CALL_TRANSCRIPT"),
+			request.NewQuery("quarter", input.Quarter),
+		},
+	)
+}
+
+// Get retrieves the earnings call transcript for the given symbol and quarter.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout handling
+//   - req: MCP tool request metadata (unused but required by interface)
+//   - input: Transcript input containing the symbol and fiscal quarter
+//
+// Returns:
+//   - *mcp.CallToolResult: Always nil (result data is in second return value)
+//   - models.TranscriptOutput: Transcript segments in speaking order
+//   - error: Any error encountered, including an empty transcript
+func (e *EarningsTranscript) Get(ctx context.Context, req *mcp.CallToolRequest, input models.TranscriptInput) (*mcp.CallToolResult, models.TranscriptOutput, error) {
+	if err := e.validateInput(input); err != nil {
+		return nil, models.TranscriptOutput{}, fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.TranscriptOutput{}, ctx.Err()
+	default:
+	}
+
+	res, err := e.newRequest(input).GetWithContext(ctx)
+	if err != nil {
+		return nil, models.TranscriptOutput{}, fmt.Errorf("failed to fetch %s earnings call transcript for symbol '%s': %w", input.Quarter, input.Symbol, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, models.TranscriptOutput{}, ctx.Err()
+	default:
+	}
+
+	data, err := parser.Transcript(res)
+	if err != nil {
+		return nil, models.TranscriptOutput{}, fmt.Errorf("failed to parse %s earnings call transcript for symbol '%s': %w", input.Quarter, input.Symbol, err)
+	}
+
+	if len(data.Segments) == 0 {
+		return nil, models.TranscriptOutput{}, fmt.Errorf("%w: no %s earnings call transcript returned for symbol '%s'", errors.ErrNotFound, input.Quarter, input.Symbol)
+	}
+
+	return nil, *data, nil
+}
+
+// BuildURL validates the input and returns the Alpha Vantage URL Get would
+// request, with the API key redacted, without calling the API.
+func (e *EarningsTranscript) BuildURL(input models.TranscriptInput) (string, error) {
+	if err := e.validateInput(input); err != nil {
+		return "", fmt.Errorf("%w: %w", errors.ErrInvalidInput, err)
+	}
+
+	return e.newRequest(input).RedactedURL()
+}
+
+// GetStats returns HTTP client statistics for monitoring
+func (e *EarningsTranscript) GetStats() client.ClientStats {
+	return e.alphaClient.GetStats()
+}
+
+// Close cleans up resources used by the tool
+func (e *EarningsTranscript) Close() error {
+	return e.alphaClient.Close()
+}
@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/request"
+)
+
+func newMockEarningsTranscript(url, body string) *EarningsTranscript {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(url, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	config := &request.AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return &EarningsTranscript{alphaClient: request.NewAlphaVantageClient(mockClient, config)}
+}
+
+func TestEarningsTranscript_Get(t *testing.T) {
+	tool := newMockEarningsTranscript(
+		"https://www.alphavantage.co/query?apikey=test-key&function=EARNINGS_CALL_TRANSCRIPT&quarter=2024Q1&symbol=IBM",
+		`{"symbol": "IBM", "quarter": "2024Q1", "transcript": [{"speaker": "Arvind Krishna", "title": "CEO", "content": "Thank you.", "sentiment": "0.5"}]}`,
+	)
+
+	_, res, err := tool.Get(context.Background(), nil, models.TranscriptInput{Symbol: "IBM", Quarter: "2024Q1"})
+	require.NoError(t, err)
+
+	require.Len(t, res.Segments, 1)
+	assert.Equal(t, "Arvind Krishna", res.Segments[0].Speaker)
+	assert.Equal(t, "Thank you.", res.Segments[0].Text)
+}
+
+func TestEarningsTranscript_EmptyTranscript(t *testing.T) {
+	tool := newMockEarningsTranscript(
+		"https://www.alphavantage.co/query?apikey=test-key&function=EARNINGS_CALL_TRANSCRIPT&quarter=2009Q1&symbol=IBM",
+		`{"symbol": "IBM", "quarter": "2009Q1", "transcript": []}`,
+	)
+
+	_, _, err := tool.Get(context.Background(), nil, models.TranscriptInput{Symbol: "IBM", Quarter: "2009Q1"})
+	assert.ErrorIs(t, err, errors.ErrNotFound)
+}
+
+func TestEarningsTranscript_InvalidQuarter(t *testing.T) {
+	tool := newMockEarningsTranscript("", "")
+
+	_, _, err := tool.Get(context.Background(), nil, models.TranscriptInput{Symbol: "IBM", Quarter: "2024-Q1"})
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	assert.Contains(t, err.Error(), "invalid quarter '2024-Q1'")
+}
@@ -0,0 +1,27 @@
+package validation
+
+import (
+	"fmt"
+)
+
+// ValidateCurrencyCode validates a physical or digital currency code such as
+// "USD", "EUR" or "BTC". Codes must be exactly three uppercase letters.
+//
+// Returns nil if valid, error with descriptive message otherwise.
+func ValidateCurrencyCode(code string) error {
+	if code == "" {
+		return fmt.Errorf("currency code cannot be empty")
+	}
+
+	if len(code) != 3 {
+		return fmt.Errorf("currency code '%s' must be exactly 3 letters", code)
+	}
+
+	for _, char := range code {
+		if char < 'A' || char > 'Z' {
+			return fmt.Errorf("currency code '%s' must contain only uppercase letters", code)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCurrencyCode(t *testing.T) {
+	testCases := []struct {
+		name        string
+		code        string
+		expectError bool
+		errorMsg    string
+	}{
+		{name: "physical currency", code: "USD", expectError: false},
+		{name: "digital currency", code: "BTC", expectError: false},
+		{name: "empty", code: "", expectError: true, errorMsg: "cannot be empty"},
+		{name: "too short", code: "US", expectError: true, errorMsg: "exactly 3 letters"},
+		{name: "too long", code: "USDT", expectError: true, errorMsg: "exactly 3 letters"},
+		{name: "lowercase", code: "usd", expectError: true, errorMsg: "uppercase letters"},
+		{name: "digits", code: "U5D", expectError: true, errorMsg: "uppercase letters"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCurrencyCode(tc.code)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tc.errorMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateInterval(t *testing.T) {
+	for _, interval := range ValidIntervals {
+		assert.NoError(t, ValidateInterval(interval))
+	}
+
+	err := ValidateInterval("2min")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid interval '2min'")
+}
+
+func TestIntervalDuration(t *testing.T) {
+	expected := map[string]time.Duration{
+		"1min":  time.Minute,
+		"5min":  5 * time.Minute,
+		"15min": 15 * time.Minute,
+		"30min": 30 * time.Minute,
+		"60min": time.Hour,
+	}
+	for _, interval := range ValidIntervals {
+		duration, err := IntervalDuration(interval)
+		assert.NoError(t, err)
+		assert.Equal(t, expected[interval], duration, interval)
+	}
+
+	_, err := IntervalDuration("daily")
+	assert.ErrorContains(t, err, "invalid interval 'daily'")
+}
@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidDatatypes lists the response formats accepted by Alpha Vantage time series functions.
+var ValidDatatypes = []string{"json", "csv"}
+
+// ValidateDatatype validates an Alpha Vantage response datatype.
+//
+// Returns nil if the datatype is one of ValidDatatypes, error with descriptive message otherwise.
+func ValidateDatatype(datatype string) error {
+	if !slices.Contains(ValidDatatypes, datatype) {
+		return fmt.Errorf("invalid datatype '%s'. Valid datatypes are: %s",
+			datatype, strings.Join(ValidDatatypes, ", "))
+	}
+
+	return nil
+}
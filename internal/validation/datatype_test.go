@@ -0,0 +1,17 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDatatype(t *testing.T) {
+	for _, datatype := range ValidDatatypes {
+		assert.NoError(t, ValidateDatatype(datatype))
+	}
+
+	err := ValidateDatatype("xml")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid datatype 'xml'")
+}
@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidEntitlements lists the data entitlements premium Alpha Vantage keys may request.
+var ValidEntitlements = []string{"realtime", "delayed"}
+
+// ValidateEntitlement validates an Alpha Vantage entitlement parameter.
+//
+// Returns nil if the entitlement is one of ValidEntitlements, error with descriptive message otherwise.
+func ValidateEntitlement(entitlement string) error {
+	if !slices.Contains(ValidEntitlements, entitlement) {
+		return fmt.Errorf("invalid entitlement '%s'. Valid entitlements are: %s",
+			entitlement, strings.Join(ValidEntitlements, ", "))
+	}
+
+	return nil
+}
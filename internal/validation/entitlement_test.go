@@ -0,0 +1,17 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEntitlement(t *testing.T) {
+	for _, entitlement := range ValidEntitlements {
+		assert.NoError(t, ValidateEntitlement(entitlement))
+	}
+
+	err := ValidateEntitlement("live")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid entitlement 'live'")
+}
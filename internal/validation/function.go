@@ -0,0 +1,65 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidFunctions lists the Alpha Vantage functions that may be called
+// through the generic function tool. Functions that only answer in CSV, such
+// as LISTING_STATUS and EARNINGS_CALENDAR, are left out.
+var ValidFunctions = []string{
+	// Core stock APIs
+	"TIME_SERIES_INTRADAY", "TIME_SERIES_DAILY", "TIME_SERIES_DAILY_ADJUSTED",
+	"TIME_SERIES_WEEKLY", "TIME_SERIES_WEEKLY_ADJUSTED",
+	"TIME_SERIES_MONTHLY", "TIME_SERIES_MONTHLY_ADJUSTED",
+	"GLOBAL_QUOTE", "REALTIME_BULK_QUOTES", "SYMBOL_SEARCH", "MARKET_STATUS",
+
+	// Options data
+	"REALTIME_OPTIONS", "HISTORICAL_OPTIONS",
+
+	// Alpha intelligence
+	"NEWS_SENTIMENT", "EARNINGS_CALL_TRANSCRIPT", "TOP_GAINERS_LOSERS",
+	"INSIDER_TRANSACTIONS", "ANALYTICS_FIXED_WINDOW", "ANALYTICS_SLIDING_WINDOW",
+
+	// Fundamental data
+	"OVERVIEW", "ETF_PROFILE", "DIVIDENDS", "SPLITS",
+	"INCOME_STATEMENT", "BALANCE_SHEET", "CASH_FLOW", "EARNINGS",
+
+	// Forex and digital currencies
+	"CURRENCY_EXCHANGE_RATE", "FX_INTRADAY", "FX_DAILY", "FX_WEEKLY", "FX_MONTHLY",
+	"CRYPTO_INTRADAY", "DIGITAL_CURRENCY_DAILY", "DIGITAL_CURRENCY_WEEKLY", "DIGITAL_CURRENCY_MONTHLY",
+
+	// Commodities
+	"WTI", "BRENT", "NATURAL_GAS", "COPPER", "ALUMINUM", "WHEAT", "CORN",
+	"COTTON", "SUGAR", "COFFEE", "ALL_COMMODITIES",
+
+	// Economic indicators
+	"REAL_GDP", "REAL_GDP_PER_CAPITA", "TREASURY_YIELD", "FEDERAL_FUNDS_RATE",
+	"CPI", "INFLATION", "RETAIL_SALES", "DURABLES", "UNEMPLOYMENT", "NONFARM_PAYROLL",
+
+	// Technical indicators
+	"SMA", "EMA", "WMA", "DEMA", "TEMA", "TRIMA", "KAMA", "MAMA", "VWAP", "T3",
+	"MACD", "MACDEXT", "STOCH", "STOCHF", "RSI", "STOCHRSI", "WILLR", "ADX",
+	"ADXR", "APO", "PPO", "MOM", "BOP", "CCI", "CMO", "ROC", "ROCR", "AROON",
+	"AROONOSC", "MFI", "TRIX", "ULTOSC", "DX", "MINUS_DI", "PLUS_DI",
+	"MINUS_DM", "PLUS_DM", "BBANDS", "MIDPOINT", "MIDPRICE", "SAR", "TRANGE",
+	"ATR", "NATR", "AD", "ADOSC", "OBV", "HT_TRENDLINE", "HT_SINE",
+	"HT_TRENDMODE", "HT_DCPERIOD", "HT_DCPHASE", "HT_PHASOR",
+}
+
+// ValidateFunction validates the name of an Alpha Vantage function.
+//
+// Returns nil if the function is one of ValidFunctions, error with descriptive message otherwise.
+func ValidateFunction(function string) error {
+	if strings.TrimSpace(function) == "" {
+		return fmt.Errorf("function cannot be empty")
+	}
+
+	if !slices.Contains(ValidFunctions, function) {
+		return fmt.Errorf("unsupported function '%s'. Use an uppercase Alpha Vantage function name that answers in JSON, e.g. TIME_SERIES_DAILY, SYMBOL_SEARCH or TOP_GAINERS_LOSERS", function)
+	}
+
+	return nil
+}
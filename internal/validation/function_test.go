@@ -0,0 +1,31 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFunction(t *testing.T) {
+	for _, function := range ValidFunctions {
+		assert.NoError(t, ValidateFunction(function))
+	}
+
+	testCases := []struct {
+		function string
+		message  string
+	}{
+		{function: "", message: "function cannot be empty"},
+		{function: "  ", message: "function cannot be empty"},
+		{function: "time_series_daily", message: "unsupported function 'time_series_daily'"},
+		{function: "LISTING_STATUS", message: "unsupported function 'LISTING_STATUS'"},
+		{function: "DROP_TABLE", message: "unsupported function 'DROP_TABLE'"},
+	}
+
+	for _, tc := range testCases {
+		err := ValidateFunction(tc.function)
+		if assert.Error(t, err, tc.function) {
+			assert.Contains(t, err.Error(), tc.message)
+		}
+	}
+}
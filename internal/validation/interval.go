@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ValidIntervals lists the intraday intervals accepted by Alpha Vantage.
+var ValidIntervals = []string{"1min", "5min", "15min", "30min", "60min"}
+
+// ValidateInterval validates an Alpha Vantage intraday interval.
+//
+// Returns nil if the interval is one of ValidIntervals, error with descriptive message otherwise.
+func ValidateInterval(interval string) error {
+	if !slices.Contains(ValidIntervals, interval) {
+		return fmt.Errorf("invalid interval '%s'. Valid intervals are: %s",
+			interval, strings.Join(ValidIntervals, ", "))
+	}
+
+	return nil
+}
+
+// IntervalDuration returns the length of the bars of an Alpha Vantage
+// intraday interval, e.g. 15 minutes for "15min".
+//
+// Returns an error if the interval is not one of ValidIntervals.
+func IntervalDuration(interval string) (time.Duration, error) {
+	if err := ValidateInterval(interval); err != nil {
+		return 0, err
+	}
+
+	return time.ParseDuration(strings.TrimSuffix(interval, "in"))
+}
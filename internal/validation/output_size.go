@@ -0,0 +1,22 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidOutputSizes lists the output sizes accepted by Alpha Vantage time series functions.
+var ValidOutputSizes = []string{"compact", "full"}
+
+// ValidateOutputSize validates an Alpha Vantage output size.
+//
+// Returns nil if the size is one of ValidOutputSizes, error with descriptive message otherwise.
+func ValidateOutputSize(outputSize string) error {
+	if !slices.Contains(ValidOutputSizes, outputSize) {
+		return fmt.Errorf("invalid output size '%s'. Valid sizes are: %s",
+			outputSize, strings.Join(ValidOutputSizes, ", "))
+	}
+
+	return nil
+}
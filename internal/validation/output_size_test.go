@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOutputSize(t *testing.T) {
+	testCases := []struct {
+		name        string
+		outputSize  string
+		expectError bool
+	}{
+		{name: "compact", outputSize: "compact", expectError: false},
+		{name: "full", outputSize: "full", expectError: false},
+		{name: "empty", outputSize: "", expectError: true},
+		{name: "unknown size", outputSize: "medium", expectError: true},
+		{name: "wrong case", outputSize: "Full", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateOutputSize(tc.outputSize)
+
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "invalid output size")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// quarterPattern matches a fiscal quarter such as "2024Q1"
+var quarterPattern = regexp.MustCompile(`^\d{4}Q[1-4]$`)
+
+// ValidateQuarter validates a fiscal quarter in YYYYQn format, e.g. "2024Q1".
+//
+// Returns nil if valid, error with descriptive message otherwise.
+func ValidateQuarter(quarter string) error {
+	if quarter == "" {
+		return fmt.Errorf("quarter cannot be empty")
+	}
+
+	if !quarterPattern.MatchString(quarter) {
+		return fmt.Errorf("invalid quarter '%s'. Expected format: YYYYQn with n from 1 to 4, e.g. 2024Q1", quarter)
+	}
+
+	return nil
+}
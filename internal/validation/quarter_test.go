@@ -0,0 +1,17 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateQuarter(t *testing.T) {
+	for _, quarter := range []string{"2024Q1", "2010Q4"} {
+		assert.NoError(t, ValidateQuarter(quarter))
+	}
+
+	for _, quarter := range []string{"", "2024Q5", "2024Q0", "24Q1", "2024q1", "2024-Q1", "2024Q1 "} {
+		assert.Error(t, ValidateQuarter(quarter), quarter)
+	}
+}
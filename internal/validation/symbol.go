@@ -4,34 +4,88 @@ package validation
 import (
 	"fmt"
 	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/yeferson59/finance-mcp/pkg/errors"
 )
 
+// SymbolRules configures which symbols ValidateSymbol accepts.
+type SymbolRules struct {
+	// MaxLength is the maximum symbol length in characters
+	MaxLength int
+
+	// Punctuation lists the characters allowed besides ASCII letters and
+	// digits, e.g. "." for BRK.A or ":" for exchange-qualified TSLA:NASDAQ
+	Punctuation string
+}
+
+// DefaultSymbolRules accepts tickers such as BRK.A, BRK-B, VOD.L and
+// TSLA:NASDAQ of up to 20 characters.
+var DefaultSymbolRules = SymbolRules{
+	MaxLength:   20,
+	Punctuation: ".-:",
+}
+
+// symbolRules holds the rules in effect, replaced by SetSymbolRules
+var symbolRules atomic.Pointer[SymbolRules]
+
+func init() {
+	rules := DefaultSymbolRules
+	symbolRules.Store(&rules)
+}
+
+// SetSymbolRules replaces the rules used by ValidateSymbol. It is meant to be
+// called once at startup, but is safe for concurrent use.
+//
+// Returns an error, leaving the current rules in place, if MaxLength is not
+// positive or Punctuation contains letters, digits, whitespace or control
+// characters.
+func SetSymbolRules(rules SymbolRules) error {
+	if rules.MaxLength < 1 {
+		return fmt.Errorf("symbol max length must be positive, got %d", rules.MaxLength)
+	}
+
+	for _, char := range rules.Punctuation {
+		if char > unicode.MaxASCII || !unicode.IsPunct(char) && !unicode.IsSymbol(char) {
+			return fmt.Errorf("symbol punctuation %q may only contain ASCII punctuation", rules.Punctuation)
+		}
+	}
+
+	symbolRules.Store(&rules)
+	return nil
+}
+
 // ValidateSymbol validates a stock symbol for common patterns and constraints.
 // It checks for:
 //   - Non-empty symbol
-//   - Maximum length of 10 characters
-//   - Only alphanumeric characters and dots
+//   - Maximum length, 20 characters by default
+//   - Only alphanumeric characters and the allowed punctuation, ".-:" by default
+//
+// The limits are configured with SetSymbolRules.
 //
 // Returns nil if valid, error with descriptive message otherwise.
 func ValidateSymbol(symbol string) error {
+	rules := symbolRules.Load()
+
 	// Check if empty or whitespace only
 	trimmed := strings.TrimSpace(symbol)
 	if trimmed == "" {
-		return fmt.Errorf("symbol cannot be empty")
+		return fmt.Errorf("%w: symbol cannot be empty", errors.ErrInvalidSymbol)
 	}
 
 	// Check length constraint
-	if len(trimmed) > 10 {
-		return fmt.Errorf("symbol '%s' appears to be invalid (too long)", trimmed)
+	if len(trimmed) > rules.MaxLength {
+		return fmt.Errorf("%w: symbol '%s' appears to be invalid (too long, max %d characters)", errors.ErrInvalidSymbol, trimmed, rules.MaxLength)
 	}
 
-	// Check for valid characters (alphanumeric and dot)
+	// Check for valid characters (alphanumeric and allowed punctuation)
 	for _, char := range trimmed {
 		if !((char >= 'A' && char <= 'Z') ||
 			(char >= 'a' && char <= 'z') ||
 			(char >= '0' && char <= '9') ||
-			char == '.') {
-			return fmt.Errorf("symbol '%s' contains invalid characters", trimmed)
+			strings.ContainsRune(rules.Punctuation, char)) {
+			return fmt.Errorf("%w: symbol '%s' contains invalid characters", errors.ErrInvalidSymbol, trimmed)
 		}
 	}
 
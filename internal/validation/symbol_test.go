@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestValidateSymbol(t *testing.T) {
@@ -46,11 +47,42 @@ func TestValidateSymbol(t *testing.T) {
 			errorMsg:    "cannot be empty",
 		},
 		{
-			name:        "symbol too long",
+			name:        "valid symbol with hyphen",
+			symbol:      "BRK-B",
+			expectError: false,
+		},
+		{
+			name:        "valid share class with hyphen",
+			symbol:      "RDS-A",
+			expectError: false,
+		},
+		{
+			name:        "valid exchange suffix",
+			symbol:      "VOD.L",
+			expectError: false,
+		},
+		{
+			name:        "valid exchange-qualified symbol",
+			symbol:      "TSLA:NASDAQ",
+			expectError: false,
+		},
+		{
+			name:        "valid long symbol",
 			symbol:      "VERYLONGSYMBOL",
+			expectError: false,
+		},
+		{
+			name:        "symbol too long",
+			symbol:      "AVERYVERYLONGSYMBOL.XX",
 			expectError: true,
 			errorMsg:    "too long",
 		},
+		{
+			name:        "slash is not allowed",
+			symbol:      "BTC/USD",
+			expectError: true,
+			errorMsg:    "invalid characters",
+		},
 		{
 			name:        "invalid characters",
 			symbol:      "AAPL!",
@@ -92,6 +124,25 @@ func TestValidateSymbol(t *testing.T) {
 	}
 }
 
+func TestSetSymbolRules(t *testing.T) {
+	defer func() { require.NoError(t, SetSymbolRules(DefaultSymbolRules)) }()
+
+	require.NoError(t, SetSymbolRules(SymbolRules{MaxLength: 5, Punctuation: "/"}))
+	assert.NoError(t, ValidateSymbol("BTC/U"))
+	assert.ErrorContains(t, ValidateSymbol("BRK-B"), "invalid characters")
+	assert.ErrorContains(t, ValidateSymbol("GOOGLE"), "too long, max 5 characters")
+
+	for _, rules := range []SymbolRules{
+		{MaxLength: 0, Punctuation: "."},
+		{MaxLength: 10, Punctuation: ". "},
+		{MaxLength: 10, Punctuation: ".a"},
+		{MaxLength: 10, Punctuation: "é"},
+	} {
+		assert.Error(t, SetSymbolRules(rules), "rules %+v should be rejected", rules)
+	}
+	assert.NoError(t, ValidateSymbol("BTC/U"), "Rejected rules should leave the current rules in place")
+}
+
 func BenchmarkValidateSymbol(b *testing.B) {
 	symbols := []string{"AAPL", "GOOGL", "MSFT", "BRK.A", "TSM"}
 	b.ResetTimer()
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// unreachableURL refuses connections immediately so every attempt fails fast
+const unreachableURL = "http://127.0.0.1:1"
+
+func newBackoffTestClient(jitter bool) (*FastHTTPClient, *[]time.Duration) {
+	config := DefaultConfig()
+	config.MaxRetries = 5
+	config.RetryBackoffBase = 100 * time.Millisecond
+	config.RetryBackoffMax = time.Second
+	config.RetryJitter = jitter
+	config.CircuitMaxFailures = 0
+
+	client := NewFastHTTPClient(config)
+
+	var sleeps []time.Duration
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return ctx.Err()
+	}
+
+	return client, &sleeps
+}
+
+func TestFastHTTPClient_ExponentialBackoff(t *testing.T) {
+	client, sleeps := newBackoffTestClient(false)
+
+	if _, err := client.Get(context.Background(), unreachableURL, nil); err == nil {
+		t.Fatal("Expected request to unreachable host to fail")
+	}
+
+	expected := []time.Duration{
+		100 * time.Millisecond,
+		200 * time.Millisecond,
+		400 * time.Millisecond,
+		800 * time.Millisecond,
+		time.Second,
+	}
+	if len(*sleeps) != len(expected) {
+		t.Fatalf("Expected %d sleeps, got %v", len(expected), *sleeps)
+	}
+	for i, want := range expected {
+		if (*sleeps)[i] != want {
+			t.Errorf("Sleep %d: expected %v, got %v", i, want, (*sleeps)[i])
+		}
+	}
+}
+
+func TestFastHTTPClient_BackoffJitter(t *testing.T) {
+	client, sleeps := newBackoffTestClient(true)
+	client.randInt63n = func(n int64) int64 { return n - 1 }
+
+	if _, err := client.Get(context.Background(), unreachableURL, nil); err == nil {
+		t.Fatal("Expected request to unreachable host to fail")
+	}
+
+	for i, sleep := range *sleeps {
+		if sleep > time.Second {
+			t.Errorf("Sleep %d exceeded the cap: %v", i, sleep)
+		}
+		if i > 0 && sleep < (*sleeps)[i-1] {
+			t.Errorf("Sleep %d decreased: %v after %v", i, sleep, (*sleeps)[i-1])
+		}
+	}
+}
+
+func TestFastHTTPClient_BackoffHonorsContext(t *testing.T) {
+	config := DefaultConfig()
+	config.RetryBackoffBase = time.Hour
+	config.RetryJitter = false
+	client := NewFastHTTPClient(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Get(ctx, unreachableURL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected backoff to stop at the deadline, took %v", elapsed)
+	}
+}
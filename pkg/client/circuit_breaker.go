@@ -0,0 +1,179 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/clock"
+)
+
+// ErrCircuitOpen is returned without performing a request while the circuit
+// breaker is open because the upstream service keeps failing.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState int
+
+const (
+	// CircuitClosed lets all requests through
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects all requests until the reset timeout elapses
+	CircuitOpen
+	// CircuitHalfOpen lets a single probe request through
+	CircuitHalfOpen
+)
+
+// String returns the lowercase name of the state
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// callOutcome is how a call let through by the breaker ended
+type callOutcome int
+
+const (
+	// callSucceeded shows the upstream service is healthy
+	callSucceeded callOutcome = iota
+	// callFailed counts towards opening the circuit
+	callFailed
+	// callInconclusive, e.g. a call its caller gave up on or a rejected
+	// request, says nothing about the upstream service's health
+	callInconclusive
+)
+
+// CircuitBreaker stops calling a failing upstream service.
+//
+// It opens after maxFailures consecutive failures occurring within
+// resetTimeout of each other. Once open, calls fail fast with ErrCircuitOpen
+// until resetTimeout has elapsed, after which a single probe is let through:
+// success closes the circuit, failure opens it again.
+type CircuitBreaker struct {
+	maxFailures  int
+	resetTimeout time.Duration
+
+	state       CircuitState
+	failures    int
+	lastFailure time.Time
+	openedAt    time.Time
+	probing     bool
+	mu          sync.Mutex
+
+	clock clock.Clock
+}
+
+// NewCircuitBreaker creates a closed circuit breaker
+func NewCircuitBreaker(maxFailures int, resetTimeout time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		maxFailures:  maxFailures,
+		resetTimeout: resetTimeout,
+		clock:        clock.Real,
+	}
+}
+
+// WithClock makes the breaker time its reset timeout with c, e.g. a
+// clock.Fake in tests, and returns the breaker. A nil c keeps the real clock.
+func (cb *CircuitBreaker) WithClock(c clock.Clock) *CircuitBreaker {
+	cb.clock = clock.OrReal(c)
+	return cb
+}
+
+// Execute runs fn if the circuit allows it and records the outcome.
+// It returns ErrCircuitOpen without running fn while the circuit is open.
+func (cb *CircuitBreaker) Execute(fn func() error) error {
+	if err := cb.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	if err != nil {
+		cb.record(callFailed)
+	} else {
+		cb.record(callSucceeded)
+	}
+	return err
+}
+
+// State returns the current state, reporting an open circuit whose reset
+// timeout has elapsed as half-open
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && cb.clock.Now().Sub(cb.openedAt) >= cb.resetTimeout {
+		return CircuitHalfOpen
+	}
+
+	return cb.state
+}
+
+// allow reports whether a call may proceed, moving to half-open when the
+// reset timeout of an open circuit has elapsed
+func (cb *CircuitBreaker) allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitOpen && cb.clock.Now().Sub(cb.openedAt) >= cb.resetTimeout {
+		cb.state = CircuitHalfOpen
+	}
+
+	switch cb.state {
+	case CircuitOpen:
+		return ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.probing {
+			return ErrCircuitOpen
+		}
+		cb.probing = true
+	}
+
+	return nil
+}
+
+// record updates the state with the outcome of a call. An inconclusive
+// probe frees the half-open circuit for another probe, leaving it half-open.
+func (cb *CircuitBreaker) record(outcome callOutcome) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.clock.Now()
+	cb.probing = false
+
+	switch outcome {
+	case callInconclusive:
+		return
+	case callSucceeded:
+		cb.state = CircuitClosed
+		cb.failures = 0
+		return
+	}
+
+	if cb.state == CircuitHalfOpen {
+		cb.state = CircuitOpen
+		cb.openedAt = now
+		return
+	}
+
+	// Failures spread further apart than the reset timeout are not consecutive
+	if cb.failures > 0 && now.Sub(cb.lastFailure) > cb.resetTimeout {
+		cb.failures = 0
+	}
+
+	cb.failures++
+	cb.lastFailure = now
+
+	if cb.failures >= cb.maxFailures {
+		cb.state = CircuitOpen
+		cb.openedAt = now
+		cb.failures = 0
+	}
+}
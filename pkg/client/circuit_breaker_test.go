@@ -0,0 +1,232 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/clock"
+)
+
+const circuitTestURL = "https://upstream.example.com"
+
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockClient()
+	mock.SetError(circuitTestURL, errors.New("connection refused"))
+
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	breaker := NewCircuitBreaker(3, time.Minute)
+	breaker.WithClock(fake)
+
+	call := func() error {
+		return breaker.Execute(func() error {
+			_, err := mock.Get(ctx, circuitTestURL, nil)
+			return err
+		})
+	}
+
+	for i := range 3 {
+		if err := call(); errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d should reach upstream, got %v", i+1, err)
+		}
+	}
+
+	if state := breaker.State(); state != CircuitOpen {
+		t.Fatalf("Expected state open after 3 failures, got %s", state)
+	}
+
+	if err := call(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen while open, got %v", err)
+	}
+	if count := mock.GetCallCount(circuitTestURL); count != 3 {
+		t.Errorf("Expected open circuit to skip upstream, got %d calls", count)
+	}
+
+	// After the reset timeout a failing probe opens the circuit again
+	fake.Advance(time.Minute)
+	if state := breaker.State(); state != CircuitHalfOpen {
+		t.Fatalf("Expected state half-open after reset timeout, got %s", state)
+	}
+	if err := call(); errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected probe to reach upstream, got %v", err)
+	}
+	if state := breaker.State(); state != CircuitOpen {
+		t.Fatalf("Expected failed probe to reopen circuit, got %s", state)
+	}
+
+	// A successful probe closes it
+	fake.Advance(time.Minute)
+	delete(mock.errors, circuitTestURL)
+	if err := call(); err != nil {
+		t.Fatalf("Expected successful probe, got %v", err)
+	}
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("Expected successful probe to close circuit, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_FailuresOutsideWindow(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.WithClock(fake)
+
+	fail := func() error { return errors.New("timeout") }
+
+	_ = breaker.Execute(fail)
+	fake.Advance(2 * time.Minute)
+	_ = breaker.Execute(fail)
+
+	if state := breaker.State(); state != CircuitClosed {
+		t.Errorf("Expected failures outside the window not to trip the circuit, got %s", state)
+	}
+}
+
+func TestCircuitBreaker_SingleProbe(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.WithClock(fake)
+
+	_ = breaker.Execute(func() error { return errors.New("timeout") })
+	fake.Advance(time.Minute)
+
+	err := breaker.Execute(func() error {
+		// A concurrent call while the probe is in flight is rejected
+		if err := breaker.Execute(func() error { return nil }); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("Expected ErrCircuitOpen during probe, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected probe to succeed, got %v", err)
+	}
+}
+
+func TestFastHTTPClient_CircuitState(t *testing.T) {
+	client := NewFastHTTPClient(DefaultConfig())
+	if client.breaker == nil {
+		t.Fatal("Expected default config to enable the circuit breaker")
+	}
+
+	if state := client.Stats().CircuitState; state != CircuitClosed {
+		t.Errorf("Expected closed circuit, got %s", state)
+	}
+
+	if NewFastHTTPClient(&Config{}).breaker != nil {
+		t.Error("Expected zero CircuitMaxFailures to disable the circuit breaker")
+	}
+}
+
+func TestFastHTTPClient_CallerDeadlinesDoNotOpenCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	config.CircuitMaxFailures = 2
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	get := func(newContext func() (context.Context, context.CancelFunc)) error {
+		ctx, cancel := newContext()
+		defer cancel()
+		_, err := client.Get(ctx, server.URL, nil)
+		return err
+	}
+
+	callerDeadline := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeout(context.Background(), 20*time.Millisecond)
+	}
+	for range 3 {
+		if err := get(callerDeadline); err == nil {
+			t.Fatal("Expected the request to time out")
+		}
+	}
+	if state := client.Stats().CircuitState; state != CircuitClosed {
+		t.Fatalf("Expected the caller's own deadlines to leave the circuit closed, got %s", state)
+	}
+
+	operationTimeout := func() (context.Context, context.CancelFunc) {
+		return context.WithTimeoutCause(context.Background(), 20*time.Millisecond, ErrOperationTimeout)
+	}
+	for range 2 {
+		if err := get(operationTimeout); err == nil {
+			t.Fatal("Expected the request to time out")
+		}
+	}
+	if state := client.Stats().CircuitState; state != CircuitOpen {
+		t.Errorf("Expected operation timeouts to open the circuit, got %s", state)
+	}
+}
+
+func TestFastHTTPClient_InconclusiveProbeKeepsCircuitHalfOpen(t *testing.T) {
+	var status atomic.Int32
+	entered := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if status.Load() == 0 {
+			// Hang until the caller hangs up
+			entered <- struct{}{}
+			<-r.Context().Done()
+			return
+		}
+		w.WriteHeader(int(status.Load()))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	config.CircuitMaxFailures = 1
+	// Requests do not stop when canceled, so the hanging probe ends here
+	config.ReadTimeout = 100 * time.Millisecond
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	client.breaker.WithClock(fake)
+
+	status.Store(http.StatusServiceUnavailable)
+	if _, err := client.Get(context.Background(), server.URL, nil); err == nil {
+		t.Fatal("Expected the upstream failure to be returned")
+	}
+	fake.Advance(time.Minute)
+
+	// A probe whose caller hangs up says nothing about upstream health
+	status.Store(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-entered
+		cancel()
+	}()
+	if _, err := client.Get(ctx, server.URL, nil); err == nil {
+		t.Fatal("Expected the canceled probe to fail")
+	}
+	if state := client.Stats().CircuitState; state != CircuitHalfOpen {
+		t.Fatalf("Expected a canceled probe to leave the circuit half-open, got %s", state)
+	}
+
+	// Neither does a rejected request
+	status.Store(http.StatusNotFound)
+	if _, err := client.Get(context.Background(), server.URL, nil); errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Expected another probe to be let through, got %v", err)
+	}
+	if state := client.Stats().CircuitState; state != CircuitHalfOpen {
+		t.Fatalf("Expected a rejected probe to leave the circuit half-open, got %s", state)
+	}
+
+	status.Store(http.StatusOK)
+	if _, err := client.Get(context.Background(), server.URL, nil); err != nil {
+		t.Fatalf("Expected a successful probe, got %v", err)
+	}
+	if state := client.Stats().CircuitState; state != CircuitClosed {
+		t.Errorf("Expected a successful probe to close the circuit, got %s", state)
+	}
+}
@@ -18,17 +18,23 @@ package client
 
 import (
 	"bytes"
-	"compress/flate"
-	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
 	"net/url"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/valyala/fasthttp"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/tracing"
 )
 
 // HTTPClient defines the interface for HTTP client implementations.
@@ -43,6 +49,10 @@ type HTTPClient interface {
 	// Do performs a request with full control over method, body, and headers
 	Do(ctx context.Context, method, url string, body []byte, headers map[string]string) (*Response, error)
 
+	// DoStream performs a request like Do but returns the body as a stream
+	// instead of buffering it. The caller must close the response body.
+	DoStream(ctx context.Context, method, url string, body []byte, headers map[string]string) (*StreamResponse, error)
+
 	// Close cleans up any resources used by the client
 	Close() error
 
@@ -63,8 +73,11 @@ type ClientStats struct {
 	SuccessfulRequests int64
 	FailedRequests     int64
 	AverageLatency     time.Duration
+	P50Latency         time.Duration
+	P95Latency         time.Duration
 	ConnectionsActive  int
 	ConnectionsTotal   int64
+	CircuitState       CircuitState
 }
 
 // Config holds configuration for HTTP clients
@@ -80,21 +93,62 @@ type Config struct {
 	WriteTimeout        time.Duration
 	MaxResponseBodySize int
 
-	// Retry settings
-	MaxRetries int
-	RetryDelay time.Duration
+	// MaxConcurrentRequests bounds the requests in flight at once, retries
+	// included; further requests wait for one to finish or for their context
+	// to be done. Non-positive places no bound.
+	MaxConcurrentRequests int
+
+	// Retry settings. Retries wait RetryBackoffBase doubled on every attempt,
+	// capped at RetryBackoffMax; with RetryJitter each wait is drawn uniformly
+	// from zero up to that value. RetryDelay is used as the base when
+	// RetryBackoffBase is not set.
+	MaxRetries       int
+	RetryDelay       time.Duration
+	RetryBackoffBase time.Duration
+	RetryBackoffMax  time.Duration
+	RetryJitter      bool
+
+	// Circuit breaker settings; a non-positive CircuitMaxFailures disables the breaker
+	CircuitMaxFailures  int
+	CircuitResetTimeout time.Duration
 
 	// Client identification
 	UserAgent string
 
+	// TLS settings for HTTPS connections
+	TLS TLSConfig
+
 	// Performance settings
 	EnableCompression bool
 	EnableKeepAlive   bool
+
+	// AcceptEncoding, when set, is sent as the Accept-Encoding header in
+	// place of the one EnableCompression implies; "identity" asks for
+	// uncompressed payloads, e.g. to inspect raw responses
+	AcceptEncoding string
+
+	// Clock times retry backoff and the circuit breaker; nil uses the real clock
+	Clock clock.Clock
+}
+
+// compressedAcceptEncoding is the Accept-Encoding sent when EnableCompression
+// is set and no AcceptEncoding override is configured
+const compressedAcceptEncoding = "gzip, deflate, br"
+
+// defaultAcceptEncoding is the AcceptEncoding of configurations returned by
+// DefaultConfig
+var defaultAcceptEncoding atomic.Pointer[string]
+
+// SetDefaultAcceptEncoding sets the AcceptEncoding of configurations returned
+// by DefaultConfig, so the header can be overridden process-wide from
+// configuration. An empty encoding restores the EnableCompression behavior.
+func SetDefaultAcceptEncoding(encoding string) {
+	defaultAcceptEncoding.Store(&encoding)
 }
 
 // DefaultConfig returns a configuration optimized for financial API usage
 func DefaultConfig() *Config {
-	return &Config{
+	config := &Config{
 		MaxConnsPerHost:     100,
 		MaxIdleConnDuration: 90 * time.Second,
 		MaxConnDuration:     10 * time.Minute,
@@ -104,36 +158,113 @@ func DefaultConfig() *Config {
 		MaxResponseBodySize: 10 * 1024 * 1024,
 		MaxRetries:          2,
 		RetryDelay:          500 * time.Millisecond,
+		RetryBackoffBase:    500 * time.Millisecond,
+		RetryBackoffMax:     5 * time.Second,
+		RetryJitter:         true,
+		CircuitMaxFailures:  5,
+		CircuitResetTimeout: 30 * time.Second,
 		UserAgent:           "Finance-MCP-Client/1.0",
 		EnableCompression:   true,
 		EnableKeepAlive:     true,
 	}
+
+	if encoding := defaultAcceptEncoding.Load(); encoding != nil {
+		config.AcceptEncoding = *encoding
+	}
+
+	return config
 }
 
+// ErrClientClosed is returned for requests made after Close
+var ErrClientClosed = errors.New("http client is closed")
+
 // FastHTTPClient implements HTTPClient using valyala/fasthttp for maximum performance
 type FastHTTPClient struct {
 	client *fasthttp.Client
 	config *Config
-	stats  *clientStats
-	mu     sync.RWMutex
+
+	// streamClient serves DoStream; it streams bodies larger than streamBufferSize
+	streamClient *fasthttp.Client
+
+	stats   *clientStats
+	breaker *CircuitBreaker
+	slots   requestSlots
+	closed  atomic.Bool
+	mu      sync.RWMutex
+
+	// initErr is returned by every request when the configuration is unusable
+	initErr error
+
+	clock clock.Clock
+
+	// sleep waits between retries and randInt63n draws jitter; replaced in tests
+	sleep      func(ctx context.Context, d time.Duration) error
+	randInt63n func(n int64) int64
 }
 
-// clientStats tracks performance metrics
+// clientStats tracks performance metrics.
+//
+// fasthttp does not expose its connection pool, so connections are counted
+// around each attempt: connectionsActive is the number of attempts in flight
+// and connectionsTotal the number ever started.
 type clientStats struct {
 	totalRequests      int64
 	successfulRequests int64
 	failedRequests     int64
 	totalLatency       time.Duration
+	latencies          latencyHistogram
 	mu                 sync.RWMutex
+
+	connectionsActive atomic.Int64
+	connectionsTotal  atomic.Int64
 }
 
-// NewFastHTTPClient creates a new FastHTTP-based client with the given configuration
+// NewFastHTTPClient creates a new FastHTTP-based client with the given configuration.
+//
+// If config.TLS is invalid, e.g. its RootCAFile cannot be read, the error is
+// logged and returned by every request rather than silently falling back to
+// the system roots. Call config.TLS.Build first to check it up front.
 func NewFastHTTPClient(config *Config) *FastHTTPClient {
 	if config == nil {
 		config = DefaultConfig()
 	}
 
-	client := &fasthttp.Client{
+	tlsConfig, initErr := config.TLS.Build()
+	if initErr != nil {
+		slog.Error("invalid TLS configuration; requests will fail", "error", initErr)
+	}
+
+	client := newFasthttpClient(config, tlsConfig)
+
+	// Bodies up to streamBufferSize are read at once; larger ones are
+	// streamed and bounded by MaxResponseBodySize as they are read
+	streamClient := newFasthttpClient(config, tlsConfig)
+	streamClient.StreamResponseBody = true
+	streamClient.MaxResponseBodySize = streamBufferSize
+
+	httpClient := &FastHTTPClient{
+		client:       client,
+		config:       config,
+		streamClient: streamClient,
+		stats:        &clientStats{},
+		clock:        clock.OrReal(config.Clock),
+		randInt63n:   rand.Int64N,
+		initErr:      initErr,
+		slots:        newRequestSlots(config.MaxConcurrentRequests),
+	}
+
+	httpClient.sleep = httpClient.sleepContext
+
+	if config.CircuitMaxFailures > 0 {
+		httpClient.breaker = NewCircuitBreaker(config.CircuitMaxFailures, config.CircuitResetTimeout).WithClock(httpClient.clock)
+	}
+
+	return httpClient
+}
+
+// newFasthttpClient creates the underlying fasthttp client for config
+func newFasthttpClient(config *Config, tlsConfig *tls.Config) *fasthttp.Client {
+	return &fasthttp.Client{
 		MaxConnsPerHost:               config.MaxConnsPerHost,
 		MaxIdleConnDuration:           config.MaxIdleConnDuration,
 		MaxConnDuration:               config.MaxConnDuration,
@@ -144,16 +275,11 @@ func NewFastHTTPClient(config *Config) *FastHTTPClient {
 		DisableHeaderNamesNormalizing: false,
 		DisablePathNormalizing:        true,
 		Name:                          config.UserAgent,
+		TLSConfig:                     tlsConfig,
 		RetryIf: func(request *fasthttp.Request) bool {
 			return false
 		},
 	}
-
-	return &FastHTTPClient{
-		client: client,
-		config: config,
-		stats:  &clientStats{},
-	}
 }
 
 // Get performs an HTTP GET request
@@ -166,8 +292,58 @@ func (c *FastHTTPClient) Post(ctx context.Context, url string, body []byte, head
 	return c.Do(ctx, "POST", url, body, headers)
 }
 
-// Do performs an HTTP request with full control over method, body, and headers
+// Do performs an HTTP request with full control over method, body, and headers.
+// When the circuit breaker is open it fails fast with ErrCircuitOpen, and
+// after Close it fails with ErrClientClosed. With MaxConcurrentRequests set it
+// first waits for a request slot.
 func (c *FastHTTPClient) Do(ctx context.Context, method, url string, body []byte, headers map[string]string) (*Response, error) {
+	if err := c.slots.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.slots.release()
+
+	return execute(c, ctx, func(ctx context.Context) (*Response, error) {
+		return c.performRequest(ctx, method, url, body, headers)
+	})
+}
+
+// execute runs a request attempt behind the closed, configuration and
+// circuit breaker checks, retrying retryable failures
+func execute[T any](c *FastHTTPClient, ctx context.Context, attempt func(context.Context) (T, error)) (T, error) {
+	var zero T
+
+	if c.closed.Load() {
+		return zero, ErrClientClosed
+	}
+
+	if c.initErr != nil {
+		return zero, c.initErr
+	}
+
+	if c.breaker == nil {
+		return doWithRetries(c, ctx, attempt)
+	}
+
+	if err := c.breaker.allow(); err != nil {
+		return zero, err
+	}
+
+	response, err := doWithRetries(c, ctx, attempt)
+	switch {
+	case err == nil:
+		c.breaker.record(callSucceeded)
+	case isUpstreamFailure(ctx, err):
+		c.breaker.record(callFailed)
+	default:
+		c.breaker.record(callInconclusive)
+	}
+
+	return response, err
+}
+
+// doWithRetries performs a request attempt, retrying retryable failures
+func doWithRetries[T any](c *FastHTTPClient, ctx context.Context, attempt func(context.Context) (T, error)) (T, error) {
+	var zero T
 	startTime := time.Now()
 
 	c.stats.mu.Lock()
@@ -176,13 +352,18 @@ func (c *FastHTTPClient) Do(ctx context.Context, method, url string, body []byte
 
 	var lastErr error
 
-	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		response, err := c.performRequest(ctx, method, url, body, headers)
+	for try := 0; try <= c.config.MaxRetries; try++ {
+		c.stats.connectionsActive.Add(1)
+		c.stats.connectionsTotal.Add(1)
+		response, err := attempt(ctx)
+		c.stats.connectionsActive.Add(-1)
+
 		if err == nil {
 			latency := time.Since(startTime)
 			c.stats.mu.Lock()
 			c.stats.successfulRequests++
 			c.stats.totalLatency += latency
+			c.stats.latencies.observe(latency)
 			c.stats.mu.Unlock()
 
 			return response, nil
@@ -190,15 +371,13 @@ func (c *FastHTTPClient) Do(ctx context.Context, method, url string, body []byte
 
 		lastErr = err
 
-		if c.shouldNotRetry(err) {
+		if !isRetryable(err) {
 			break
 		}
 
-		if attempt < c.config.MaxRetries {
-			select {
-			case <-time.After(c.config.RetryDelay):
-			case <-ctx.Done():
-				return nil, ctx.Err()
+		if try < c.config.MaxRetries {
+			if err := c.sleep(ctx, c.backoff(try)); err != nil {
+				return zero, err
 			}
 		}
 	}
@@ -207,16 +386,134 @@ func (c *FastHTTPClient) Do(ctx context.Context, method, url string, body []byte
 	c.stats.failedRequests++
 	c.stats.mu.Unlock()
 
-	return nil, fmt.Errorf("failed after %d attempts: %w", c.config.MaxRetries+1, lastErr)
+	return zero, fmt.Errorf("failed after %d attempts: %w", c.config.MaxRetries+1, lastErr)
+}
+
+// backoff returns the wait before retrying after the given zero-based attempt
+func (c *FastHTTPClient) backoff(attempt int) time.Duration {
+	base := c.config.RetryBackoffBase
+	if base <= 0 {
+		base = c.config.RetryDelay
+	}
+	if base <= 0 {
+		return 0
+	}
+
+	delay := base
+	for range attempt {
+		delay *= 2
+		if c.config.RetryBackoffMax > 0 && delay >= c.config.RetryBackoffMax {
+			break
+		}
+	}
+
+	if c.config.RetryBackoffMax > 0 && delay > c.config.RetryBackoffMax {
+		delay = c.config.RetryBackoffMax
+	}
+
+	if c.config.RetryJitter {
+		delay = time.Duration(c.randInt63n(int64(delay) + 1))
+	}
+
+	return delay
+}
+
+// sleepContext waits for d or until ctx is done, returning the context error
+func (c *FastHTTPClient) sleepContext(ctx context.Context, d time.Duration) error {
+	select {
+	case <-c.clock.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-// performRequest executes a single HTTP request
+// performRequest executes a single HTTP request inside a tracing span
 func (c *FastHTTPClient) performRequest(ctx context.Context, method, url string, body []byte, headers map[string]string) (*Response, error) {
+	ctx, span := tracing.Start(ctx, "HTTP "+method, requestAttributes(method, url)...)
+	defer span.End()
+
+	response, err := c.sendRequest(ctx, method, url, body, headers)
+	if err != nil {
+		recordSpanResult(span, 0, err)
+		return nil, err
+	}
+
+	recordSpanResult(span, response.StatusCode, nil)
+	return response, nil
+}
+
+// recordSpanResult annotates span with the response status code, or with the
+// request error and the status code it carries, if any
+func recordSpanResult(span tracing.Span, statusCode int, err error) {
+	var httpErr *HTTPError
+	switch {
+	case errors.As(err, &httpErr):
+		span.SetAttributes(tracing.Int("http.response.status_code", httpErr.StatusCode))
+		span.RecordError(err)
+	case err != nil:
+		span.RecordError(err)
+	default:
+		span.SetAttributes(tracing.Int("http.response.status_code", statusCode))
+	}
+}
+
+// requestAttributes returns span attributes describing an outgoing request,
+// including the Alpha Vantage function and symbol query parameters
+func requestAttributes(method, rawURL string) []tracing.Attribute {
+	attrs := []tracing.Attribute{tracing.String("http.request.method", method)}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return attrs
+	}
+
+	attrs = append(attrs, tracing.String("server.address", parsed.Host))
+
+	query := parsed.Query()
+	if function := query.Get("function"); function != "" {
+		attrs = append(attrs, tracing.String("finance.function", function))
+	}
+	if symbol := query.Get("symbol"); symbol != "" {
+		attrs = append(attrs, tracing.String("finance.symbol", symbol))
+	}
+
+	return attrs
+}
+
+// sendRequest executes a single HTTP request
+func (c *FastHTTPClient) sendRequest(ctx context.Context, method, url string, body []byte, headers map[string]string) (*Response, error) {
 	req := fasthttp.AcquireRequest()
 	resp := fasthttp.AcquireResponse()
 	defer fasthttp.ReleaseRequest(req)
 	defer fasthttp.ReleaseResponse(resp)
 
+	c.prepareRequest(req, method, url, body, headers)
+
+	if err := c.client.DoTimeout(req, resp, requestTimeout(ctx, c.config.ReadTimeout)); err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	response, err := c.convertResponse(resp)
+	if err != nil {
+		return nil, fmt.Errorf("response conversion failed: %w", err)
+	}
+
+	if response.StatusCode >= fasthttp.StatusBadRequest {
+		return nil, &HTTPError{StatusCode: response.StatusCode, Body: response.Body}
+	}
+
+	return response, nil
+}
+
+// prepareRequest sets the URL, method, body and headers of req
+func (c *FastHTTPClient) prepareRequest(req *fasthttp.Request, method, url string, body []byte, headers map[string]string) {
 	req.SetRequestURI(url)
 	req.Header.SetMethod(method)
 	req.Header.SetUserAgent(c.config.UserAgent)
@@ -225,8 +522,11 @@ func (c *FastHTTPClient) performRequest(ctx context.Context, method, url string,
 		req.SetBody(body)
 	}
 
-	if c.config.EnableCompression {
-		req.Header.Set("Accept-Encoding", "gzip, deflate")
+	switch {
+	case c.config.AcceptEncoding != "":
+		req.Header.Set("Accept-Encoding", c.config.AcceptEncoding)
+	case c.config.EnableCompression:
+		req.Header.Set("Accept-Encoding", compressedAcceptEncoding)
 	}
 
 	if c.config.EnableKeepAlive {
@@ -238,36 +538,22 @@ func (c *FastHTTPClient) performRequest(ctx context.Context, method, url string,
 	for key, value := range headers {
 		req.Header.Set(key, value)
 	}
+}
 
-	timeout := c.config.ReadTimeout
+// requestTimeout returns the read timeout, shortened to the context deadline
+func requestTimeout(ctx context.Context, timeout time.Duration) time.Duration {
 	if deadline, ok := ctx.Deadline(); ok {
 		if remaining := time.Until(deadline); remaining < timeout {
 			timeout = remaining
 		}
 	}
 
-	if err := c.client.DoTimeout(req, resp, timeout); err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
-	}
-
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	default:
-	}
-
-	response, err := c.convertResponse(resp)
-	if err != nil {
-		return nil, fmt.Errorf("response conversion failed: %w", err)
-	}
-
-	return response, nil
+	return timeout
 }
 
 // convertResponse converts fasthttp.Response to our Response type with decompression
 func (c *FastHTTPClient) convertResponse(resp *fasthttp.Response) (*Response, error) {
-	headers := make(map[string]string)
-	resp.Header.All()
+	headers := responseHeaders(resp)
 
 	body, err := c.decompressBody(resp)
 	if err != nil {
@@ -281,79 +567,74 @@ func (c *FastHTTPClient) convertResponse(resp *fasthttp.Response) (*Response, er
 	}, nil
 }
 
-// decompressBody handles automatic decompression of response body
+// responseHeaders copies the response headers, joining repeated headers
+// such as Set-Cookie with ", "
+func responseHeaders(resp *fasthttp.Response) map[string]string {
+	headers := make(map[string]string)
+	for key, value := range resp.Header.All() {
+		name := string(key)
+		if existing, exists := headers[name]; exists {
+			headers[name] = existing + ", " + string(value)
+			continue
+		}
+		headers[name] = string(value)
+	}
+
+	return headers
+}
+
+// decompressBody handles automatic decompression of gzip, deflate and brotli
+// response bodies
 func (c *FastHTTPClient) decompressBody(resp *fasthttp.Response) ([]byte, error) {
 	contentEncoding := string(resp.Header.Peek("Content-Encoding"))
+	if contentEncoding == "" {
+		contentEncoding = sniffEncoding(resp.Body())
+	}
 
 	// Fast path: no compression (most common case for Alpha Vantage)
 	// Avoid unnecessary copy by directly using the response body
-	if contentEncoding == "" {
+	if contentEncoding == "" || contentEncoding == "identity" {
 		bodyBytes := make([]byte, len(resp.Body()))
 		copy(bodyBytes, resp.Body())
 		return bodyBytes, nil
 	}
 
 	// For compressed responses, we need to decompress
-	bodyBytes := resp.Body()
-
-	switch contentEncoding {
-	case "gzip":
-		reader, err := gzip.NewReader(bytes.NewReader(bodyBytes))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
-		}
-		defer reader.Close()
-
-		decompressed, err := io.ReadAll(reader)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decompress gzip content: %w", err)
-		}
-
-		return decompressed, nil
-
-	case "deflate":
-		reader := flate.NewReader(bytes.NewReader(bodyBytes))
-		defer reader.Close()
-
-		decompressed, err := io.ReadAll(reader)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decompress deflate content: %w", err)
-		}
-
-		return decompressed, nil
+	reader, err := decompressStream(contentEncoding, bytes.NewReader(resp.Body()))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
 
-	default:
-		return nil, fmt.Errorf("unsupported compression type: %s", contentEncoding)
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s content: %w", contentEncoding, err)
 	}
-}
 
-// shouldNotRetry determines if an error should not trigger a retry
-func (c *FastHTTPClient) shouldNotRetry(err error) bool {
-	errStr := strings.ToLower(err.Error())
+	return decompressed, nil
+}
 
-	nonRetryableErrors := []string{
-		"rate limit",
-		"authentication",
-		"authorization",
-		"invalid",
-		"bad request",
-		"not found",
-		"forbidden",
-	}
+// gzipMagic starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
 
-	for _, nonRetryable := range nonRetryableErrors {
-		if strings.Contains(errStr, nonRetryable) {
-			return true
-		}
+// sniffEncoding detects a gzip body sent without Content-Encoding, as some
+// proxies do after re-encoding a response. JSON and CSV payloads never start
+// with the gzip magic bytes; other encodings have no reliable signature.
+func sniffEncoding(prefix []byte) string {
+	if bytes.HasPrefix(prefix, gzipMagic) {
+		return "gzip"
 	}
 
-	return false
+	return ""
 }
 
-// Close cleans up client resources
+// Close closes idle pooled connections and makes further requests fail with
+// ErrClientClosed. Requests already in flight are allowed to finish. Calling
+// Close more than once is safe.
 func (c *FastHTTPClient) Close() error {
-	// FastHTTP client doesn't have explicit close method
-	// Resources are cleaned up automatically
+	c.closed.Store(true)
+	c.client.CloseIdleConnections()
+	c.streamClient.CloseIdleConnections()
 	return nil
 }
 
@@ -367,13 +648,21 @@ func (c *FastHTTPClient) Stats() ClientStats {
 		avgLatency = c.stats.totalLatency / time.Duration(c.stats.successfulRequests)
 	}
 
+	circuitState := CircuitClosed
+	if c.breaker != nil {
+		circuitState = c.breaker.State()
+	}
+
 	return ClientStats{
 		TotalRequests:      c.stats.totalRequests,
 		SuccessfulRequests: c.stats.successfulRequests,
 		FailedRequests:     c.stats.failedRequests,
 		AverageLatency:     avgLatency,
-		ConnectionsActive:  0,
-		ConnectionsTotal:   0,
+		P50Latency:         c.stats.latencies.quantile(0.50),
+		P95Latency:         c.stats.latencies.quantile(0.95),
+		ConnectionsActive:  int(c.stats.connectionsActive.Load()),
+		ConnectionsTotal:   c.stats.connectionsTotal.Load(),
+		CircuitState:       circuitState,
 	}
 }
 
@@ -414,12 +703,39 @@ func (b *URLBuilder) Build() (string, error) {
 	return parsedURL.String(), nil
 }
 
-// MockClient implements HTTPClient for testing purposes
+// MockClient implements HTTPClient for testing purposes.
+//
+// Responses are looked up by exact URL first (errors, then sequences, then
+// single responses), then by the matchers registered with SetResponseFunc,
+// SetResponsePrefix and SetResponseContains in registration order. Unmatched
+// URLs receive a generic 200 response. Every call is recorded and can be
+// inspected with GetRequests.
 type MockClient struct {
 	responses map[string]*Response
 	errors    map[string]error
+	sequences map[string][]*Response
+	matchers  []responseMatcher
+	requests  []MockRequest
 	callCount map[string]int
 	mu        sync.RWMutex
+
+	// closeErr is returned by Close and closeCount counts its calls
+	closeErr   error
+	closeCount int
+}
+
+// responseMatcher is a response returned for every URL accepted by match
+type responseMatcher struct {
+	match    func(url string) bool
+	response *Response
+}
+
+// MockRequest is a request received by a MockClient
+type MockRequest struct {
+	Method  string
+	URL     string
+	Headers map[string]string
+	Body    []byte
 }
 
 // NewMockClient creates a new mock client for testing
@@ -427,6 +743,7 @@ func NewMockClient() *MockClient {
 	return &MockClient{
 		responses: make(map[string]*Response),
 		errors:    make(map[string]error),
+		sequences: make(map[string][]*Response),
 		callCount: make(map[string]int),
 	}
 }
@@ -438,6 +755,34 @@ func (m *MockClient) SetResponse(url string, response *Response) {
 	m.responses[url] = response
 }
 
+// SetResponseSequence configures the mock to return responses for a URL in
+// order, one per call, repeating the last response once the sequence is
+// exhausted. This lets tests script e.g. 500, 500, 200 for retry handling.
+func (m *MockClient) SetResponseSequence(url string, responses []*Response) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sequences[url] = append([]*Response(nil), responses...)
+}
+
+// SetResponseFunc configures the mock to return response for every URL that
+// matcher accepts and that has no exact-match response or error.
+func (m *MockClient) SetResponseFunc(matcher func(url string) bool, response *Response) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matchers = append(m.matchers, responseMatcher{match: matcher, response: response})
+}
+
+// SetResponsePrefix configures the mock to return response for URLs starting with prefix
+func (m *MockClient) SetResponsePrefix(prefix string, response *Response) {
+	m.SetResponseFunc(func(url string) bool { return strings.HasPrefix(url, prefix) }, response)
+}
+
+// SetResponseContains configures the mock to return response for URLs
+// containing substr, e.g. "function=OVERVIEW"
+func (m *MockClient) SetResponseContains(substr string, response *Response) {
+	m.SetResponseFunc(func(url string) bool { return strings.Contains(url, substr) }, response)
+}
+
 // SetError configures the mock to return an error for a URL
 func (m *MockClient) SetError(url string, err error) {
 	m.mu.Lock()
@@ -445,6 +790,20 @@ func (m *MockClient) SetError(url string, err error) {
 	m.errors[url] = err
 }
 
+// SetCloseError configures the mock to return err from Close
+func (m *MockClient) SetCloseError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closeErr = err
+}
+
+// CloseCount returns how many times Close was called
+func (m *MockClient) CloseCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.closeCount
+}
+
 // GetCallCount returns how many times a URL was called
 func (m *MockClient) GetCallCount(url string) int {
 	m.mu.RLock()
@@ -452,6 +811,27 @@ func (m *MockClient) GetCallCount(url string) int {
 	return m.callCount[url]
 }
 
+// GetRequests returns the requests made to url in call order
+func (m *MockClient) GetRequests(url string) []MockRequest {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var requests []MockRequest
+	for _, request := range m.requests {
+		if request.URL == url {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// Requests returns every request made to the mock in call order
+func (m *MockClient) Requests() []MockRequest {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]MockRequest(nil), m.requests...)
+}
+
 // Get implements HTTPClient interface
 func (m *MockClient) Get(ctx context.Context, url string, headers map[string]string) (*Response, error) {
 	return m.Do(ctx, "GET", url, nil, headers)
@@ -462,23 +842,46 @@ func (m *MockClient) Post(ctx context.Context, url string, body []byte, headers
 	return m.Do(ctx, "POST", url, body, headers)
 }
 
-// Do implements HTTPClient interface
+// Do implements HTTPClient interface. Like a real client, it fails with the
+// context error without recording the request once ctx is done.
 func (m *MockClient) Do(ctx context.Context, method, url string, body []byte, headers map[string]string) (*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	m.mu.Lock()
-	m.callCount[url]++
-	m.mu.Unlock()
+	defer m.mu.Unlock()
 
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	m.callCount[url]++
+	m.requests = append(m.requests, MockRequest{
+		Method:  method,
+		URL:     url,
+		Headers: maps.Clone(headers),
+		Body:    bytes.Clone(body),
+	})
 
 	if err, exists := m.errors[url]; exists {
 		return nil, err
 	}
 
+	if sequence := m.sequences[url]; len(sequence) > 0 {
+		response := sequence[0]
+		if len(sequence) > 1 {
+			m.sequences[url] = sequence[1:]
+		}
+		return response, nil
+	}
+
 	if response, exists := m.responses[url]; exists {
 		return response, nil
 	}
 
+	for _, matcher := range m.matchers {
+		if matcher.match(url) {
+			return matcher.response, nil
+		}
+	}
+
 	return &Response{
 		StatusCode: 200,
 		Headers:    make(map[string]string),
@@ -486,9 +889,27 @@ func (m *MockClient) Do(ctx context.Context, method, url string, body []byte, he
 	}, nil
 }
 
+// DoStream implements HTTPClient interface, streaming the response Do
+// would return
+func (m *MockClient) DoStream(ctx context.Context, method, url string, body []byte, headers map[string]string) (*StreamResponse, error) {
+	response, err := m.Do(ctx, method, url, body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamResponse{
+		StatusCode: response.StatusCode,
+		Headers:    response.Headers,
+		Body:       io.NopCloser(bytes.NewReader(response.Body)),
+	}, nil
+}
+
 // Close implements HTTPClient interface
 func (m *MockClient) Close() error {
-	return nil
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closeCount++
+	return m.closeErr
 }
 
 // Stats implements HTTPClient interface
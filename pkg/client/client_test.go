@@ -2,8 +2,10 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -144,6 +146,234 @@ func TestMockClient_Errors(t *testing.T) {
 	}
 }
 
+func TestMockClient_ContextCancellation(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetResponse("https://example.com", &Response{StatusCode: 200, Body: []byte(`{"ok": true}`)})
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	testCases := []struct {
+		name string
+		ctx  context.Context
+		want error
+	}{
+		{"canceled", canceled, context.Canceled},
+		{"deadline exceeded", expired, context.DeadlineExceeded},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := mock.Get(tc.ctx, "https://example.com", nil)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Expected %v, got %v", tc.want, err)
+			}
+			if resp != nil {
+				t.Error("Expected nil response for a done context")
+			}
+
+			if _, err := mock.DoStream(tc.ctx, "GET", "https://example.com", nil, nil); !errors.Is(err, tc.want) {
+				t.Errorf("Expected DoStream to fail with %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	if count := mock.GetCallCount("https://example.com"); count != 0 {
+		t.Errorf("Expected requests with a done context not to be recorded, got %d", count)
+	}
+
+	if _, err := mock.Get(context.Background(), "https://example.com", nil); err != nil {
+		t.Errorf("Expected a live context to get the configured response, got %v", err)
+	}
+}
+
+func TestMockClient_Matchers(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockClient()
+
+	prefixResp := &Response{StatusCode: 200, Body: []byte(`{"match": "prefix"}`)}
+	containsResp := &Response{StatusCode: 200, Body: []byte(`{"match": "contains"}`)}
+	funcResp := &Response{StatusCode: 200, Body: []byte(`{"match": "func"}`)}
+
+	mock.SetResponsePrefix("https://api.example.com/query", prefixResp)
+	mock.SetResponseContains("function=OVERVIEW", containsResp)
+	mock.SetResponseFunc(func(url string) bool {
+		return len(url) > 0
+	}, funcResp)
+
+	tests := []struct {
+		name string
+		url  string
+		want string
+	}{
+		{"prefix", "https://api.example.com/query?function=OVERVIEW", `{"match": "prefix"}`},
+		{"contains", "https://other.example.com/query?function=OVERVIEW&symbol=AAPL", `{"match": "contains"}`},
+		{"func", "https://other.example.com/anything", `{"match": "func"}`},
+	}
+
+	for _, tt := range tests {
+		resp, err := mock.Get(ctx, tt.url, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if string(resp.Body) != tt.want {
+			t.Errorf("%s: expected body %s, got %s", tt.name, tt.want, string(resp.Body))
+		}
+	}
+}
+
+func TestMockClient_ExactMatchPrecedence(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockClient()
+
+	exactURL := "https://api.example.com/query?function=OVERVIEW&symbol=AAPL"
+	errorURL := "https://api.example.com/query?function=OVERVIEW&symbol=FAIL"
+
+	mock.SetResponseContains("function=OVERVIEW", &Response{StatusCode: 200, Body: []byte(`{"match": "contains"}`)})
+	mock.SetResponse(exactURL, &Response{StatusCode: 200, Body: []byte(`{"match": "exact"}`)})
+	mock.SetError(errorURL, fmt.Errorf("exact error"))
+
+	resp, err := mock.Get(ctx, exactURL, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(resp.Body) != `{"match": "exact"}` {
+		t.Errorf("Expected exact match to win, got %s", string(resp.Body))
+	}
+
+	if _, err := mock.Get(ctx, errorURL, nil); err == nil || err.Error() != "exact error" {
+		t.Errorf("Expected exact error to win, got %v", err)
+	}
+
+	resp, err = mock.Get(ctx, "https://api.example.com/query?function=OVERVIEW&symbol=MSFT", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(resp.Body) != `{"match": "contains"}` {
+		t.Errorf("Expected matcher response, got %s", string(resp.Body))
+	}
+
+	// Unmatched URLs still receive the default response
+	resp, err = mock.Get(ctx, "https://api.example.com/other", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(resp.Body) != `{"status": "mock"}` {
+		t.Errorf("Expected default response, got %s", string(resp.Body))
+	}
+}
+
+func TestMockClient_ResponseSequence(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockClient()
+
+	testURL := "https://api.example.com/flaky"
+	mock.SetResponseSequence(testURL, []*Response{
+		{StatusCode: 500, Body: []byte(`{"error": "first"}`)},
+		{StatusCode: 503, Body: []byte(`{"error": "second"}`)},
+		{StatusCode: 200, Body: []byte(`{"status": "ok"}`)},
+	})
+
+	expected := []int{500, 503, 200, 200}
+	for i, want := range expected {
+		resp, err := mock.Get(ctx, testURL, nil)
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i+1, err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("call %d: expected status %d, got %d", i+1, want, resp.StatusCode)
+		}
+	}
+
+	if mock.GetCallCount(testURL) != len(expected) {
+		t.Errorf("Expected call count %d, got %d", len(expected), mock.GetCallCount(testURL))
+	}
+}
+
+func TestMockClient_ResponseSequenceConcurrent(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockClient()
+
+	testURL := "https://api.example.com/sequence"
+	const calls = 50
+	responses := make([]*Response, calls)
+	for i := range responses {
+		responses[i] = &Response{StatusCode: 200 + i}
+	}
+	mock.SetResponseSequence(testURL, responses)
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		seen = make(map[int]bool)
+	)
+	for range calls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := mock.Get(ctx, testURL, nil)
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+			mu.Lock()
+			seen[resp.StatusCode] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// Every response is handed out exactly once
+	if len(seen) != calls {
+		t.Errorf("Expected %d distinct responses, got %d", calls, len(seen))
+	}
+}
+
+func TestMockClient_RecordsRequests(t *testing.T) {
+	ctx := context.Background()
+	mock := NewMockClient()
+
+	testURL := "https://api.example.com/test"
+	headers := map[string]string{"Accept": "application/json"}
+
+	if _, err := mock.Get(ctx, testURL, headers); err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	if _, err := mock.Post(ctx, testURL, []byte(`{"key": "value"}`), nil); err != nil {
+		t.Fatalf("POST failed: %v", err)
+	}
+	if _, err := mock.Get(ctx, "https://api.example.com/other", nil); err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+
+	// Mutating the caller's headers must not change the recorded request
+	headers["Accept"] = "text/plain"
+
+	requests := mock.GetRequests(testURL)
+	if len(requests) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requests))
+	}
+
+	if requests[0].Method != "GET" || requests[0].Headers["Accept"] != "application/json" {
+		t.Errorf("Unexpected first request: %+v", requests[0])
+	}
+
+	if requests[1].Method != "POST" || string(requests[1].Body) != `{"key": "value"}` {
+		t.Errorf("Unexpected second request: %+v", requests[1])
+	}
+
+	if len(mock.Requests()) != 3 {
+		t.Errorf("Expected 3 recorded requests, got %d", len(mock.Requests()))
+	}
+
+	if len(mock.GetRequests("https://api.example.com/missing")) != 0 {
+		t.Error("Expected no requests for an uncalled URL")
+	}
+}
+
 func TestMockClient_AllMethods(t *testing.T) {
 	ctx := context.Background()
 	mock := NewMockClient()
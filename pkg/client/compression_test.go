@@ -0,0 +1,232 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/valyala/fasthttp"
+)
+
+// brotliCompress returns the brotli encoding of body
+func brotliCompress(t *testing.T, body string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := brotli.NewWriter(&buf)
+	if _, err := writer.Write([]byte(body)); err != nil {
+		t.Fatalf("Failed to compress body: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress body: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestFastHTTPClient_Brotli(t *testing.T) {
+	const body = `{"Symbol": "IBM", "Name": "International Business Machines"}`
+	compressed := brotliCompress(t, body)
+
+	var acceptEncoding string
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		acceptEncoding = string(ctx.Request.Header.Peek("Accept-Encoding"))
+		ctx.Response.Header.Set("Content-Encoding", "br")
+		ctx.SetBody(compressed)
+	})
+
+	client := NewFastHTTPClient(DefaultConfig())
+	defer client.Close()
+
+	response, err := client.Get(context.Background(), url, nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if string(response.Body) != body {
+		t.Errorf("Expected decompressed body %q, got %q", body, response.Body)
+	}
+	if !strings.Contains(acceptEncoding, "br") {
+		t.Errorf("Expected Accept-Encoding to advertise br, got %q", acceptEncoding)
+	}
+
+	stream, err := client.DoStream(context.Background(), "GET", url, nil, nil)
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	defer stream.Body.Close()
+
+	got, err := io.ReadAll(stream.Body)
+	if err != nil {
+		t.Fatalf("Failed to read stream: %v", err)
+	}
+	if string(got) != body {
+		t.Errorf("Expected streamed body %q, got %q", body, got)
+	}
+}
+
+func TestFastHTTPClient_CompressionDisabled(t *testing.T) {
+	var acceptEncoding string
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		acceptEncoding = string(ctx.Request.Header.Peek("Accept-Encoding"))
+		ctx.SetBodyString(`{}`)
+	})
+
+	config := DefaultConfig()
+	config.EnableCompression = false
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	if _, err := client.Get(context.Background(), url, nil); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if acceptEncoding != "" {
+		t.Errorf("Expected no Accept-Encoding header, got %q", acceptEncoding)
+	}
+}
+
+func TestFastHTTPClient_UnknownEncoding(t *testing.T) {
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Set("Content-Encoding", "zstd")
+		ctx.SetBodyString("not really zstd")
+	})
+
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	_, err := client.Get(context.Background(), url, nil)
+	if err == nil {
+		t.Fatal("Expected an error for an unsupported encoding")
+	}
+	if !strings.Contains(err.Error(), "unsupported compression type: zstd") {
+		t.Errorf("Expected unsupported compression error, got %v", err)
+	}
+}
+
+// gzipCompress returns the gzip encoding of body
+func gzipCompress(t *testing.T, body string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(body)); err != nil {
+		t.Fatalf("Failed to compress body: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress body: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestFastHTTPClient_AcceptEncodingOverride(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptEncoding string
+		compression    bool
+		want           string
+	}{
+		{name: "default", compression: true, want: "gzip, deflate, br"},
+		{name: "identity", acceptEncoding: "identity", compression: true, want: "identity"},
+		{name: "custom", acceptEncoding: "gzip;q=1.0, br;q=0.5", compression: true, want: "gzip;q=1.0, br;q=0.5"},
+		{name: "custom without compression", acceptEncoding: "gzip", want: "gzip"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var acceptEncoding string
+			url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+				acceptEncoding = string(ctx.Request.Header.Peek("Accept-Encoding"))
+				ctx.SetBodyString(`{"status": "ok"}`)
+			})
+
+			config := DefaultConfig()
+			config.EnableCompression = tc.compression
+			config.AcceptEncoding = tc.acceptEncoding
+			client := NewFastHTTPClient(config)
+			defer client.Close()
+
+			response, err := client.Get(context.Background(), url, nil)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if acceptEncoding != tc.want {
+				t.Errorf("Expected Accept-Encoding %q, got %q", tc.want, acceptEncoding)
+			}
+			if string(response.Body) != `{"status": "ok"}` {
+				t.Errorf("Unexpected body %q", response.Body)
+			}
+		})
+	}
+}
+
+func TestFastHTTPClient_IdentityResponses(t *testing.T) {
+	const body = `{"Symbol": "IBM"}`
+
+	testCases := []struct {
+		name            string
+		contentEncoding string
+		payload         []byte
+	}{
+		{name: "no content encoding", payload: []byte(body)},
+		{name: "explicit identity", contentEncoding: "identity", payload: []byte(body)},
+		{name: "unlabelled gzip", payload: gzipCompress(t, body)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+				if tc.contentEncoding != "" {
+					ctx.Response.Header.Set("Content-Encoding", tc.contentEncoding)
+				}
+				ctx.SetBody(tc.payload)
+			})
+
+			config := DefaultConfig()
+			config.AcceptEncoding = "identity"
+			client := NewFastHTTPClient(config)
+			defer client.Close()
+
+			response, err := client.Get(context.Background(), url, nil)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			if string(response.Body) != body {
+				t.Errorf("Expected body %q, got %q", body, response.Body)
+			}
+
+			stream, err := client.DoStream(context.Background(), "GET", url, nil, nil)
+			if err != nil {
+				t.Fatalf("DoStream failed: %v", err)
+			}
+			defer stream.Body.Close()
+
+			got, err := io.ReadAll(stream.Body)
+			if err != nil {
+				t.Fatalf("Failed to read stream: %v", err)
+			}
+			if string(got) != body {
+				t.Errorf("Expected streamed body %q, got %q", body, got)
+			}
+		})
+	}
+}
+
+func TestSetDefaultAcceptEncoding(t *testing.T) {
+	defer SetDefaultAcceptEncoding("")
+
+	SetDefaultAcceptEncoding("identity")
+	if got := DefaultConfig().AcceptEncoding; got != "identity" {
+		t.Errorf("Expected DefaultConfig AcceptEncoding identity, got %q", got)
+	}
+
+	SetDefaultAcceptEncoding("")
+	if got := DefaultConfig().AcceptEncoding; got != "" {
+		t.Errorf("Expected empty DefaultConfig AcceptEncoding, got %q", got)
+	}
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// requestSlots is a semaphore bounding the requests a client has in flight.
+// A nil requestSlots places no bound.
+type requestSlots chan struct{}
+
+// newRequestSlots creates slots for n concurrent requests, or nil when n is
+// not positive
+func newRequestSlots(n int) requestSlots {
+	if n <= 0 {
+		return nil
+	}
+
+	return make(requestSlots, n)
+}
+
+// acquire blocks until a slot is free or ctx is done
+func (s requestSlots) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for a request slot: %w", ctx.Err())
+	}
+}
+
+// release frees a slot taken by acquire
+func (s requestSlots) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// slotBody is a streamed response body that holds a request slot until it
+// is closed, since the body is read from a connection still in use
+type slotBody struct {
+	io.ReadCloser
+	release func()
+}
+
+// Close implements io.Closer, releasing the slot once
+func (b *slotBody) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// withSlot makes response hold the slot acquired for it until its body is
+// closed
+func (s requestSlots) withSlot(response *StreamResponse) *StreamResponse {
+	if s != nil {
+		response.Body = &slotBody{ReadCloser: response.Body, release: sync.OnceFunc(s.release)}
+	}
+
+	return response
+}
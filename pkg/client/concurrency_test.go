@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFastHTTPClient_MaxConcurrentRequests(t *testing.T) {
+	const limit = 3
+
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxConcurrentRequests = limit
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4*limit)
+	for range 4 * limit {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get(context.Background(), server.URL, nil); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Request failed: %v", err)
+	}
+
+	if got := peak.Load(); got > limit {
+		t.Errorf("Expected at most %d requests in flight, got %d", limit, got)
+	}
+	if got := client.Stats().TotalRequests; got != 4*limit {
+		t.Errorf("Expected %d requests, got %d", 4*limit, got)
+	}
+}
+
+func TestFastHTTPClient_MaxConcurrentRequestsWaitHonorsContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	config := DefaultConfig()
+	config.MaxConcurrentRequests = 1
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		client.Get(context.Background(), server.URL, nil)
+	}()
+	<-started
+
+	// Give the first request time to take the only slot
+	time.Sleep(50 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Get(ctx, server.URL, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the wait for a slot to end with the context, got %v", err)
+	}
+	if got := client.Stats().TotalRequests; got != 1 {
+		t.Errorf("Expected only the first request to be sent, got %d", got)
+	}
+}
+
+func TestFastHTTPClient_MaxConcurrentRequestsHeldByStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status": "ok"}`))
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxConcurrentRequests = 1
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	response, err := client.DoStream(context.Background(), "GET", server.URL, nil, nil)
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Get(ctx, server.URL, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected an open stream to hold the slot, got %v", err)
+	}
+
+	// Closing twice releases the slot once
+	response.Body.Close()
+	response.Body.Close()
+
+	if _, err := client.Get(context.Background(), server.URL, nil); err != nil {
+		t.Fatalf("Expected the slot to be free once the stream is closed, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrOperationTimeout is the cause to give, with context.WithTimeoutCause,
+// to the deadline of a client's own operation timeout. Requests that fail
+// once that deadline passes count as upstream failures, unlike requests cut
+// short by the caller's own deadline.
+var ErrOperationTimeout = errors.New("operation timed out")
+
+// HTTPError is returned by FastHTTPClient when the server responds with a
+// 4xx or 5xx status code.
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// isRetryable reports whether a failed attempt should be retried.
+//
+// Client errors (4xx) are never retried except 429 Too Many Requests, which
+// is retried after the backoff delay. Server errors (5xx) and network errors
+// are always retried; context cancellation never is.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		if httpErr.StatusCode == http.StatusTooManyRequests {
+			return true
+		}
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// isUpstreamFailure reports whether an error of a request made on ctx
+// indicates the upstream service is unhealthy, as opposed to a problem with
+// the request itself. Only upstream failures count towards opening the
+// circuit breaker.
+//
+// Once ctx is done the request failed because the caller gave up or its
+// deadline passed, which says nothing about the upstream service, unless
+// the deadline is an operation timeout marked with ErrOperationTimeout.
+func isUpstreamFailure(ctx context.Context, err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	// Request timeouts are cut to the deadline of ctx, so a request may time
+	// out just before ctx reports its deadline passed
+	if deadline, ok := ctx.Deadline(); ok && !time.Now().Before(deadline) {
+		<-ctx.Done()
+	}
+
+	if ctx.Err() != nil {
+		return errors.Is(context.Cause(ctx), ErrOperationTimeout)
+	}
+
+	return !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFastHTTPClient_RetryByStatus(t *testing.T) {
+	testCases := []struct {
+		status       int
+		wantAttempts int32
+	}{
+		{status: http.StatusBadRequest, wantAttempts: 1},
+		{status: http.StatusUnauthorized, wantAttempts: 1},
+		{status: http.StatusTooManyRequests, wantAttempts: 3},
+		{status: http.StatusInternalServerError, wantAttempts: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			config := DefaultConfig()
+			config.MaxRetries = 2
+			config.RetryBackoffBase = time.Millisecond
+			config.CircuitMaxFailures = 0
+			client := NewFastHTTPClient(config)
+
+			_, err := client.Get(context.Background(), server.URL, nil)
+
+			var httpErr *HTTPError
+			if !errors.As(err, &httpErr) {
+				t.Fatalf("Expected HTTPError, got %v", err)
+			}
+			if httpErr.StatusCode != tc.status {
+				t.Errorf("Expected status %d, got %d", tc.status, httpErr.StatusCode)
+			}
+			if got := attempts.Load(); got != tc.wantAttempts {
+				t.Errorf("Expected %d attempts, got %d", tc.wantAttempts, got)
+			}
+		})
+	}
+}
+
+func TestFastHTTPClient_RetriesDialErrors(t *testing.T) {
+	client, sleeps := newBackoffTestClient(false)
+	client.config.MaxRetries = 2
+
+	_, err := client.Get(context.Background(), unreachableURL, nil)
+	if err == nil {
+		t.Fatal("Expected dial error")
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		t.Errorf("Expected a network error, got %v", err)
+	}
+	if len(*sleeps) != 2 {
+		t.Errorf("Expected dial errors to be retried twice, got %d retries", len(*sleeps))
+	}
+}
+
+func TestCircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.CircuitMaxFailures = 1
+	client := NewFastHTTPClient(config)
+
+	for range 2 {
+		if _, err := client.Get(context.Background(), server.URL, nil); errors.Is(err, ErrCircuitOpen) {
+			t.Fatal("Expected 4xx responses not to open the circuit")
+		}
+	}
+}
+
+func TestFastHTTPClient_Close(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	client := NewFastHTTPClient(config)
+
+	if _, err := client.Get(context.Background(), server.URL, nil); err != nil {
+		t.Fatalf("Expected request before Close to succeed, got %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	_, err := client.Do(context.Background(), "GET", server.URL, nil, nil)
+	if !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected ErrClientClosed after Close, got %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Errorf("Expected second Close to succeed, got %v", err)
+	}
+
+	// Rejected requests are not counted against the circuit breaker
+	if state := client.Stats().CircuitState; state != CircuitClosed {
+		t.Errorf("Expected closed circuit, got %s", state)
+	}
+}
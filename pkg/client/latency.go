@@ -0,0 +1,74 @@
+package client
+
+import (
+	"math"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the latency histogram buckets.
+// Observations above the last bound fall into an overflow bucket.
+var latencyBuckets = [...]time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// latencyHistogram is a fixed-bucket histogram of request latencies. It uses
+// constant memory regardless of the number of requests, at the cost of
+// reporting quantiles at bucket resolution. It is not safe for concurrent
+// use; clientStats guards it with its mutex.
+type latencyHistogram struct {
+	counts [len(latencyBuckets) + 1]int64
+	total  int64
+	max    time.Duration
+}
+
+// observe records a single latency
+func (h *latencyHistogram) observe(latency time.Duration) {
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+
+	h.counts[bucket]++
+	h.total++
+	h.max = max(h.max, latency)
+}
+
+// quantile returns an upper estimate of the q-th quantile (0 < q <= 1): the
+// bound of the bucket holding that observation, capped at the largest
+// latency seen. It returns zero when nothing has been observed.
+func (h *latencyHistogram) quantile(q float64) time.Duration {
+	if h.total == 0 {
+		return 0
+	}
+
+	// Rank of the observation at the quantile, rounded up
+	rank := max(int64(math.Ceil(q*float64(h.total))), 1)
+
+	var seen int64
+	for i, count := range h.counts {
+		seen += count
+		if seen < rank {
+			continue
+		}
+		if i == len(latencyBuckets) {
+			return h.max
+		}
+		return min(latencyBuckets[i], h.max)
+	}
+
+	return h.max
+}
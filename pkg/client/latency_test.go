@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLatencyHistogram_Quantile(t *testing.T) {
+	var h latencyHistogram
+
+	if got := h.quantile(0.5); got != 0 {
+		t.Errorf("Expected 0 for an empty histogram, got %v", got)
+	}
+
+	// 90 fast requests and 10 slow ones
+	for range 90 {
+		h.observe(3 * time.Millisecond)
+	}
+	for range 10 {
+		h.observe(400 * time.Millisecond)
+	}
+
+	// Quantiles are reported at bucket resolution
+	if got := h.quantile(0.50); got != 5*time.Millisecond {
+		t.Errorf("Expected p50 of 5ms, got %v", got)
+	}
+	// The 500ms bucket is capped at the largest latency seen
+	if got := h.quantile(0.95); got != 400*time.Millisecond {
+		t.Errorf("Expected p95 of 400ms, got %v", got)
+	}
+}
+
+func TestLatencyHistogram_Overflow(t *testing.T) {
+	var h latencyHistogram
+	h.observe(time.Millisecond)
+	h.observe(45 * time.Second)
+
+	if got := h.quantile(0.95); got != 45*time.Second {
+		t.Errorf("Expected overflow bucket to report the max latency, got %v", got)
+	}
+	if got := h.quantile(0.50); got != 5*time.Millisecond {
+		t.Errorf("Expected p50 at the first bucket bound, got %v", got)
+	}
+}
+
+func TestFastHTTPClient_ConnectionStats(t *testing.T) {
+	const concurrency = 5
+
+	arrived := make(chan struct{}, concurrency)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	config.CircuitMaxFailures = 0
+	client := NewFastHTTPClient(config)
+
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Get(context.Background(), server.URL, nil); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+
+	for range concurrency {
+		<-arrived
+	}
+
+	stats := client.Stats()
+	if stats.ConnectionsActive != concurrency {
+		t.Errorf("Expected %d active connections, got %d", concurrency, stats.ConnectionsActive)
+	}
+	if stats.ConnectionsTotal != concurrency {
+		t.Errorf("Expected %d total connections, got %d", concurrency, stats.ConnectionsTotal)
+	}
+
+	close(release)
+	wg.Wait()
+
+	stats = client.Stats()
+	if stats.ConnectionsActive != 0 {
+		t.Errorf("Expected no active connections after completion, got %d", stats.ConnectionsActive)
+	}
+	if stats.ConnectionsTotal != concurrency {
+		t.Errorf("Expected total connections to stay at %d, got %d", concurrency, stats.ConnectionsTotal)
+	}
+	if stats.P50Latency <= 0 || stats.P95Latency < stats.P50Latency {
+		t.Errorf("Expected positive ordered percentiles, got p50=%v p95=%v", stats.P50Latency, stats.P95Latency)
+	}
+}
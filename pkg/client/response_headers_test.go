@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestFastHTTPClient_ResponseHeaders(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	server := &fasthttp.Server{
+		Handler: func(ctx *fasthttp.RequestCtx) {
+			ctx.SetContentType("application/json")
+			ctx.Response.Header.Set("Retry-After", "60")
+			ctx.Response.Header.Set("X-RateLimit-Remaining", "4")
+			ctx.Response.Header.Add("Vary", "Accept")
+			ctx.Response.Header.Add("Vary", "Origin")
+			ctx.SetBodyString(`{"status": "ok"}`)
+		},
+	}
+	go server.Serve(listener)
+	defer server.Shutdown()
+
+	client := NewFastHTTPClient(DefaultConfig())
+	response, err := client.Get(context.Background(), "http://"+listener.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"Content-Type":          "application/json",
+		"Retry-After":           "60",
+		"X-Ratelimit-Remaining": "4",
+		"Vary":                  "Accept, Origin",
+	}
+	for name, value := range expected {
+		if got := response.Headers[name]; got != value {
+			t.Errorf("Expected header %s to be %q, got %q", name, value, got)
+		}
+	}
+}
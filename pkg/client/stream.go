@@ -0,0 +1,247 @@
+package client
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/valyala/fasthttp"
+	"github.com/yeferson59/finance-mcp/pkg/tracing"
+)
+
+// streamBufferSize is the largest response body DoStream reads in one go;
+// larger bodies are streamed from the connection as the caller reads them
+const streamBufferSize = 64 * 1024
+
+// maxStreamErrorBodySize bounds how much of an error response body DoStream
+// reads into the returned HTTPError
+const maxStreamErrorBodySize = 64 * 1024
+
+// StreamResponse represents an HTTP response whose body is read as a stream.
+// The caller must close Body to release the underlying connection.
+type StreamResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       io.ReadCloser
+}
+
+// DoStream performs a request like Do but hands the body to the caller as a
+// stream, decompressing gzip, deflate and brotli encodings on the fly. Bodies are
+// still bounded by MaxResponseBodySize, which is enforced while reading.
+//
+// Retries and the circuit breaker only cover getting the response headers;
+// errors while reading the body are returned from Body.Read. A request slot
+// of MaxConcurrentRequests is held until Body is closed.
+func (c *FastHTTPClient) DoStream(ctx context.Context, method, url string, body []byte, headers map[string]string) (*StreamResponse, error) {
+	if err := c.slots.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	response, err := execute(c, ctx, func(ctx context.Context) (*StreamResponse, error) {
+		return c.performStreamRequest(ctx, method, url, body, headers)
+	})
+	if err != nil {
+		c.slots.release()
+		return nil, err
+	}
+
+	return c.slots.withSlot(response), nil
+}
+
+// performStreamRequest executes a single streaming HTTP request inside a
+// tracing span. The span ends once the headers are received.
+func (c *FastHTTPClient) performStreamRequest(ctx context.Context, method, url string, body []byte, headers map[string]string) (*StreamResponse, error) {
+	ctx, span := tracing.Start(ctx, "HTTP "+method, requestAttributes(method, url)...)
+	defer span.End()
+
+	response, err := c.sendStreamRequest(ctx, method, url, body, headers)
+	if err != nil {
+		recordSpanResult(span, 0, err)
+		return nil, err
+	}
+
+	recordSpanResult(span, response.StatusCode, nil)
+	return response, nil
+}
+
+// sendStreamRequest executes a single HTTP request, leaving the response
+// body on the connection
+func (c *FastHTTPClient) sendStreamRequest(ctx context.Context, method, url string, body []byte, headers map[string]string) (*StreamResponse, error) {
+	req := fasthttp.AcquireRequest()
+	defer fasthttp.ReleaseRequest(req)
+	resp := fasthttp.AcquireResponse()
+
+	c.prepareRequest(req, method, url, body, headers)
+
+	if err := c.streamClient.DoTimeout(req, resp, requestTimeout(ctx, c.config.ReadTimeout)); err != nil {
+		fasthttp.ReleaseResponse(resp)
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	raw := &responseStream{resp: resp}
+
+	if resp.StatusCode() >= fasthttp.StatusBadRequest {
+		defer raw.Close()
+
+		errorBody, err := io.ReadAll(io.LimitReader(raw.reader(), maxStreamErrorBodySize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read error response: %w", err)
+		}
+
+		return nil, &HTTPError{StatusCode: resp.StatusCode(), Body: errorBody}
+	}
+
+	if err := ctx.Err(); err != nil {
+		raw.Close()
+		return nil, err
+	}
+
+	wire := raw.reader()
+	if c.config.MaxResponseBodySize > 0 {
+		wire = &limitedReader{r: wire, remaining: int64(c.config.MaxResponseBodySize)}
+	}
+
+	buffered := bufio.NewReader(wire)
+	contentEncoding := string(resp.Header.Peek("Content-Encoding"))
+	if contentEncoding == "" {
+		// A short or failed peek is reported again by the first Read
+		prefix, _ := buffered.Peek(len(gzipMagic))
+		contentEncoding = sniffEncoding(prefix)
+	}
+
+	decoded, err := decompressStream(contentEncoding, buffered)
+	if err != nil {
+		raw.Close()
+		return nil, fmt.Errorf("decompression failed: %w", err)
+	}
+
+	return &StreamResponse{
+		StatusCode: resp.StatusCode(),
+		Headers:    responseHeaders(resp),
+		Body: &streamBody{
+			ctx:     ctx,
+			reader:  decoded,
+			closers: []io.Closer{decoded, raw},
+		},
+	}, nil
+}
+
+// decompressStream wraps r in a decoder for the given content encoding
+func decompressStream(contentEncoding string, r io.Reader) (io.ReadCloser, error) {
+	switch contentEncoding {
+	case "", "identity":
+		return io.NopCloser(r), nil
+
+	case "gzip":
+		reader, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		return reader, nil
+
+	case "deflate":
+		return flate.NewReader(r), nil
+
+	case "br":
+		return io.NopCloser(brotli.NewReader(r)), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported compression type: %s", contentEncoding)
+	}
+}
+
+// responseStream owns a fasthttp response whose body is still being read
+type responseStream struct {
+	resp *fasthttp.Response
+}
+
+// reader returns the response body stream
+func (s *responseStream) reader() io.Reader {
+	if stream := s.resp.BodyStream(); stream != nil {
+		return stream
+	}
+
+	// Bodies skipped by fasthttp, e.g. for HEAD requests, have no stream
+	return &emptyReader{}
+}
+
+// Close closes the body stream and returns the response to the pool
+func (s *responseStream) Close() error {
+	err := s.resp.CloseBodyStream()
+	fasthttp.ReleaseResponse(s.resp)
+	return err
+}
+
+// emptyReader is an io.Reader that is always at EOF
+type emptyReader struct{}
+
+// Read implements io.Reader
+func (*emptyReader) Read([]byte) (int, error) {
+	return 0, io.EOF
+}
+
+// limitedReader fails with fasthttp.ErrBodyTooLarge once more than remaining
+// bytes have been read, matching the buffered client's limit
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read implements io.Reader
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, fasthttp.ErrBodyTooLarge
+	}
+
+	// Read one byte past the limit so bodies of exactly the limit succeed
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, fasthttp.ErrBodyTooLarge
+	}
+
+	return n, err
+}
+
+// streamBody is the body of a StreamResponse. Reads fail once the request
+// context is done, and Close releases the decoder and the connection.
+type streamBody struct {
+	ctx     context.Context
+	reader  io.Reader
+	closers []io.Closer
+	closed  bool
+}
+
+// Read implements io.Reader
+func (b *streamBody) Read(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return b.reader.Read(p)
+}
+
+// Close implements io.Closer. Calling Close more than once is safe.
+func (b *streamBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	var firstErr error
+	for _, closer := range b.closers {
+		if err := closer.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
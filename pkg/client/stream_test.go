@@ -0,0 +1,221 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+// largeGzipFixture returns a multi-megabyte JSON body and its gzip encoding
+func largeGzipFixture(t *testing.T) (plain, compressed []byte) {
+	t.Helper()
+
+	var body bytes.Buffer
+	body.WriteString(`{"Time Series (1min)": {`)
+	for i := range 40000 {
+		if i > 0 {
+			body.WriteString(",")
+		}
+		fmt.Fprintf(&body, `"2024-01-01 %05d": {"1. open": "%d.0000", "2. high": "%d.5000", "3. low": "%d.2500", "4. close": "%d.7500", "5. volume": "%d"}`,
+			i, i, i, i, i, i*100)
+	}
+	body.WriteString("}}")
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body.Bytes()); err != nil {
+		t.Fatalf("Failed to compress fixture: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Failed to compress fixture: %v", err)
+	}
+
+	return body.Bytes(), buf.Bytes()
+}
+
+// startStreamServer serves handler on a local listener until the test ends
+func startStreamServer(t *testing.T, handler fasthttp.RequestHandler) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	server := &fasthttp.Server{Handler: handler}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Shutdown() })
+
+	return "http://" + listener.Addr().String()
+}
+
+func TestFastHTTPClient_DoStreamGzip(t *testing.T) {
+	plain, compressed := largeGzipFixture(t)
+	if len(plain) < 4*1024*1024 {
+		t.Fatalf("Expected a multi-megabyte fixture, got %d bytes", len(plain))
+	}
+	if len(compressed) <= streamBufferSize {
+		t.Fatalf("Expected the compressed fixture to exceed the stream buffer, got %d bytes", len(compressed))
+	}
+
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetContentType("application/json")
+		ctx.Response.Header.Set("Content-Encoding", "gzip")
+		ctx.SetBody(compressed)
+	})
+
+	config := DefaultConfig()
+	config.MaxResponseBodySize = 2 * len(compressed)
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	response, err := client.DoStream(context.Background(), "GET", url, nil, nil)
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", response.StatusCode)
+	}
+	if got := response.Headers["Content-Type"]; got != "application/json" {
+		t.Errorf("Expected Content-Type application/json, got %q", got)
+	}
+
+	got, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("Failed to read stream: %v", err)
+	}
+	if !bytes.Equal(got, plain) {
+		t.Errorf("Expected %d decompressed bytes to match the fixture, got %d bytes", len(plain), len(got))
+	}
+
+	if err := response.Body.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+}
+
+func TestFastHTTPClient_DoStreamSmallBody(t *testing.T) {
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetBodyString(`{"status": "ok"}`)
+	})
+
+	client := NewFastHTTPClient(DefaultConfig())
+	defer client.Close()
+
+	response, err := client.DoStream(context.Background(), "GET", url, nil, nil)
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	got, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("Failed to read stream: %v", err)
+	}
+	if string(got) != `{"status": "ok"}` {
+		t.Errorf("Unexpected body %q", got)
+	}
+}
+
+func TestFastHTTPClient_DoStreamBodyTooLarge(t *testing.T) {
+	_, compressed := largeGzipFixture(t)
+
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Set("Content-Encoding", "gzip")
+		ctx.SetBody(compressed)
+	})
+
+	config := DefaultConfig()
+	config.MaxResponseBodySize = len(compressed) / 2
+	client := NewFastHTTPClient(config)
+	defer client.Close()
+
+	response, err := client.DoStream(context.Background(), "GET", url, nil, nil)
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	if _, err := io.Copy(io.Discard, response.Body); !errors.Is(err, fasthttp.ErrBodyTooLarge) {
+		t.Errorf("Expected ErrBodyTooLarge, got %v", err)
+	}
+}
+
+func TestFastHTTPClient_DoStreamErrorStatus(t *testing.T) {
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetBodyString(`{"error": "bad request"}`)
+	})
+
+	client := NewFastHTTPClient(DefaultConfig())
+	defer client.Close()
+
+	_, err := client.DoStream(context.Background(), "GET", url, nil, nil)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("Expected HTTPError, got %v", err)
+	}
+	if httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", httpErr.StatusCode)
+	}
+	if string(httpErr.Body) != `{"error": "bad request"}` {
+		t.Errorf("Unexpected error body %q", httpErr.Body)
+	}
+}
+
+func TestFastHTTPClient_DoStreamCanceledContext(t *testing.T) {
+	_, compressed := largeGzipFixture(t)
+
+	url := startStreamServer(t, func(ctx *fasthttp.RequestCtx) {
+		ctx.Response.Header.Set("Content-Encoding", "gzip")
+		ctx.SetBody(compressed)
+	})
+
+	client := NewFastHTTPClient(DefaultConfig())
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	response, err := client.DoStream(ctx, "GET", url, nil, nil)
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	cancel()
+
+	if _, err := io.Copy(io.Discard, response.Body); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestMockClient_DoStream(t *testing.T) {
+	mock := NewMockClient()
+	mock.SetResponse("https://example.com", &Response{StatusCode: 200, Body: []byte(`{"ok": true}`)})
+
+	response, err := mock.DoStream(context.Background(), "GET", "https://example.com", nil, nil)
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	got, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("Failed to read stream: %v", err)
+	}
+	if string(got) != `{"ok": true}` {
+		t.Errorf("Unexpected body %q", got)
+	}
+	if count := mock.GetCallCount("https://example.com"); count != 1 {
+		t.Errorf("Expected 1 call, got %d", count)
+	}
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// TLSConfig configures how FastHTTPClient verifies HTTPS servers. The zero
+// value keeps Go's defaults: system root CAs and TLS 1.2 as the minimum.
+type TLSConfig struct {
+	// MinVersion is the minimum TLS version, e.g. tls.VersionTLS13
+	MinVersion uint16
+
+	// RootCAFile is a PEM file of CA certificates trusted instead of the
+	// system roots, for gateways signed by a private CA
+	RootCAFile string
+
+	// InsecureSkipVerify disables certificate verification entirely. It
+	// exposes requests to interception and must never be used in production.
+	InsecureSkipVerify bool
+}
+
+// Build converts the settings into a *tls.Config, returning nil when the
+// zero value leaves the defaults in place.
+//
+// Returns an error if RootCAFile cannot be read or holds no certificates.
+func (t TLSConfig) Build() (*tls.Config, error) {
+	if t == (TLSConfig{}) {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: t.MinVersion,
+	}
+
+	if t.RootCAFile != "" {
+		pem, err := os.ReadFile(t.RootCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read root CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in root CA file %s", t.RootCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if t.InsecureSkipVerify {
+		slog.Warn("TLS certificate verification is DISABLED; connections can be intercepted. Never enable InsecureSkipVerify in production")
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return tlsConfig, nil
+}
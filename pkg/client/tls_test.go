@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeServerCA writes the certificate of a TLS test server to a PEM file
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	block := &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+
+	return path
+}
+
+// newTLSTestClient creates a client without retries or circuit breaker
+func newTLSTestClient(tlsConfig TLSConfig) *FastHTTPClient {
+	config := DefaultConfig()
+	config.MaxRetries = 0
+	config.CircuitMaxFailures = 0
+	config.TLS = tlsConfig
+	return NewFastHTTPClient(config)
+}
+
+func TestFastHTTPClient_CustomRootCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The self-signed test certificate is not trusted by the system roots
+	if _, err := newTLSTestClient(TLSConfig{}).Get(context.Background(), server.URL, nil); err == nil {
+		t.Fatal("Expected verification to fail without the custom CA")
+	}
+
+	client := newTLSTestClient(TLSConfig{
+		MinVersion: tls.VersionTLS12,
+		RootCAFile: writeServerCA(t, server),
+	})
+	resp, err := client.Get(context.Background(), server.URL, nil)
+	if err != nil {
+		t.Fatalf("Expected request trusting the custom CA to succeed, got %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestFastHTTPClient_InsecureSkipVerify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTLSTestClient(TLSConfig{InsecureSkipVerify: true})
+	if _, err := client.Get(context.Background(), server.URL, nil); err != nil {
+		t.Fatalf("Expected request skipping verification to succeed, got %v", err)
+	}
+}
+
+func TestTLSConfig_Build(t *testing.T) {
+	tlsConfig, err := TLSConfig{}.Build()
+	if err != nil || tlsConfig != nil {
+		t.Errorf("Expected zero value to keep defaults, got %v, %v", tlsConfig, err)
+	}
+
+	tlsConfig, err = TLSConfig{MinVersion: tls.VersionTLS13}.Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS13 || tlsConfig.InsecureSkipVerify {
+		t.Errorf("Unexpected TLS config: %+v", tlsConfig)
+	}
+
+	invalidCA := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalidCA, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	if _, err := (TLSConfig{RootCAFile: invalidCA}).Build(); err == nil {
+		t.Error("Expected error for a file without certificates")
+	}
+
+	if _, err := (TLSConfig{RootCAFile: filepath.Join(t.TempDir(), "missing.pem")}).Build(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected not-exist error for a missing file, got %v", err)
+	}
+}
+
+func TestFastHTTPClient_InvalidTLSConfig(t *testing.T) {
+	client := newTLSTestClient(TLSConfig{RootCAFile: filepath.Join(t.TempDir(), "missing.pem")})
+
+	_, err := client.Get(context.Background(), "https://example.com", nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the TLS configuration error, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"context"
+	"errors"
+)
+
+// Code is a machine-readable error category reported to MCP clients so they
+// can branch on failures without parsing messages.
+type Code string
+
+const (
+	// CodeInvalidInput means the tool arguments failed validation
+	CodeInvalidInput Code = "INVALID_INPUT"
+	// CodeInvalidSymbol means the symbol is malformed or unknown to the provider
+	CodeInvalidSymbol Code = "INVALID_SYMBOL"
+	// CodeNotFound means the request was valid but no data was returned
+	CodeNotFound Code = "NOT_FOUND"
+	// CodeRateLimited means the API quota was exhausted; retry later
+	CodeRateLimited Code = "RATE_LIMITED"
+	// CodePremiumRequired means the data needs a premium API key
+	CodePremiumRequired Code = "PREMIUM_REQUIRED"
+	// CodeInvalidAPIKey means the API key is missing or was rejected
+	CodeInvalidAPIKey Code = "INVALID_API_KEY"
+	// CodeTimeout means the call was cancelled or timed out
+	CodeTimeout Code = "TIMEOUT"
+	// CodeUpstreamError means the upstream request or its response failed
+	CodeUpstreamError Code = "UPSTREAM_ERROR"
+)
+
+// Retryable reports whether errors with this code are transient, so the
+// same call may succeed if retried later.
+func (c Code) Retryable() bool {
+	switch c {
+	case CodeRateLimited, CodeTimeout, CodeUpstreamError:
+		return true
+	default:
+		return false
+	}
+}
+
+// CodeOf classifies err into a Code. Errors that match no known condition
+// are reported as CodeUpstreamError.
+func CodeOf(err error) Code {
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return CodeTimeout
+	case errors.Is(err, ErrInvalidSymbol):
+		return CodeInvalidSymbol
+	case errors.Is(err, ErrInvalidInput), errors.Is(err, ErrSymbolRequired):
+		return CodeInvalidInput
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrRateLimited), errors.Is(err, ErrRateLimitWouldExceedDaily):
+		return CodeRateLimited
+	case errors.Is(err, ErrPremiumRequired):
+		return CodePremiumRequired
+	case errors.Is(err, ErrInvalidAPIKey), errors.Is(err, ErrAPIKeyRequired):
+		return CodeInvalidAPIKey
+	default:
+		return CodeUpstreamError
+	}
+}
+
+// Envelope is the JSON error body returned to MCP clients when a tool fails.
+type Envelope struct {
+	Code      Code   `json:"code"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`
+}
+
+// NewEnvelope classifies err and builds its error envelope
+func NewEnvelope(err error) Envelope {
+	code := CodeOf(err)
+	return Envelope{
+		Code:      code,
+		Message:   err.Error(),
+		Retryable: code.Retryable(),
+	}
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeOf(t *testing.T) {
+	testCases := []struct {
+		name      string
+		err       error
+		code      Code
+		retryable bool
+	}{
+		{
+			name: "invalid input",
+			err:  fmt.Errorf("%w: invalid interval '2min'", ErrInvalidInput),
+			code: CodeInvalidInput,
+		},
+		{
+			name: "invalid symbol",
+			err:  fmt.Errorf("API error: %w", ErrInvalidSymbol),
+			code: CodeInvalidSymbol,
+		},
+		{
+			name: "invalid API key",
+			err:  fmt.Errorf("failed to fetch stock data: API error: %w", ErrInvalidAPIKey),
+			code: CodeInvalidAPIKey,
+		},
+		{
+			name: "missing API key",
+			err:  fmt.Errorf("failed to build URL: %w", ErrAPIKeyRequired),
+			code: CodeInvalidAPIKey,
+		},
+		{
+			name:      "rate limited",
+			err:       fmt.Errorf("API error: %w", ErrRateLimited),
+			code:      CodeRateLimited,
+			retryable: true,
+		},
+		{
+			name:      "timeout",
+			err:       context.DeadlineExceeded,
+			code:      CodeTimeout,
+			retryable: true,
+		},
+		{
+			name:      "unknown",
+			err:       errors.New("unexpected end of JSON input"),
+			code:      CodeUpstreamError,
+			retryable: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code := CodeOf(tc.err)
+			assert.Equal(t, tc.code, code)
+			assert.Equal(t, tc.retryable, code.Retryable())
+		})
+	}
+}
+
+func TestNewEnvelope_InvalidAPIKeyIsNotRetryable(t *testing.T) {
+	envelope := NewEnvelope(fmt.Errorf("%w (status 401)", ErrInvalidAPIKey))
+
+	assert.Equal(t, CodeInvalidAPIKey, envelope.Code)
+	assert.False(t, envelope.Retryable, "A rejected key fails the same way until it is replaced")
+	assert.Equal(t, "invalid API key (status 401)", envelope.Message)
+}
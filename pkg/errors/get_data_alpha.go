@@ -7,4 +7,36 @@ var (
 	ErrAPIKeyRequired       = errors.New("api key is required")
 	ErrBaseURLRequired      = errors.New("base url is required")
 	ErrUnexpectedStatusCode = errors.New("unexpected status code")
+
+	// ErrRateLimitWouldExceedDaily is returned instead of calling the API
+	// when the configured daily request budget has been used up
+	ErrRateLimitWouldExceedDaily = errors.New("daily API request budget exhausted")
+
+	// ErrInvalidInput is wrapped by tools when the tool input fails validation
+	ErrInvalidInput = errors.New("input validation failed")
+
+	// ErrInvalidSymbol is wrapped when a symbol is empty or malformed
+	ErrInvalidSymbol = errors.New("invalid symbol")
+
+	// ErrNotFound is wrapped when a well-formed request returns no data
+	ErrNotFound = errors.New("not found")
+
+	// ErrRateLimited is wrapped when Alpha Vantage reports that the call
+	// frequency or daily request quota has been exceeded
+	ErrRateLimited = errors.New("API rate limit reached")
+
+	// ErrInvalidAPIKey is wrapped when Alpha Vantage rejects the API key
+	ErrInvalidAPIKey = errors.New("invalid API key")
+
+	// ErrUpstreamUnavailable is wrapped when Alpha Vantage cannot be reached
+	// or fails to answer, e.g. on network errors, timeouts or 5xx statuses
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+
+	// ErrPremiumRequired is wrapped when the requested function or
+	// parameter is only available with a premium API key
+	ErrPremiumRequired = errors.New("premium API key required")
+
+	// ErrIntervalMismatch is wrapped when a time series response holds bars
+	// of a different interval than the one requested
+	ErrIntervalMismatch = errors.New("interval mismatch")
 )
@@ -0,0 +1,76 @@
+// Package logging provides structured JSON logging built on log/slog, with
+// per-request correlation IDs carried through context.Context.
+//
+// Usage:
+//
+//	slog.SetDefault(logging.New(os.Stderr, logging.ParseLevel("info")))
+//	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+//	logging.FromContext(ctx).Info("upstream call", "function", "OVERVIEW")
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// RequestIDHeader is the HTTP header carrying the request correlation ID
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the context key of the request correlation ID
+type requestIDKey struct{}
+
+// New creates a logger writing JSON records at or above level to w. API keys
+// in attribute values are redacted before they are written.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	}))
+}
+
+// ParseLevel converts a LOG_LEVEL value ("debug", "info", "warn", "error")
+// into a slog.Level, defaulting to info for unknown values.
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewRequestID generates a random 128-bit hex correlation ID
+func NewRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// WithRequestID returns a copy of ctx carrying the request correlation ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request correlation ID carried by ctx, if any
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// FromContext returns the default logger annotated with the request
+// correlation ID carried by ctx
+func FromContext(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	if requestID := RequestID(ctx); requestID != "" {
+		logger = logger.With("request_id", requestID)
+	}
+	return logger
+}
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLevel("DEBUG"))
+	assert.Equal(t, slog.LevelWarn, ParseLevel("warn"))
+	assert.Equal(t, slog.LevelError, ParseLevel("error"))
+	assert.Equal(t, slog.LevelInfo, ParseLevel("verbose"))
+}
+
+func TestFromContext_IncludesRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(New(&buf, slog.LevelInfo))
+	defer slog.SetDefault(previous)
+
+	ctx := WithRequestID(context.Background(), "req-123")
+	FromContext(ctx).Info("upstream call", "function", "OVERVIEW")
+	FromContext(ctx).Debug("filtered out by level")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "req-123", record["request_id"])
+	assert.Equal(t, "OVERVIEW", record["function"])
+	assert.Equal(t, "upstream call", record["msg"])
+}
+
+func TestNewRequestID(t *testing.T) {
+	id := NewRequestID()
+	assert.Len(t, id, 32)
+	assert.NotEqual(t, id, NewRequestID())
+	assert.Empty(t, RequestID(context.Background()))
+}
+
+func TestRedactAPIKey(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"first parameter", "https://host/query?apikey=abc&symbol=IBM", "https://host/query?apikey=***&symbol=IBM"},
+		{"last parameter", "https://host/query?symbol=IBM&apikey=abc", "https://host/query?symbol=IBM&apikey=***"},
+		{"mixed case", "https://host/query?APIKEY=abc", "https://host/query?APIKEY=***"},
+		{"inside error text", `Get "https://host/query?apikey=abc": timeout`, `Get "https://host/query?apikey=***": timeout`},
+		{"no key", "https://host/query?symbol=IBM", "https://host/query?symbol=IBM"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, RedactAPIKey(tc.in))
+		})
+	}
+}
+
+func TestNew_RedactsAPIKey(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	logger.Info("upstream call failed",
+		"url", "https://host/query?apikey=secret&symbol=IBM",
+		"error", errors.New(`Get "https://host/query?apikey=secret": timeout`))
+
+	assert.NotContains(t, buf.String(), "secret")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "https://host/query?apikey=***&symbol=IBM", record["url"])
+	assert.Equal(t, `Get "https://host/query?apikey=***": timeout`, record["error"])
+}
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"log/slog"
+	"regexp"
+)
+
+// apiKeyParam matches the value of an apikey query parameter
+var apiKeyParam = regexp.MustCompile(`(?i)([?&]apikey=)[^&#\s"']*`)
+
+// RedactAPIKey replaces the value of any apikey query parameter in s with
+// "***". s may be a bare URL or a message embedding one, such as the text
+// of an error returned by an HTTP client.
+func RedactAPIKey(s string) string {
+	return apiKeyParam.ReplaceAllString(s, "${1}***")
+}
+
+// redactAttr scrubs API keys from string and error attribute values, so a
+// key embedded in a logged URL or error never reaches the log output
+func redactAttr(groups []string, attr slog.Attr) slog.Attr {
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		attr.Value = slog.StringValue(RedactAPIKey(attr.Value.String()))
+	case slog.KindAny:
+		if err, ok := attr.Value.Any().(error); ok {
+			attr.Value = slog.StringValue(RedactAPIKey(err.Error()))
+		}
+	}
+
+	return attr
+}
@@ -0,0 +1,61 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+// CheckAPIMessages reports the error described by the "Error Message",
+// "Note" or "Information" key of an Alpha Vantage response, for responses
+// decoded straight into a struct. Without this check an error body decodes
+// into an empty struct and is mistaken for missing data.
+//
+// Bodies that are not JSON objects are left for the struct decoding to
+// reject, so it returns nil for them.
+func CheckAPIMessages(jsonData []byte) error {
+	var rawData map[string]rawMessage
+	if err := unmarshal(jsonData, &rawData); err != nil {
+		return nil
+	}
+
+	return checkAPIMessages(rawMessages(rawData))
+}
+
+// checkAPIMessages inspects a decoded Alpha Vantage response for the
+// "Error Message", "Note" and "Information" keys the API uses to report
+// invalid calls and rate limits with a 200 status code.
+//
+// The returned errors wrap errors.ErrInvalidSymbol, errors.ErrRateLimited or
+// errors.ErrPremiumRequired when the message identifies the cause.
+func checkAPIMessages(rawResponse map[string]any) error {
+	if errorMsg, exists := rawResponse["Error Message"]; exists {
+		// Tool inputs are validated first, so an invalid call almost always
+		// means the symbol is unknown to Alpha Vantage
+		if strings.Contains(fmt.Sprint(errorMsg), "Invalid API call") {
+			return fmt.Errorf("API error: %w: %v", errors.ErrInvalidSymbol, errorMsg)
+		}
+		return fmt.Errorf("API error: %v", errorMsg)
+	}
+
+	if note, exists := rawResponse["Note"]; exists {
+		return fmt.Errorf("API note (likely rate limit): %w: %v", errors.ErrRateLimited, note)
+	}
+
+	if info, exists := rawResponse["Information"]; exists {
+		if infoStr, ok := info.(string); ok {
+			lower := strings.ToLower(infoStr)
+			switch {
+			// Rate limit messages also advertise the premium plans, so check them first
+			case strings.Contains(lower, "rate limit") || strings.Contains(lower, "call frequency"):
+				return fmt.Errorf("%w: %v", errors.ErrRateLimited, info)
+			case strings.Contains(lower, "premium"):
+				return fmt.Errorf("%w: %v", errors.ErrPremiumRequired, info)
+			}
+			return fmt.Errorf("API information: %v", info)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+func TestCheckAPIMessages_Sentinels(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response string
+		sentinel error
+	}{
+		{
+			name:     "invalid API call",
+			response: `{"Error Message": "Invalid API call. Please retry or visit the documentation (https://www.alphavantage.co/documentation/) for TIME_SERIES_INTRADAY."}`,
+			sentinel: errors.ErrInvalidSymbol,
+		},
+		{
+			name:     "call frequency note",
+			response: `{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute and 100 calls per day. Please subscribe to any of the premium plans at https://www.alphavantage.co/premium/ to instantly remove all daily rate limits."}`,
+			sentinel: errors.ErrRateLimited,
+		},
+		{
+			name:     "daily rate limit information",
+			response: `{"Information": "We have detected your API key and our standard API rate limit is 25 requests per day. Please subscribe to any of the premium plans at https://www.alphavantage.co/premium/ to instantly remove all daily rate limits."}`,
+			sentinel: errors.ErrRateLimited,
+		},
+		{
+			name:     "call frequency information",
+			response: `{"Information": "Thank you for using Alpha Vantage! Please consider spreading out your free API requests more sparingly (1 request per second). You may subscribe to any of the premium plans at https://www.alphavantage.co/premium/ to lift the free key rate limit (25 requests per day), raise the per-second burst limit, and instantly unlock all premium endpoints"}`,
+			sentinel: errors.ErrRateLimited,
+		},
+		{
+			name:     "premium endpoint information",
+			response: `{"Information": "Thank you for using Alpha Vantage! This is a premium endpoint. You may subscribe to any of the premium plans at https://www.alphavantage.co/premium/ to instantly unlock all premium endpoints"}`,
+			sentinel: errors.ErrPremiumRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := IntradayPrices([]byte(tc.response))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.sentinel)
+
+			for _, other := range []error{errors.ErrInvalidSymbol, errors.ErrRateLimited, errors.ErrPremiumRequired} {
+				if other != tc.sentinel {
+					assert.False(t, stderrors.Is(err, other), "should not match %v", other)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckAPIMessages_Untyped(t *testing.T) {
+	testCases := []string{
+		`{"Error Message": "the parameter apikey is invalid or missing."}`,
+		`{"Information": "The **demo** API key is for demo purposes only."}`,
+	}
+
+	for _, response := range testCases {
+		_, err := IntradayPrices([]byte(response))
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, errors.ErrInvalidSymbol)
+		assert.NotErrorIs(t, err, errors.ErrRateLimited)
+		assert.NotErrorIs(t, err, errors.ErrPremiumRequired)
+	}
+}
+
+func TestCheckAPIMessages_Exported(t *testing.T) {
+	err := CheckAPIMessages([]byte(`{"Note": "API rate limit exceeded for this key."}`))
+	assert.ErrorIs(t, err, errors.ErrRateLimited)
+
+	assert.NoError(t, CheckAPIMessages([]byte(`{"Symbol": "IBM", "Name": "International Business Machines"}`)))
+	assert.NoError(t, CheckAPIMessages([]byte(`not json`)), "Malformed bodies are left to the struct decoding")
+}
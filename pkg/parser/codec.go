@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// API is the JSON encoding configuration behind a JSON parser. sonic.API
+// satisfies it, as does the encoding/json fallback.
+type API interface {
+	Marshal(v any) ([]byte, error)
+	MarshalToString(v any) (string, error)
+	Unmarshal(data []byte, v any) error
+	UnmarshalFromString(data string, v any) error
+}
+
+// codec is the JSON implementation used by the parser: sonic when built
+// with the sonic tag on the architectures it supports, encoding/json
+// otherwise. See codec_sonic.go and codec_std.go.
+type codec interface {
+	API
+
+	// Decode decodes the next JSON value read from r into v
+	Decode(r io.Reader, v any) error
+}
+
+// rawCodec decodes the raw responses split by the parse functions. It is a
+// variable so tests can run the fixtures through the fallback.
+var rawCodec = defaultRawCodec()
+
+// unmarshal decodes data into v with rawCodec
+func unmarshal(data []byte, v any) error {
+	return rawCodec.Unmarshal(data, v)
+}
+
+// stdCodec is the encoding/json codec. With useNumber, numbers decoded into
+// interface values become json.Number instead of float64.
+type stdCodec struct {
+	useNumber bool
+}
+
+// Marshal implements API, leaving HTML characters unescaped like sonic
+func (c stdCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+
+	// Encode terminates the value with a newline
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// MarshalToString implements API
+func (c stdCodec) MarshalToString(v any) (string, error) {
+	data, err := c.Marshal(v)
+	return string(data), err
+}
+
+// Unmarshal implements API. Like json.Unmarshal, data must hold a single
+// JSON value.
+func (c stdCodec) Unmarshal(data []byte, v any) error {
+	decoder := c.newDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+
+	return nil
+}
+
+// UnmarshalFromString implements API
+func (c stdCodec) UnmarshalFromString(data string, v any) error {
+	return c.Unmarshal([]byte(data), v)
+}
+
+// Decode implements codec
+func (c stdCodec) Decode(r io.Reader, v any) error {
+	return c.newDecoder(r).Decode(v)
+}
+
+// newDecoder creates a decoder reading from r with the codec's settings
+func (c stdCodec) newDecoder(r io.Reader) *json.Decoder {
+	decoder := json.NewDecoder(r)
+	if c.useNumber {
+		decoder.UseNumber()
+	}
+
+	return decoder
+}
@@ -0,0 +1,40 @@
+//go:build sonic && (amd64 || arm64)
+
+package parser
+
+import (
+	"io"
+
+	"github.com/bytedance/sonic"
+)
+
+// rawMessage is an undecoded JSON value. sonic's variant references the
+// parsed input instead of copying it.
+type rawMessage = sonic.NoCopyRawMessage
+
+// sonicCodec is the sonic codec
+type sonicCodec struct {
+	sonic.API
+}
+
+// Decode implements codec
+func (c sonicCodec) Decode(r io.Reader, v any) error {
+	return c.NewDecoder(r).Decode(v)
+}
+
+// defaultRawCodec returns the codec of the parse functions
+func defaultRawCodec() codec {
+	return sonicCodec{sonic.ConfigDefault}
+}
+
+// newParserCodec returns the codec of a JSON parser, configured for
+// financial data
+func newParserCodec(useNumber bool) codec {
+	return sonicCodec{sonic.Config{
+		UseNumber:        useNumber,
+		EscapeHTML:       false,
+		CompactMarshaler: true,
+		CopyString:       true,
+		ValidateString:   true,
+	}.Froze()}
+}
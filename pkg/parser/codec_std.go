@@ -0,0 +1,19 @@
+//go:build !sonic || !(amd64 || arm64)
+
+package parser
+
+import "encoding/json"
+
+// rawMessage is an undecoded JSON value
+type rawMessage = json.RawMessage
+
+// defaultRawCodec returns the codec of the parse functions
+func defaultRawCodec() codec {
+	return stdCodec{}
+}
+
+// newParserCodec returns the codec of a JSON parser, configured for
+// financial data
+func newParserCodec(useNumber bool) codec {
+	return stdCodec{useNumber: useNumber}
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codecFixtures are Alpha Vantage responses run through both JSON codecs
+var codecFixtures = map[string]func() (any, error){
+	"intraday": func() (any, error) {
+		return IntradayPrices(largeIntradayResponse(50))
+	},
+	"intraday stream": func() (any, error) {
+		return IntradayPricesFrom(bytes.NewReader(largeIntradayResponse(50)))
+	},
+	"intraday processed": func() (any, error) {
+		response, err := IntradayPrices(largeIntradayResponse(50))
+		if err != nil {
+			return nil, err
+		}
+		return response.ProcessTimeSeries()
+	},
+	"rate limit note": func() (any, error) {
+		return IntradayPrices([]byte(`{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`))
+	},
+	"invalid json": func() (any, error) {
+		return IntradayPrices([]byte(`{"Meta Data": `))
+	},
+	"technical indicator": func() (any, error) {
+		return TechnicalIndicator([]byte(`{
+			"Meta Data": {"1: Symbol": "IBM", "4: Interval": "weekly", "5: Time Period": 10, "7: Time Zone": "US/Eastern"},
+			"Technical Analysis: MACD": {
+				"2024-01-12": {"MACD": "0.1234", "MACD_Signal": "0.1000", "MACD_Hist": "0.0234"},
+				"2024-01-05": {"MACD": "0.1100", "MACD_Signal": "0.0950", "MACD_Hist": "0.0150"}
+			}
+		}`), "MACD")
+	},
+	"earnings": func() (any, error) {
+		return Earnings([]byte(`{
+			"symbol": "IBM",
+			"annualEarnings": [{"fiscalDateEnding": "2023-12-31", "reportedEPS": "9.61"}],
+			"quarterlyEarnings": [{"fiscalDateEnding": "1996-03-31", "reportedEPS": "1.01", "estimatedEPS": "None"}]
+		}`))
+	},
+	"exchange rate": func() (any, error) {
+		return ExchangeRate([]byte(`{"Realtime Currency Exchange Rate": {"1. From_Currency Code": "USD", "3. To_Currency Code": "JPY", "5. Exchange Rate": "144.86000000", "6. Last Refreshed": "2024-01-12 17:04:01", "7. Time Zone": "UTC", "9. Ask Price": "-"}}`))
+	},
+	"news sentiment": func() (any, error) {
+		return NewsSentiment([]byte(`{"items": "1", "feed": [{"title": "Markets <rally> & more", "time_published": "20240112T153000", "overall_sentiment_score": -0.2}]}`))
+	},
+	"default parser": func() (any, error) {
+		var value struct {
+			MarketCap any     `json:"marketCap"`
+			EPS       float64 `json:"eps"`
+			Name      string  `json:"name"`
+		}
+		if err := ParseBytes(&value, []byte(`{"marketCap": 2500000000000, "eps": 9.61, "name": "AT&T <T>"}`)); err != nil {
+			return nil, err
+		}
+
+		data, err := MarshalBytes(value)
+		return string(data), err
+	},
+}
+
+// withStdCodec runs fn with the encoding/json codec in place of the build's
+func withStdCodec(fn func()) {
+	savedRaw, savedDefault := rawCodec, Default
+	defer func() {
+		rawCodec, Default = savedRaw, savedDefault
+	}()
+
+	rawCodec = stdCodec{}
+	Default = &JSON{config: stdCodec{useNumber: true}}
+	fn()
+}
+
+func TestStdCodec_MatchesDefaultCodec(t *testing.T) {
+	for name, parse := range codecFixtures {
+		t.Run(name, func(t *testing.T) {
+			want, wantErr := parse()
+
+			var got any
+			var gotErr error
+			withStdCodec(func() {
+				got, gotErr = parse()
+			})
+
+			assert.Equal(t, wantErr != nil, gotErr != nil, "codecs disagree on the error: %v, %v", wantErr, gotErr)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestStdCodec(t *testing.T) {
+	codec := stdCodec{useNumber: true}
+
+	data, err := codec.Marshal(map[string]string{"name": "AT&T <T>"})
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"AT&T <T>"}`, string(data), "HTML must stay unescaped without a trailing newline")
+
+	var value map[string]any
+	require.NoError(t, codec.UnmarshalFromString(`{"marketCap": 2500000000000}`, &value))
+	assert.Equal(t, json.Number("2500000000000"), value["marketCap"])
+
+	assert.Error(t, codec.Unmarshal([]byte(`{} {}`), &value), "Trailing data must be rejected")
+}
@@ -0,0 +1,137 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// cryptoPriceFields lists the numbered price columns of a digital currency bar
+var cryptoPriceFields = []struct {
+	number string
+	name   string
+}{
+	{"1", "open"},
+	{"2", "high"},
+	{"3", "low"},
+	{"4", "close"},
+}
+
+// CryptoPrices parses a DIGITAL_CURRENCY_DAILY or CRYPTO_INTRADAY response,
+// keeping the prices quoted in the given market.
+//
+// Daily responses may carry both market and USD columns, e.g. "1a. open (EUR)"
+// and "1b. open (USD)", so prices are looked up by market rather than decoded
+// with the stock OHLCV extractor.
+func CryptoPrices(jsonData []byte, market string, intraday bool) (*models.CryptoOutput, error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	series, err := findSeries(rawResponse, "time series")
+	if err != nil {
+		return nil, fmt.Errorf("error extracting time series: %w", err)
+	}
+
+	metaData, _ := findSeries(rawResponse, "meta data")
+
+	layout := DateLayout
+	if intraday {
+		layout = IntradayLayout
+	}
+
+	output := &models.CryptoOutput{
+		MetaData: models.CryptoMetaData{
+			Information:         metaDataValue(metaData, "Information"),
+			DigitalCurrencyCode: metaDataValue(metaData, "Digital Currency Code"),
+			DigitalCurrencyName: metaDataValue(metaData, "Digital Currency Name"),
+			MarketCode:          metaDataValue(metaData, "Market Code"),
+			MarketName:          metaDataValue(metaData, "Market Name"),
+			LastRefreshed:       metaDataValue(metaData, "Last Refreshed"),
+			Interval:            metaDataValue(metaData, "Interval"),
+			TimeZone:            metaDataValue(metaData, "Time Zone"),
+		},
+		TimeSeries: make([]models.CryptoBar, 0, len(series)),
+	}
+
+	for timestampStr, entry := range series {
+		values, ok := entry.(map[string]any)
+		if !ok {
+			continue // Skip invalid entries
+		}
+
+		bar, err := cryptoBar(timestampStr, values, market, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		output.TimeSeries = append(output.TimeSeries, bar)
+	}
+
+	sort.Slice(output.TimeSeries, func(i, j int) bool {
+		return output.TimeSeries[i].Timestamp.Before(output.TimeSeries[j].Timestamp)
+	})
+
+	return output, nil
+}
+
+// cryptoBar converts a single digital currency entry into a CryptoBar
+func cryptoBar(timestampStr string, values map[string]any, market, layout string) (models.CryptoBar, error) {
+	timestamp, err := time.Parse(layout, timestampStr)
+	if err != nil {
+		return models.CryptoBar{}, fmt.Errorf("error parsing timestamp %s: %w", timestampStr, err)
+	}
+
+	prices := make([]float64, len(cryptoPriceFields))
+	for i, field := range cryptoPriceFields {
+		raw, ok := cryptoValue(values,
+			fmt.Sprintf("%sa. %s (%s)", field.number, field.name, market),
+			fmt.Sprintf("%s. %s (%s)", field.number, field.name, market),
+			fmt.Sprintf("%s. %s", field.number, field.name),
+		)
+		if !ok {
+			return models.CryptoBar{}, fmt.Errorf("missing %s price in %s for %s", field.name, market, timestampStr)
+		}
+
+		prices[i], err = strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return models.CryptoBar{}, fmt.Errorf("error parsing %s price for %s: %w", field.name, timestampStr, err)
+		}
+	}
+
+	var volume float64
+	if raw, ok := cryptoValue(values, "5. volume"); ok {
+		volume, err = strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return models.CryptoBar{}, fmt.Errorf("error parsing volume for %s: %w", timestampStr, err)
+		}
+	}
+
+	return models.CryptoBar{
+		Timestamp: timestamp,
+		Open:      prices[0],
+		High:      prices[1],
+		Low:       prices[2],
+		Close:     prices[3],
+		Volume:    volume,
+	}, nil
+}
+
+// cryptoValue returns the string value of the first key present in values
+func cryptoValue(values map[string]any, keys ...string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := values[key].(string); ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoPrices_DailyWithMarketAndUSDColumns(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {
+			"1. Information": "Daily Prices and Volumes for Digital Currency",
+			"2. Digital Currency Code": "BTC",
+			"3. Digital Currency Name": "Bitcoin",
+			"4. Market Code": "EUR",
+			"5. Market Name": "Euro",
+			"6. Last Refreshed": "2024-01-12 00:00:00",
+			"7. Time Zone": "UTC"
+		},
+		"Time Series (Digital Currency Daily)": {
+			"2024-01-12": {
+				"1a. open (EUR)": "42000.10",
+				"1b. open (USD)": "46000.10",
+				"2a. high (EUR)": "43000.20",
+				"2b. high (USD)": "47000.20",
+				"3a. low (EUR)": "41000.30",
+				"3b. low (USD)": "45000.30",
+				"4a. close (EUR)": "42500.40",
+				"4b. close (USD)": "46500.40",
+				"5. volume": "1234.5678",
+				"6. market cap (USD)": "1234.5678"
+			}
+		}
+	}`
+
+	output, err := CryptoPrices([]byte(mockResponse), "EUR", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "BTC", output.MetaData.DigitalCurrencyCode)
+	assert.Equal(t, "EUR", output.MetaData.MarketCode)
+	require.Len(t, output.TimeSeries, 1)
+
+	bar := output.TimeSeries[0]
+	assert.Equal(t, time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), bar.Timestamp)
+	assert.Equal(t, 42000.10, bar.Open)
+	assert.Equal(t, 43000.20, bar.High)
+	assert.Equal(t, 41000.30, bar.Low)
+	assert.Equal(t, 42500.40, bar.Close)
+	assert.Equal(t, 1234.5678, bar.Volume)
+}
+
+func TestCryptoPrices_Intraday(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {
+			"1. Information": "Crypto Intraday (5min) Time Series",
+			"2. Digital Currency Code": "ETH",
+			"4. Market Code": "USD",
+			"7. Interval": "5min",
+			"9. Time Zone": "UTC"
+		},
+		"Time Series Crypto (5min)": {
+			"2024-01-12 20:35:00": {"1. open": "2600.1", "2. high": "2605.2", "3. low": "2598.3", "4. close": "2601.4", "5. volume": "150"},
+			"2024-01-12 20:30:00": {"1. open": "2599.0", "2. high": "2601.0", "3. low": "2597.0", "4. close": "2600.1", "5. volume": "98"}
+		}
+	}`
+
+	output, err := CryptoPrices([]byte(mockResponse), "USD", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "5min", output.MetaData.Interval)
+	require.Len(t, output.TimeSeries, 2)
+	assert.Equal(t, time.Date(2024, 1, 12, 20, 30, 0, 0, time.UTC), output.TimeSeries[0].Timestamp)
+	assert.Equal(t, 2601.4, output.TimeSeries[1].Close)
+}
+
+func TestCryptoPrices_MissingMarket(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {},
+		"Time Series (Digital Currency Daily)": {
+			"2024-01-12": {"1a. open (EUR)": "1", "2a. high (EUR)": "1", "3a. low (EUR)": "1", "4a. close (EUR)": "1"}
+		}
+	}`
+
+	_, err := CryptoPrices([]byte(mockResponse), "CNY", false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing open price in CNY")
+}
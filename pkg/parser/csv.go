@@ -0,0 +1,169 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// csvColumns maps CSV header names to the OHLCV field they populate.
+// Adjusted series add "adjusted close" and "dividend amount" columns.
+var csvColumns = map[string]func(*OHLCV, string){
+	"open":            func(o *OHLCV, v string) { o.Open = v },
+	"high":            func(o *OHLCV, v string) { o.High = v },
+	"low":             func(o *OHLCV, v string) { o.Low = v },
+	"close":           func(o *OHLCV, v string) { o.Close = v },
+	"volume":          func(o *OHLCV, v string) { o.Volume = v },
+	"adjusted close":  func(o *OHLCV, v string) { o.AdjustedClose = v },
+	"dividend amount": func(o *OHLCV, v string) { o.DividendAmount = v },
+}
+
+// requiredCSVColumns must be present in the header of every time series CSV
+var requiredCSVColumns = []string{"timestamp", "open", "high", "low", "close"}
+
+// IntradayPricesCSV parses a TIME_SERIES_INTRADAY response requested with
+// datatype=csv.
+//
+// CSV responses carry no metadata, so the caller supplies it; LastRefreshed is
+// filled from the latest row when empty.
+func IntradayPricesCSV(csvData []byte, metaData MetaData) (*AlphaVantageResponse, error) {
+	return parseTimeSeriesCSV(csvData, metaData, IntradayLayout)
+}
+
+// TimeSeriesPricesCSV parses a date-keyed time series response such as
+// TIME_SERIES_WEEKLY requested with datatype=csv. See IntradayPricesCSV.
+func TimeSeriesPricesCSV(csvData []byte, metaData MetaData) (*AlphaVantageResponse, error) {
+	return parseTimeSeriesCSV(csvData, metaData, DateLayout)
+}
+
+// parseTimeSeriesCSV parses a time series CSV whose header row names the
+// columns, e.g. "timestamp,open,high,low,close,volume". Blank lines are skipped.
+func parseTimeSeriesCSV(csvData []byte, metaData MetaData, layout string) (*AlphaVantageResponse, error) {
+	if err := checkCSVResponse(csvData); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(csvData))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	response := &AlphaVantageResponse{
+		MetaData:   metaData,
+		TimeSeries: make(map[string]OHLCV),
+		layout:     layout,
+	}
+
+	var header []string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV: %w", err)
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if header == nil {
+			header, err = csvHeader(record)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		timestamp, ohlcv, err := csvRow(header, record)
+		if err != nil {
+			return nil, err
+		}
+		response.TimeSeries[timestamp] = ohlcv
+
+		// Timestamps share one layout, so the latest also sorts last
+		if metaData.LastRefreshed == "" && timestamp > response.MetaData.LastRefreshed {
+			response.MetaData.LastRefreshed = timestamp
+		}
+	}
+
+	if header == nil {
+		return nil, fmt.Errorf("no CSV header found in response")
+	}
+
+	return response, nil
+}
+
+// checkCSVResponse returns the API error carried by a JSON body received in
+// place of CSV; errors and rate limit notes are returned as JSON even when
+// CSV is requested
+func checkCSVResponse(csvData []byte) error {
+	trimmed := bytes.TrimSpace(csvData)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil
+	}
+
+	var rawResponse map[string]any
+	if err := unmarshal(trimmed, &rawResponse); err != nil {
+		return fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return err
+	}
+	return fmt.Errorf("expected CSV response but received JSON")
+}
+
+// csvHeader normalizes and validates the header row
+func csvHeader(record []string) ([]string, error) {
+	header := make([]string, len(record))
+	for i, column := range record {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	for _, required := range requiredCSVColumns {
+		if !slices.Contains(header, required) {
+			return nil, fmt.Errorf("CSV header is missing the %q column", required)
+		}
+	}
+
+	return header, nil
+}
+
+// csvRow converts a data row into its timestamp and raw OHLCV values
+func csvRow(header, record []string) (string, OHLCV, error) {
+	if len(record) != len(header) {
+		return "", OHLCV{}, fmt.Errorf("CSV row has %d fields, expected %d: %s",
+			len(record), len(header), strings.Join(record, ","))
+	}
+
+	var (
+		timestamp string
+		ohlcv     OHLCV
+	)
+	for i, column := range header {
+		value := strings.TrimSpace(record[i])
+		if column == "timestamp" {
+			timestamp = value
+			continue
+		}
+		if set, ok := csvColumns[column]; ok {
+			set(&ohlcv, value)
+		}
+	}
+
+	return timestamp, ohlcv, nil
+}
+
+// isBlankRecord reports whether a CSV record only holds whitespace
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
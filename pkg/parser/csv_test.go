@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// intradayCSVFixture mirrors a TIME_SERIES_INTRADAY datatype=csv response,
+// newest row first and with a trailing blank line
+const intradayCSVFixture = "timestamp,open,high,low,close,volume\r\n" +
+	"2024-01-15 20:00:00,185.50,185.75,185.25,185.60,125000\r\n" +
+	"2024-01-15 19:55:00,185.20,185.55,185.15,185.50,98000\r\n" +
+	"2024-01-15 19:50:00,184.80,185.25,184.75,185.20,87500\r\n" +
+	"\r\n"
+
+func TestIntradayPricesCSV_Success(t *testing.T) {
+	response, err := IntradayPricesCSV([]byte(intradayCSVFixture), MetaData{Symbol: "AAPL", Interval: "5min"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "AAPL", response.MetaData.Symbol)
+	assert.Equal(t, "5min", response.MetaData.Interval)
+	assert.Equal(t, "2024-01-15 20:00:00", response.MetaData.LastRefreshed)
+	assert.Len(t, response.TimeSeries, 3)
+
+	processed, err := response.ProcessTimeSeries()
+	require.NoError(t, err)
+	require.Len(t, processed.TimeSeries, 3)
+
+	first := processed.TimeSeries[0]
+	assert.Equal(t, time.Date(2024, 1, 15, 19, 50, 0, 0, time.UTC), first.Timestamp)
+	assert.Equal(t, 184.80, first.Open)
+	assert.Equal(t, 185.25, first.High)
+	assert.Equal(t, 184.75, first.Low)
+	assert.Equal(t, 185.20, first.Close)
+	assert.Equal(t, int64(87500), first.Volume)
+
+	assert.Equal(t, 185.60, processed.TimeSeries[2].Close)
+}
+
+func TestTimeSeriesPricesCSV_Adjusted(t *testing.T) {
+	csvData := "timestamp,open,high,low,close,adjusted close,volume,dividend amount\n" +
+		"2024-01-12,160.00,165.00,159.00,164.00,163.50,20000000,0.0000\n" +
+		"2024-01-05,158.00,161.00,157.00,160.00,159.50,18000000,1.6600\n"
+
+	response, err := TimeSeriesPricesCSV([]byte(csvData), MetaData{Symbol: "IBM"})
+	require.NoError(t, err)
+
+	processed, err := response.ProcessTimeSeries()
+	require.NoError(t, err)
+	require.Len(t, processed.TimeSeries, 2)
+
+	first := processed.TimeSeries[0]
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), first.Timestamp)
+	assert.Equal(t, 159.50, first.AdjustedClose)
+	assert.Equal(t, 1.66, first.DividendAmount)
+	assert.Equal(t, int64(18000000), first.Volume)
+}
+
+func TestIntradayPricesCSV_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		csvData  string
+		errorMsg string
+	}{
+		{
+			name:     "JSON error message",
+			csvData:  `{"Error Message": "Invalid API call."}`,
+			errorMsg: "API error",
+		},
+		{
+			name:     "JSON rate limit note",
+			csvData:  `{"Note": "Thank you for using Alpha Vantage!"}`,
+			errorMsg: "API note",
+		},
+		{
+			name:     "empty body",
+			csvData:  "\n\n",
+			errorMsg: "no CSV header found",
+		},
+		{
+			name:     "missing close column",
+			csvData:  "timestamp,open,high,low,volume\n2024-01-15 20:00:00,1,2,0.5,100\n",
+			errorMsg: `missing the "close" column`,
+		},
+		{
+			name:     "short row",
+			csvData:  "timestamp,open,high,low,close,volume\n2024-01-15 20:00:00,1,2\n",
+			errorMsg: "CSV row has 3 fields, expected 6",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := IntradayPricesCSV([]byte(tc.csvData), MetaData{Symbol: "AAPL"})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errorMsg)
+		})
+	}
+}
+
+func TestIntradayStockOutputCSV_RoundTrip(t *testing.T) {
+	response, err := IntradayPricesCSV([]byte(intradayCSVFixture), MetaData{Symbol: "AAPL", Interval: "5min", TimeZone: "US/Eastern"})
+	require.NoError(t, err)
+
+	processed, err := response.ProcessTimeSeries()
+	require.NoError(t, err)
+
+	serialized, err := processed.CSV()
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(bytes.NewReader(serialized)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, len(processed.TimeSeries)+1)
+	assert.Equal(t, []string{"timestamp", "open", "high", "low", "close", "volume"}, records[0])
+	assert.Equal(t, "2024-01-15T19:50:00-05:00", records[1][0], "Timestamps should carry the metadata zone offset")
+
+	// Parse the serialized rows back and compare them with the processed bars
+	for i, record := range records[1:] {
+		timestamp, err := time.Parse(time.RFC3339, record[0])
+		require.NoError(t, err)
+
+		values := make([]float64, 4)
+		for j := range values {
+			values[j], err = strconv.ParseFloat(record[j+1], 64)
+			require.NoError(t, err)
+		}
+		volume, err := strconv.ParseInt(record[5], 10, 64)
+		require.NoError(t, err)
+
+		bar := processed.TimeSeries[i]
+		assert.True(t, bar.Timestamp.Equal(timestamp), "row %d timestamp", i)
+		assert.Equal(t, []float64{bar.Open, bar.High, bar.Low, bar.Close}, values, "row %d prices", i)
+		assert.Equal(t, bar.Volume, volume, "row %d volume", i)
+	}
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+type rawEarnings struct {
+	Symbol            string           `json:"symbol"`
+	AnnualEarnings    []map[string]any `json:"annualEarnings"`
+	QuarterlyEarnings []map[string]any `json:"quarterlyEarnings"`
+}
+
+// Earnings parses an EARNINGS response into annual and quarterly EPS history.
+// "None" values, common for estimates of older quarters, become nil.
+func Earnings(jsonData []byte) (*models.EarningsOutput, error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	var raw rawEarnings
+	if err := unmarshal(jsonData, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into structured response: %w", err)
+	}
+
+	annualFields, err := reportFields(reflect.TypeFor[models.AnnualEarnings]())
+	if err != nil {
+		return nil, err
+	}
+
+	quarterlyFields, err := reportFields(reflect.TypeFor[models.QuarterlyEarnings]())
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.EarningsOutput{
+		Symbol:            raw.Symbol,
+		AnnualEarnings:    decodeReports[models.AnnualEarnings](raw.AnnualEarnings, annualFields),
+		QuarterlyEarnings: decodeReports[models.QuarterlyEarnings](raw.QuarterlyEarnings, quarterlyFields),
+	}, nil
+}
@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEarnings_NoneSentinels(t *testing.T) {
+	mockResponse := `{
+		"symbol": "IBM",
+		"annualEarnings": [
+			{"fiscalDateEnding": "2023-12-31", "reportedEPS": "9.61"}
+		],
+		"quarterlyEarnings": [
+			{
+				"fiscalDateEnding": "2023-12-31",
+				"reportedDate": "2024-01-24",
+				"reportedEPS": "3.87",
+				"estimatedEPS": "3.78",
+				"surprise": "0.09",
+				"surprisePercentage": "2.381",
+				"reportTime": "post-market"
+			},
+			{
+				"fiscalDateEnding": "1996-03-31",
+				"reportedDate": "1996-04-16",
+				"reportedEPS": "1.01",
+				"estimatedEPS": "None",
+				"surprise": "None",
+				"surprisePercentage": "None"
+			}
+		]
+	}`
+
+	output, err := Earnings([]byte(mockResponse))
+	require.NoError(t, err)
+
+	assert.Equal(t, "IBM", output.Symbol)
+	require.Len(t, output.AnnualEarnings, 1)
+	require.NotNil(t, output.AnnualEarnings[0].ReportedEPS)
+	assert.Equal(t, 9.61, *output.AnnualEarnings[0].ReportedEPS)
+
+	require.Len(t, output.QuarterlyEarnings, 2)
+	latest := output.QuarterlyEarnings[0]
+	assert.Equal(t, "2024-01-24", latest.ReportedDate)
+	assert.Equal(t, "post-market", latest.ReportTime)
+	require.NotNil(t, latest.SurprisePercentage)
+	assert.Equal(t, 2.381, *latest.SurprisePercentage)
+
+	oldest := output.QuarterlyEarnings[1]
+	require.NotNil(t, oldest.ReportedEPS)
+	assert.Nil(t, oldest.EstimatedEPS)
+	assert.Nil(t, oldest.Surprise)
+	assert.Nil(t, oldest.SurprisePercentage)
+}
+
+func TestEarnings_RateLimitNote(t *testing.T) {
+	_, err := Earnings([]byte(`{"Note": "Thank you for using Alpha Vantage!"}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "API note")
+}
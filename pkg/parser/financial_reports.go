@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// noneValue is the sentinel Alpha Vantage uses for values that were not reported
+const noneValue = "None"
+
+// Reports holds the annual and quarterly reports of a fundamentals response
+// (INCOME_STATEMENT, BALANCE_SHEET, CASH_FLOW) decoded into report type T.
+type Reports[T any] struct {
+	Symbol           string
+	AnnualReports    []T
+	QuarterlyReports []T
+}
+
+type rawReports struct {
+	Symbol           string           `json:"symbol"`
+	AnnualReports    []map[string]any `json:"annualReports"`
+	QuarterlyReports []map[string]any `json:"quarterlyReports"`
+}
+
+// NullableFloat converts an Alpha Vantage numeric string into a float.
+// It returns nil for the "None" sentinel, empty strings and values that
+// are not numbers, so missing data never surfaces as a parse error.
+func NullableFloat(value string) *float64 {
+	value = strings.TrimSpace(value)
+	if value == "" || value == noneValue {
+		return nil
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil
+	}
+
+	return &parsed
+}
+
+// bigFloatPrec is the mantissa precision of NullableBigFloat, enough for
+// every digit of a monetary value in the trillions and its cents
+const bigFloatPrec = 128
+
+// NullableBigFloat converts an Alpha Vantage numeric string, or the literal
+// of a json.Number, into a big.Float without the rounding of float64. It
+// returns nil in the same cases as NullableFloat.
+func NullableBigFloat(value string) *big.Float {
+	value = strings.TrimSpace(value)
+	if value == "" || value == noneValue {
+		return nil
+	}
+
+	parsed, _, err := big.ParseFloat(value, 10, bigFloatPrec, big.ToNearestEven)
+	if err != nil {
+		return nil
+	}
+
+	return parsed
+}
+
+// FinancialReports parses the annualReports and quarterlyReports arrays of a
+// fundamentals response into T.
+//
+// T must be a struct whose fields are tagged with the Alpha Vantage key names.
+// string fields receive the raw value and *float64 fields are converted with
+// NullableFloat; keys without a matching field are ignored.
+func FinancialReports[T any](jsonData []byte) (*Reports[T], error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	var raw rawReports
+	if err := unmarshal(jsonData, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into structured response: %w", err)
+	}
+
+	fields, err := reportFields(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reports[T]{
+		Symbol:           raw.Symbol,
+		AnnualReports:    decodeReports[T](raw.AnnualReports, fields),
+		QuarterlyReports: decodeReports[T](raw.QuarterlyReports, fields),
+	}, nil
+}
+
+// reportFields maps JSON key names to field indexes of a report struct
+func reportFields(reportType reflect.Type) (map[string]int, error) {
+	if reportType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("report type %s is not a struct", reportType)
+	}
+
+	fields := make(map[string]int, reportType.NumField())
+	for i := range reportType.NumField() {
+		field := reportType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = i
+	}
+
+	return fields, nil
+}
+
+// decodeReports converts raw report maps into typed reports
+func decodeReports[T any](rawReports []map[string]any, fields map[string]int) []T {
+	reports := make([]T, 0, len(rawReports))
+
+	for _, rawReport := range rawReports {
+		var report T
+		reportValue := reflect.ValueOf(&report).Elem()
+
+		for key, value := range rawReport {
+			index, ok := fields[key]
+			if !ok {
+				continue
+			}
+
+			str, ok := value.(string)
+			if !ok {
+				continue
+			}
+
+			field := reportValue.Field(index)
+			switch field.Interface().(type) {
+			case string:
+				field.SetString(str)
+			case *float64:
+				if parsed := NullableFloat(str); parsed != nil {
+					field.Set(reflect.ValueOf(parsed))
+				}
+			}
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports
+}
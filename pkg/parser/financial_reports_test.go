@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+func TestNullableFloat(t *testing.T) {
+	assert.Nil(t, NullableFloat("None"))
+	assert.Nil(t, NullableFloat(""))
+	assert.Nil(t, NullableFloat("n/a"))
+
+	value := NullableFloat("-1234.5")
+	require.NotNil(t, value)
+	assert.Equal(t, -1234.5, *value)
+}
+
+func TestFinancialReports_IncomeStatement(t *testing.T) {
+	mockResponse := `{
+		"symbol": "IBM",
+		"annualReports": [
+			{
+				"fiscalDateEnding": "2023-12-31",
+				"reportedCurrency": "USD",
+				"grossProfit": "34300000000",
+				"totalRevenue": "61860000000",
+				"investmentIncomeNet": "None",
+				"unknownField": "42"
+			}
+		],
+		"quarterlyReports": [
+			{
+				"fiscalDateEnding": "2023-12-31",
+				"reportedCurrency": "USD",
+				"netIncome": "3288000000"
+			},
+			{
+				"fiscalDateEnding": "2023-09-30",
+				"reportedCurrency": "USD",
+				"netIncome": "None"
+			}
+		]
+	}`
+
+	reports, err := FinancialReports[models.IncomeStatementReport]([]byte(mockResponse))
+	require.NoError(t, err)
+
+	assert.Equal(t, "IBM", reports.Symbol)
+	require.Len(t, reports.AnnualReports, 1)
+	annual := reports.AnnualReports[0]
+	assert.Equal(t, "2023-12-31", annual.FiscalDateEnding)
+	assert.Equal(t, "USD", annual.ReportedCurrency)
+	require.NotNil(t, annual.GrossProfit)
+	assert.Equal(t, 34300000000.0, *annual.GrossProfit)
+	assert.Nil(t, annual.InvestmentIncomeNet)
+	assert.Nil(t, annual.EBITDA)
+
+	require.Len(t, reports.QuarterlyReports, 2)
+	require.NotNil(t, reports.QuarterlyReports[0].NetIncome)
+	assert.Equal(t, 3288000000.0, *reports.QuarterlyReports[0].NetIncome)
+	assert.Nil(t, reports.QuarterlyReports[1].NetIncome)
+}
+
+func TestFinancialReports_APIError(t *testing.T) {
+	mockResponse := `{"Error Message": "Invalid API call."}`
+
+	_, err := FinancialReports[models.CashFlowReport]([]byte(mockResponse))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "API error")
+}
+
+func TestFinancialReports_NonStructType(t *testing.T) {
+	_, err := FinancialReports[string]([]byte(`{"symbol": "IBM"}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a struct")
+}
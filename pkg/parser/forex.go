@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+type realtimeExchangeRate struct {
+	FromCurrencyCode string `json:"1. From_Currency Code"`
+	FromCurrencyName string `json:"2. From_Currency Name"`
+	ToCurrencyCode   string `json:"3. To_Currency Code"`
+	ToCurrencyName   string `json:"4. To_Currency Name"`
+	ExchangeRate     string `json:"5. Exchange Rate"`
+	LastRefreshed    string `json:"6. Last Refreshed"`
+	TimeZone         string `json:"7. Time Zone"`
+	BidPrice         string `json:"8. Bid Price"`
+	AskPrice         string `json:"9. Ask Price"`
+}
+
+type exchangeRateResponse struct {
+	Rate *realtimeExchangeRate `json:"Realtime Currency Exchange Rate"`
+}
+
+// ExchangeRate parses a CURRENCY_EXCHANGE_RATE response.
+func ExchangeRate(jsonData []byte) (*models.ExchangeRateOutput, error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	var response exchangeRateResponse
+	if err := unmarshal(jsonData, &response); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into structured response: %w", err)
+	}
+
+	if response.Rate == nil {
+		return nil, fmt.Errorf("no exchange rate data found in response")
+	}
+
+	rate, err := strconv.ParseFloat(response.Rate.ExchangeRate, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing exchange rate %q: %w", response.Rate.ExchangeRate, err)
+	}
+
+	return &models.ExchangeRateOutput{
+		FromCurrencyCode: response.Rate.FromCurrencyCode,
+		FromCurrencyName: response.Rate.FromCurrencyName,
+		ToCurrencyCode:   response.Rate.ToCurrencyCode,
+		ToCurrencyName:   response.Rate.ToCurrencyName,
+		ExchangeRate:     rate,
+		LastRefreshed:    response.Rate.LastRefreshed,
+		TimeZone:         response.Rate.TimeZone,
+		BidPrice:         NullableFloat(response.Rate.BidPrice),
+		AskPrice:         NullableFloat(response.Rate.AskPrice),
+	}, nil
+}
+
+// FXPrices parses an FX_INTRADAY, FX_DAILY, FX_WEEKLY or FX_MONTHLY response.
+// Intraday keys include a time of day, so the layout is chosen per function.
+func FXPrices(jsonData []byte, intraday bool) (*AlphaVantageResponse, error) {
+	if intraday {
+		return parseTimeSeries(jsonData, IntradayLayout)
+	}
+
+	return parseTimeSeries(jsonData, DateLayout)
+}
+
+// ProcessFXTimeSeries converts an FX response into the FX output format.
+// FX metadata is numbered differently from stock metadata, so fields are
+// matched by name rather than by position.
+func (r *AlphaVantageResponse) ProcessFXTimeSeries() (*models.FXTimeSeriesOutput, error) {
+	processed, err := r.ProcessTimeSeries()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FXTimeSeriesOutput{
+		MetaData: models.FXMetaData{
+			Information:   metaDataValue(r.metaData, "Information"),
+			FromSymbol:    metaDataValue(r.metaData, "From Symbol"),
+			ToSymbol:      metaDataValue(r.metaData, "To Symbol"),
+			LastRefreshed: metaDataValue(r.metaData, "Last Refreshed"),
+			Interval:      metaDataValue(r.metaData, "Interval"),
+			OutputSize:    metaDataValue(r.metaData, "Output Size"),
+			TimeZone:      metaDataValue(r.metaData, "Time Zone"),
+		},
+		TimeSeries: processed.TimeSeries,
+	}, nil
+}
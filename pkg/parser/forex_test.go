@@ -0,0 +1,79 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExchangeRate_Success(t *testing.T) {
+	mockResponse := `{
+		"Realtime Currency Exchange Rate": {
+			"1. From_Currency Code": "USD",
+			"2. From_Currency Name": "United States Dollar",
+			"3. To_Currency Code": "JPY",
+			"4. To_Currency Name": "Japanese Yen",
+			"5. Exchange Rate": "144.86000000",
+			"6. Last Refreshed": "2024-01-12 17:04:01",
+			"7. Time Zone": "UTC",
+			"8. Bid Price": "144.85600000",
+			"9. Ask Price": "-"
+		}
+	}`
+
+	output, err := ExchangeRate([]byte(mockResponse))
+	require.NoError(t, err)
+
+	assert.Equal(t, "USD", output.FromCurrencyCode)
+	assert.Equal(t, "JPY", output.ToCurrencyCode)
+	assert.Equal(t, 144.86, output.ExchangeRate)
+	require.NotNil(t, output.BidPrice)
+	assert.Equal(t, 144.856, *output.BidPrice)
+	assert.Nil(t, output.AskPrice)
+}
+
+func TestExchangeRate_Missing(t *testing.T) {
+	_, err := ExchangeRate([]byte(`{}`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no exchange rate data found")
+}
+
+func TestFXPrices_Intraday(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {
+			"1. Information": "FX Intraday (5min) Time Series",
+			"2. From Symbol": "EUR",
+			"3. To Symbol": "USD",
+			"4. Last Refreshed": "2024-01-12 21:55:00",
+			"5. Interval": "5min",
+			"6. Output Size": "Compact",
+			"7. Time Zone": "UTC"
+		},
+		"Time Series FX (5min)": {
+			"2024-01-12 21:55:00": {
+				"1. open": "1.09500",
+				"2. high": "1.09520",
+				"3. low": "1.09480",
+				"4. close": "1.09510"
+			}
+		}
+	}`
+
+	response, err := FXPrices([]byte(mockResponse), true)
+	require.NoError(t, err)
+
+	output, err := response.ProcessFXTimeSeries()
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", output.MetaData.FromSymbol)
+	assert.Equal(t, "USD", output.MetaData.ToSymbol)
+	assert.Equal(t, "5min", output.MetaData.Interval)
+	assert.Equal(t, "2024-01-12 21:55:00", output.MetaData.LastRefreshed)
+	assert.Equal(t, "UTC", output.MetaData.TimeZone)
+	require.Len(t, output.TimeSeries, 1)
+	assert.Equal(t, time.Date(2024, 1, 12, 21, 55, 0, 0, time.UTC), output.TimeSeries[0].Timestamp)
+	assert.Equal(t, 1.0951, output.TimeSeries[0].Close)
+	assert.Zero(t, output.TimeSeries[0].Volume)
+}
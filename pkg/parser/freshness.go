@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"time"
+)
+
+// refreshedLayouts are the formats of Last Refreshed values: intraday
+// timestamps with or without seconds, and dates
+var refreshedLayouts = []string{IntradayLayout, "2006-01-02 15:04", DateLayout}
+
+// Freshness reports how old data last refreshed at lastRefreshed is at now.
+//
+// lastRefreshed is a timestamp or a date in the named time zone, UTC when the
+// zone is empty or unknown. A date counts as refreshed at the end of that
+// day, and data refreshed after now, such as a quote for a session still
+// open, is zero seconds old.
+//
+// isStale reports whether the age exceeds staleAfter and is nil when
+// staleAfter is not positive. Both results are nil when lastRefreshed cannot
+// be parsed.
+func Freshness(lastRefreshed, timeZone string, now time.Time, staleAfter time.Duration) (ageSeconds *int64, isStale *bool) {
+	refreshed, isDate, ok := parseLastRefreshed(lastRefreshed, loadLocation(timeZone))
+	if !ok {
+		return nil, nil
+	}
+
+	if isDate {
+		refreshed = refreshed.AddDate(0, 0, 1)
+	}
+
+	age := max(now.Sub(refreshed), 0)
+	seconds := int64(age / time.Second)
+	if staleAfter <= 0 {
+		return &seconds, nil
+	}
+
+	stale := age > staleAfter
+	return &seconds, &stale
+}
+
+// parseLastRefreshed parses a Last Refreshed value in loc, trying each of
+// refreshedLayouts. isDate reports a value without a time of day, which
+// parses to the start of that day.
+func parseLastRefreshed(value string, loc *time.Location) (refreshed time.Time, isDate, ok bool) {
+	for _, layout := range refreshedLayouts {
+		refreshed, err := time.ParseInLocation(layout, value, loc)
+		if err == nil {
+			return refreshed, layout == DateLayout, true
+		}
+	}
+
+	return time.Time{}, false, false
+}
+
+// loadLocation returns the named time zone, or UTC when the name is empty or
+// unknown
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+)
+
+func TestFreshness(t *testing.T) {
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 12, 16, 0, 0, 0, eastern)
+
+	testCases := []struct {
+		name          string
+		lastRefreshed string
+		timeZone      string
+		wantAge       int64
+		wantStale     bool
+	}{
+		{name: "fresh", lastRefreshed: "2024-01-12 15:55:00", timeZone: "US/Eastern", wantAge: 300},
+		{name: "stale", lastRefreshed: "2024-01-12 13:00:00", timeZone: "US/Eastern", wantAge: 3 * 3600, wantStale: true},
+		{name: "without seconds", lastRefreshed: "2024-01-12 15:30", timeZone: "US/Eastern", wantAge: 1800},
+		{name: "other time zone", lastRefreshed: "2024-01-12 20:59:00", timeZone: "UTC", wantAge: 60},
+		{name: "unknown time zone is UTC", lastRefreshed: "2024-01-12 20:59:00", timeZone: "Mars/Olympus", wantAge: 60},
+		{name: "date counts until its end", lastRefreshed: "2024-01-11", timeZone: "US/Eastern", wantAge: 16 * 3600, wantStale: true},
+		{name: "session still open", lastRefreshed: "2024-01-12", timeZone: "US/Eastern", wantAge: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			age, stale := Freshness(tc.lastRefreshed, tc.timeZone, now, time.Hour)
+			require.NotNil(t, age)
+			require.NotNil(t, stale)
+			assert.Equal(t, tc.wantAge, *age)
+			assert.Equal(t, tc.wantStale, *stale)
+		})
+	}
+}
+
+func TestFreshness_Unparseable(t *testing.T) {
+	for _, lastRefreshed := range []string{"", "yesterday", "12/01/2024"} {
+		age, stale := Freshness(lastRefreshed, "US/Eastern", time.Now(), time.Hour)
+		assert.Nil(t, age, lastRefreshed)
+		assert.Nil(t, stale, lastRefreshed)
+	}
+}
+
+func TestFreshness_NoThreshold(t *testing.T) {
+	age, stale := Freshness("2024-01-12 15:55:00", "UTC", time.Date(2024, 1, 12, 16, 0, 0, 0, time.UTC), 0)
+	require.NotNil(t, age)
+	assert.Equal(t, int64(300), *age)
+	assert.Nil(t, stale)
+}
+
+func TestProcessTimeSeriesWithOptions_Freshness(t *testing.T) {
+	response, err := IntradayPrices(largeIntradayResponse(3))
+	require.NoError(t, err)
+
+	// The fixture was last refreshed at 2024-01-31 19:59:00 US/Eastern
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+	refreshed := time.Date(2024, 1, 31, 19, 59, 0, 0, eastern)
+
+	opts := DefaultProcessOptions()
+	opts.StaleAfter = time.Hour
+
+	fake := clock.NewFake(refreshed.Add(10 * time.Minute))
+	opts.Clock = fake
+	fresh, err := response.ProcessTimeSeriesWithOptions(opts)
+	require.NoError(t, err)
+	require.NotNil(t, fresh.AgeSeconds)
+	assert.Equal(t, int64(600), *fresh.AgeSeconds)
+	require.NotNil(t, fresh.IsStale)
+	assert.False(t, *fresh.IsStale)
+
+	fake.Advance(170 * time.Minute)
+	stale, err := response.ProcessTimeSeriesWithOptions(opts)
+	require.NoError(t, err)
+	require.NotNil(t, stale.IsStale)
+	assert.True(t, *stale.IsStale)
+
+	// Without a threshold the fields are left out
+	plain, err := response.ProcessTimeSeries()
+	require.NoError(t, err)
+	assert.Nil(t, plain.AgeSeconds)
+	assert.Nil(t, plain.IsStale)
+}
+
+func TestProcessTimeSeries_LastRefreshedTime(t *testing.T) {
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		parse    func([]byte) (*AlphaVantageResponse, error)
+		response string
+		want     time.Time // Zero when Last Refreshed cannot be parsed
+	}{
+		{
+			name:  "intraday timestamp",
+			parse: IntradayPrices,
+			response: `{
+				"Meta Data": {"2. Symbol": "IBM", "3. Last Refreshed": "2024-01-31 19:59:00", "4. Interval": "5min", "6. Time Zone": "US/Eastern"},
+				"Time Series (5min)": {"2024-01-31 19:55:00": {"1. open": "1", "2. high": "1", "3. low": "1", "4. close": "1", "5. volume": "1"}}
+			}`,
+			want: time.Date(2024, 1, 31, 19, 59, 0, 0, eastern),
+		},
+		{
+			name:  "daily date",
+			parse: TimeSeriesPrices,
+			response: `{
+				"Meta Data": {"2. Symbol": "IBM", "3. Last Refreshed": "2024-01-26", "4. Time Zone": "US/Eastern"},
+				"Weekly Time Series": {"2024-01-26": {"1. open": "1", "2. high": "1", "3. low": "1", "4. close": "1", "5. volume": "1"}}
+			}`,
+			want: time.Date(2024, 1, 26, 0, 0, 0, 0, eastern),
+		},
+		{
+			name:  "unparseable",
+			parse: IntradayPrices,
+			response: `{
+				"Meta Data": {"2. Symbol": "IBM", "3. Last Refreshed": "yesterday", "4. Interval": "5min", "6. Time Zone": "US/Eastern"},
+				"Time Series (5min)": {}
+			}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := tc.parse([]byte(tc.response))
+			require.NoError(t, err)
+
+			processed, err := response.ProcessTimeSeries()
+			require.NoError(t, err)
+
+			// The raw value is kept for compatibility
+			assert.Equal(t, response.MetaData.LastRefreshed, processed.MetaData.LastRefreshed)
+			if tc.want.IsZero() {
+				assert.Nil(t, processed.MetaData.LastRefreshedTime)
+				return
+			}
+
+			require.NotNil(t, processed.MetaData.LastRefreshedTime)
+			assert.True(t, tc.want.Equal(*processed.MetaData.LastRefreshedTime), "got %v", *processed.MetaData.LastRefreshedTime)
+			assert.Equal(t, eastern, processed.MetaData.LastRefreshedTime.Location())
+		})
+	}
+}
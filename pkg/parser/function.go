@@ -0,0 +1,18 @@
+package parser
+
+import "fmt"
+
+// Function parses the JSON response of any Alpha Vantage function into a
+// generic map, keeping numbers as written by Alpha Vantage.
+func Function(jsonData []byte) (map[string]any, error) {
+	var response map[string]any
+	if err := Default.ParseBytes(&response, jsonData); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,28 @@
+package parser
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+func TestFunction(t *testing.T) {
+	data, err := Function([]byte(`{"top_gainers": [{"ticker": "ABC", "price": "1.23"}], "count": 3}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, json.Number("3"), data["count"])
+	gainers, ok := data["top_gainers"].([]any)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"ticker": "ABC", "price": "1.23"}, gainers[0])
+}
+
+func TestFunction_Errors(t *testing.T) {
+	_, err := Function([]byte(`{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`))
+	assert.ErrorIs(t, err, errors.ErrRateLimited)
+
+	_, err = Function([]byte("symbol,name\nIBM,International Business Machines\n"))
+	assert.Error(t, err, "CSV responses are not JSON objects")
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+type rawGlobalQuote struct {
+	Symbol           string `json:"01. symbol"`
+	Open             string `json:"02. open"`
+	High             string `json:"03. high"`
+	Low              string `json:"04. low"`
+	Price            string `json:"05. price"`
+	Volume           string `json:"06. volume"`
+	LatestTradingDay string `json:"07. latest trading day"`
+	PreviousClose    string `json:"08. previous close"`
+	Change           string `json:"09. change"`
+	ChangePercent    string `json:"10. change percent"`
+}
+
+type globalQuoteResponse struct {
+	Quote *rawGlobalQuote `json:"Global Quote"`
+}
+
+// GlobalQuote parses a GLOBAL_QUOTE response. Alpha Vantage answers unknown
+// symbols with an empty quote, which is returned with an empty Symbol.
+func GlobalQuote(jsonData []byte) (*models.GlobalQuoteOutput, error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	var response globalQuoteResponse
+	if err := unmarshal(jsonData, &response); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into structured response: %w", err)
+	}
+
+	if response.Quote == nil {
+		return nil, fmt.Errorf("no global quote data found in response")
+	}
+
+	raw := response.Quote
+	if raw.Symbol == "" {
+		return &models.GlobalQuoteOutput{}, nil
+	}
+
+	quote := models.GlobalQuoteOutput{
+		Symbol:           raw.Symbol,
+		LatestTradingDay: raw.LatestTradingDay,
+	}
+
+	for _, field := range []struct {
+		name  string
+		value string
+		dst   *float64
+	}{
+		{"open", raw.Open, &quote.Open},
+		{"high", raw.High, &quote.High},
+		{"low", raw.Low, &quote.Low},
+		{"price", raw.Price, &quote.Price},
+		{"previous close", raw.PreviousClose, &quote.PreviousClose},
+		{"change", raw.Change, &quote.Change},
+		{"change percent", strings.TrimSuffix(raw.ChangePercent, "%"), &quote.ChangePercent},
+	} {
+		parsed, err := strconv.ParseFloat(field.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s %q: %w", field.name, field.value, err)
+		}
+		*field.dst = parsed
+	}
+
+	volume, err := strconv.ParseInt(raw.Volume, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing volume %q: %w", raw.Volume, err)
+	}
+	quote.Volume = volume
+
+	return &quote, nil
+}
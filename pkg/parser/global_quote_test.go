@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+func TestGlobalQuote_Success(t *testing.T) {
+	mockResponse := `{
+		"Global Quote": {
+			"01. symbol": "IBM",
+			"02. open": "185.0000",
+			"03. high": "187.4000",
+			"04. low": "184.6200",
+			"05. price": "187.1500",
+			"06. volume": "4255134",
+			"07. latest trading day": "2024-01-12",
+			"08. previous close": "185.5900",
+			"09. change": "1.5600",
+			"10. change percent": "0.8406%"
+		}
+	}`
+
+	quote, err := GlobalQuote([]byte(mockResponse))
+	require.NoError(t, err)
+
+	assert.Equal(t, "IBM", quote.Symbol)
+	assert.Equal(t, 187.15, quote.Price)
+	assert.Equal(t, int64(4255134), quote.Volume)
+	assert.Equal(t, "2024-01-12", quote.LatestTradingDay)
+	assert.Equal(t, 1.56, quote.Change)
+	assert.Equal(t, 0.8406, quote.ChangePercent)
+}
+
+func TestGlobalQuote_UnknownSymbol(t *testing.T) {
+	quote, err := GlobalQuote([]byte(`{"Global Quote": {}}`))
+	require.NoError(t, err)
+	assert.Empty(t, quote.Symbol)
+}
+
+func TestGlobalQuote_Errors(t *testing.T) {
+	_, err := GlobalQuote([]byte(`{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`))
+	assert.ErrorIs(t, err, errors.ErrRateLimited)
+
+	_, err = GlobalQuote([]byte(`{}`))
+	assert.ErrorContains(t, err, "no global quote data")
+
+	_, err = GlobalQuote([]byte(`{"Global Quote": {"01. symbol": "IBM", "05. price": "n/a"}}`))
+	assert.Error(t, err)
+}
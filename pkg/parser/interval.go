@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+// SeriesInterval returns the interval named in the time series key, e.g.
+// "5min" for "Time Series (5min)", or "" when the key names none (CSV and
+// daily or periodic responses).
+func (r *AlphaVantageResponse) SeriesInterval() string {
+	_, suffix, found := strings.Cut(r.seriesKey, "(")
+	if !found {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(suffix), ")"))
+}
+
+// CheckInterval verifies that the response holds bars of the requested
+// interval. Both the metadata interval and the interval in the time series
+// key are compared when present, so a cached or proxied response of the
+// wrong granularity is rejected instead of being served silently.
+//
+// Returns an error wrapping errors.ErrIntervalMismatch on disagreement.
+func (r *AlphaVantageResponse) CheckInterval(requested string) error {
+	sources := []struct {
+		name     string
+		interval string
+	}{
+		{"metadata interval", r.MetaData.Interval},
+		{"time series key", r.SeriesInterval()},
+	}
+
+	for _, source := range sources {
+		if source.interval != "" && !strings.EqualFold(source.interval, requested) {
+			return fmt.Errorf("%w: requested %s but %s is %s", errors.ErrIntervalMismatch, requested, source.name, source.interval)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+// intervalResponse builds an intraday response whose metadata and time
+// series key name the given intervals
+func intervalResponse(metaInterval, keyInterval string) []byte {
+	return []byte(`{
+		"Meta Data": {
+			"2. Symbol": "IBM",
+			"4. Interval": "` + metaInterval + `",
+			"6. Time Zone": "US/Eastern"
+		},
+		"Time Series (` + keyInterval + `)": {
+			"2024-01-12 16:00:00": {"1. open": "1", "2. high": "2", "3. low": "0.5", "4. close": "1.5", "5. volume": "10"}
+		}
+	}`)
+}
+
+func TestCheckInterval(t *testing.T) {
+	testCases := []struct {
+		name         string
+		metaInterval string
+		keyInterval  string
+		requested    string
+		wantErr      string
+	}{
+		{"matching", "5min", "5min", "5min", ""},
+		{"different case", "5min", "5min", "5MIN", ""},
+		{"metadata disagrees with request", "1min", "1min", "5min", "requested 5min but metadata interval is 1min"},
+		{"key disagrees with metadata", "5min", "1min", "5min", "requested 5min but time series key is 1min"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := IntradayPrices(intervalResponse(tc.metaInterval, tc.keyInterval))
+			require.NoError(t, err)
+			assert.Equal(t, tc.keyInterval, response.SeriesInterval())
+
+			err = response.CheckInterval(tc.requested)
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, errors.ErrIntervalMismatch)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestCheckInterval_NoIntervalInResponse(t *testing.T) {
+	// CSV responses carry neither a series key nor response metadata
+	response, err := IntradayPricesCSV([]byte("timestamp,open,high,low,close,volume\n2024-01-12 16:00:00,1,2,0.5,1.5,10\n"), MetaData{Symbol: "IBM"})
+	require.NoError(t, err)
+
+	assert.Empty(t, response.SeriesInterval())
+	assert.NoError(t, response.CheckInterval("5min"))
+}
@@ -1,24 +1,38 @@
 package parser
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"maps"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/bytedance/sonic"
-
+	"github.com/yeferson59/finance-mcp/internal/clock"
 	"github.com/yeferson59/finance-mcp/internal/models"
 )
 
+// Timestamp layouts used by Alpha Vantage time series keys.
+const (
+	// IntradayLayout is the layout of intraday keys, e.g. "2024-01-15 20:00:00".
+	IntradayLayout = "2006-01-02 15:04:05"
+	// DateLayout is the layout of daily, weekly and monthly keys, e.g. "2024-01-12".
+	// It is also the default when a response does not specify a layout.
+	DateLayout = "2006-01-02"
+)
+
 type OHLCV struct {
-	Open   string `json:"1. open"`
-	High   string `json:"2. high"`
-	Low    string `json:"3. low"`
-	Close  string `json:"4. close"`
-	Volume string `json:"5. volume"`
+	Open           string `json:"1. open"`
+	High           string `json:"2. high"`
+	Low            string `json:"3. low"`
+	Close          string `json:"4. close"`
+	Volume         string `json:"5. volume"`
+	AdjustedClose  string `json:"5. adjusted close"`
+	DividendAmount string `json:"7. dividend amount"`
 }
 
 type OHLCVFloat struct {
@@ -39,89 +53,306 @@ type MetaData struct {
 	TimeZone      string `json:"6. Time Zone"`
 }
 
+// toModel converts the metadata to its output form, parsing Last Refreshed
+// in the metadata time zone
+func (m MetaData) toModel() models.MetaData {
+	metaData := models.MetaData{
+		Information:   m.Information,
+		Symbol:        m.Symbol,
+		LastRefreshed: m.LastRefreshed,
+		Interval:      m.Interval,
+		OutputSize:    m.OutputSize,
+		TimeZone:      m.TimeZone,
+	}
+
+	if refreshed, _, ok := parseLastRefreshed(m.LastRefreshed, loadLocation(m.TimeZone)); ok {
+		metaData.LastRefreshedTime = &refreshed
+	}
+
+	return metaData
+}
+
 type AlphaVantageResponse struct {
 	MetaData   MetaData         `json:"Meta Data"`
 	TimeSeries map[string]OHLCV `json:"-"`
-	rawData    map[string]any
+	rawData    map[string]rawMessage
+	layout     string
+
+	// metaData is the decoded "Meta Data" object, searched by metaDataValue
+	metaData map[string]any
+
+	// seriesKey is the key the time series was found under, e.g. "Time Series (5min)"
+	seriesKey string
+}
+
+// seriesEntry is the wire form of a time series entry. Adjusted series shift
+// volume to "6. volume" to make room for the adjusted close.
+type seriesEntry struct {
+	OHLCV
+	AdjustedVolume *string `json:"6. volume"`
 }
 
+// IntradayPrices parses a TIME_SERIES_INTRADAY response.
 func IntradayPrices(jsonData []byte) (*AlphaVantageResponse, error) {
-	var response AlphaVantageResponse
-	var rawResponse map[string]any
+	return parseTimeSeries(jsonData, IntradayLayout)
+}
 
-	// First, unmarshal into a generic map to handle dynamic keys
-	err := sonic.Unmarshal(jsonData, &rawResponse)
-	if err != nil {
+// IntradayPricesFrom parses a TIME_SERIES_INTRADAY response read from r,
+// decoding as the body streams in rather than from a buffered copy.
+func IntradayPricesFrom(r io.Reader) (*AlphaVantageResponse, error) {
+	return parseTimeSeriesFrom(r, IntradayLayout)
+}
+
+// TimeSeriesPrices parses a date-keyed time series response such as
+// TIME_SERIES_WEEKLY, TIME_SERIES_MONTHLY or their adjusted variants.
+func TimeSeriesPrices(jsonData []byte) (*AlphaVantageResponse, error) {
+	return parseTimeSeries(jsonData, DateLayout)
+}
+
+// TimeSeriesPricesFrom parses a date-keyed time series response read from r.
+func TimeSeriesPricesFrom(r io.Reader) (*AlphaVantageResponse, error) {
+	return parseTimeSeriesFrom(r, DateLayout)
+}
+
+// parseTimeSeries parses any Alpha Vantage time series response whose
+// entries are keyed by timestamps in the given layout.
+//
+// The payload is split once into raw top-level values; the metadata, API
+// messages and time series are then decoded from their own raw values, so
+// large series are scanned a single time.
+func parseTimeSeries(jsonData []byte, layout string) (*AlphaVantageResponse, error) {
+	response := AlphaVantageResponse{layout: layout}
+
+	// Split the top level first to handle dynamic keys
+	if err := unmarshal(jsonData, &response.rawData); err != nil {
 		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
 	}
 
-	// Store raw data for later processing
-	response.rawData = rawResponse
+	return response.parseRawData()
+}
 
-	// Unmarshal into the structured response for MetaData
-	err = sonic.Unmarshal(jsonData, &response)
-	if err != nil {
+// parseTimeSeriesFrom is parseTimeSeries for a response read from r
+func parseTimeSeriesFrom(r io.Reader, layout string) (*AlphaVantageResponse, error) {
+	response := AlphaVantageResponse{layout: layout}
+
+	if err := rawCodec.Decode(r, &response.rawData); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	return response.parseRawData()
+}
+
+// parseRawData decodes the metadata, API messages and time series from the
+// raw top-level values
+func (r *AlphaVantageResponse) parseRawData() (*AlphaVantageResponse, error) {
+	if err := r.decodeMetaData(); err != nil {
 		return nil, fmt.Errorf("error parsing JSON into structured response: %w", err)
 	}
 
 	// Check for API error messages
-	if errorMsg, exists := rawResponse["Error Message"]; exists {
-		return nil, fmt.Errorf("API error: %v", errorMsg)
+	if err := checkAPIMessages(rawMessages(r.rawData)); err != nil {
+		return nil, err
 	}
 
-	if note, exists := rawResponse["Note"]; exists {
-		return nil, fmt.Errorf("API note (likely rate limit): %v", note)
+	// Key positions differ between functions (e.g. weekly metadata carries
+	// the time zone under "4. Time Zone"), so fill fields the exact keys missed
+	r.MetaData.fillMissing(r.metaData)
+
+	// Find and extract the time series data
+	if err := r.extractTimeSeries(); err != nil {
+		return nil, fmt.Errorf("error extracting time series: %w", err)
 	}
 
-	if info, exists := rawResponse["Information"]; exists {
-		if infoStr, ok := info.(string); ok {
-			if strings.Contains(strings.ToLower(infoStr), "rate limit") || strings.Contains(strings.ToLower(infoStr), "premium") {
-				return nil, fmt.Errorf("API rate limit reached: %v", info)
+	// The raw values alias the input and are not needed once decoded
+	r.rawData = nil
+
+	return r, nil
+}
+
+// decodeMetaData decodes the "Meta Data" object into MetaData by its exact
+// keys, and into metaData for the semantic lookups of metaDataValue
+func (r *AlphaVantageResponse) decodeMetaData() error {
+	key, raw, ok := findRawEntry(r.rawData, "meta data")
+	if !ok {
+		return nil
+	}
+
+	if strings.EqualFold(key, "Meta Data") {
+		if err := unmarshal(raw, &r.MetaData); err != nil {
+			return err
+		}
+	}
+
+	// Metadata that is not an object is treated as missing
+	_ = unmarshal(raw, &r.metaData)
+	return nil
+}
+
+// rawMessages decodes the top-level keys Alpha Vantage uses to report
+// invalid calls and rate limits, for checkAPIMessages
+func rawMessages(rawData map[string]rawMessage) map[string]any {
+	messages := make(map[string]any)
+	for _, key := range []string{"Error Message", "Note", "Information"} {
+		raw, exists := rawData[key]
+		if !exists {
+			continue
+		}
+
+		var value any
+		_ = unmarshal(raw, &value)
+		messages[key] = value
+	}
+
+	return messages
+}
+
+// findRawEntry returns the first top-level key, in sorted order, that
+// contains marker (case-insensitive) along with its raw value
+func findRawEntry(rawData map[string]rawMessage, marker string) (string, rawMessage, bool) {
+	for _, key := range slices.Sorted(maps.Keys(rawData)) {
+		if strings.Contains(strings.ToLower(key), marker) {
+			return key, rawData[key], true
+		}
+	}
+
+	return "", nil, false
+}
+
+// findSeries returns the object stored under the first top-level key that
+// contains marker (case-insensitive), e.g. "time series" matches both
+// "Time Series (5min)" and "Weekly Adjusted Time Series". Keys are searched
+// in sorted order so a response with several matching keys always resolves
+// to the same one.
+func findSeries(rawData map[string]any, marker string) (map[string]any, error) {
+	for _, key := range slices.Sorted(maps.Keys(rawData)) {
+		if strings.Contains(strings.ToLower(key), marker) {
+			series, ok := rawData[key].(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s data is not in expected format: %q holds %s, expected an object", marker, key, jsonKind(rawData[key]))
 			}
-			return nil, fmt.Errorf("API information: %v", info)
+			return series, nil
 		}
 	}
 
-	// Find and extract the time series data
-	err = response.extractTimeSeries()
-	if err != nil {
-		return nil, fmt.Errorf("error extracting time series: %w", err)
+	return nil, fmt.Errorf("no %s data found in response", marker)
+}
+
+// jsonKind names the JSON type of a value decoded into an any
+func jsonKind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]any:
+		return "an object"
+	case []any:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
+
+// metaDataValue returns the "Meta Data" value for the named field.
+//
+// Keys are compared case-insensitively without their position prefix
+// ("2. Symbol", "1: Symbol"), first exactly and then by containment, so
+// fields still match when Alpha Vantage reorders or rewords them.
+func metaDataValue(metaData map[string]any, field string) string {
+	field = strings.ToLower(field)
+	partial := ""
+	for _, key := range slices.Sorted(maps.Keys(metaData)) {
+		str, ok := metaData[key].(string)
+		if !ok {
+			continue
+		}
+
+		name := metaDataKeyName(key)
+		if name == field {
+			return str
+		}
+		if partial == "" && strings.Contains(name, field) {
+			partial = str
+		}
 	}
 
-	return &response, nil
+	return partial
+}
+
+// metaDataKeyName strips the numeric position prefix from a metadata key
+// and lowercases it, e.g. "4. Time Zone" becomes "time zone"
+func metaDataKeyName(key string) string {
+	name := strings.TrimLeft(key, "0123456789")
+	name = strings.TrimLeft(name, ".:")
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// fillMissing sets the fields not decoded from their exact keys by matching
+// the metadata keys semantically
+func (m *MetaData) fillMissing(metaData map[string]any) {
+	fields := []struct {
+		value *string
+		name  string
+	}{
+		{&m.Information, "information"},
+		{&m.Symbol, "symbol"},
+		{&m.LastRefreshed, "last refreshed"},
+		{&m.Interval, "interval"},
+		{&m.OutputSize, "output size"},
+		{&m.TimeZone, "time zone"},
+	}
+
+	for _, field := range fields {
+		if *field.value == "" {
+			*field.value = metaDataValue(metaData, field.name)
+		}
+	}
 }
 
 // extractTimeSeries finds the time series data in the raw response
-// The key format is "Time Series (interval)" where interval can be 1min, 5min, etc.
+// The key format is "Time Series (interval)" for intraday data, or
+// "Weekly Time Series", "Monthly Adjusted Time Series", etc. for periodic data.
+//
+// Well-formed series are decoded straight into their entries. A series with
+// entries of unexpected types falls back to a lenient walk that skips
+// entries which are not objects and fields which are not strings.
 func (r *AlphaVantageResponse) extractTimeSeries() error {
 	if r.rawData == nil {
 		return fmt.Errorf("no raw data available")
 	}
 
-	var timeSeriesKey string
-	var timeSeriesData any
+	seriesKey, raw, ok := findRawEntry(r.rawData, "time series")
+	if !ok {
+		return fmt.Errorf("no time series data found in response")
+	}
 
-	// Look for time series key in the raw data
-	for key, value := range r.rawData {
-		if strings.Contains(strings.ToLower(key), "time series") {
-			timeSeriesKey = key
-			timeSeriesData = value
-			break
+	var entries map[string]seriesEntry
+	if err := unmarshal(raw, &entries); err == nil && entries != nil {
+		r.seriesKey = seriesKey
+		r.TimeSeries = make(map[string]OHLCV, len(entries))
+		for timestamp, entry := range entries {
+			if entry.AdjustedVolume != nil {
+				entry.Volume = *entry.AdjustedVolume
+			}
+			r.TimeSeries[timestamp] = entry.OHLCV
 		}
+		return nil
 	}
 
-	if timeSeriesKey == "" {
-		return fmt.Errorf("no time series data found in response")
+	var series any
+	if err := unmarshal(raw, &series); err != nil {
+		return fmt.Errorf("time series data is not in expected format: %w", err)
 	}
 
-	// Convert the time series data to our expected format
-	timeSeriesMap, ok := timeSeriesData.(map[string]any)
+	timeSeriesMap, ok := series.(map[string]any)
 	if !ok {
-		return fmt.Errorf("time series data is not in expected format")
+		return fmt.Errorf("time series data is not in expected format: %q holds %s, expected an object", seriesKey, jsonKind(series))
 	}
+	r.seriesKey = seriesKey
 
-	r.TimeSeries = make(map[string]OHLCV)
-
+	r.TimeSeries = make(map[string]OHLCV, len(timeSeriesMap))
 	for timestamp, ohlcvData := range timeSeriesMap {
 		ohlcvMap, ok := ohlcvData.(map[string]any)
 		if !ok {
@@ -155,9 +386,24 @@ func (r *AlphaVantageResponse) extractTimeSeries() error {
 			}
 		}
 
-		if volume, exists := ohlcvMap["5. volume"]; exists {
-			if volumeStr, ok := volume.(string); ok {
-				ohlcv.Volume = volumeStr
+		// Adjusted series shift volume to "6. volume" to make room for the adjusted close
+		for _, volumeKey := range []string{"5. volume", "6. volume"} {
+			if volume, exists := ohlcvMap[volumeKey]; exists {
+				if volumeStr, ok := volume.(string); ok {
+					ohlcv.Volume = volumeStr
+				}
+			}
+		}
+
+		if adjustedClose, exists := ohlcvMap["5. adjusted close"]; exists {
+			if adjustedCloseStr, ok := adjustedClose.(string); ok {
+				ohlcv.AdjustedClose = adjustedCloseStr
+			}
+		}
+
+		if dividend, exists := ohlcvMap["7. dividend amount"]; exists {
+			if dividendStr, ok := dividend.(string); ok {
+				ohlcv.DividendAmount = dividendStr
 			}
 		}
 
@@ -167,97 +413,199 @@ func (r *AlphaVantageResponse) extractTimeSeries() error {
 	return nil
 }
 
+// ProcessOptions controls how raw time series entries are converted.
+type ProcessOptions struct {
+	// StrictParsing fails the whole series on the first unparseable entry.
+	// When false, such entries are skipped and reported in the output's
+	// SkippedBars and ParseErrors instead.
+	StrictParsing bool
+
+	// StaleAfter, when positive, sets the output's AgeSeconds and IsStale
+	// from the metadata's LastRefreshed; see Freshness
+	StaleAfter time.Duration
+
+	// Clock tells the current time the age is measured at; nil uses the
+	// real clock
+	Clock clock.Clock
+}
+
+// DefaultProcessOptions returns the options used by ProcessTimeSeries,
+// which parse strictly.
+func DefaultProcessOptions() ProcessOptions {
+	return ProcessOptions{StrictParsing: true}
+}
+
+// processedEntry is the outcome of converting one raw time series entry
+type processedEntry struct {
+	entry models.OHLCVFloat
+	err   error
+}
+
+// ProcessTimeSeries converts the raw entries into bars sorted oldest first,
+// failing on the first entry that cannot be parsed.
 func (r *AlphaVantageResponse) ProcessTimeSeries() (*models.IntradayStockOutput, error) {
-	if r.TimeSeries == nil {
-		return &models.IntradayStockOutput{
-			MetaData:   models.MetaData(r.MetaData),
-			TimeSeries: []models.OHLCVFloat{},
-		}, nil
-	}
+	return r.ProcessTimeSeriesWithOptions(DefaultProcessOptions())
+}
 
+// ProcessTimeSeriesWithOptions converts the raw entries into bars sorted
+// oldest first using the given options.
+func (r *AlphaVantageResponse) ProcessTimeSeriesWithOptions(opts ProcessOptions) (*models.IntradayStockOutput, error) {
 	processed := &models.IntradayStockOutput{
-		MetaData:   models.MetaData(r.MetaData),
+		MetaData:   r.MetaData.toModel(),
 		TimeSeries: make([]models.OHLCVFloat, 0, len(r.TimeSeries)),
 	}
 
+	if opts.StaleAfter > 0 {
+		now := clock.OrReal(opts.Clock).Now()
+		processed.AgeSeconds, processed.IsStale = Freshness(r.MetaData.LastRefreshed, r.MetaData.TimeZone, now, opts.StaleAfter)
+	}
+
+	if r.TimeSeries == nil {
+		return processed, nil
+	}
+
+	loc := r.location()
+
+	var results []processedEntry
+
 	// For small to medium datasets (< 1000 entries), sequential processing is faster
 	// than goroutine overhead. For larger datasets, we use a worker pool.
 	if len(r.TimeSeries) < 1000 {
 		// Sequential processing for better performance on small datasets
+		results = make([]processedEntry, 0, len(r.TimeSeries))
 		for timestampStr, ohlcv := range r.TimeSeries {
-			processedEntry, err := r.processEntry(timestampStr, ohlcv)
-			if err != nil {
+			entry, err := r.processEntry(loc, timestampStr, ohlcv)
+			if err != nil && opts.StrictParsing {
 				return nil, err
 			}
-			processed.TimeSeries = append(processed.TimeSeries, processedEntry)
+			results = append(results, processedEntry{entry: entry, err: err})
 		}
 	} else {
-		// Use worker pool for large datasets to limit goroutine count
-		const numWorkers = 8
-		type job struct {
-			timestamp string
-			ohlcv     OHLCV
+		var err error
+		results, err = r.processConcurrently(loc, opts)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		jobs := make(chan job, len(r.TimeSeries))
-		results := make(chan models.OHLCVFloat, len(r.TimeSeries))
-		errChan := make(chan error, 1)
-		var wg sync.WaitGroup
-
-		// Start workers
-		for w := 0; w < numWorkers; w++ {
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				for j := range jobs {
-					processedEntry, err := r.processEntry(j.timestamp, j.ohlcv)
-					if err != nil {
-						select {
-						case errChan <- err:
-						default:
-						}
-						return
-					}
-					results <- processedEntry
-				}
-			}()
+	for _, result := range results {
+		if result.err != nil {
+			processed.SkippedBars++
+			processed.ParseErrors = append(processed.ParseErrors, result.err.Error())
+			continue
+		}
+		processed.TimeSeries = append(processed.TimeSeries, result.entry)
+	}
+
+	// Sort by timestamp
+	sort.Slice(processed.TimeSeries, func(i, j int) bool {
+		return processed.TimeSeries[i].Timestamp.Before(processed.TimeSeries[j].Timestamp)
+	})
+
+	// Map iteration order is random, so report skipped entries deterministically
+	sort.Strings(processed.ParseErrors)
+
+	return processed, nil
+}
+
+// processWorkers is the number of goroutines used for large datasets
+const processWorkers = 8
+
+// processConcurrently converts the raw entries with a fixed pool of workers.
+//
+// A producer feeds entries to the workers and a collector drains their
+// results as they arrive. In strict mode the first parse error cancels the
+// producer and workers, which are waited for before the error is returned.
+func (r *AlphaVantageResponse) processConcurrently(loc *time.Location, opts ProcessOptions) ([]processedEntry, error) {
+	type job struct {
+		timestamp string
+		ohlcv     OHLCV
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := make(chan job)
+	results := make(chan processedEntry, processWorkers)
+
+	var wg sync.WaitGroup
+
+	// Fan out: the producer stops handing out entries once cancelled
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		for timestampStr, ohlcv := range r.TimeSeries {
+			select {
+			case jobs <- job{timestampStr, ohlcv}:
+			case <-ctx.Done():
+				return
+			}
 		}
+	}()
 
-		// Send jobs
+	for range processWorkers {
+		wg.Add(1)
 		go func() {
-			for timestampStr, ohlcv := range r.TimeSeries {
-				jobs <- job{timestampStr, ohlcv}
+			defer wg.Done()
+			for j := range jobs {
+				entry, err := r.processEntry(loc, j.timestamp, j.ohlcv)
+				select {
+				case results <- processedEntry{entry: entry, err: err}:
+				case <-ctx.Done():
+					return
+				}
 			}
-			close(jobs)
 		}()
+	}
 
-		// Wait and close results
-		wg.Wait()
-		close(results)
-		close(errChan)
-
-		// Check for errors
-		if len(errChan) > 0 {
-			return nil, <-errChan
+	// Fan in: the collector keeps draining after a cancellation so no
+	// worker blocks on a full results channel
+	var (
+		collected = make([]processedEntry, 0, len(r.TimeSeries))
+		firstErr  error
+		done      = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		for result := range results {
+			if result.err != nil && opts.StrictParsing {
+				if firstErr == nil {
+					firstErr = result.err
+					cancel()
+				}
+				continue
+			}
+			collected = append(collected, result)
 		}
+	}()
 
-		// Collect results
-		for v := range results {
-			processed.TimeSeries = append(processed.TimeSeries, v)
-		}
+	wg.Wait()
+	close(results)
+	<-done
+
+	if firstErr != nil {
+		return nil, firstErr
 	}
 
-	// Sort by timestamp
-	sort.Slice(processed.TimeSeries, func(i, j int) bool {
-		return processed.TimeSeries[i].Timestamp.Before(processed.TimeSeries[j].Timestamp)
-	})
+	return collected, nil
+}
 
-	return processed, nil
+// location returns the zone named by the metadata's time zone, e.g.
+// "US/Eastern". Missing or unknown zone names fall back to UTC.
+func (r *AlphaVantageResponse) location() *time.Location {
+	return loadLocation(r.MetaData.TimeZone)
 }
 
-// processEntry processes a single time series entry
-func (r *AlphaVantageResponse) processEntry(timestampStr string, ohlcv OHLCV) (models.OHLCVFloat, error) {
-	timestamp, err := time.Parse("2006-01-02 15:04:05", timestampStr)
+// processEntry processes a single time series entry, interpreting its
+// timestamp in loc
+func (r *AlphaVantageResponse) processEntry(loc *time.Location, timestampStr string, ohlcv OHLCV) (models.OHLCVFloat, error) {
+	layout := r.layout
+	if layout == "" {
+		layout = DateLayout
+	}
+
+	timestamp, err := time.ParseInLocation(layout, timestampStr, loc)
 	if err != nil {
 		return models.OHLCVFloat{}, fmt.Errorf("error parsing timestamp %s: %w", timestampStr, err)
 	}
@@ -282,17 +630,37 @@ func (r *AlphaVantageResponse) processEntry(timestampStr string, ohlcv OHLCV) (m
 		return models.OHLCVFloat{}, fmt.Errorf("error parsing close price for %s: %w", timestampStr, err)
 	}
 
-	volume, err := strconv.ParseInt(ohlcv.Volume, 10, 64)
-	if err != nil {
-		return models.OHLCVFloat{}, fmt.Errorf("error parsing volume for %s: %w", timestampStr, err)
+	// FX series do not report volume
+	var volume int64
+	if ohlcv.Volume != "" {
+		volume, err = strconv.ParseInt(ohlcv.Volume, 10, 64)
+		if err != nil {
+			return models.OHLCVFloat{}, fmt.Errorf("error parsing volume for %s: %w", timestampStr, err)
+		}
 	}
 
-	return models.OHLCVFloat{
+	entry := models.OHLCVFloat{
 		Timestamp: timestamp,
 		Open:      open,
 		High:      high,
 		Low:       low,
 		Close:     closePrice,
 		Volume:    volume,
-	}, nil
+	}
+
+	if ohlcv.AdjustedClose != "" {
+		entry.AdjustedClose, err = strconv.ParseFloat(ohlcv.AdjustedClose, 64)
+		if err != nil {
+			return models.OHLCVFloat{}, fmt.Errorf("error parsing adjusted close price for %s: %w", timestampStr, err)
+		}
+	}
+
+	if ohlcv.DividendAmount != "" {
+		entry.DividendAmount, err = strconv.ParseFloat(ohlcv.DividendAmount, 64)
+		if err != nil {
+			return models.OHLCVFloat{}, fmt.Errorf("error parsing dividend amount for %s: %w", timestampStr, err)
+		}
+	}
+
+	return entry, nil
 }
@@ -0,0 +1,30 @@
+package parser
+
+import "testing"
+
+// FuzzIntradayPrices checks that no response body makes the intraday parser
+// panic: malformed input must be rejected with an error. The seed corpus in
+// testdata/fuzz/FuzzIntradayPrices holds the fixtures of the parser tests.
+func FuzzIntradayPrices(f *testing.F) {
+	f.Add([]byte(`{"Meta Data": {"2. Symbol": "IBM"}, "Time Series (5min)": []}`))
+	f.Add([]byte(`{"Meta Data": [], "Time Series (5min)": {}}`))
+	f.Add([]byte(`{"Time Series (5min)": {"2024-01-15 20:00:00": [1, 2, 3]}}`))
+	f.Add([]byte(`[{"Time Series (5min)": {}}]`))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		response, err := IntradayPrices(data)
+		if err != nil {
+			if response != nil {
+				t.Fatalf("IntradayPrices returned a response along with error %v", err)
+			}
+			return
+		}
+
+		if _, err := response.ProcessTimeSeriesWithOptions(ProcessOptions{}); err != nil {
+			t.Fatalf("lenient processing failed: %v", err)
+		}
+		_, _ = response.ProcessTimeSeries()
+		_ = response.SeriesInterval()
+	})
+}
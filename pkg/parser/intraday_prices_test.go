@@ -1,6 +1,10 @@
 package parser
 
 import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -114,7 +118,9 @@ func TestIntradayPrices_ProcessTimeSeries(t *testing.T) {
 
 	// Test first data point (earlier timestamp)
 	firstPoint := processed.TimeSeries[0]
-	expectedTime, _ := time.Parse("2006-01-02 15:04:05", "2024-01-15 15:59:00")
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+	expectedTime, _ := time.ParseInLocation("2006-01-02 15:04:05", "2024-01-15 15:59:00", eastern)
 	assert.Equal(t, expectedTime, firstPoint.Timestamp)
 	assert.Equal(t, 380.20, firstPoint.Open)
 	assert.Equal(t, 380.55, firstPoint.High)
@@ -124,7 +130,7 @@ func TestIntradayPrices_ProcessTimeSeries(t *testing.T) {
 
 	// Test second data point (later timestamp)
 	secondPoint := processed.TimeSeries[1]
-	expectedTime2, _ := time.Parse("2006-01-02 15:04:05", "2024-01-15 16:00:00")
+	expectedTime2, _ := time.ParseInLocation("2006-01-02 15:04:05", "2024-01-15 16:00:00", eastern)
 	assert.Equal(t, expectedTime2, secondPoint.Timestamp)
 	assert.Equal(t, 380.50, secondPoint.Open)
 	assert.Equal(t, 380.75, secondPoint.High)
@@ -298,6 +304,44 @@ func TestIntradayPrices_MalformedTimeSeriesData(t *testing.T) {
 	assert.Contains(t, err.Error(), "error parsing")
 }
 
+func TestIntradayPrices_MalformedShapes(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response string
+		errorMsg string
+	}{
+		{"time series array", `{"Meta Data": {"2. Symbol": "AAPL"}, "Time Series (5min)": [1, 2]}`, `"Time Series (5min)" holds an array, expected an object`},
+		{"time series string", `{"Time Series (5min)": "none"}`, `"Time Series (5min)" holds a string, expected an object`},
+		{"time series null", `{"Time Series (5min)": null}`, `"Time Series (5min)" holds null, expected an object`},
+		{"meta data array", `{"Meta Data": [], "Time Series (5min)": {}}`, "error parsing JSON into structured response"},
+		{"top-level array", `[{"Time Series (5min)": {}}]`, "error parsing JSON into raw map"},
+		{"top-level null", `null`, "no raw data available"},
+		{"deeply nested", `{"Time Series (5min)": {"x": ` + strings.Repeat("[", 100000) + strings.Repeat("]", 100000) + `}}`, "error parsing JSON"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := IntradayPrices([]byte(tc.response))
+			assert.Nil(t, response)
+			assert.ErrorContains(t, err, tc.errorMsg)
+		})
+	}
+}
+
+func TestIntradayPrices_SkipsNonObjectEntries(t *testing.T) {
+	mockResponse := `{
+		"Time Series (5min)": {
+			"2024-01-15 19:55:00": [1, 2, 3],
+			"2024-01-15 20:00:00": {"1. open": "185.50", "2. high": "185.75", "3. low": "185.25", "4. close": "185.60", "5. volume": 125000}
+		}
+	}`
+
+	response, err := IntradayPrices([]byte(mockResponse))
+	require.NoError(t, err)
+	require.Len(t, response.TimeSeries, 1)
+	assert.Empty(t, response.TimeSeries["2024-01-15 20:00:00"].Volume, "Non-string values should be ignored")
+}
+
 func TestIntradayPrices_InvalidTimestamp(t *testing.T) {
 	mockResponse := `{
 		"Meta Data": {
@@ -327,6 +371,90 @@ func TestIntradayPrices_InvalidTimestamp(t *testing.T) {
 	assert.Contains(t, err.Error(), "error parsing timestamp")
 }
 
+// timeSeriesWithBadBar builds a daily series of n valid bars plus one bar
+// whose close price cannot be parsed
+func timeSeriesWithBadBar(n int) *AlphaVantageResponse {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := make(map[string]OHLCV, n+1)
+	for i := range n {
+		series[start.AddDate(0, 0, i).Format(DateLayout)] = OHLCV{
+			Open: "1", High: "2", Low: "0.5", Close: "1.5", Volume: "10",
+		}
+	}
+	series["2019-12-31"] = OHLCV{Open: "1", High: "2", Low: "0.5", Close: "n/a", Volume: "10"}
+
+	return &AlphaVantageResponse{TimeSeries: series, layout: DateLayout}
+}
+
+func TestProcessTimeSeriesWithOptions_Strict(t *testing.T) {
+	for _, n := range []int{3, 1500} {
+		response := timeSeriesWithBadBar(n)
+
+		_, err := response.ProcessTimeSeriesWithOptions(ProcessOptions{StrictParsing: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error parsing close price for 2019-12-31")
+
+		// ProcessTimeSeries parses strictly by default
+		_, err = response.ProcessTimeSeries()
+		require.Error(t, err)
+	}
+}
+
+func TestProcessTimeSeriesWithOptions_Lenient(t *testing.T) {
+	for _, n := range []int{3, 1500} {
+		response := timeSeriesWithBadBar(n)
+
+		processed, err := response.ProcessTimeSeriesWithOptions(ProcessOptions{StrictParsing: false})
+		require.NoError(t, err)
+
+		require.Len(t, processed.TimeSeries, n)
+		assert.Equal(t, 1, processed.SkippedBars)
+		require.Len(t, processed.ParseErrors, 1)
+		assert.Contains(t, processed.ParseErrors[0], "error parsing close price for 2019-12-31")
+
+		assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), processed.TimeSeries[0].Timestamp)
+		for i := 1; i < len(processed.TimeSeries); i++ {
+			assert.True(t, processed.TimeSeries[i-1].Timestamp.Before(processed.TimeSeries[i].Timestamp))
+		}
+	}
+}
+
+func TestProcessTimeSeries_ConcurrentErrorNoLeak(t *testing.T) {
+	response := timeSeriesWithBadBar(4999)
+	require.Len(t, response.TimeSeries, 5000)
+
+	baseline := runtime.NumGoroutine()
+
+	for range 20 {
+		_, err := response.ProcessTimeSeries()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error parsing close price for 2019-12-31")
+
+	}
+
+	// No producer, worker or collector goroutine outlives the calls. Polled
+	// inline since assert.Eventually runs its condition on a goroutine.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseline)
+}
+
+func TestProcessTimeSeriesWithOptions_LenientNoErrors(t *testing.T) {
+	response := &AlphaVantageResponse{
+		TimeSeries: map[string]OHLCV{
+			"2024-01-12": {Open: "1", High: "2", Low: "0.5", Close: "1.5", Volume: "10"},
+		},
+	}
+
+	processed, err := response.ProcessTimeSeriesWithOptions(ProcessOptions{})
+	require.NoError(t, err)
+	assert.Len(t, processed.TimeSeries, 1)
+	assert.Zero(t, processed.SkippedBars)
+	assert.Nil(t, processed.ParseErrors)
+}
+
 func TestProcessTimeSeries_SortingOrder(t *testing.T) {
 	mockResponse := `{
 		"Meta Data": {
@@ -382,8 +510,274 @@ func TestProcessTimeSeries_SortingOrder(t *testing.T) {
 		"2024-01-15 20:00:00",
 	}
 
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+
 	for i, expectedTime := range expectedTimes {
-		expected, _ := time.Parse("2006-01-02 15:04:05", expectedTime)
+		expected, _ := time.ParseInLocation("2006-01-02 15:04:05", expectedTime, eastern)
 		assert.Equal(t, expected, processed.TimeSeries[i].Timestamp)
 	}
 }
+
+func TestTimeSeriesPrices_Monthly(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {
+			"1. Information": "Monthly Prices (open, high, low, close) and Volumes",
+			"2. Symbol": "IBM",
+			"3. Last Refreshed": "2024-01-12",
+			"4. Time Zone": "US/Eastern"
+		},
+		"Monthly Time Series": {
+			"2024-01-12": {
+				"1. open": "162.8300",
+				"2. high": "166.0000",
+				"3. low": "157.8850",
+				"4. close": "165.8000",
+				"5. volume": "38778557"
+			},
+			"2023-12-29": {
+				"1. open": "158.4100",
+				"2. high": "166.3400",
+				"3. low": "158.0000",
+				"4. close": "163.5500",
+				"5. volume": "87358302"
+			}
+		}
+	}`
+
+	response, err := TimeSeriesPrices([]byte(mockResponse))
+	require.NoError(t, err)
+	assert.Equal(t, "IBM", response.MetaData.Symbol)
+	assert.Equal(t, "US/Eastern", response.MetaData.TimeZone)
+
+	processed, err := response.ProcessTimeSeries()
+	require.NoError(t, err)
+	require.Len(t, processed.TimeSeries, 2)
+
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 29, 0, 0, 0, 0, eastern), processed.TimeSeries[0].Timestamp)
+	assert.Equal(t, 163.55, processed.TimeSeries[0].Close)
+	assert.Equal(t, int64(87358302), processed.TimeSeries[0].Volume)
+	assert.Zero(t, processed.TimeSeries[0].AdjustedClose)
+}
+
+func TestProcessTimeSeries_DefaultLayout(t *testing.T) {
+	response := &AlphaVantageResponse{
+		TimeSeries: map[string]OHLCV{
+			"2024-01-12": {Open: "1", High: "2", Low: "0.5", Close: "1.5", Volume: "10"},
+		},
+	}
+
+	processed, err := response.ProcessTimeSeries()
+	require.NoError(t, err)
+	require.Len(t, processed.TimeSeries, 1)
+	assert.Equal(t, time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), processed.TimeSeries[0].Timestamp)
+}
+
+func TestTimeSeriesPrices_DailyMetaData(t *testing.T) {
+	// Daily metadata has no interval, so output size and time zone shift up
+	mockResponse := `{
+		"Meta Data": {
+			"1. Information": "Daily Prices (open, high, low, close) and Volumes",
+			"2. Symbol": "IBM",
+			"3. Last Refreshed": "2024-01-12",
+			"4. Output Size": "Compact",
+			"5. Time Zone": "US/Eastern"
+		},
+		"Time Series (Daily)": {
+			"2024-01-12": {
+				"1. open": "162.9700",
+				"2. high": "166.0000",
+				"3. low": "162.6100",
+				"4. close": "165.8000",
+				"5. volume": "4930839"
+			}
+		}
+	}`
+
+	response, err := TimeSeriesPrices([]byte(mockResponse))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Daily Prices (open, high, low, close) and Volumes", response.MetaData.Information)
+	assert.Equal(t, "IBM", response.MetaData.Symbol)
+	assert.Equal(t, "2024-01-12", response.MetaData.LastRefreshed)
+	assert.Empty(t, response.MetaData.Interval)
+	assert.Equal(t, "Compact", response.MetaData.OutputSize)
+	assert.Equal(t, "US/Eastern", response.MetaData.TimeZone)
+}
+
+func TestIntradayPrices_ReorderedMetaData(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {
+			"1. Symbol": "AAPL",
+			"2: Interval": "5min",
+			"3. last refreshed": "2024-01-15 20:00:00",
+			"4. Information": "Intraday (5min) open, high, low, close prices and volume",
+			"5. Time Zone (Exchange)": "US/Eastern"
+		},
+		"Time Series (5min)": {
+			"2024-01-15 20:00:00": {
+				"1. open": "185.50",
+				"2. high": "185.75",
+				"3. low": "185.25",
+				"4. close": "185.60",
+				"5. volume": "125000"
+			}
+		}
+	}`
+
+	response, err := IntradayPrices([]byte(mockResponse))
+	require.NoError(t, err)
+
+	assert.Equal(t, "AAPL", response.MetaData.Symbol)
+	assert.Equal(t, "5min", response.MetaData.Interval)
+	assert.Equal(t, "2024-01-15 20:00:00", response.MetaData.LastRefreshed)
+	assert.Equal(t, "Intraday (5min) open, high, low, close prices and volume", response.MetaData.Information)
+	assert.Equal(t, "US/Eastern", response.MetaData.TimeZone)
+	assert.Empty(t, response.MetaData.OutputSize)
+}
+
+func TestMetaDataKeyName(t *testing.T) {
+	assert.Equal(t, "symbol", metaDataKeyName("2. Symbol"))
+	assert.Equal(t, "last refreshed", metaDataKeyName("3: Last Refreshed"))
+	assert.Equal(t, "time zone", metaDataKeyName("Time Zone"))
+	assert.Equal(t, "output size", metaDataKeyName("10. Output Size"))
+}
+
+func TestProcessTimeSeries_TimeZone(t *testing.T) {
+	eastern, err := time.LoadLocation("US/Eastern")
+	require.NoError(t, err)
+
+	response := &AlphaVantageResponse{
+		MetaData: MetaData{TimeZone: "US/Eastern"},
+		TimeSeries: map[string]OHLCV{
+			// EST (UTC-5) in January, EDT (UTC-4) in July
+			"2024-01-15 09:30:00": {Open: "1", High: "2", Low: "0.5", Close: "1.5", Volume: "10"},
+			"2024-07-15 09:30:00": {Open: "1", High: "2", Low: "0.5", Close: "1.5", Volume: "10"},
+		},
+		layout: IntradayLayout,
+	}
+
+	processed, err := response.ProcessTimeSeries()
+	require.NoError(t, err)
+	require.Len(t, processed.TimeSeries, 2)
+
+	winter := processed.TimeSeries[0].Timestamp
+	assert.Equal(t, eastern, winter.Location())
+	assert.Equal(t, time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC), winter.UTC())
+	_, offset := winter.Zone()
+	assert.Equal(t, -5*60*60, offset)
+
+	summer := processed.TimeSeries[1].Timestamp
+	assert.Equal(t, time.Date(2024, 7, 15, 13, 30, 0, 0, time.UTC), summer.UTC())
+	_, offset = summer.Zone()
+	assert.Equal(t, -4*60*60, offset)
+}
+
+func TestProcessTimeSeries_UnknownTimeZone(t *testing.T) {
+	for _, zone := range []string{"", "Mars/Olympus_Mons"} {
+		response := &AlphaVantageResponse{
+			MetaData: MetaData{TimeZone: zone},
+			TimeSeries: map[string]OHLCV{
+				"2024-01-15 09:30:00": {Open: "1", High: "2", Low: "0.5", Close: "1.5", Volume: "10"},
+			},
+			layout: IntradayLayout,
+		}
+
+		processed, err := response.ProcessTimeSeries()
+		require.NoError(t, err)
+		require.Len(t, processed.TimeSeries, 1)
+		assert.Equal(t, time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC), processed.TimeSeries[0].Timestamp)
+	}
+}
+
+func TestTimeSeriesPrices_AdjustedVolume(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {"2. Symbol": "IBM"},
+		"Monthly Adjusted Time Series": {
+			"2024-01-31": {"1. open": "162.8300", "2. high": "196.9000", "3. low": "157.8850", "4. close": "183.6600", "5. adjusted close": "178.5000", "6. volume": "20871633", "7. dividend amount": "0.0000"}
+		}
+	}`
+
+	response, err := TimeSeriesPrices([]byte(mockResponse))
+	require.NoError(t, err)
+
+	entry := response.TimeSeries["2024-01-31"]
+	assert.Equal(t, "20871633", entry.Volume, "6. volume should be read as the volume")
+	assert.Equal(t, "178.5000", entry.AdjustedClose)
+	assert.Equal(t, "0.0000", entry.DividendAmount)
+}
+
+func TestIntradayPricesFrom_MatchesBuffered(t *testing.T) {
+	data := largeIntradayResponse(5000)
+
+	buffered, err := IntradayPrices(data)
+	require.NoError(t, err)
+
+	streamed, err := IntradayPricesFrom(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	assert.Equal(t, buffered.MetaData, streamed.MetaData)
+	assert.Equal(t, buffered.TimeSeries, streamed.TimeSeries)
+	assert.Len(t, streamed.TimeSeries, 5000)
+}
+
+func TestIntradayPricesFrom_APIError(t *testing.T) {
+	body := `{"Error Message": "Invalid API call."}`
+
+	_, err := IntradayPricesFrom(strings.NewReader(body))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid API call")
+}
+
+func TestIntradayPricesFrom_InvalidJSON(t *testing.T) {
+	_, err := IntradayPricesFrom(strings.NewReader(`{"Meta Data": {`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error parsing JSON")
+}
+
+func TestTimeSeriesPricesFrom(t *testing.T) {
+	body := `{
+		"Meta Data": {"2. Symbol": "IBM"},
+		"Weekly Time Series": {
+			"2024-01-12": {"1. open": "161.0000", "2. high": "165.9000", "3. low": "158.6000", "4. close": "165.8000", "5. volume": "20185000"}
+		}
+	}`
+
+	response, err := TimeSeriesPricesFrom(strings.NewReader(body))
+	require.NoError(t, err)
+	assert.Equal(t, "IBM", response.MetaData.Symbol)
+	assert.Equal(t, "165.8000", response.TimeSeries["2024-01-12"].Close)
+}
+
+// largeIntradayResponse builds a TIME_SERIES_INTRADAY response with n one-minute bars
+func largeIntradayResponse(n int) []byte {
+	var b strings.Builder
+	b.WriteString(`{"Meta Data": {"1. Information": "Intraday (1min) open, high, low, close prices and volume", "2. Symbol": "IBM", "3. Last Refreshed": "2024-01-31 19:59:00", "4. Interval": "1min", "5. Output Size": "Full size", "6. Time Zone": "US/Eastern"}, "Time Series (1min)": {`)
+
+	start := time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)
+	for i := range n {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `"%s": {"1. open": "185.5000", "2. high": "185.7500", "3. low": "185.2500", "4. close": "185.6000", "5. volume": "%d"}`,
+			start.Add(time.Duration(i)*time.Minute).Format(IntradayLayout), 1000+i)
+	}
+
+	b.WriteString("}}")
+	return []byte(b.String())
+}
+
+func BenchmarkIntradayPrices(b *testing.B) {
+	// Roughly a month of full-size one-minute bars
+	data := largeIntradayResponse(20000)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	for b.Loop() {
+		if _, err := IntradayPrices(data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,18 +1,31 @@
 package parser
 
-import (
-	"io"
-
-	"github.com/bytedance/sonic"
-)
+import "io"
 
 // JSON represents a high-performance JSON parser optimized for financial data.
-// It uses sonic's optimized configuration to provide better performance
-// for API responses containing stock/market data.
-// Note: sonic.API is thread-safe, no additional locking is required.
+// Built with the sonic tag, it uses sonic's optimized configuration to
+// provide better performance for API responses containing stock/market
+// data; otherwise, or on platforms sonic does not support, it uses
+// encoding/json.
+// Note: both implementations are thread-safe, no additional locking is required.
 type JSON struct {
-	// config holds the sonic API configuration
-	config sonic.API
+	// config holds the JSON codec configuration
+	config codec
+}
+
+// JSONOptions controls how a JSON parser decodes numbers.
+type JSONOptions struct {
+	// LosslessNumbers decodes numbers into interface values as json.Number,
+	// which keeps the exact literal, instead of float64. Market caps and
+	// other monetary values in the trillions can then be converted with
+	// NullableBigFloat without float64 rounding.
+	LosslessNumbers bool
+}
+
+// DefaultJSONOptions returns the options used by NewJSON, which keep
+// numbers lossless.
+func DefaultJSONOptions() JSONOptions {
+	return JSONOptions{LosslessNumbers: true}
 }
 
 // NewJSON creates a new optimized JSON parser instance.
@@ -21,16 +34,13 @@ type JSON struct {
 //
 // Returns a thread-safe parser ready for concurrent use.
 func NewJSON() *JSON {
-	config := sonic.Config{
-		UseNumber:        true,
-		EscapeHTML:       false,
-		CompactMarshaler: true,
-		CopyString:       true,
-		ValidateString:   true,
-	}.Froze()
+	return NewJSONWithOptions(DefaultJSONOptions())
+}
 
+// NewJSONWithOptions creates a JSON parser using the given options.
+func NewJSONWithOptions(opts JSONOptions) *JSON {
 	return &JSON{
-		config: config,
+		config: newParserCodec(opts.LosslessNumbers),
 	}
 }
 
@@ -42,10 +52,9 @@ func NewJSON() *JSON {
 //   - src: io.Reader containing JSON data
 //
 // Returns error if parsing fails or if input is invalid.
-// Note: sonic decoder is thread-safe, no locking needed.
+// Note: the decoder is thread-safe, no locking needed.
 func (j *JSON) Parse(dst any, src io.Reader) error {
-	decoder := j.config.NewDecoder(src)
-	return decoder.Decode(dst)
+	return j.config.Decode(src, dst)
 }
 
 // ParseBytes parses JSON data directly from byte slice into the provided destination.
@@ -77,7 +86,7 @@ func (j *JSON) ParseString(dst any, data string) error {
 }
 
 // MarshalBytes marshals the provided data into JSON byte slice.
-// Uses the optimized sonic configuration for consistent, high-performance serialization.
+// Uses the optimized codec configuration for consistent, high-performance serialization.
 //
 // Parameters:
 //   - src: Data to marshal into JSON
@@ -98,9 +107,10 @@ func (j *JSON) MarshalString(src any) (string, error) {
 	return j.config.MarshalToString(src)
 }
 
-// Config returns the underlying sonic configuration.
+// Config returns the underlying JSON configuration, a sonic.API unless the
+// encoding/json fallback is in use.
 // This can be useful for debugging or advanced customization.
-func (j *JSON) Config() sonic.API {
+func (j *JSON) Config() API {
 	return j.config
 }
 
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON_LosslessMarketCap(t *testing.T) {
+	// 13 digits plus cents is beyond the 15-17 significant digits of float64
+	const marketCap = "2987654321098.37"
+	data := []byte(`{"Symbol": "AAPL", "MarketCapitalization": ` + marketCap + `}`)
+
+	var overview map[string]any
+	require.NoError(t, NewJSON().ParseBytes(&overview, data))
+
+	number, ok := overview["MarketCapitalization"].(json.Number)
+	require.True(t, ok, "Numbers must decode into json.Number, got %T", overview["MarketCapitalization"])
+	assert.Equal(t, marketCap, number.String())
+
+	exact := NullableBigFloat(number.String())
+	require.NotNil(t, exact)
+	assert.Equal(t, marketCap, exact.Text('f', -1))
+
+	asFloat, err := number.Float64()
+	require.NoError(t, err)
+	assert.NotZero(t, exact.Cmp(big.NewFloat(asFloat)), "float64 rounds the value")
+}
+
+func TestJSON_WithoutLosslessNumbers(t *testing.T) {
+	var overview map[string]any
+	parser := NewJSONWithOptions(JSONOptions{LosslessNumbers: false})
+	require.NoError(t, parser.ParseBytes(&overview, []byte(`{"MarketCapitalization": 2987654321098}`)))
+
+	assert.Equal(t, float64(2987654321098), overview["MarketCapitalization"])
+}
+
+func TestNullableBigFloat(t *testing.T) {
+	value := NullableBigFloat(" 2987654321098 ")
+	require.NotNil(t, value)
+	assert.Equal(t, "2987654321098", value.Text('f', -1))
+
+	assert.Nil(t, NullableBigFloat(""))
+	assert.Nil(t, NullableBigFloat("None"))
+	assert.Nil(t, NullableBigFloat("-"))
+}
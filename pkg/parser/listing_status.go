@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// listingStatusColumns maps LISTING_STATUS CSV header names to the field they populate
+var listingStatusColumns = map[string]func(*models.ListingStatus, string){
+	"symbol":        func(l *models.ListingStatus, v string) { l.Symbol = v },
+	"name":          func(l *models.ListingStatus, v string) { l.Name = v },
+	"exchange":      func(l *models.ListingStatus, v string) { l.Exchange = v },
+	"assettype":     func(l *models.ListingStatus, v string) { l.AssetType = v },
+	"ipodate":       func(l *models.ListingStatus, v string) { l.IPODate = v },
+	"delistingdate": func(l *models.ListingStatus, v string) { l.DelistingDate = v },
+	"status":        func(l *models.ListingStatus, v string) { l.Status = v },
+}
+
+// ListingStatus parses a LISTING_STATUS response, which is always CSV with
+// the header "symbol,name,exchange,assetType,ipoDate,delistingDate,status".
+//
+// Rows are read one at a time, as the listing holds thousands of symbols.
+// The "null" Alpha Vantage writes for missing dates becomes an empty string.
+func ListingStatus(csvData []byte) ([]models.ListingStatus, error) {
+	if err := checkCSVResponse(csvData); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(csvData))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	var (
+		setters  []func(*models.ListingStatus, string)
+		listings []models.ListingStatus
+	)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading CSV: %w", err)
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if setters == nil {
+			setters, err = listingStatusHeader(record)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if len(record) != len(setters) {
+			return nil, fmt.Errorf("CSV row has %d fields, expected %d: %s",
+				len(record), len(setters), strings.Join(record, ","))
+		}
+
+		var listing models.ListingStatus
+		for i, set := range setters {
+			value := strings.TrimSpace(record[i])
+			if set == nil || value == "null" {
+				continue
+			}
+			set(&listing, value)
+		}
+		listings = append(listings, listing)
+	}
+
+	if setters == nil {
+		return nil, fmt.Errorf("no CSV header found in response")
+	}
+
+	return listings, nil
+}
+
+// listingStatusHeader returns the setter for each column of the header row,
+// nil for unknown columns
+func listingStatusHeader(record []string) ([]func(*models.ListingStatus, string), error) {
+	setters := make([]func(*models.ListingStatus, string), len(record))
+	hasSymbol := false
+	for i, column := range record {
+		name := strings.ToLower(strings.TrimSpace(column))
+		setters[i] = listingStatusColumns[name]
+		hasSymbol = hasSymbol || name == "symbol"
+	}
+
+	if !hasSymbol {
+		return nil, fmt.Errorf("CSV header is missing the %q column", "symbol")
+	}
+
+	return setters, nil
+}
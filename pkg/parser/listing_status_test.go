@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/models"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+const listingStatusCSV = `symbol,name,exchange,assetType,ipoDate,delistingDate,status
+A,Agilent Technologies Inc,NYSE,Stock,1999-11-18,null,Active
+AAA,"Listed Funds Trust - AAF First Priority CLO Bond ETF",NYSE ARCA,ETF,2020-09-09,null,Active
+
+AAPL,Apple Inc,NASDAQ,Stock,1980-12-12,null,Active
+`
+
+func TestListingStatus(t *testing.T) {
+	listings, err := ListingStatus([]byte(listingStatusCSV))
+	require.NoError(t, err)
+	require.Len(t, listings, 3)
+
+	assert.Equal(t, models.ListingStatus{
+		Symbol:    "A",
+		Name:      "Agilent Technologies Inc",
+		Exchange:  "NYSE",
+		AssetType: "Stock",
+		IPODate:   "1999-11-18",
+		Status:    "Active",
+	}, listings[0])
+	assert.Equal(t, "Listed Funds Trust - AAF First Priority CLO Bond ETF", listings[1].Name)
+	assert.Equal(t, "ETF", listings[1].AssetType)
+	assert.Equal(t, "AAPL", listings[2].Symbol)
+}
+
+func TestListingStatus_Delisted(t *testing.T) {
+	csvData := "symbol,name,exchange,assetType,ipoDate,delistingDate,status\r\n" +
+		"AAC-U,Ares Acquisition Corp - Units,NYSE,Stock,2021-02-02,2023-11-06,Delisted\r\n"
+
+	listings, err := ListingStatus([]byte(csvData))
+	require.NoError(t, err)
+	require.Len(t, listings, 1)
+	assert.Equal(t, "2023-11-06", listings[0].DelistingDate)
+	assert.Equal(t, "Delisted", listings[0].Status)
+}
+
+func TestListingStatus_LargeListing(t *testing.T) {
+	var builder strings.Builder
+	builder.WriteString("symbol,name,exchange,assetType,ipoDate,delistingDate,status\n")
+	for range 5000 {
+		builder.WriteString("XYZ,Example Corp,NASDAQ,Stock,2000-01-03,null,Active\n")
+	}
+
+	listings, err := ListingStatus([]byte(builder.String()))
+	require.NoError(t, err)
+	assert.Len(t, listings, 5000)
+}
+
+func TestListingStatus_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		errMsg   string
+		sentinel error
+	}{
+		{"rate limit JSON", `{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute."}`, "", errors.ErrRateLimited},
+		{"missing symbol column", "name,exchange\nApple Inc,NASDAQ\n", `missing the "symbol" column`, nil},
+		{"ragged row", "symbol,name,exchange,assetType,ipoDate,delistingDate,status\nAAPL,Apple Inc\n", "CSV row has 2 fields, expected 7", nil},
+		{"empty body", "", "no CSV header found", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ListingStatus([]byte(tc.body))
+			require.Error(t, err)
+			if tc.sentinel != nil {
+				assert.ErrorIs(t, err, tc.sentinel)
+			}
+			if tc.errMsg != "" {
+				assert.Contains(t, err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// NewsTimeLayout is the layout of NEWS_SENTIMENT timestamps, e.g. "20240112T153000".
+const NewsTimeLayout = "20060102T150405"
+
+type newsTickerSentiment struct {
+	Ticker               string `json:"ticker"`
+	RelevanceScore       string `json:"relevance_score"`
+	TickerSentimentScore string `json:"ticker_sentiment_score"`
+	TickerSentimentLabel string `json:"ticker_sentiment_label"`
+}
+
+type newsArticle struct {
+	Title                 string                `json:"title"`
+	URL                   string                `json:"url"`
+	TimePublished         string                `json:"time_published"`
+	Summary               string                `json:"summary"`
+	Source                string                `json:"source"`
+	OverallSentimentScore float64               `json:"overall_sentiment_score"`
+	OverallSentimentLabel string                `json:"overall_sentiment_label"`
+	TickerSentiment       []newsTickerSentiment `json:"ticker_sentiment"`
+}
+
+type newsSentimentResponse struct {
+	Items string        `json:"items"`
+	Feed  []newsArticle `json:"feed"`
+}
+
+// NewsSentiment parses a NEWS_SENTIMENT response into typed articles.
+// Numeric strings are converted to floats and publication times to time.Time.
+func NewsSentiment(jsonData []byte) (*models.NewsSentimentOutput, error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	var response newsSentimentResponse
+	if err := unmarshal(jsonData, &response); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into structured response: %w", err)
+	}
+
+	output := &models.NewsSentimentOutput{
+		Feed: make([]models.NewsArticle, 0, len(response.Feed)),
+	}
+
+	if response.Items != "" {
+		items, err := strconv.Atoi(response.Items)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing items count %q: %w", response.Items, err)
+		}
+		output.Items = items
+	}
+
+	for _, article := range response.Feed {
+		published, err := time.Parse(NewsTimeLayout, article.TimePublished)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing time published for %q: %w", article.Title, err)
+		}
+
+		tickers := make([]models.TickerSentiment, 0, len(article.TickerSentiment))
+		for _, ts := range article.TickerSentiment {
+			relevance, err := strconv.ParseFloat(ts.RelevanceScore, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing relevance score for %s: %w", ts.Ticker, err)
+			}
+
+			score, err := strconv.ParseFloat(ts.TickerSentimentScore, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing sentiment score for %s: %w", ts.Ticker, err)
+			}
+
+			tickers = append(tickers, models.TickerSentiment{
+				Ticker:         ts.Ticker,
+				RelevanceScore: relevance,
+				SentimentScore: score,
+				SentimentLabel: ts.TickerSentimentLabel,
+			})
+		}
+
+		output.Feed = append(output.Feed, models.NewsArticle{
+			Title:                 article.Title,
+			URL:                   article.URL,
+			TimePublished:         published,
+			Summary:               article.Summary,
+			Source:                article.Source,
+			OverallSentimentScore: article.OverallSentimentScore,
+			OverallSentimentLabel: article.OverallSentimentLabel,
+			TickerSentiment:       tickers,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewsSentiment_Success(t *testing.T) {
+	mockResponse := `{
+		"items": "1",
+		"feed": [{
+			"title": "Markets rally",
+			"url": "https://example.com/rally",
+			"time_published": "20240112T153000",
+			"overall_sentiment_score": -0.2,
+			"overall_sentiment_label": "Somewhat-Bearish",
+			"ticker_sentiment": [
+				{"ticker": "MSFT", "relevance_score": "0.5", "ticker_sentiment_score": "-0.25", "ticker_sentiment_label": "Somewhat-Bearish"}
+			]
+		}]
+	}`
+
+	output, err := NewsSentiment([]byte(mockResponse))
+	require.NoError(t, err)
+	assert.Equal(t, 1, output.Items)
+	require.Len(t, output.Feed, 1)
+	assert.Equal(t, -0.2, output.Feed[0].OverallSentimentScore)
+	assert.Equal(t, -0.25, output.Feed[0].TickerSentiment[0].SentimentScore)
+}
+
+func TestNewsSentiment_RateLimit(t *testing.T) {
+	mockResponse := `{"Information": "You have reached the API rate limit of 25 requests per day."}`
+
+	_, err := NewsSentiment([]byte(mockResponse))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "API rate limit reached")
+}
+
+func TestNewsSentiment_InvalidTimestamp(t *testing.T) {
+	mockResponse := `{"items": "1", "feed": [{"title": "x", "time_published": "2024-01-12"}]}`
+
+	_, err := NewsSentiment([]byte(mockResponse))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error parsing time published")
+}
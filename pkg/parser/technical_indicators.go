@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+// indicatorLayouts are the timestamp layouts used by technical indicator
+// responses; intraday values omit seconds while daily and longer use dates.
+var indicatorLayouts = []string{"2006-01-02 15:04", IntradayLayout, DateLayout}
+
+// TechnicalIndicator parses a technical indicator response such as SMA, EMA,
+// RSI or MACD. The series is stored under "Technical Analysis: <INDICATOR>"
+// and each entry holds the value under the indicator name; MACD entries also
+// carry "MACD_Signal" and "MACD_Hist".
+func TechnicalIndicator(jsonData []byte, indicator string) (*models.IndicatorOutput, error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	series, err := findSeries(rawResponse, "technical analysis")
+	if err != nil {
+		return nil, fmt.Errorf("error extracting indicator values: %w", err)
+	}
+
+	metaData, _ := findSeries(rawResponse, "meta data")
+
+	output := &models.IndicatorOutput{
+		Symbol:        metaDataValue(metaData, "Symbol"),
+		Indicator:     metaDataValue(metaData, "Indicator"),
+		Interval:      metaDataValue(metaData, "Interval"),
+		LastRefreshed: metaDataValue(metaData, "Last Refreshed"),
+		TimeZone:      metaDataValue(metaData, "Time Zone"),
+		Values:        make([]models.IndicatorPoint, 0, len(series)),
+	}
+
+	for timestampStr, entry := range series {
+		values, ok := entry.(map[string]any)
+		if !ok {
+			continue // Skip invalid entries
+		}
+
+		point, err := indicatorPoint(timestampStr, values, indicator)
+		if err != nil {
+			return nil, err
+		}
+
+		output.Values = append(output.Values, point)
+	}
+
+	sort.Slice(output.Values, func(i, j int) bool {
+		return output.Values[i].Timestamp.Before(output.Values[j].Timestamp)
+	})
+
+	return output, nil
+}
+
+// indicatorPoint converts a single indicator entry into an IndicatorPoint
+func indicatorPoint(timestampStr string, values map[string]any, indicator string) (models.IndicatorPoint, error) {
+	timestamp, err := parseIndicatorTimestamp(timestampStr)
+	if err != nil {
+		return models.IndicatorPoint{}, err
+	}
+
+	raw, ok := values[indicator].(string)
+	if !ok {
+		return models.IndicatorPoint{}, fmt.Errorf("missing %s value for %s", indicator, timestampStr)
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return models.IndicatorPoint{}, fmt.Errorf("error parsing %s value for %s: %w", indicator, timestampStr, err)
+	}
+
+	point := models.IndicatorPoint{
+		Timestamp: timestamp,
+		Value:     value,
+	}
+
+	if signal, ok := values[indicator+"_Signal"].(string); ok {
+		point.Signal = NullableFloat(signal)
+	}
+
+	if histogram, ok := values[indicator+"_Hist"].(string); ok {
+		point.Histogram = NullableFloat(histogram)
+	}
+
+	return point, nil
+}
+
+// parseIndicatorTimestamp parses a timestamp using the first matching indicator layout
+func parseIndicatorTimestamp(timestampStr string) (time.Time, error) {
+	for _, layout := range indicatorLayouts {
+		if timestamp, err := time.Parse(layout, timestampStr); err == nil {
+			return timestamp, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("error parsing timestamp %s: unrecognized layout", timestampStr)
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTechnicalIndicator_SMA(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {
+			"1: Symbol": "IBM",
+			"2: Indicator": "Simple Moving Average (SMA)",
+			"3: Last Refreshed": "2024-01-12",
+			"4: Interval": "weekly",
+			"5: Time Period": 10,
+			"6: Series Type": "open",
+			"7: Time Zone": "US/Eastern"
+		},
+		"Technical Analysis: SMA": {
+			"2024-01-12": {"SMA": "161.3150"},
+			"2024-01-05": {"SMA": "159.8720"}
+		}
+	}`
+
+	output, err := TechnicalIndicator([]byte(mockResponse), "SMA")
+	require.NoError(t, err)
+
+	assert.Equal(t, "IBM", output.Symbol)
+	assert.Equal(t, "weekly", output.Interval)
+	assert.Equal(t, "US/Eastern", output.TimeZone)
+	require.Len(t, output.Values, 2)
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), output.Values[0].Timestamp)
+	assert.Equal(t, 161.315, output.Values[1].Value)
+	assert.Nil(t, output.Values[1].Signal)
+}
+
+func TestTechnicalIndicator_MACDIntraday(t *testing.T) {
+	mockResponse := `{
+		"Meta Data": {"1: Symbol": "IBM", "4: Interval": "5min"},
+		"Technical Analysis: MACD": {
+			"2024-01-12 19:55": {"MACD": "0.1234", "MACD_Signal": "0.1000", "MACD_Hist": "0.0234"}
+		}
+	}`
+
+	output, err := TechnicalIndicator([]byte(mockResponse), "MACD")
+	require.NoError(t, err)
+
+	require.Len(t, output.Values, 1)
+	point := output.Values[0]
+	assert.Equal(t, time.Date(2024, 1, 12, 19, 55, 0, 0, time.UTC), point.Timestamp)
+	assert.Equal(t, 0.1234, point.Value)
+	require.NotNil(t, point.Signal)
+	assert.Equal(t, 0.1, *point.Signal)
+	require.NotNil(t, point.Histogram)
+	assert.Equal(t, 0.0234, *point.Histogram)
+}
+
+func TestTechnicalIndicator_NoSeries(t *testing.T) {
+	_, err := TechnicalIndicator([]byte(`{"Meta Data": {}}`), "RSI")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no technical analysis data found")
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/yeferson59/finance-mcp/internal/models"
+)
+
+type transcriptSegment struct {
+	Speaker   string `json:"speaker"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	Sentiment string `json:"sentiment"`
+}
+
+type transcriptResponse struct {
+	Symbol     string              `json:"symbol"`
+	Quarter    string              `json:"quarter"`
+	Transcript []transcriptSegment `json:"transcript"`
+}
+
+// Transcript parses an EARNINGS_CALL_TRANSCRIPT response into speaker
+// segments. Sentiment scores are converted to floats; a missing score is nil.
+func Transcript(jsonData []byte) (*models.TranscriptOutput, error) {
+	var rawResponse map[string]any
+	if err := unmarshal(jsonData, &rawResponse); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into raw map: %w", err)
+	}
+
+	if err := checkAPIMessages(rawResponse); err != nil {
+		return nil, err
+	}
+
+	var response transcriptResponse
+	if err := unmarshal(jsonData, &response); err != nil {
+		return nil, fmt.Errorf("error parsing JSON into structured response: %w", err)
+	}
+
+	output := &models.TranscriptOutput{
+		Symbol:   response.Symbol,
+		Quarter:  response.Quarter,
+		Segments: make([]models.TranscriptSegment, 0, len(response.Transcript)),
+	}
+
+	for i, segment := range response.Transcript {
+		parsed := models.TranscriptSegment{
+			Speaker: segment.Speaker,
+			Title:   segment.Title,
+			Text:    segment.Content,
+		}
+
+		if segment.Sentiment != "" {
+			sentiment, err := strconv.ParseFloat(segment.Sentiment, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing sentiment of segment %d (%s): %w", i, segment.Speaker, err)
+			}
+			parsed.Sentiment = &sentiment
+		}
+
+		output.Segments = append(output.Segments, parsed)
+	}
+
+	return output, nil
+}
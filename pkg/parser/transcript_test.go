@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscript_Success(t *testing.T) {
+	mockResponse := `{
+		"symbol": "IBM",
+		"quarter": "2024Q1",
+		"transcript": [
+			{
+				"speaker": "Olympia McNerney",
+				"title": "Global Head of Investor Relations",
+				"content": "Welcome to IBM's first quarter 2024 earnings presentation.",
+				"sentiment": "0.6"
+			},
+			{
+				"speaker": "Arvind Krishna",
+				"title": "Chairman and Chief Executive Officer",
+				"content": "Thank you for joining us today. We are off to a good start to the year.",
+				"sentiment": "0.7"
+			},
+			{
+				"speaker": "Operator",
+				"title": "Operator",
+				"content": "Our first question comes from the line of Amit Daryanani."
+			}
+		]
+	}`
+
+	output, err := Transcript([]byte(mockResponse))
+	require.NoError(t, err)
+	assert.Equal(t, "IBM", output.Symbol)
+	assert.Equal(t, "2024Q1", output.Quarter)
+	require.Len(t, output.Segments, 3)
+
+	assert.Equal(t, "Arvind Krishna", output.Segments[1].Speaker)
+	assert.Equal(t, "Chairman and Chief Executive Officer", output.Segments[1].Title)
+	assert.Equal(t, "Thank you for joining us today. We are off to a good start to the year.", output.Segments[1].Text)
+	require.NotNil(t, output.Segments[1].Sentiment)
+	assert.Equal(t, 0.7, *output.Segments[1].Sentiment)
+
+	assert.Nil(t, output.Segments[2].Sentiment)
+}
+
+func TestTranscript_RateLimit(t *testing.T) {
+	mockResponse := `{"Information": "You have reached the API rate limit of 25 requests per day."}`
+
+	_, err := Transcript([]byte(mockResponse))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "API rate limit reached")
+}
+
+func TestTranscript_InvalidSentiment(t *testing.T) {
+	mockResponse := `{"symbol": "IBM", "quarter": "2024Q1", "transcript": [{"speaker": "Operator", "content": "Hello", "sentiment": "high"}]}`
+
+	_, err := Transcript([]byte(mockResponse))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "error parsing sentiment of segment 0")
+}
@@ -0,0 +1,217 @@
+package request
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/clock"
+)
+
+// IntradayCacheTTL caps how long responses of intraday functions are cached,
+// since intraday bars go stale within minutes.
+const IntradayCacheTTL = 60 * time.Second
+
+// DefaultStaleAfter is the age beyond which data is reported stale when no
+// threshold is configured. Intraday bars and quotes refresh every few minutes
+// during trading hours, so older data usually means the market is closed.
+const DefaultStaleAfter = time.Hour
+
+// DefaultCacheMaxEntries bounds the responses a cache holds unless
+// WithMaxEntries says otherwise. Keys vary by symbol, interval and range, so
+// a long-running server would otherwise cache without limit, and full price
+// histories run to megabytes each.
+const DefaultCacheMaxEntries = 256
+
+// DefaultCacheMaxBytes bounds the total size of the response bodies a cache
+// holds unless WithMaxBytes says otherwise. Bodies run up to
+// MaxResponseBodySize, so the entry bound alone would let a cache of full
+// intraday series grow to gigabytes.
+const DefaultCacheMaxBytes = 32 * 1024 * 1024
+
+// cacheEntry is a cached response body and its expiry time
+type cacheEntry struct {
+	body      []byte
+	expiresAt time.Time
+}
+
+// Cache stores raw Alpha Vantage response bodies keyed by request URL
+// (without the API key) so repeated identical queries do not spend quota.
+//
+// The cache is bounded by entry count and by the total size of the cached
+// bodies; a body larger than the whole size budget is not cached. Expired
+// entries are removed when they are looked up or to make room. When the
+// cache is full, the entry closest to expiring is evicted.
+//
+// Cache is safe for concurrent use.
+type Cache struct {
+	ttl        time.Duration
+	maxEntries int
+	maxBytes   int
+	entries    map[string]cacheEntry
+	mu         sync.RWMutex
+
+	// size is the total length of the cached bodies
+	size int
+
+	clock clock.Clock
+}
+
+// NewCache creates a cache whose entries expire after ttl
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:        ttl,
+		maxEntries: DefaultCacheMaxEntries,
+		maxBytes:   DefaultCacheMaxBytes,
+		entries:    make(map[string]cacheEntry),
+		clock:      clock.Real,
+	}
+}
+
+// WithMaxEntries bounds the cache to n entries and returns the cache. A
+// non-positive n removes the bound.
+func (c *Cache) WithMaxEntries(n int) *Cache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxEntries = n
+	return c
+}
+
+// WithMaxBytes bounds the total size of the cached bodies to n bytes and
+// returns the cache. A non-positive n removes the bound.
+func (c *Cache) WithMaxBytes(n int) *Cache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxBytes = n
+	return c
+}
+
+// WithClock makes entries expire by the time of c, e.g. a clock.Fake in
+// tests, and returns the cache. A nil c keeps the real clock.
+func (c *Cache) WithClock(clk clock.Clock) *Cache {
+	c.clock = clock.OrReal(clk)
+	return c
+}
+
+// TTLFor returns the time-to-live for responses of the given Alpha Vantage
+// function. Intraday functions are capped at IntradayCacheTTL.
+func (c *Cache) TTLFor(function string) time.Duration {
+	if strings.Contains(strings.ToUpper(function), "INTRADAY") {
+		return min(c.ttl, IntradayCacheTTL)
+	}
+
+	return c.ttl
+}
+
+// Get returns a copy of the cached body for key if present and not
+// expired. An expired entry is removed.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+
+	if !c.clock.Now().Before(entry.expiresAt) {
+		c.removeLocked(key)
+		return nil, false
+	}
+
+	return append([]byte(nil), entry.body...), true
+}
+
+// Set stores a copy of body under key for the given ttl, first making room
+// when the cache is full. Non-positive ttl values and bodies larger than the
+// size budget are ignored, though the latter still replace an entry under
+// key so an older body is not served in their place.
+func (c *Cache) Set(key string, body []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.removeLocked(key)
+	if c.maxBytes > 0 && len(body) > c.maxBytes {
+		return
+	}
+
+	for len(c.entries) > 0 && c.fullLocked(len(body)) {
+		c.evictLocked(len(body))
+	}
+
+	c.entries[key] = cacheEntry{
+		body:      append([]byte(nil), body...),
+		expiresAt: c.clock.Now().Add(ttl),
+	}
+	c.size += len(body)
+}
+
+// fullLocked reports whether a body of size bytes does not fit in the cache;
+// callers must hold c.mu
+func (c *Cache) fullLocked(size int) bool {
+	return (c.maxEntries > 0 && len(c.entries) >= c.maxEntries) ||
+		(c.maxBytes > 0 && c.size+size > c.maxBytes)
+}
+
+// removeLocked removes the entry under key, if any; callers must hold c.mu
+func (c *Cache) removeLocked(key string) {
+	if entry, exists := c.entries[key]; exists {
+		c.size -= len(entry.body)
+		delete(c.entries, key)
+	}
+}
+
+// evictLocked removes every expired entry, or when none has expired and a
+// body of size bytes still does not fit, the entry closest to expiring;
+// callers must hold c.mu and c must not be empty
+func (c *Cache) evictLocked(size int) {
+	now := c.clock.Now()
+
+	var (
+		soonestKey string
+		soonest    time.Time
+	)
+	for key, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			c.removeLocked(key)
+			continue
+		}
+		if soonestKey == "" || entry.expiresAt.Before(soonest) {
+			soonestKey, soonest = key, entry.expiresAt
+		}
+	}
+
+	if soonestKey != "" && c.fullLocked(size) {
+		c.removeLocked(soonestKey)
+	}
+}
+
+// MaxBodySize returns the size of the largest body the cache holds, or 0
+// when the size is unbounded
+func (c *Cache) MaxBodySize() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return max(c.maxBytes, 0)
+}
+
+// Len returns the number of cached entries, including expired ones not yet
+// removed
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.entries)
+}
+
+// Purge removes all cached entries
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cacheEntry)
+	c.size = 0
+}
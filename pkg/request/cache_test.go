@@ -0,0 +1,184 @@
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+)
+
+const cacheTestURL = "https://www.alphavantage.co/query?apikey=test-key&function=OVERVIEW&symbol=IBM"
+
+func newCachedTestClient(mockClient *client.MockClient, ttl time.Duration) *AlphaVantageClient {
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	return NewAlphaVantageClient(mockClient, config).WithCache(ttl)
+}
+
+func TestCache_SecondCallServedFromCache(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(cacheTestURL, &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)})
+
+	alphaClient := newCachedTestClient(mockClient, time.Hour)
+	queries := []Query{NewQuery("function", "OVERVIEW")}
+
+	first, err := NewAlphaWithClient(alphaClient, "IBM", queries).GetWithContext(context.Background())
+	require.NoError(t, err)
+
+	second, err := NewAlphaWithClient(alphaClient, "ibm", queries).GetWithContext(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, mockClient.GetCallCount(cacheTestURL))
+
+	alphaClient.Purge()
+	_, err = NewAlphaWithClient(alphaClient, "IBM", queries).GetWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, mockClient.GetCallCount(cacheTestURL))
+}
+
+func TestCache_ErrorsAreNotCached(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(cacheTestURL, &client.Response{StatusCode: 200, Body: []byte(`{"Error Message": "Invalid API call"}`)})
+
+	alphaClient := newCachedTestClient(mockClient, time.Hour)
+	queries := []Query{NewQuery("function", "OVERVIEW")}
+
+	for range 2 {
+		_, err := NewAlphaWithClient(alphaClient, "IBM", queries).GetWithContext(context.Background())
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, 2, mockClient.GetCallCount(cacheTestURL))
+	assert.Equal(t, 0, alphaClient.cache.Len())
+}
+
+func TestCache_KeyHashesAPIKey(t *testing.T) {
+	alphaClient := newCachedTestClient(client.NewMockClient(), time.Hour)
+
+	key, err := NewAlphaWithClient(alphaClient, "IBM", []Query{NewQuery("function", "OVERVIEW")}).CanonicalURL()
+	require.NoError(t, err)
+	assert.NotContains(t, key, "apikey")
+	assert.NotContains(t, key, alphaClient.config.APIKey)
+	assert.Contains(t, key, "keyhash="+apiKeyHash(alphaClient.config.APIKey))
+	assert.Contains(t, key, "symbol=IBM")
+}
+
+func TestCache_Expiry(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	cache := NewCache(time.Hour)
+	cache.WithClock(fake)
+
+	cache.Set("intraday", []byte("bars"), cache.TTLFor("TIME_SERIES_INTRADAY"))
+	cache.Set("overview", []byte("company"), cache.TTLFor("OVERVIEW"))
+
+	fake.Advance(IntradayCacheTTL)
+	_, ok := cache.Get("intraday")
+	assert.False(t, ok, "intraday entries expire after IntradayCacheTTL")
+
+	body, ok := cache.Get("overview")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("company"), body)
+
+	fake.Advance(time.Hour)
+	_, ok = cache.Get("overview")
+	assert.False(t, ok)
+}
+
+func TestCache_GetRemovesExpiredEntries(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	cache := NewCache(time.Minute).WithClock(fake)
+
+	cache.Set("overview", []byte("company"), time.Minute)
+	fake.Advance(time.Minute)
+
+	_, ok := cache.Get("overview")
+	assert.False(t, ok)
+	assert.Zero(t, cache.Len(), "An expired entry should be removed once looked up")
+}
+
+func TestCache_MaxEntries(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	cache := NewCache(time.Hour).WithClock(fake).WithMaxEntries(2)
+
+	cache.Set("a", []byte("a"), time.Hour)
+	cache.Set("b", []byte("b"), 2*time.Hour)
+	cache.Set("a", []byte("a2"), 3*time.Hour)
+	assert.Equal(t, 2, cache.Len(), "Replacing an entry needs no room")
+
+	// b expires first, so it makes room for c
+	cache.Set("c", []byte("c"), time.Hour)
+	assert.Equal(t, 2, cache.Len())
+	_, ok := cache.Get("b")
+	assert.False(t, ok)
+
+	// Expired entries are swept before live ones are evicted
+	fake.Advance(time.Hour)
+	cache.Set("d", []byte("d"), time.Hour)
+	assert.Equal(t, 2, cache.Len())
+	body, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("a2"), body)
+
+	// Shrinking the bound evicts down to it
+	cache.WithMaxEntries(1)
+	cache.Set("e", []byte("e"), 10*time.Hour)
+	assert.Equal(t, 1, cache.Len())
+	_, ok = cache.Get("e")
+	assert.True(t, ok)
+}
+
+func TestCache_MaxBytes(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC))
+	cache := NewCache(time.Hour).WithClock(fake).WithMaxBytes(10)
+
+	cache.Set("a", []byte("aaaa"), time.Hour)
+	cache.Set("b", []byte("bbbb"), 2*time.Hour)
+	assert.Equal(t, 8, cache.size)
+
+	// a expires first, so it makes room for c
+	cache.Set("c", []byte("cccc"), 3*time.Hour)
+	assert.Equal(t, 2, cache.Len())
+	assert.Equal(t, 8, cache.size)
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+
+	// Replacing an entry counts only its new body
+	cache.Set("b", []byte("bb"), 2*time.Hour)
+	assert.Equal(t, 6, cache.size)
+
+	// A body larger than the budget is not cached and leaves the rest alone
+	cache.Set("big", []byte("0123456789x"), time.Hour)
+	_, ok = cache.Get("big")
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.Len())
+
+	// ...but drops an older body cached under its key
+	cache.Set("c", []byte("0123456789x"), time.Hour)
+	_, ok = cache.Get("c")
+	assert.False(t, ok)
+	assert.Equal(t, 2, cache.size)
+
+	cache.Purge()
+	assert.Zero(t, cache.size)
+}
+
+func TestCache_GetReturnsCopy(t *testing.T) {
+	cache := NewCache(time.Hour)
+	cache.Set("overview", []byte("company"), time.Hour)
+
+	body, ok := cache.Get("overview")
+	require.True(t, ok)
+	body[0] = 'X'
+
+	body, ok = cache.Get("overview")
+	require.True(t, ok)
+	assert.Equal(t, []byte("company"), body, "Changing a returned body must not change the cache")
+}
@@ -15,13 +15,24 @@ package request
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/valyala/fasthttp"
+	"github.com/yeferson59/finance-mcp/internal/clock"
 	"github.com/yeferson59/finance-mcp/pkg/client"
 	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/logging"
+	"golang.org/x/sync/singleflight"
 )
 
 // Query represents a URL query parameter with name and value
@@ -38,20 +49,159 @@ func NewQuery(name string, value string) Query {
 	}
 }
 
+// DefaultQueryPath is the path of the Alpha Vantage query endpoint
+const DefaultQueryPath = "/query"
+
+// DefaultUserAgent is the User-Agent sent to Alpha Vantage when neither the
+// client configuration nor SetDefaultUserAgent sets one
+const DefaultUserAgent = "Finance-MCP-Server/1.0"
+
+// defaultUserAgent is the User-Agent of clients whose configuration sets none
+var defaultUserAgent atomic.Pointer[string]
+
+// SetDefaultUserAgent sets the User-Agent sent by clients whose configuration
+// sets none, so a deployment can identify itself process-wide. An empty
+// userAgent restores DefaultUserAgent.
+func SetDefaultUserAgent(userAgent string) {
+	defaultUserAgent.Store(&userAgent)
+}
+
 // AlphaVantageConfig holds configuration specific to Alpha Vantage API
 type AlphaVantageConfig struct {
-	BaseURL   string
-	APIKey    string
+	BaseURL string
+	APIKey  string
+
+	// UserAgent identifies the client in requests; see HTTPConfig. Empty uses
+	// the SetDefaultUserAgent value.
 	UserAgent string
-	Timeout   time.Duration
+
+	Timeout time.Duration
+
+	// OperationTimeout, when positive, bounds the whole upstream call of
+	// GetWithContext and StreamWithContext: every attempt of the HTTP
+	// client's retry loop and the backoff between them, and for streams the
+	// reading of the body. It applies on top of any deadline of the caller
+	// or Timeout, so per-attempt timeouts and MaxRetries cannot add up to
+	// minutes. Rate limiter waits are not counted.
+	OperationTimeout time.Duration
+
+	// QueryPath is appended to BaseURL unless BaseURL already ends with it,
+	// so both "https://www.alphavantage.co" and ".../query" work. Defaults to
+	// DefaultQueryPath; set "/" for proxies that serve the API at BaseURL itself.
+	QueryPath string
+
+	// Method is the HTTP method used for requests: GET (the default when
+	// empty) sends the parameters in the query string, POST sends them as a
+	// form-encoded body for gateways that require it
+	Method string
+
+	// Entitlement is sent as the entitlement parameter ("realtime" or
+	// "delayed") on requests that do not set one, so premium keys receive
+	// live data by default. Empty sends no entitlement.
+	Entitlement string
+
+	// DefaultInterval and DefaultOutputSize are used by the intraday tool for
+	// inputs that leave interval or outputSize empty, e.g. "5min" and
+	// "compact". Empty leaves the field required or unset as usual.
+	DefaultInterval   string
+	DefaultOutputSize string
+
+	// IntervalOutputSizes maps intraday intervals to the output size used
+	// when the input leaves it empty, taking precedence over
+	// DefaultOutputSize. Nil uses DefaultIntervalOutputSizes for intervals
+	// when DefaultOutputSize is empty too; see IntradayOutputSize.
+	IntervalOutputSizes map[string]string
+
+	// DefaultAdjusted is sent as the adjusted parameter of intraday requests
+	// that leave it unset, so whether bars are split and dividend adjusted
+	// never depends on Alpha Vantage's default. Nil sends no parameter.
+	DefaultAdjusted *bool
+
+	// StaleAfter is the age beyond which tools report their data as stale,
+	// e.g. intraday bars an hour old while the market is closed. Zero uses
+	// DefaultStaleAfter.
+	StaleAfter time.Duration
+
+	// RequestsPerMinute and RequestsPerDay enable client-side rate limiting
+	// when positive (the free tier allows 5 per minute and 25 per day)
+	RequestsPerMinute int
+	RequestsPerDay    int
+
+	// MaxConcurrentRequests bounds the requests in flight at once on the
+	// HTTP client built from HTTPConfig; unlike the rate limits it bounds
+	// concurrency, not rate. Non-positive places no bound.
+	MaxConcurrentRequests int
+
+	// Clock times the rate limiter, the response cache, the staleness of
+	// data and, through HTTPConfig, retry backoff; nil uses the real clock
+	Clock clock.Clock
+}
+
+// Endpoint returns the query endpoint URL: BaseURL with QueryPath appended
+// when it is not already the final path segment(s). Trailing slashes are
+// ignored and the query string of BaseURL, if any, is preserved.
+func (c *AlphaVantageConfig) Endpoint() string {
+	queryPath := c.QueryPath
+	if queryPath == "" {
+		queryPath = DefaultQueryPath
+	}
+	queryPath = strings.Trim(queryPath, "/")
+
+	parsed, err := url.Parse(c.BaseURL)
+	if err != nil || queryPath == "" {
+		return c.BaseURL
+	}
+
+	basePath := strings.TrimRight(parsed.Path, "/")
+	if basePath == "/"+queryPath || strings.HasSuffix(basePath, "/"+queryPath) {
+		return c.BaseURL
+	}
+
+	parsed.Path = basePath + "/" + queryPath
+	parsed.RawPath = ""
+	return parsed.String()
+}
+
+// userAgent returns the User-Agent sent with requests
+func (c *AlphaVantageConfig) userAgent() string {
+	if c.UserAgent != "" {
+		return c.UserAgent
+	}
+
+	if userAgent := defaultUserAgent.Load(); userAgent != nil && *userAgent != "" {
+		return *userAgent
+	}
+
+	return DefaultUserAgent
+}
+
+// HTTPConfig returns client.DefaultConfig with the User-Agent,
+// MaxConcurrentRequests and Clock of c, for the HTTP client that sends its requests
+func (c *AlphaVantageConfig) HTTPConfig() *client.Config {
+	httpConfig := client.DefaultConfig()
+	httpConfig.UserAgent = c.userAgent()
+	httpConfig.MaxConcurrentRequests = c.MaxConcurrentRequests
+	httpConfig.Clock = c.Clock
+
+	return httpConfig
+}
+
+// DefaultIntervalOutputSizes returns the output sizes intraday requests use
+// when nothing is configured: the 100 bars of compact cover under two hours
+// of 1min bars and a day of 5min bars, so those intervals fetch the full
+// series, while compact suits the longer intervals.
+func DefaultIntervalOutputSizes() map[string]string {
+	return map[string]string{
+		"1min": "full",
+		"5min": "full",
+	}
 }
 
 // DefaultAlphaVantageConfig returns default configuration for Alpha Vantage API
 func DefaultAlphaVantageConfig() *AlphaVantageConfig {
 	return &AlphaVantageConfig{
-		BaseURL:   "https://www.alphavantage.co/query",
-		UserAgent: "Finance-MCP-Server/1.0",
-		Timeout:   30 * time.Second,
+		BaseURL: "https://www.alphavantage.co/query",
+		Timeout: 30 * time.Second,
 	}
 }
 
@@ -59,6 +209,27 @@ func DefaultAlphaVantageConfig() *AlphaVantageConfig {
 type AlphaVantageClient struct {
 	httpClient client.HTTPClient
 	config     *AlphaVantageConfig
+
+	// sharedHTTPClient is set by WithSharedHTTPClient when httpClient is
+	// owned and closed elsewhere
+	sharedHTTPClient bool
+
+	// cache holds successful responses when enabled with WithCache
+	cache *Cache
+
+	// limiter throttles requests when rate limits are configured
+	limiter *RateLimiter
+
+	// timeout bounds requests whose context has no deadline. It starts as
+	// config.Timeout and is atomic so SetTimeout can race with requests.
+	timeout atomic.Int64
+
+	// flights merges concurrent identical requests into one upstream call
+	flights singleflight.Group
+
+	// waiters counts, by key, the callers waiting for a call in flights
+	waiters   map[string]int
+	waitersMu sync.Mutex
 }
 
 // NewAlphaVantageClient creates a new Alpha Vantage client with dependency injection
@@ -67,10 +238,17 @@ func NewAlphaVantageClient(httpClient client.HTTPClient, config *AlphaVantageCon
 		config = DefaultAlphaVantageConfig()
 	}
 
-	return &AlphaVantageClient{
+	alphaClient := &AlphaVantageClient{
 		httpClient: httpClient,
 		config:     config,
 	}
+	alphaClient.timeout.Store(int64(config.Timeout))
+
+	if config.RequestsPerMinute > 0 || config.RequestsPerDay > 0 {
+		alphaClient.limiter = NewRateLimiter(config.RequestsPerMinute, config.RequestsPerDay).WithClock(config.Clock)
+	}
+
+	return alphaClient
 }
 
 // NewDefaultAlphaVantageClient creates a client with FastHTTP implementation and default config
@@ -78,8 +256,7 @@ func NewDefaultAlphaVantageClient(apiKey string) *AlphaVantageClient {
 	config := DefaultAlphaVantageConfig()
 	config.APIKey = apiKey
 
-	httpConfig := client.DefaultConfig()
-	httpConfig.UserAgent = config.UserAgent
+	httpConfig := config.HTTPConfig()
 	httpConfig.ReadTimeout = config.Timeout
 	httpConfig.WriteTimeout = config.Timeout
 
@@ -92,10 +269,16 @@ type RequestAlpha struct {
 	client  *AlphaVantageClient
 	symbol  string
 	queries []Query
+
+	// symbolOptional allows functions such as NEWS_SENTIMENT that are not keyed by symbol
+	symbolOptional bool
 }
 
 // NewAlpha creates a new Alpha Vantage request instance using the client
 // This maintains compatibility with the existing API while using the new client internally
+//
+// Deprecated: NewAlpha creates an untestable HTTP client per request. Use
+// NewAlphaWithClient with an injected AlphaVantageClient instead.
 func NewAlpha(baseURL string, apiKey string, symbol string, queries []Query) *RequestAlpha {
 	config := &AlphaVantageConfig{
 		BaseURL: baseURL,
@@ -103,7 +286,7 @@ func NewAlpha(baseURL string, apiKey string, symbol string, queries []Query) *Re
 		Timeout: 30 * time.Second,
 	}
 
-	httpConfig := client.DefaultConfig()
+	httpConfig := config.HTTPConfig()
 	httpClient := client.NewFastHTTPClient(httpConfig)
 	alphaClient := NewAlphaVantageClient(httpClient, config)
 
@@ -124,9 +307,20 @@ func NewAlphaWithClient(alphaClient *AlphaVantageClient, symbol string, queries
 	}
 }
 
+// NewAlphaQueryWithClient creates a request that is not tied to a single symbol.
+// Only the given queries and the API key are sent, which suits functions like
+// NEWS_SENTIMENT that take their own filter parameters.
+func NewAlphaQueryWithClient(alphaClient *AlphaVantageClient, queries []Query) *RequestAlpha {
+	return &RequestAlpha{
+		client:         alphaClient,
+		queries:        queries,
+		symbolOptional: true,
+	}
+}
+
 // validate checks if all required fields are present
 func (ra *RequestAlpha) validate() error {
-	if strings.TrimSpace(ra.symbol) == "" {
+	if !ra.symbolOptional && strings.TrimSpace(ra.symbol) == "" {
 		return errors.ErrSymbolRequired
 	}
 
@@ -138,18 +332,151 @@ func (ra *RequestAlpha) validate() error {
 		return errors.ErrBaseURLRequired
 	}
 
+	switch ra.client.config.Method {
+	case "", fasthttp.MethodGet, fasthttp.MethodPost:
+	default:
+		return fmt.Errorf("unsupported HTTP method '%s': must be GET or POST", ra.client.config.Method)
+	}
+
+	switch ra.client.config.Entitlement {
+	case "", "realtime", "delayed":
+	default:
+		return fmt.Errorf("invalid entitlement '%s': must be realtime or delayed", ra.client.config.Entitlement)
+	}
+
 	return nil
 }
 
 // buildURL constructs the complete API URL with all parameters using URLBuilder
 func (ra *RequestAlpha) buildURL() (string, error) {
-	symbol := strings.ToUpper(strings.TrimSpace(ra.symbol))
-
 	if err := ra.validate(); err != nil {
 		return "", err
 	}
 
-	builder := client.NewURLBuilder(ra.client.config.BaseURL)
+	builder := ra.newURLBuilder()
+	builder.AddParam("apikey", ra.client.config.APIKey)
+
+	return builder.Build()
+}
+
+// RedactedURL builds the request URL without sending it, with the API key
+// replaced by "***", so the exact parameters of a call can be inspected
+// without spending quota or exposing the key.
+//
+// Returns the same validation errors as GetWithContext.
+func (ra *RequestAlpha) RedactedURL() (string, error) {
+	url, err := ra.buildURL()
+	if err != nil {
+		return "", err
+	}
+
+	return logging.RedactAPIKey(url), nil
+}
+
+// redactedError hides the API key in the message of an error that may embed
+// the request URL, keeping the wrapped error available to errors.Is and As
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return logging.RedactAPIKey(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactAPIKey wraps err so its message never contains the API key
+func redactAPIKey(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &redactedError{err: err}
+}
+
+// canonicalUpperParams are the parameters whose values Alpha Vantage treats
+// case-insensitively, so their case does not distinguish requests
+var canonicalUpperParams = []string{"function", "symbol"}
+
+// CanonicalURL returns a deterministic form of the request URL for keying
+// the response cache and concurrent request sharing. Logically identical
+// requests have the same canonical URL whatever the order of their queries
+// and the case of parameter names, of the scheme and host, and of the
+// function and symbol. The API key is replaced by a hash of it, so keys never
+// end up in cache storage while a key with one plan or entitlement is never
+// served a response cached for another.
+func (ra *RequestAlpha) CanonicalURL() (string, error) {
+	built, err := ra.newURLBuilder().Build()
+	if err != nil {
+		return "", err
+	}
+
+	parsed, err := url.Parse(built)
+	if err != nil {
+		return "", fmt.Errorf("invalid request URL: %w", err)
+	}
+
+	params := make(url.Values)
+	for name, values := range parsed.Query() {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "apikey" {
+			continue
+		}
+
+		for _, value := range values {
+			value = strings.TrimSpace(value)
+			if slices.Contains(canonicalUpperParams, name) {
+				value = strings.ToUpper(value)
+			}
+			params[name] = append(params[name], value)
+		}
+	}
+	for _, values := range params {
+		slices.Sort(values)
+	}
+	params.Set("keyhash", apiKeyHash(ra.client.config.APIKey))
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	parsed.RawQuery = params.Encode()
+	return parsed.String(), nil
+}
+
+// apiKeyHash identifies an API key in cache keys without revealing it
+func apiKeyHash(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// function returns the Alpha Vantage function of the request
+func (ra *RequestAlpha) function() string {
+	for _, query := range ra.queries {
+		if query.Name == "function" {
+			return query.Value
+		}
+	}
+
+	return ""
+}
+
+// hasQuery reports whether the request sets the named query parameter
+func (ra *RequestAlpha) hasQuery(name string) bool {
+	for _, query := range ra.queries {
+		if query.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newURLBuilder creates a URLBuilder with the request queries and symbol
+func (ra *RequestAlpha) newURLBuilder() *client.URLBuilder {
+	symbol := strings.ToUpper(strings.TrimSpace(ra.symbol))
+
+	builder := client.NewURLBuilder(ra.client.config.Endpoint())
 
 	// Add custom queries
 	for _, query := range ra.queries {
@@ -161,86 +488,337 @@ func (ra *RequestAlpha) buildURL() (string, error) {
 		builder.AddParam(query.Name, value)
 	}
 
-	builder.AddParam("symbol", symbol)
-	builder.AddParam("apikey", ra.client.config.APIKey)
+	if symbol != "" {
+		builder.AddParam("symbol", symbol)
+	}
 
-	return builder.Build()
+	if entitlement := ra.client.config.Entitlement; entitlement != "" && !ra.hasQuery("entitlement") {
+		builder.AddParam("entitlement", entitlement)
+	}
+
+	return builder
 }
 
-// Get performs the HTTP GET request to Alpha Vantage API
+// Get performs the HTTP request to Alpha Vantage API
+//
+// Deprecated: Get cannot be cancelled. Use GetWithContext instead.
 func (ra *RequestAlpha) Get() ([]byte, error) {
 	return ra.GetWithContext(context.Background())
 }
 
-// GetWithContext performs the HTTP GET request with context support
+// GetWithContext performs the HTTP request with context support, using the
+// configured method (GET unless AlphaVantageConfig.Method is POST).
+//
+// Waiting for the rate limiter is bounded only by ctx, so a caller without a
+// deadline waits for quota as long as it takes; the request itself is then
+// bounded by the client timeout.
 func (ra *RequestAlpha) GetWithContext(ctx context.Context) ([]byte, error) {
 	url, err := ra.buildURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", redactAPIKey(err))
+	}
+
+	key, err := ra.CanonicalURL()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build URL: %w", err)
 	}
 
-	if ctx == context.Background() {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, ra.client.config.Timeout)
+	if ra.client.cache != nil {
+		if body, ok := ra.client.cache.Get(key); ok {
+			logging.FromContext(ctx).DebugContext(ctx, "upstream cache hit",
+				"function", ra.function(), "symbol", ra.symbol)
+			return body, nil
+		}
+	}
+
+	// Concurrent identical requests share one upstream call. The call runs
+	// on a context of its own, so a caller giving up only stops waiting.
+	defer ra.client.awaitFlight(key)()
+	flight := ra.client.flights.DoChan(key, func() (any, error) {
+		flightCtx, cancel := ra.flightContext(ctx)
 		defer cancel()
+
+		return ra.fetch(flightCtx, url, key)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-flight:
+		if stderrors.Is(result.Err, errStreamNotShared) {
+			return ra.fetch(ctx, url, key)
+		}
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return result.Val.([]byte), nil
 	}
+}
 
-	headers := map[string]string{
-		"Cache-Control": "no-cache",
-		"Accept":        "application/json",
+// awaitFlight counts the caller as waiting for the call in flight under key
+// until the returned function is called
+func (c *AlphaVantageClient) awaitFlight(key string) func() {
+	c.waitersMu.Lock()
+	defer c.waitersMu.Unlock()
+
+	if c.waiters == nil {
+		c.waiters = make(map[string]int)
 	}
+	c.waiters[key]++
 
-	response, err := ra.client.httpClient.Get(ctx, url, headers)
+	return func() {
+		c.waitersMu.Lock()
+		defer c.waitersMu.Unlock()
+
+		if c.waiters[key]--; c.waiters[key] == 0 {
+			delete(c.waiters, key)
+		}
+	}
+}
+
+// flightWaiting reports whether any caller is waiting for the call in
+// flight under key
+func (c *AlphaVantageClient) flightWaiting(key string) bool {
+	c.waitersMu.Lock()
+	defer c.waitersMu.Unlock()
+	return c.waiters[key] > 0
+}
+
+// flightContext returns the context of an upstream call shared by concurrent
+// identical requests. It keeps the values of ctx but not its cancellation, so
+// the caller that started the call cannot cut it short for the others. When
+// ctx has a deadline the call lasts until that deadline or the client
+// timeout, whichever is later; otherwise it has no deadline, and
+// operationContext bounds the request once the rate limiter lets it through.
+func (ra *RequestAlpha) flightContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctxDeadline, ok := ctx.Deadline()
+	if !ok {
+		return context.WithCancel(context.WithoutCancel(ctx))
+	}
+
+	if deadline := time.Now().Add(ra.client.Timeout()); !ctxDeadline.After(deadline) {
+		return context.WithDeadlineCause(context.WithoutCancel(ctx), deadline, client.ErrOperationTimeout)
+	}
+
+	return context.WithDeadline(context.WithoutCancel(ctx), ctxDeadline)
+}
+
+// fetch waits for the rate limiter, performs the upstream call of url and
+// caches a successful response under key when caching is enabled
+func (ra *RequestAlpha) fetch(ctx context.Context, url, key string) ([]byte, error) {
+	if ra.client.limiter != nil {
+		if err := ra.client.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	sendCtx, cancel := ra.operationContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	response, err := ra.send(sendCtx, url, requestHeaders())
+	// Transport errors may quote the request URL, including the API key
+	err = redactAPIKey(err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform HTTP request: %w", err)
+		ra.logUpstreamCall(ctx, start, 0, err)
+		return nil, upstreamError(err)
 	}
+	ra.logUpstreamCall(ctx, start, response.StatusCode, nil)
 
 	if response.StatusCode != fasthttp.StatusOK {
-		switch response.StatusCode {
-		case fasthttp.StatusTooManyRequests:
-			return nil, fmt.Errorf("API rate limit exceeded (status %d)", response.StatusCode)
-		case fasthttp.StatusUnauthorized:
-			return nil, fmt.Errorf("invalid API key (status %d)", response.StatusCode)
-		case fasthttp.StatusForbidden:
-			return nil, fmt.Errorf("access forbidden - check API permissions (status %d)", response.StatusCode)
-		default:
-			return nil, fmt.Errorf("%w: received status %d", errors.ErrUnexpectedStatusCode, response.StatusCode)
-		}
+		return nil, statusError(response.StatusCode)
 	}
 
 	if err := ra.checkAPIError(response.Body); err != nil {
 		return nil, err
 	}
 
+	if ra.client.cache != nil {
+		ra.client.cache.Set(key, response.Body, ra.client.cache.TTLFor(ra.function()))
+	}
+
 	return response.Body, nil
 }
 
-// checkAPIError checks if the Alpha Vantage response contains an error message
-// Uses bytes.Contains for better performance by avoiding string allocation
+// operationContext bounds the request made on ctx after the rate limiter
+// wait: by the client timeout when ctx has no deadline, including
+// value-wrapped and TODO contexts, and by the configured OperationTimeout,
+// if any. These deadlines are the client's own, so the HTTP client counts
+// requests failing on them towards its circuit breaker.
+func (ra *RequestAlpha) operationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	cancel := context.CancelFunc(func() {})
+	if _, ok := ctx.Deadline(); !ok {
+		ctx, cancel = context.WithTimeoutCause(ctx, ra.client.Timeout(), client.ErrOperationTimeout)
+	}
+
+	if timeout := ra.client.config.OperationTimeout; timeout > 0 {
+		opCtx, opCancel := context.WithTimeoutCause(ctx, timeout, client.ErrOperationTimeout)
+		return opCtx, func() {
+			opCancel()
+			cancel()
+		}
+	}
+
+	return ctx, cancel
+}
+
+// requestHeaders returns the headers sent with every Alpha Vantage request
+func requestHeaders() map[string]string {
+	return map[string]string{
+		"Cache-Control": "no-cache",
+		"Accept":        "application/json",
+	}
+}
+
+// send dispatches the request with the configured method. For POST the query
+// string of url is moved into a form-encoded body.
+func (ra *RequestAlpha) send(ctx context.Context, url string, headers map[string]string) (*client.Response, error) {
+	if ra.client.config.Method != fasthttp.MethodPost {
+		return ra.client.httpClient.Get(ctx, url, headers)
+	}
+
+	endpoint, form, _ := strings.Cut(url, "?")
+	headers["Content-Type"] = "application/x-www-form-urlencoded"
+
+	return ra.client.httpClient.Post(ctx, endpoint, []byte(form), headers)
+}
+
+// logUpstreamCall logs the outcome of an Alpha Vantage HTTP call
+func (ra *RequestAlpha) logUpstreamCall(ctx context.Context, start time.Time, statusCode int, err error) {
+	attrs := []any{
+		"function", ra.function(),
+		"latency_ms", time.Since(start).Milliseconds(),
+	}
+	if ra.symbol != "" {
+		attrs = append(attrs, "symbol", ra.symbol)
+	}
+
+	logger := logging.FromContext(ctx)
+	if err != nil {
+		logger.WarnContext(ctx, "upstream call failed", append(attrs, "error", err.Error())...)
+		return
+	}
+
+	logger.InfoContext(ctx, "upstream call", append(attrs, "status", statusCode)...)
+}
+
+// upstreamError maps a failed HTTP call to the error returned to callers
+func upstreamError(err error) error {
+	var httpErr *client.HTTPError
+	if stderrors.As(err, &httpErr) {
+		return statusError(httpErr.StatusCode)
+	}
+
+	return fmt.Errorf("failed to perform HTTP request: %w", err)
+}
+
+// statusError describes a non-200 response status from Alpha Vantage
+func statusError(statusCode int) error {
+	switch statusCode {
+	case fasthttp.StatusTooManyRequests:
+		return fmt.Errorf("%w (status %d)", errors.ErrRateLimited, statusCode)
+	case fasthttp.StatusUnauthorized:
+		return fmt.Errorf("%w (status %d)", errors.ErrInvalidAPIKey, statusCode)
+	case fasthttp.StatusForbidden:
+		return fmt.Errorf("access forbidden - check API permissions (status %d)", statusCode)
+	default:
+		return fmt.Errorf("%w: received status %d", errors.ErrUnexpectedStatusCode, statusCode)
+	}
+}
+
+// apiMessageKeys are the top-level keys Alpha Vantage uses to report invalid
+// calls, rate limits and premium endpoints with a 200 status code
+var apiMessageKeys = []string{"Error Message", "Note", "Information"}
+
+// apiMessages returns the messages under apiMessageKeys in a JSON object
+// body, keyed like the body. Only top-level keys count, so text inside the
+// data, e.g. news summaries or call transcripts, is never taken for a
+// message. The body is only decoded when it contains one of the keys, and a
+// body that is not a complete JSON object, such as the truncated start of a
+// large stream, has no messages.
+func apiMessages(body []byte) map[string]string {
+	found := false
+	for _, key := range apiMessageKeys {
+		if bytes.Contains(body, []byte(`"`+key+`"`)) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return nil
+	}
+
+	messages := make(map[string]string)
+	for _, key := range apiMessageKeys {
+		raw, exists := object[key]
+		if !exists {
+			continue
+		}
+
+		var message string
+		if err := json.Unmarshal(raw, &message); err != nil {
+			message = string(raw)
+		}
+		messages[key] = message
+	}
+
+	return messages
+}
+
+// checkAPIError checks if the Alpha Vantage response reports an error under
+// one of apiMessageKeys.
+//
+// Rate limit, premium and invalid key messages wrap errors.ErrRateLimited,
+// errors.ErrPremiumRequired and errors.ErrInvalidAPIKey respectively.
+// Alpha Vantage answers an unknown symbol, function or parameter alike with
+// an invalid call message, which wraps errors.ErrInvalidSymbol when the
+// request carries a symbol and errors.ErrInvalidInput otherwise.
 func (ra *RequestAlpha) checkAPIError(body []byte) error {
+	invalidCall := errors.ErrInvalidInput
+	if strings.TrimSpace(ra.symbol) != "" {
+		invalidCall = errors.ErrInvalidSymbol
+	}
+
+	messages := apiMessages(body)
+	if len(messages) == 0 {
+		return nil
+	}
+
 	errorPatterns := []struct {
-		pattern []byte
-		message string
+		pattern string
+		err     error
 	}{
-		{[]byte("Invalid API call"), "Invalid API function or parameters"},
-		{[]byte("the parameter apikey is invalid"), "Invalid API key"},
-		{[]byte("higher API call frequency"), "API call frequency limit reached"},
-		{[]byte("Thank you for using Alpha Vantage"), "API limit reached - premium key required"},
-		{[]byte("Error Message"), "API returned an error"},
+		{"Invalid API call", fmt.Errorf("%w: Invalid API function or parameters", invalidCall)},
+		{"the parameter apikey is invalid", errors.ErrInvalidAPIKey},
+		{"higher API call frequency", fmt.Errorf("%w: API call frequency limit reached", errors.ErrRateLimited)},
+		{"standard API rate limit", fmt.Errorf("%w: daily request limit reached", errors.ErrRateLimited)},
+		{"premium endpoint", errors.ErrPremiumRequired},
+		{"Thank you for using Alpha Vantage", fmt.Errorf("%w - premium key required", errors.ErrRateLimited)},
 	}
 
 	for _, errorPattern := range errorPatterns {
-		if bytes.Contains(body, errorPattern.pattern) {
-			return fmt.Errorf("API error: %s", errorPattern.message)
+		for _, message := range messages {
+			if strings.Contains(message, errorPattern.pattern) {
+				return fmt.Errorf("API error: %w", errorPattern.err)
+			}
 		}
 	}
 
+	if _, exists := messages["Error Message"]; exists {
+		return fmt.Errorf("API error: %w", stderrors.New("API returned an error"))
+	}
+
 	return nil
 }
 
-// SetTimeout configures the request timeout
+// SetTimeout configures the request timeout of the request's client
 func (ra *RequestAlpha) SetTimeout(timeout time.Duration) *RequestAlpha {
-	ra.client.config.Timeout = timeout
+	ra.client.SetTimeout(timeout)
 	return ra
 }
 
@@ -249,15 +827,21 @@ func (ra *RequestAlpha) GetStats() client.ClientStats {
 	return ra.client.httpClient.Stats()
 }
 
-// Close cleans up resources
+// Close cleans up the resources of the request's client
 func (ra *RequestAlpha) Close() error {
-	return ra.client.httpClient.Close()
+	return ra.client.Close()
 }
 
-// AlphaVantageClientPool manages a pool of Alpha Vantage clients for different API keys
+// AlphaVantageClientPool manages a pool of Alpha Vantage clients for different API keys.
+// When rate limits are configured, each API key gets a single RateLimiter
+// shared by every client created for that key.
+//
+// An AlphaVantageClientPool is safe for concurrent use.
 type AlphaVantageClientPool struct {
-	clients map[string]*AlphaVantageClient
-	config  *AlphaVantageConfig
+	clients  map[string]*AlphaVantageClient
+	limiters map[string]*RateLimiter
+	config   *AlphaVantageConfig
+	mu       sync.RWMutex
 }
 
 // NewAlphaVantageClientPool creates a new client pool
@@ -267,44 +851,87 @@ func NewAlphaVantageClientPool(config *AlphaVantageConfig) *AlphaVantageClientPo
 	}
 
 	return &AlphaVantageClientPool{
-		clients: make(map[string]*AlphaVantageClient),
-		config:  config,
+		clients:  make(map[string]*AlphaVantageClient),
+		limiters: make(map[string]*RateLimiter),
+		config:   config,
 	}
 }
 
 // GetClient returns a client for the specified API key, creating it if necessary
 func (pool *AlphaVantageClientPool) GetClient(apiKey string) *AlphaVantageClient {
-	if client, exists := pool.clients[apiKey]; exists {
-		return client
+	pool.mu.RLock()
+	alphaClient, exists := pool.clients[apiKey]
+	pool.mu.RUnlock()
+	if exists {
+		return alphaClient
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	// Another caller may have created the client while the lock was released
+	if alphaClient, exists := pool.clients[apiKey]; exists {
+		return alphaClient
 	}
 
 	config := *pool.config
 	config.APIKey = apiKey
 
-	httpConfig := client.DefaultConfig()
-	httpConfig.UserAgent = config.UserAgent
+	httpConfig := config.HTTPConfig()
 	httpConfig.ReadTimeout = config.Timeout
 	httpConfig.WriteTimeout = config.Timeout
 
 	httpClient := client.NewFastHTTPClient(httpConfig)
-	alphaClient := NewAlphaVantageClient(httpClient, &config)
+	alphaClient = NewAlphaVantageClient(httpClient, &config).WithRateLimiter(pool.limiterLocked(apiKey))
 
 	pool.clients[apiKey] = alphaClient
 	return alphaClient
 }
 
-// Close closes all clients in the pool
+// Limiter returns the rate limiter shared by all clients using apiKey, or nil
+// if the pool config sets no rate limits. Pass it to WithRateLimiter to make
+// clients created outside the pool draw from the same per-key quota.
+func (pool *AlphaVantageClientPool) Limiter(apiKey string) *RateLimiter {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	return pool.limiterLocked(apiKey)
+}
+
+// limiterLocked implements Limiter; callers must hold pool.mu
+func (pool *AlphaVantageClientPool) limiterLocked(apiKey string) *RateLimiter {
+	if pool.config.RequestsPerMinute <= 0 && pool.config.RequestsPerDay <= 0 {
+		return nil
+	}
+
+	limiter, exists := pool.limiters[apiKey]
+	if !exists {
+		limiter = NewRateLimiter(pool.config.RequestsPerMinute, pool.config.RequestsPerDay).WithClock(pool.config.Clock)
+		pool.limiters[apiKey] = limiter
+	}
+
+	return limiter
+}
+
+// Close closes all clients in the pool. Every client is closed even if some
+// fail; the errors are joined.
 func (pool *AlphaVantageClientPool) Close() error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	var errs []error
 	for _, client := range pool.clients {
 		if err := client.httpClient.Close(); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
-	return nil
+	return stderrors.Join(errs...)
 }
 
 // GetPoolStats returns aggregated statistics for all clients in the pool
 func (pool *AlphaVantageClientPool) GetPoolStats() map[string]client.ClientStats {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
 	stats := make(map[string]client.ClientStats)
 	for apiKey, client := range pool.clients {
 		stats[apiKey] = client.httpClient.Stats()
@@ -317,12 +944,104 @@ func (ac *AlphaVantageClient) GetStats() client.ClientStats {
 	return ac.httpClient.Stats()
 }
 
-// Close cleans up resources used by the Alpha Vantage client
+// Close cleans up resources used by the Alpha Vantage client: it drops the
+// cached responses and closes the HTTP client, unless WithSharedHTTPClient
+// left that to its owner.
 func (ac *AlphaVantageClient) Close() error {
+	ac.Purge()
+
+	if ac.sharedHTTPClient {
+		return nil
+	}
 	return ac.httpClient.Close()
 }
 
-// SetTimeout configures the request timeout for the Alpha Vantage client
+// WithSharedHTTPClient marks the HTTP client as shared with other clients,
+// so Close leaves it open for its owner to close, and returns the client.
+func (ac *AlphaVantageClient) WithSharedHTTPClient() *AlphaVantageClient {
+	ac.sharedHTTPClient = true
+	return ac
+}
+
+// WithCache enables response caching with the given TTL and returns the client.
+// Responses of intraday functions are cached for at most IntradayCacheTTL.
+func (ac *AlphaVantageClient) WithCache(ttl time.Duration) *AlphaVantageClient {
+	ac.cache = NewCache(ttl).WithClock(ac.config.Clock)
+	return ac
+}
+
+// WithRateLimiter replaces the client's rate limiter and returns the client.
+// Pass the same limiter to every client using an API key to share its quota.
+func (ac *AlphaVantageClient) WithRateLimiter(limiter *RateLimiter) *AlphaVantageClient {
+	ac.limiter = limiter
+	return ac
+}
+
+// Purge removes all cached responses. It is a no-op when caching is disabled.
+func (ac *AlphaVantageClient) Purge() {
+	if ac.cache != nil {
+		ac.cache.Purge()
+	}
+}
+
+// StaleAfter returns the age beyond which tools report their data as stale
+func (ac *AlphaVantageClient) StaleAfter() time.Duration {
+	if ac.config.StaleAfter > 0 {
+		return ac.config.StaleAfter
+	}
+
+	return DefaultStaleAfter
+}
+
+// Clock returns the clock of the client's config, or clock.Real if it sets none
+func (ac *AlphaVantageClient) Clock() clock.Clock {
+	return clock.OrReal(ac.config.Clock)
+}
+
+// IntradayDefaults returns the configured default intraday interval and output size
+func (ac *AlphaVantageClient) IntradayDefaults() (interval, outputSize string) {
+	return ac.config.DefaultInterval, ac.config.DefaultOutputSize
+}
+
+// IntradayOutputSize returns the output size of intraday requests for
+// interval that leave it empty: the IntervalOutputSizes entry of the
+// interval, else DefaultOutputSize, else the DefaultIntervalOutputSizes entry
+// when IntervalOutputSizes is nil. Empty lets Alpha Vantage pick compact.
+func (ac *AlphaVantageClient) IntradayOutputSize(interval string) string {
+	if outputSize, ok := ac.config.IntervalOutputSizes[interval]; ok {
+		return outputSize
+	}
+
+	if ac.config.DefaultOutputSize != "" {
+		return ac.config.DefaultOutputSize
+	}
+
+	if ac.config.IntervalOutputSizes == nil {
+		return DefaultIntervalOutputSizes()[interval]
+	}
+
+	return ""
+}
+
+// IntradayAdjusted returns a copy of the configured DefaultAdjusted, or nil
+// when intraday requests leave adjusted to Alpha Vantage
+func (ac *AlphaVantageClient) IntradayAdjusted() *bool {
+	if ac.config.DefaultAdjusted == nil {
+		return nil
+	}
+
+	adjusted := *ac.config.DefaultAdjusted
+	return &adjusted
+}
+
+// Timeout returns the timeout applied to requests whose context has no deadline
+func (ac *AlphaVantageClient) Timeout() time.Duration {
+	return time.Duration(ac.timeout.Load())
+}
+
+// SetTimeout configures the request timeout for the Alpha Vantage client.
+// It is safe to call concurrently with requests; the shared config is not
+// modified.
 func (ac *AlphaVantageClient) SetTimeout(timeout time.Duration) {
-	ac.config.Timeout = timeout
+	ac.timeout.Store(int64(timeout))
 }
@@ -0,0 +1,778 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+	"github.com/yeferson59/finance-mcp/pkg/logging"
+)
+
+const apiErrorTestURL = "https://www.alphavantage.co/query?apikey=test-key&function=OVERVIEW&symbol=IBM"
+
+func newAPIErrorTestRequest(response *client.Response) *RequestAlpha {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(apiErrorTestURL, response)
+
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+
+	return NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+}
+
+func TestGetWithContext_APIErrorSentinels(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		sentinel error
+	}{
+		{
+			name:     "invalid API call",
+			body:     `{"Error Message": "Invalid API call. Please retry or visit the documentation (https://www.alphavantage.co/documentation/) for OVERVIEW."}`,
+			sentinel: errors.ErrInvalidSymbol,
+		},
+		{
+			name:     "call frequency note",
+			body:     `{"Note": "Thank you for using Alpha Vantage! Our standard API call frequency is 5 calls per minute and 500 calls per day. Please visit https://www.alphavantage.co/premium/ if you would like to target a higher API call frequency."}`,
+			sentinel: errors.ErrRateLimited,
+		},
+		{
+			name:     "daily rate limit",
+			body:     `{"Information": "We have detected your API key as TEST and our standard API rate limit is 25 requests per day. Please subscribe to any of the premium plans at https://www.alphavantage.co/premium/ to instantly remove all daily rate limits."}`,
+			sentinel: errors.ErrRateLimited,
+		},
+		{
+			name:     "premium endpoint",
+			body:     `{"Information": "Thank you for using Alpha Vantage! This is a premium endpoint. You may subscribe to any of the premium plans at https://www.alphavantage.co/premium/ to instantly unlock all premium endpoints"}`,
+			sentinel: errors.ErrPremiumRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newAPIErrorTestRequest(&client.Response{StatusCode: 200, Body: []byte(tc.body)})
+
+			_, err := req.GetWithContext(context.Background())
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.sentinel)
+		})
+	}
+}
+
+func TestGetWithContext_MessagesOnlyAtTopLevel(t *testing.T) {
+	// Free text in the data quoting Alpha Vantage's own messages, as news
+	// summaries and call transcripts may, is not an API error
+	bodies := map[string]string{
+		"news summary": `{"items": "1", "feed": [{"title": "Note on data vendors", "summary": "The standard API rate limit of the premium endpoint was raised. Thank you for using Alpha Vantage.", "Information": "Invalid API call"}]}`,
+		"transcript":   `{"symbol": "IBM", "transcript": [{"speaker": "CEO", "content": "Error Message: we hit a higher API call frequency than planned"}]}`,
+	}
+
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			req := newAPIErrorTestRequest(&client.Response{StatusCode: 200, Body: []byte(body)})
+
+			got, err := req.GetWithContext(context.Background())
+			require.NoError(t, err)
+			assert.JSONEq(t, body, string(got))
+		})
+	}
+}
+
+func TestGetWithContext_CanceledContext(t *testing.T) {
+	req := newAPIErrorTestRequest(&client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := req.GetWithContext(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, req.GetStats().TotalRequests, "A cancelled request should not reach the client")
+}
+
+func TestGetWithContext_TooManyRequests(t *testing.T) {
+	req := newAPIErrorTestRequest(&client.Response{StatusCode: 429})
+
+	_, err := req.GetWithContext(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrRateLimited)
+	assert.Contains(t, err.Error(), "status 429")
+}
+
+func TestGetWithContext_UnexpectedStatus(t *testing.T) {
+	req := newAPIErrorTestRequest(&client.Response{StatusCode: 500})
+
+	_, err := req.GetWithContext(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrUnexpectedStatusCode)
+	assert.NotErrorIs(t, err, errors.ErrRateLimited)
+}
+
+func TestGetWithContext_Post(t *testing.T) {
+	const endpoint = "https://www.alphavantage.co/query"
+
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(endpoint, &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)})
+
+	config := &AlphaVantageConfig{
+		BaseURL: endpoint,
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+		Method:  "POST",
+	}
+	req := NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	body, err := req.GetWithContext(context.Background())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Symbol": "IBM"}`, string(body))
+
+	requests := mockClient.GetRequests(endpoint)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "POST", requests[0].Method)
+	assert.Equal(t, "application/x-www-form-urlencoded", requests[0].Headers["Content-Type"])
+	assert.Equal(t, "application/json", requests[0].Headers["Accept"])
+	assert.Equal(t, "apikey=test-key&function=OVERVIEW&symbol=IBM", string(requests[0].Body))
+}
+
+func TestGetWithContext_PostAPIError(t *testing.T) {
+	const endpoint = "https://www.alphavantage.co/query"
+
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(endpoint, &client.Response{StatusCode: 200, Body: []byte(`{"Error Message": "Invalid API call."}`)})
+
+	config := &AlphaVantageConfig{BaseURL: endpoint, APIKey: "test-key", Timeout: 30 * time.Second, Method: "POST"}
+	req := NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	_, err := req.GetWithContext(context.Background())
+	assert.ErrorIs(t, err, errors.ErrInvalidSymbol)
+}
+
+func TestGetWithContext_InvalidCallWithoutSymbol(t *testing.T) {
+	const endpoint = "https://www.alphavantage.co/query?apikey=test-key&function=NOT_A_FUNCTION"
+
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(endpoint, &client.Response{StatusCode: 200, Body: []byte(`{"Error Message": "Invalid API call. Please retry or visit the documentation (https://www.alphavantage.co/documentation/) for NOT_A_FUNCTION."}`)})
+
+	config := &AlphaVantageConfig{BaseURL: "https://www.alphavantage.co/query", APIKey: "test-key", Timeout: 30 * time.Second}
+	req := NewAlphaQueryWithClient(NewAlphaVantageClient(mockClient, config), []Query{
+		NewQuery("function", "NOT_A_FUNCTION"),
+	})
+
+	// Without a symbol the call itself is invalid, not a symbol
+	_, err := req.GetWithContext(context.Background())
+	assert.ErrorIs(t, err, errors.ErrInvalidInput)
+	assert.NotErrorIs(t, err, errors.ErrInvalidSymbol)
+	assert.Equal(t, errors.CodeInvalidInput, errors.CodeOf(err))
+}
+
+func TestGetWithContext_UnsupportedMethod(t *testing.T) {
+	mockClient := client.NewMockClient()
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+		Method:  "PUT",
+	}
+	req := NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	_, err := req.GetWithContext(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported HTTP method 'PUT'")
+	assert.Empty(t, mockClient.Requests(), "No request should be sent")
+}
+
+// deadlineClient records the deadline of the context each request is made with
+type deadlineClient struct {
+	*client.MockClient
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (d *deadlineClient) Get(ctx context.Context, url string, headers map[string]string) (*client.Response, error) {
+	d.deadline, d.hasDeadline = ctx.Deadline()
+	return &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)}, nil
+}
+
+type contextKey struct{}
+
+func TestGetWithContext_AppliesTimeoutWithoutDeadline(t *testing.T) {
+	testCases := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"background", context.Background()},
+		{"todo", context.TODO()},
+		{"value-wrapped", context.WithValue(context.Background(), contextKey{}, "value")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient := &deadlineClient{MockClient: client.NewMockClient()}
+			config := &AlphaVantageConfig{
+				BaseURL: "https://www.alphavantage.co/query",
+				APIKey:  "test-key",
+				Timeout: 30 * time.Second,
+			}
+			req := NewAlphaWithClient(NewAlphaVantageClient(httpClient, config), "IBM", []Query{
+				NewQuery("function", "OVERVIEW"),
+			})
+
+			start := time.Now()
+			_, err := req.GetWithContext(tc.ctx)
+			require.NoError(t, err)
+			require.True(t, httpClient.hasDeadline, "A deadline should be applied")
+			assert.WithinDuration(t, start.Add(config.Timeout), httpClient.deadline, 5*time.Second)
+		})
+	}
+}
+
+func TestGetWithContext_KeepsCallerDeadline(t *testing.T) {
+	httpClient := &deadlineClient{MockClient: client.NewMockClient()}
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	req := NewAlphaWithClient(NewAlphaVantageClient(httpClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	_, err := req.GetWithContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, want, httpClient.deadline, "The caller's deadline should not be replaced")
+}
+
+func TestGetWithContext_LimiterWaitOutlastsTimeout(t *testing.T) {
+	mockClient := client.NewMockClient()
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 10 * time.Millisecond,
+	}
+	fake := clock.NewFake(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	alphaClient := NewAlphaVantageClient(mockClient, config).WithRateLimiter(NewRateLimiter(1, 0).WithClock(fake))
+
+	get := func(symbol string) error {
+		_, err := NewAlphaWithClient(alphaClient, symbol, []Query{NewQuery("function", "OVERVIEW")}).GetWithContext(context.Background())
+		return err
+	}
+	require.NoError(t, get("IBM"))
+
+	// The second request waits a minute for quota, far past the timeout,
+	// which only bounds the request once the limiter lets it through
+	done := make(chan error, 1)
+	go func() { done <- get("AAPL") }()
+	require.Eventually(t, func() bool { return fake.Waiters() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(5 * config.Timeout)
+	fake.Advance(time.Minute)
+
+	require.NoError(t, <-done)
+	assert.Len(t, mockClient.Requests(), 2)
+}
+
+func TestAlphaVantageClient_SetTimeout(t *testing.T) {
+	httpClient := &deadlineClient{MockClient: client.NewMockClient()}
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	alphaClient := NewAlphaVantageClient(httpClient, config)
+
+	alphaClient.SetTimeout(5 * time.Second)
+	assert.Equal(t, 5*time.Second, alphaClient.Timeout())
+	assert.Equal(t, 30*time.Second, config.Timeout, "The shared config should not be modified")
+
+	start := time.Now()
+	_, err := NewAlphaWithClient(alphaClient, "IBM", []Query{NewQuery("function", "OVERVIEW")}).GetWithContext(context.Background())
+	require.NoError(t, err)
+	require.True(t, httpClient.hasDeadline)
+	assert.WithinDuration(t, start.Add(5*time.Second), httpClient.deadline, time.Second)
+}
+
+func TestAlphaVantageClient_IntradayOutputSize(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config AlphaVantageConfig
+		want   map[string]string
+	}{
+		{
+			name:   "built-in defaults",
+			config: AlphaVantageConfig{},
+			want:   map[string]string{"1min": "full", "5min": "full", "15min": "", "60min": ""},
+		},
+		{
+			name:   "default output size replaces the built-in defaults",
+			config: AlphaVantageConfig{DefaultOutputSize: "compact"},
+			want:   map[string]string{"1min": "compact", "5min": "compact", "60min": "compact"},
+		},
+		{
+			name: "interval entries take precedence",
+			config: AlphaVantageConfig{
+				DefaultOutputSize:   "compact",
+				IntervalOutputSizes: map[string]string{"15min": "full"},
+			},
+			want: map[string]string{"1min": "compact", "15min": "full", "60min": "compact"},
+		},
+		{
+			name:   "configured entries replace the built-in defaults",
+			config: AlphaVantageConfig{IntervalOutputSizes: map[string]string{"30min": "full"}},
+			want:   map[string]string{"1min": "", "5min": "", "30min": "full"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alphaClient := NewAlphaVantageClient(client.NewMockClient(), &tc.config)
+			for interval, want := range tc.want {
+				assert.Equal(t, want, alphaClient.IntradayOutputSize(interval), interval)
+			}
+		})
+	}
+}
+
+func TestAlphaVantageConfig_Endpoint(t *testing.T) {
+	testCases := []struct {
+		name      string
+		baseURL   string
+		queryPath string
+		want      string
+	}{
+		{"bare host", "https://www.alphavantage.co", "", "https://www.alphavantage.co/query"},
+		{"bare host with trailing slash", "https://www.alphavantage.co/", "", "https://www.alphavantage.co/query"},
+		{"query path present", "https://www.alphavantage.co/query", "", "https://www.alphavantage.co/query"},
+		{"query path with trailing slash", "https://www.alphavantage.co/query/", "", "https://www.alphavantage.co/query/"},
+		{"proxy prefix", "https://proxy.internal/alpha", "", "https://proxy.internal/alpha/query"},
+		{"proxy prefix with trailing slash", "https://proxy.internal/alpha/", "", "https://proxy.internal/alpha/query"},
+		{"custom query path", "https://proxy.internal", "/av/v1", "https://proxy.internal/av/v1"},
+		{"custom query path present", "https://proxy.internal/av/v1", "av/v1/", "https://proxy.internal/av/v1"},
+		{"disabled query path", "https://proxy.internal/gateway", "/", "https://proxy.internal/gateway"},
+		{"similar suffix", "https://proxy.internal/myquery", "", "https://proxy.internal/myquery/query"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &AlphaVantageConfig{BaseURL: tc.baseURL, QueryPath: tc.queryPath}
+			assert.Equal(t, tc.want, config.Endpoint())
+		})
+	}
+}
+
+func TestGetWithContext_AppendsQueryPath(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(apiErrorTestURL, &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)})
+
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	req := NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	_, err := req.GetWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, mockClient.GetRequests(apiErrorTestURL), 1, "Request should be sent to the /query endpoint")
+}
+
+func TestGetWithContext_DefaultEntitlement(t *testing.T) {
+	testCases := []struct {
+		name    string
+		queries []Query
+		wantURL string
+	}{
+		{
+			name:    "config default",
+			queries: []Query{NewQuery("function", "TIME_SERIES_INTRADAY")},
+			wantURL: "https://www.alphavantage.co/query?apikey=test-key&entitlement=realtime&function=TIME_SERIES_INTRADAY&symbol=IBM",
+		},
+		{
+			name:    "request overrides default",
+			queries: []Query{NewQuery("function", "TIME_SERIES_INTRADAY"), NewQuery("entitlement", "delayed")},
+			wantURL: "https://www.alphavantage.co/query?apikey=test-key&entitlement=delayed&function=TIME_SERIES_INTRADAY&symbol=IBM",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := client.NewMockClient()
+			config := &AlphaVantageConfig{
+				BaseURL:     "https://www.alphavantage.co/query",
+				APIKey:      "test-key",
+				Timeout:     30 * time.Second,
+				Entitlement: "realtime",
+			}
+			req := NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", tc.queries)
+
+			_, err := req.GetWithContext(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, mockClient.GetRequests(tc.wantURL), 1)
+		})
+	}
+}
+
+func TestGetWithContext_InvalidEntitlement(t *testing.T) {
+	mockClient := client.NewMockClient()
+	config := &AlphaVantageConfig{
+		BaseURL:     "https://www.alphavantage.co/query",
+		APIKey:      "test-key",
+		Timeout:     30 * time.Second,
+		Entitlement: "live",
+	}
+	req := NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", []Query{
+		NewQuery("function", "TIME_SERIES_INTRADAY"),
+	})
+
+	_, err := req.GetWithContext(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid entitlement 'live'")
+	assert.Empty(t, mockClient.Requests())
+}
+
+func TestRequestAlpha_CanonicalURL(t *testing.T) {
+	newClient := func(baseURL, apiKey string) *AlphaVantageClient {
+		return NewAlphaVantageClient(client.NewMockClient(), &AlphaVantageConfig{BaseURL: baseURL, APIKey: apiKey})
+	}
+	canonical := func(t *testing.T, req *RequestAlpha) string {
+		key, err := req.CanonicalURL()
+		require.NoError(t, err)
+		return key
+	}
+
+	want := canonical(t, NewAlphaWithClient(newClient("https://www.alphavantage.co/query", "key-a"), "IBM", []Query{
+		NewQuery("function", "TIME_SERIES_INTRADAY"),
+		NewQuery("interval", "5min"),
+		NewQuery("outputsize", "full"),
+	}))
+	assert.Equal(t, "https://www.alphavantage.co/query?function=TIME_SERIES_INTRADAY&interval=5min&keyhash="+apiKeyHash("key-a")+"&outputsize=full&symbol=IBM", want)
+
+	equivalent := map[string]*RequestAlpha{
+		"reordered queries": NewAlphaWithClient(newClient("https://www.alphavantage.co/query", "key-a"), "IBM", []Query{
+			NewQuery("outputsize", "full"),
+			NewQuery("interval", "5min"),
+			NewQuery("function", "TIME_SERIES_INTRADAY"),
+		}),
+		"host case": NewAlphaWithClient(newClient("HTTPS://WWW.AlphaVantage.co/query", "key-a"), "IBM", []Query{
+			NewQuery("function", "TIME_SERIES_INTRADAY"),
+			NewQuery("interval", "5min"),
+			NewQuery("outputsize", "full"),
+		}),
+		"lowercase function and symbol": NewAlphaWithClient(newClient("https://www.alphavantage.co/query", "key-a"), " ibm ", []Query{
+			NewQuery("function", "time_series_intraday"),
+			NewQuery("interval", "5min"),
+			NewQuery("outputsize", "full"),
+		}),
+		"uppercase names and symbol as a query": NewAlphaQueryWithClient(newClient("https://www.alphavantage.co/query", "key-a"), []Query{
+			NewQuery("SYMBOL", "ibm"),
+			NewQuery("Interval", "5min"),
+			NewQuery("OUTPUTSIZE", "full"),
+			NewQuery("Function", "TIME_SERIES_INTRADAY"),
+		}),
+	}
+
+	for name, req := range equivalent {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, want, canonical(t, req))
+		})
+	}
+
+	other := NewAlphaWithClient(newClient("https://www.alphavantage.co/query", "key-a"), "IBM", []Query{
+		NewQuery("function", "TIME_SERIES_INTRADAY"),
+		NewQuery("interval", "1min"),
+		NewQuery("outputsize", "full"),
+	})
+	assert.NotEqual(t, want, canonical(t, other), "Different parameters must not share a key")
+
+	// Keys may differ in plan and entitlement, so they never share responses
+	otherKey := NewAlphaWithClient(newClient("https://www.alphavantage.co/query", "key-b"), "IBM", []Query{
+		NewQuery("function", "TIME_SERIES_INTRADAY"),
+		NewQuery("interval", "5min"),
+		NewQuery("outputsize", "full"),
+	})
+	assert.NotEqual(t, want, canonical(t, otherKey), "Different API keys must not share a key")
+}
+
+func TestRedactedURL(t *testing.T) {
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co",
+		APIKey:  "secret-key",
+		Timeout: 30 * time.Second,
+	}
+	req := NewAlphaWithClient(NewAlphaVantageClient(client.NewMockClient(), config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	url, err := req.RedactedURL()
+	require.NoError(t, err)
+	assert.Equal(t, "https://www.alphavantage.co/query?apikey=***&function=OVERVIEW&symbol=IBM", url)
+	assert.NotContains(t, url, "secret-key")
+}
+
+func TestGetWithContext_ErrorRedactsAPIKey(t *testing.T) {
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&logs, slog.LevelInfo))
+	defer slog.SetDefault(previous)
+
+	const key = "secret-key-123"
+	requestURL := "https://www.alphavantage.co/query?apikey=" + key + "&function=OVERVIEW&symbol=IBM"
+	transportErr := &url.Error{Op: "Get", URL: requestURL, Err: stderrors.New("connection refused")}
+
+	mockClient := client.NewMockClient()
+	mockClient.SetError(requestURL, transportErr)
+
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co",
+		APIKey:  key,
+		Timeout: 30 * time.Second,
+	}
+	req := NewAlphaWithClient(NewAlphaVantageClient(mockClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	_, err := req.GetWithContext(context.Background())
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), key)
+	assert.Contains(t, err.Error(), "apikey=***")
+	assert.ErrorIs(t, err, transportErr, "Redaction should keep the error chain")
+
+	assert.Contains(t, logs.String(), "upstream call failed")
+	assert.NotContains(t, logs.String(), key)
+}
+
+func TestGetWithContext_UserAgent(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configUserAgent  string
+		defaultUserAgent string
+		want             string
+	}{
+		{name: "configured", configUserAgent: "Acme-Finance/2.0 (prod)", defaultUserAgent: "Other/1.0", want: "Acme-Finance/2.0 (prod)"},
+		{name: "process default", defaultUserAgent: "Acme-Finance/2.0 (staging)", want: "Acme-Finance/2.0 (staging)"},
+		{name: "unset", want: DefaultUserAgent},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaultUserAgent(tc.defaultUserAgent)
+			t.Cleanup(func() { SetDefaultUserAgent("") })
+
+			var got string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.UserAgent()
+				_, _ = w.Write([]byte(`{"Symbol": "IBM"}`))
+			}))
+			defer server.Close()
+
+			config := &AlphaVantageConfig{
+				BaseURL:   server.URL,
+				APIKey:    "test-key",
+				UserAgent: tc.configUserAgent,
+				Timeout:   5 * time.Second,
+			}
+			httpClient := client.NewFastHTTPClient(config.HTTPConfig())
+			defer httpClient.Close()
+
+			req := NewAlphaWithClient(NewAlphaVantageClient(httpClient, config), "IBM", []Query{
+				NewQuery("function", "OVERVIEW"),
+			})
+
+			_, err := req.GetWithContext(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestGetWithContext_OperationTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		time.Sleep(150 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &AlphaVantageConfig{
+		BaseURL:          server.URL,
+		APIKey:           "test-key",
+		Timeout:          30 * time.Second,
+		OperationTimeout: 400 * time.Millisecond,
+	}
+
+	// Six slow attempts and their backoff would take well over a second
+	httpConfig := config.HTTPConfig()
+	httpConfig.MaxRetries = 5
+	httpConfig.RetryDelay = 100 * time.Millisecond
+	httpConfig.CircuitMaxFailures = 0
+	httpClient := client.NewFastHTTPClient(httpConfig)
+	defer httpClient.Close()
+
+	req := NewAlphaWithClient(NewAlphaVantageClient(httpClient, config), "IBM", []Query{
+		NewQuery("function", "OVERVIEW"),
+	})
+
+	start := time.Now()
+	_, err := req.GetWithContext(context.Background())
+	elapsed := time.Since(start)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, elapsed, time.Second, "The retry loop should stop at the operation budget")
+	assert.Less(t, attempts.Load(), int32(6), "Not every attempt should be made")
+}
+
+// gatedClient holds every request until release is closed, counting the
+// requests it receives
+type gatedClient struct {
+	*client.MockClient
+	calls    atomic.Int32
+	received chan struct{}
+	release  chan struct{}
+}
+
+func newGatedClient() *gatedClient {
+	return &gatedClient{
+		MockClient: client.NewMockClient(),
+		received:   make(chan struct{}, 100),
+		release:    make(chan struct{}),
+	}
+}
+
+func (g *gatedClient) Get(ctx context.Context, url string, headers map[string]string) (*client.Response, error) {
+	g.calls.Add(1)
+	g.received <- struct{}{}
+	<-g.release
+	return &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)}, nil
+}
+
+func (g *gatedClient) DoStream(ctx context.Context, method, url string, body []byte, headers map[string]string) (*client.StreamResponse, error) {
+	response, err := g.Get(ctx, url, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.StreamResponse{
+		StatusCode: response.StatusCode,
+		Body:       io.NopCloser(bytes.NewReader(response.Body)),
+	}, nil
+}
+
+func newGatedTestRequest(httpClient client.HTTPClient) func() *RequestAlpha {
+	alphaClient := NewAlphaVantageClient(httpClient, &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	})
+
+	return func() *RequestAlpha {
+		return NewAlphaWithClient(alphaClient, "IBM", []Query{NewQuery("function", "OVERVIEW")})
+	}
+}
+
+func TestGetWithContext_SharesConcurrentIdenticalRequests(t *testing.T) {
+	const callers = 10
+	httpClient := newGatedClient()
+	newRequest := newGatedTestRequest(httpClient)
+
+	var wg sync.WaitGroup
+	bodies := make([][]byte, callers)
+	errs := make([]error, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bodies[i], errs[i] = newRequest().GetWithContext(context.Background())
+		}()
+	}
+
+	// Give every caller time to join the call in flight
+	<-httpClient.received
+	time.Sleep(50 * time.Millisecond)
+	close(httpClient.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), httpClient.calls.Load(), "Identical requests should share one upstream call")
+	for i := range callers {
+		require.NoError(t, errs[i])
+		assert.JSONEq(t, `{"Symbol": "IBM"}`, string(bodies[i]))
+	}
+
+	// Once the call is done, the next request goes upstream again
+	_, err := newRequest().GetWithContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), httpClient.calls.Load())
+}
+
+func TestGetWithContext_CanceledCallerLeavesSharedCall(t *testing.T) {
+	httpClient := newGatedClient()
+	newRequest := newGatedTestRequest(httpClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := newRequest().GetWithContext(ctx)
+		canceled <- err
+	}()
+	<-httpClient.received
+
+	waiting := make(chan error, 1)
+	go func() {
+		_, err := newRequest().GetWithContext(context.Background())
+		waiting <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// The caller that started the call gives up; the other keeps waiting
+	cancel()
+	assert.ErrorIs(t, <-canceled, context.Canceled)
+
+	close(httpClient.release)
+	assert.NoError(t, <-waiting)
+	assert.Equal(t, int32(1), httpClient.calls.Load())
+}
+
+func TestAlphaVantageClient_Close(t *testing.T) {
+	config := &AlphaVantageConfig{BaseURL: "https://www.alphavantage.co/query", APIKey: "test-key", Timeout: 30 * time.Second}
+
+	owned := client.NewMockClient()
+	require.NoError(t, NewAlphaVantageClient(owned, config).Close())
+	assert.Equal(t, 1, owned.CloseCount())
+
+	shared := client.NewMockClient()
+	alphaClient := NewAlphaVantageClient(shared, config).WithSharedHTTPClient().WithCache(time.Minute)
+	alphaClient.cache.Set("key", []byte("body"), time.Minute)
+
+	require.NoError(t, alphaClient.Close())
+	assert.Zero(t, shared.CloseCount(), "A shared HTTP client is left to its owner")
+	assert.Zero(t, alphaClient.cache.Len(), "Closing drops the cached responses")
+}
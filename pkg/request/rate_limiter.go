@@ -0,0 +1,140 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+// RateLimiter is a token bucket limiting requests per minute, combined with a
+// daily request budget that resets at midnight UTC.
+//
+// A RateLimiter is safe for concurrent use and can be shared by every client
+// that uses the same API key, since Alpha Vantage enforces quotas per key.
+type RateLimiter struct {
+	requestsPerMinute int
+	requestsPerDay    int
+
+	// tokens available in the per-minute bucket
+	tokens     float64
+	lastRefill time.Time
+
+	// day is the start of the current UTC day and dayCount the requests made in it
+	day      time.Time
+	dayCount int
+
+	mu sync.Mutex
+
+	clock clock.Clock
+}
+
+// NewRateLimiter creates a limiter allowing requestsPerMinute requests per
+// minute and requestsPerDay requests per day. A non-positive value disables
+// the corresponding limit.
+func NewRateLimiter(requestsPerMinute, requestsPerDay int) *RateLimiter {
+	return &RateLimiter{
+		requestsPerMinute: requestsPerMinute,
+		requestsPerDay:    requestsPerDay,
+		tokens:            float64(requestsPerMinute),
+		clock:             clock.Real,
+	}
+}
+
+// WithClock makes the limiter tell time and wait for tokens with c, e.g. a
+// clock.Fake in tests, and returns the limiter. A nil c keeps the real clock.
+func (rl *RateLimiter) WithClock(c clock.Clock) *RateLimiter {
+	rl.clock = clock.OrReal(c)
+	return rl
+}
+
+// Wait blocks until a request may be made, consuming one token.
+//
+// It returns errors.ErrRateLimitWouldExceedDaily without blocking when the
+// daily budget is exhausted, and the context error if ctx is done before a
+// token becomes available.
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay, err := rl.reserve()
+		if err != nil || delay == 0 {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for rate limiter: %w", ctx.Err())
+		case <-rl.clock.After(delay):
+		}
+	}
+}
+
+// Allow consumes a token without blocking and reports whether a request may
+// be made now. When it may not, retryAfter is how long until the next token
+// is added, or zero when the daily budget is exhausted.
+func (rl *RateLimiter) Allow() (retryAfter time.Duration, ok bool) {
+	delay, err := rl.reserve()
+	if err != nil {
+		return 0, false
+	}
+
+	return delay, delay == 0
+}
+
+// reserve consumes a token if one is available. Otherwise it returns how long
+// to wait until the next token is added to the bucket.
+func (rl *RateLimiter) reserve() (time.Duration, error) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := rl.clock.Now()
+
+	if day := now.UTC().Truncate(24 * time.Hour); !day.Equal(rl.day) {
+		rl.day = day
+		rl.dayCount = 0
+	}
+
+	if rl.requestsPerDay > 0 && rl.dayCount >= rl.requestsPerDay {
+		return 0, errors.ErrRateLimitWouldExceedDaily
+	}
+
+	if rl.requestsPerMinute > 0 {
+		perToken := time.Minute / time.Duration(rl.requestsPerMinute)
+
+		if !rl.lastRefill.IsZero() {
+			elapsed := now.Sub(rl.lastRefill)
+			rl.tokens = min(float64(rl.requestsPerMinute), rl.tokens+float64(elapsed)/float64(perToken))
+		}
+		rl.lastRefill = now
+
+		// Rounded up, so a bucket just short of a token never reports a
+		// zero delay, which callers take as a token consumed
+		if rl.tokens < 1 {
+			return max(time.Duration(math.Ceil((1-rl.tokens)*float64(perToken))), 1), nil
+		}
+		rl.tokens--
+	}
+
+	rl.dayCount++
+	return 0, nil
+}
+
+// Remaining returns the number of requests left in today's budget,
+// or -1 if there is no daily limit.
+func (rl *RateLimiter) Remaining() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if rl.requestsPerDay <= 0 {
+		return -1
+	}
+
+	if !rl.clock.Now().UTC().Truncate(24 * time.Hour).Equal(rl.day) {
+		return rl.requestsPerDay
+	}
+
+	return rl.requestsPerDay - rl.dayCount
+}
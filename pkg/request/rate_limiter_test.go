@@ -0,0 +1,224 @@
+package request
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/internal/clock"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+func TestRateLimiter_BlocksUntilTokenAvailable(t *testing.T) {
+	// 600 per minute refills one token every 100ms
+	fake := clock.NewFake(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	limiter := NewRateLimiter(600, 0).WithClock(fake)
+	limiter.tokens = 1
+
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- limiter.Wait(context.Background())
+	}()
+
+	require.Eventually(t, func() bool { return fake.Waiters() == 1 }, time.Second, time.Millisecond)
+	select {
+	case err := <-done:
+		t.Fatalf("Wait returned before a token was added: %v", err)
+	default:
+	}
+
+	fake.Advance(100 * time.Millisecond)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return once a token was added")
+	}
+}
+
+func TestRateLimiter_RespectsContextDeadline(t *testing.T) {
+	limiter := NewRateLimiter(1, 0)
+	require.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := limiter.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	limiter := NewRateLimiter(2, 3)
+	limiter.WithClock(fake)
+
+	for range 2 {
+		_, ok := limiter.Allow()
+		require.True(t, ok)
+	}
+
+	retryAfter, ok := limiter.Allow()
+	assert.False(t, ok)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	fake.Advance(30 * time.Second)
+	_, ok = limiter.Allow()
+	assert.True(t, ok)
+
+	// The daily budget of 3 is spent
+	fake.Advance(time.Minute)
+	retryAfter, ok = limiter.Allow()
+	assert.False(t, ok)
+	assert.Zero(t, retryAfter)
+}
+
+func TestRateLimiter_AlmostFullTokenIsNotConsumed(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	limiter := NewRateLimiter(60, 5).WithClock(fake)
+
+	// The delay to the next token is a fraction of a nanosecond
+	limiter.tokens = math.Nextafter(1, 0)
+	limiter.lastRefill = fake.Now()
+
+	retryAfter, ok := limiter.Allow()
+	assert.False(t, ok, "A request must not be allowed without a whole token")
+	assert.Positive(t, retryAfter)
+	assert.Equal(t, 5, limiter.Remaining(), "A denied request must not count against the daily budget")
+}
+
+func TestRateLimiter_DailyBudget(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 2, 23, 59, 0, 0, time.UTC))
+	limiter := NewRateLimiter(0, 2)
+	limiter.WithClock(fake)
+
+	require.NoError(t, limiter.Wait(context.Background()))
+	require.NoError(t, limiter.Wait(context.Background()))
+	assert.Equal(t, 0, limiter.Remaining())
+	assert.ErrorIs(t, limiter.Wait(context.Background()), errors.ErrRateLimitWouldExceedDaily)
+
+	fake.Advance(2 * time.Minute)
+	assert.Equal(t, 2, limiter.Remaining())
+	assert.NoError(t, limiter.Wait(context.Background()))
+}
+
+func TestRateLimiter_ExhaustedBudgetSkipsAPI(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(cacheTestURL, &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)})
+
+	config := &AlphaVantageConfig{
+		BaseURL:        "https://www.alphavantage.co/query",
+		APIKey:         "test-key",
+		Timeout:        30 * time.Second,
+		RequestsPerDay: 1,
+	}
+	alphaClient := NewAlphaVantageClient(mockClient, config)
+	queries := []Query{NewQuery("function", "OVERVIEW")}
+
+	_, err := NewAlphaWithClient(alphaClient, "IBM", queries).GetWithContext(context.Background())
+	require.NoError(t, err)
+
+	_, err = NewAlphaWithClient(alphaClient, "IBM", queries).GetWithContext(context.Background())
+	assert.ErrorIs(t, err, errors.ErrRateLimitWouldExceedDaily)
+	assert.Equal(t, 1, mockClient.GetCallCount(cacheTestURL))
+}
+
+func TestAlphaVantageClientPool_SharesLimiterPerKey(t *testing.T) {
+	pool := NewAlphaVantageClientPool(&AlphaVantageConfig{
+		BaseURL:        "https://www.alphavantage.co/query",
+		Timeout:        30 * time.Second,
+		RequestsPerDay: 25,
+	})
+	defer pool.Close()
+
+	limiter := pool.Limiter("key-a")
+	require.NotNil(t, limiter)
+	assert.Same(t, limiter, pool.GetClient("key-a").limiter)
+	assert.NotSame(t, limiter, pool.GetClient("key-b").limiter)
+
+	assert.Nil(t, NewAlphaVantageClientPool(nil).Limiter("key-a"))
+}
+
+func TestAlphaVantageClientPool_Close(t *testing.T) {
+	pool := NewAlphaVantageClientPool(&AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		Timeout: 30 * time.Second,
+	})
+	clientA := pool.GetClient("key-a")
+	clientB := pool.GetClient("key-b")
+
+	require.NoError(t, pool.Close())
+
+	for _, alphaClient := range []*AlphaVantageClient{clientA, clientB} {
+		_, err := alphaClient.httpClient.Get(context.Background(), "https://www.alphavantage.co/query", nil)
+		assert.ErrorIs(t, err, client.ErrClientClosed)
+	}
+}
+
+func TestAlphaVantageClientPool_CloseClosesEveryClient(t *testing.T) {
+	pool := NewAlphaVantageClientPool(nil)
+
+	errA := stderrors.New("close key-a")
+	errC := stderrors.New("close key-c")
+	mocks := map[string]*client.MockClient{}
+	for _, apiKey := range []string{"key-a", "key-b", "key-c"} {
+		mocks[apiKey] = client.NewMockClient()
+		pool.clients[apiKey] = NewAlphaVantageClient(mocks[apiKey], pool.config)
+	}
+	mocks["key-a"].SetCloseError(errA)
+	mocks["key-c"].SetCloseError(errC)
+
+	// A failing client does not keep the others open
+	err := pool.Close()
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errC)
+	for apiKey, mockClient := range mocks {
+		assert.Equal(t, 1, mockClient.CloseCount(), apiKey)
+	}
+}
+
+func TestAlphaVantageClientPool_ConcurrentGetClient(t *testing.T) {
+	pool := NewAlphaVantageClientPool(&AlphaVantageConfig{
+		BaseURL:        "https://www.alphavantage.co/query",
+		Timeout:        30 * time.Second,
+		RequestsPerDay: 25,
+	})
+	defer pool.Close()
+
+	const keys = 20
+	clients := make([][]*AlphaVantageClient, keys)
+	for key := range clients {
+		clients[key] = make([]*AlphaVantageClient, 10)
+	}
+
+	var wg sync.WaitGroup
+	for key := range keys {
+		for caller := range clients[key] {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				apiKey := fmt.Sprintf("key-%d", key)
+				clients[key][caller] = pool.GetClient(apiKey)
+				pool.Limiter(apiKey)
+				pool.GetPoolStats()
+			}()
+		}
+	}
+	wg.Wait()
+
+	// Every caller of a key got the one client created for it
+	for key := range clients {
+		for _, alphaClient := range clients[key] {
+			assert.Same(t, clients[key][0], alphaClient)
+		}
+	}
+	assert.Len(t, pool.GetPoolStats(), keys)
+}
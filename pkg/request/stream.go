@@ -0,0 +1,303 @@
+package request
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/logging"
+)
+
+// apiErrorPeekSize is how much of a streamed body is inspected for Alpha
+// Vantage error messages. Error responses are small JSON objects, so their
+// message always falls within the first bytes of the body.
+const apiErrorPeekSize = 4 * 1024
+
+// errStreamNotShared is the result of a shared stream whose body was not
+// recorded, for the callers that joined too late to receive it. They make
+// their own call instead.
+var errStreamNotShared = errors.New("streamed response was not recorded")
+
+// StreamWithContext performs the request like GetWithContext but returns the
+// body as a stream, so responses as large as full-size intraday series are
+// decoded while they download. The caller must close the returned reader.
+//
+// Like GetWithContext it serves cached responses from memory and shares one
+// upstream call between concurrent identical requests, streamed or not. The
+// caller that starts the call streams the body, which is recorded as it is
+// read while it fits in the cache or other callers wait for it; once that
+// caller closes the stream, the rest of the body is read for the cache and
+// for the callers that joined, which receive it whole. A body too large to
+// record is not cached, and callers joining after recording stopped make
+// their own call.
+//
+// API error messages are detected from the start of the body before it is
+// returned.
+func (ra *RequestAlpha) StreamWithContext(ctx context.Context) (io.ReadCloser, error) {
+	url, err := ra.buildURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", redactAPIKey(err))
+	}
+
+	key, err := ra.CanonicalURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build URL: %w", err)
+	}
+
+	if ra.client.cache != nil {
+		if body, ok := ra.client.cache.Get(key); ok {
+			logging.FromContext(ctx).DebugContext(ctx, "upstream cache hit",
+				"function", ra.function(), "symbol", ra.symbol)
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	// Waiting for the rate limiter is bounded only by ctx, as in
+	// GetWithContext. Cancelling on return or close releases the context.
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Only the function of the caller that starts the call runs, so only
+	// that caller receives the stream on opened
+	defer ra.client.awaitFlight(key)()
+	opened := make(chan *sharedStream, 1)
+	flight := ra.client.flights.DoChan(key, func() (any, error) {
+		flightCtx, cancel := ra.flightContext(ctx)
+		defer cancel()
+
+		return ra.streamShared(flightCtx, url, key, opened)
+	})
+
+	select {
+	case <-ctx.Done():
+		// A stream handed over after giving up is closed, so the shared call
+		// still completes for the callers that joined it
+		go func() {
+			select {
+			case stream := <-opened:
+				stream.Close()
+			case <-flight:
+			}
+		}()
+		cancel()
+		return nil, ctx.Err()
+	case stream := <-opened:
+		return &streamBody{Reader: &contextReader{ctx: ctx, r: stream}, body: stream, cancel: cancel}, nil
+	case result := <-flight:
+		if errors.Is(result.Err, errStreamNotShared) {
+			stream, err := ra.openStream(ctx, url)
+			if err != nil {
+				cancel()
+				return nil, err
+			}
+			return &streamBody{Reader: stream, body: stream, cancel: cancel}, nil
+		}
+		cancel()
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		return io.NopCloser(bytes.NewReader(result.Val.([]byte))), nil
+	}
+}
+
+// streamShared performs the upstream call of a stream shared under key. It
+// hands the stream to the caller that started the call on opened, recording
+// what that caller reads, and once the stream is closed reads the rest of
+// the body, caches it and returns it for the callers that joined.
+//
+// Recording stops once the body outgrows the cache while no caller is
+// waiting for it. The call is then forgotten, so later callers start their
+// own, and it returns errStreamNotShared without reading the rest.
+func (ra *RequestAlpha) streamShared(ctx context.Context, url, key string, opened chan<- *sharedStream) ([]byte, error) {
+	stream, err := ra.openStream(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	recorder := &streamRecorder{
+		limit: ra.recordLimit(),
+		waiting: func() bool {
+			return ra.client.flightWaiting(key)
+		},
+		stop: func() {
+			ra.client.flights.Forget(key)
+		},
+	}
+	shared := &sharedStream{Reader: io.TeeReader(stream, recorder), closed: make(chan struct{})}
+	opened <- shared
+	<-shared.closed
+
+	recorder.draining = true
+	if _, err := io.Copy(recorder, stream); recorder.stopped {
+		return nil, errStreamNotShared
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", redactAPIKey(err))
+	}
+
+	if ra.client.cache != nil {
+		ra.client.cache.Set(key, recorder.body.Bytes(), ra.client.cache.TTLFor(ra.function()))
+	}
+
+	return recorder.body.Bytes(), nil
+}
+
+// recordLimit returns how much of a streamed body is recorded while no
+// caller waits for it: as much as the cache holds, or nothing without one
+func (ra *RequestAlpha) recordLimit() int {
+	if ra.client.cache == nil {
+		return 0
+	}
+
+	if size := ra.client.cache.MaxBodySize(); size > 0 {
+		return size
+	}
+
+	return math.MaxInt
+}
+
+// streamRecorder records the body of a shared stream. Past limit bytes it
+// records only while waiting reports a caller waiting for the body;
+// otherwise it drops the body, calls stop and records nothing more.
+//
+// Writes succeed while the caller reads the stream, so recording does not
+// disturb it. Once draining is set they fail after recording stops, which
+// ends the reading of the rest of the body.
+type streamRecorder struct {
+	body     bytes.Buffer
+	limit    int
+	waiting  func() bool
+	stop     func()
+	stopped  bool
+	draining bool
+}
+
+// Write implements io.Writer
+func (r *streamRecorder) Write(p []byte) (int, error) {
+	if !r.stopped && r.body.Len()+len(p) > r.limit && !r.waiting() {
+		r.stopped = true
+		r.body = bytes.Buffer{}
+		r.stop()
+	}
+
+	if r.stopped {
+		if r.draining {
+			return 0, errStreamNotShared
+		}
+		return len(p), nil
+	}
+
+	return r.body.Write(p)
+}
+
+// sharedStream is the stream of a shared call read by the caller that
+// started it. Closing it lets the call finish reading the body.
+type sharedStream struct {
+	io.Reader
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Close implements io.Closer
+func (s *sharedStream) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+	return nil
+}
+
+// openStream sends the request and checks the status and the start of the
+// body for API errors. The OperationTimeout budget covers the request and
+// the reading of the body, and is released when the stream is closed.
+func (ra *RequestAlpha) openStream(ctx context.Context, url string) (*streamBody, error) {
+	if ra.client.limiter != nil {
+		if err := ra.client.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	sendCtx, cancel := ra.operationContext(ctx)
+
+	start := time.Now()
+	response, err := ra.sendStream(sendCtx, url, requestHeaders())
+	// Transport errors may quote the request URL, including the API key
+	err = redactAPIKey(err)
+	if err != nil {
+		cancel()
+		ra.logUpstreamCall(ctx, start, 0, err)
+		return nil, upstreamError(err)
+	}
+	ra.logUpstreamCall(ctx, start, response.StatusCode, nil)
+
+	if response.StatusCode != fasthttp.StatusOK {
+		response.Body.Close()
+		cancel()
+		return nil, statusError(response.StatusCode)
+	}
+
+	reader := bufio.NewReaderSize(response.Body, apiErrorPeekSize)
+	prefix, err := reader.Peek(apiErrorPeekSize)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		response.Body.Close()
+		cancel()
+		return nil, fmt.Errorf("failed to read response: %w", redactAPIKey(err))
+	}
+
+	if err := ra.checkAPIError(prefix); err != nil {
+		response.Body.Close()
+		cancel()
+		return nil, err
+	}
+
+	return &streamBody{Reader: &contextReader{ctx: sendCtx, r: reader}, body: response.Body, cancel: cancel}, nil
+}
+
+// contextReader fails reads once its context is done, so a deadline bounds
+// the reading of a streamed body and not only the request
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements io.Reader
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return cr.r.Read(p)
+}
+
+// sendStream dispatches a streaming request with the configured method
+func (ra *RequestAlpha) sendStream(ctx context.Context, url string, headers map[string]string) (*client.StreamResponse, error) {
+	if ra.client.config.Method != fasthttp.MethodPost {
+		return ra.client.httpClient.DoStream(ctx, fasthttp.MethodGet, url, nil, headers)
+	}
+
+	endpoint, form, _ := strings.Cut(url, "?")
+	headers["Content-Type"] = "application/x-www-form-urlencoded"
+
+	return ra.client.httpClient.DoStream(ctx, fasthttp.MethodPost, endpoint, []byte(form), headers)
+}
+
+// streamBody is the body returned by StreamWithContext. Closing it releases
+// the connection and the request timeout.
+type streamBody struct {
+	io.Reader
+	body   io.Closer
+	cancel context.CancelFunc
+}
+
+// Close implements io.Closer
+func (b *streamBody) Close() error {
+	defer b.cancel()
+	return b.body.Close()
+}
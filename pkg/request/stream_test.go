@@ -0,0 +1,308 @@
+package request
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+func TestStreamWithContext_Success(t *testing.T) {
+	// Larger than the peeked prefix, so the body is read past it
+	body := `{"Symbol": "IBM", "Description": "` + strings.Repeat("x", 3*apiErrorPeekSize) + `"}`
+	req := newAPIErrorTestRequest(&client.Response{StatusCode: 200, Body: []byte(body)})
+
+	stream, err := req.StreamWithContext(context.Background())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(got))
+	assert.NoError(t, stream.Close())
+}
+
+func TestStreamWithContext_APIError(t *testing.T) {
+	body := `{"Information": "We have detected your API key as TEST and our standard API rate limit is 25 requests per day."}`
+	req := newAPIErrorTestRequest(&client.Response{StatusCode: 200, Body: []byte(body)})
+
+	_, err := req.StreamWithContext(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrRateLimited)
+}
+
+func TestStreamWithContext_TooManyRequests(t *testing.T) {
+	req := newAPIErrorTestRequest(&client.Response{StatusCode: 429})
+
+	_, err := req.StreamWithContext(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errors.ErrRateLimited)
+	assert.Contains(t, err.Error(), "status 429")
+}
+
+func TestStreamWithContext_ServesCache(t *testing.T) {
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(apiErrorTestURL, &client.Response{StatusCode: 200, Body: []byte(`{"Symbol": "IBM"}`)})
+
+	config := &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}
+	alphaClient := NewAlphaVantageClient(mockClient, config).WithCache(time.Minute)
+	req := NewAlphaWithClient(alphaClient, "IBM", []Query{NewQuery("function", "OVERVIEW")})
+
+	_, err := req.GetWithContext(context.Background())
+	require.NoError(t, err)
+
+	stream, err := req.StreamWithContext(context.Background())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Symbol": "IBM"}`, string(got))
+	assert.Equal(t, 1, mockClient.GetCallCount(apiErrorTestURL), "The cached response should be streamed")
+}
+
+func TestStreamWithContext_SharesConcurrentIdenticalRequests(t *testing.T) {
+	const streams = 5
+	httpClient := newGatedClient()
+	alphaClient := NewAlphaVantageClient(httpClient, &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}).WithCache(time.Minute)
+	newRequest := func() *RequestAlpha {
+		return NewAlphaWithClient(alphaClient, "IBM", []Query{NewQuery("function", "OVERVIEW")})
+	}
+
+	var wg sync.WaitGroup
+	bodies := make([][]byte, streams+1)
+	errs := make([]error, streams+1)
+	for i := range streams {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := newRequest().StreamWithContext(context.Background())
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer stream.Close()
+			bodies[i], errs[i] = io.ReadAll(stream)
+		}()
+	}
+
+	// A buffered request joins the streamed call too
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		bodies[streams], errs[streams] = newRequest().GetWithContext(context.Background())
+	}()
+
+	// Give every caller time to join the call in flight
+	<-httpClient.received
+	time.Sleep(50 * time.Millisecond)
+	close(httpClient.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), httpClient.calls.Load(), "Identical requests should share one upstream call")
+	for i := range bodies {
+		require.NoError(t, errs[i])
+		assert.JSONEq(t, `{"Symbol": "IBM"}`, string(bodies[i]))
+	}
+
+	// The shared body is cached
+	stream, err := newRequest().StreamWithContext(context.Background())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Symbol": "IBM"}`, string(got))
+	assert.Equal(t, int32(1), httpClient.calls.Load(), "The shared body should be served from the cache")
+}
+
+func TestStreamWithContext_SharesBodyUnreadByFirstCaller(t *testing.T) {
+	httpClient := newGatedClient()
+	newRequest := newGatedTestRequest(httpClient)
+
+	first := make(chan io.ReadCloser, 1)
+	go func() {
+		stream, err := newRequest().StreamWithContext(context.Background())
+		assert.NoError(t, err)
+		first <- stream
+	}()
+
+	<-httpClient.received
+	joined := make(chan []byte, 1)
+	go func() {
+		body, err := newRequest().GetWithContext(context.Background())
+		assert.NoError(t, err)
+		joined <- body
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(httpClient.release)
+
+	// The first caller closes the stream without reading it
+	stream := <-first
+	require.NotNil(t, stream)
+	require.NoError(t, stream.Close())
+
+	select {
+	case body := <-joined:
+		assert.JSONEq(t, `{"Symbol": "IBM"}`, string(body))
+	case <-time.After(5 * time.Second):
+		t.Fatal("Closing the stream should complete the shared call")
+	}
+	assert.Equal(t, int32(1), httpClient.calls.Load())
+}
+
+func TestStreamWithContext_DoesNotRecordBodyLargerThanCache(t *testing.T) {
+	body := `{"Symbol": "IBM", "Description": "` + strings.Repeat("x", 3*apiErrorPeekSize) + `"}`
+	mockClient := client.NewMockClient()
+	mockClient.SetResponse(apiErrorTestURL, &client.Response{StatusCode: 200, Body: []byte(body)})
+
+	alphaClient := NewAlphaVantageClient(mockClient, &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		APIKey:  "test-key",
+		Timeout: 30 * time.Second,
+	}).WithCache(time.Minute)
+	alphaClient.cache.WithMaxBytes(apiErrorPeekSize)
+	newRequest := func() *RequestAlpha {
+		return NewAlphaWithClient(alphaClient, "IBM", []Query{NewQuery("function", "OVERVIEW")})
+	}
+
+	stream, err := newRequest().StreamWithContext(context.Background())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	got, err := io.ReadAll(stream)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(got))
+
+	// With no caller waiting, the body outgrew the cache and stopped being
+	// recorded, so a caller arriving while the stream is open makes its own
+	// call instead of waiting for a body that will not be shared
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	again, err := newRequest().GetWithContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(again))
+	assert.Equal(t, 2, mockClient.GetCallCount(apiErrorTestURL))
+	assert.Zero(t, alphaClient.cache.Len(), "A body larger than the cache must not be cached")
+}
+
+func TestStreamRecorder(t *testing.T) {
+	waiting, stops := false, 0
+	recorder := &streamRecorder{
+		limit:   4,
+		waiting: func() bool { return waiting },
+		stop:    func() { stops++ },
+	}
+
+	_, err := recorder.Write([]byte("abcd"))
+	require.NoError(t, err)
+
+	// Past the limit the body is recorded only while a caller waits for it
+	waiting = true
+	_, err = recorder.Write([]byte("ef"))
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", recorder.body.String())
+
+	waiting = false
+	n, err := recorder.Write([]byte("gh"))
+	require.NoError(t, err, "Writes must not fail while the caller reads the stream")
+	assert.Equal(t, 2, n)
+	assert.True(t, recorder.stopped)
+	assert.Zero(t, recorder.body.Len(), "The recorded body should be dropped")
+	assert.Equal(t, 1, stops)
+
+	recorder.draining = true
+	_, err = recorder.Write([]byte("ij"))
+	assert.ErrorIs(t, err, errStreamNotShared, "Draining should end once recording stopped")
+	assert.Equal(t, 1, stops)
+}
+
+func TestStreamWithContext_OperationTimeout(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		time.Sleep(150 * time.Millisecond)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := &AlphaVantageConfig{
+		BaseURL:          server.URL,
+		APIKey:           "test-key",
+		Timeout:          30 * time.Second,
+		OperationTimeout: 400 * time.Millisecond,
+	}
+
+	// Six slow attempts and their backoff would take well over a second
+	httpConfig := config.HTTPConfig()
+	httpConfig.MaxRetries = 5
+	httpConfig.RetryDelay = 100 * time.Millisecond
+	httpConfig.CircuitMaxFailures = 0
+	httpClient := client.NewFastHTTPClient(httpConfig)
+	defer httpClient.Close()
+
+	req := NewAlphaWithClient(NewAlphaVantageClient(httpClient, config), "IBM", []Query{
+		NewQuery("function", "TIME_SERIES_INTRADAY"),
+	})
+
+	start := time.Now()
+	_, err := req.StreamWithContext(context.Background())
+	elapsed := time.Since(start)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, elapsed, time.Second, "The retry loop should stop at the operation budget")
+	assert.Less(t, attempts.Load(), int32(6), "Not every attempt should be made")
+}
+
+func TestStreamWithContext_OperationTimeoutBoundsBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// More than the peeked prefix, so the stream opens before the body stalls
+		w.Write([]byte(`{"Meta Data": {"1. Information": "` + strings.Repeat("x", 2*apiErrorPeekSize) + `"}, "Time Series (5min)": {`))
+		w.(http.Flusher).Flush()
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	config := &AlphaVantageConfig{
+		BaseURL:          server.URL,
+		APIKey:           "test-key",
+		Timeout:          30 * time.Second,
+		OperationTimeout: 200 * time.Millisecond,
+	}
+	httpClient := client.NewFastHTTPClient(config.HTTPConfig())
+	defer httpClient.Close()
+
+	req := NewAlphaWithClient(NewAlphaVantageClient(httpClient, config), "IBM", []Query{
+		NewQuery("function", "TIME_SERIES_INTRADAY"),
+	})
+
+	stream, err := req.StreamWithContext(context.Background())
+	require.NoError(t, err)
+	defer stream.Close()
+
+	// Reads stop once the budget is spent, even though the body is unfinished
+	time.Sleep(300 * time.Millisecond)
+	_, err = io.ReadAll(stream)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
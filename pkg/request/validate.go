@@ -0,0 +1,72 @@
+package request
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/valyala/fasthttp"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+// ValidateSymbol is the symbol quoted by Validate
+const ValidateSymbol = "IBM"
+
+// Validate checks that Alpha Vantage is reachable and accepts the configured
+// API key with a GLOBAL_QUOTE request for ValidateSymbol, the cheapest call
+// every key may make. It returns nil when the key works, or an error that
+// wraps one of:
+//   - errors.ErrInvalidAPIKey when the key is missing or rejected
+//   - errors.ErrRateLimited when the key is valid but its quota is used up
+//   - errors.ErrUpstreamUnavailable when the request fails for any other
+//     reason, such as a network error or timeout
+//
+// On a client created with WithCache, a cached quote answers the check
+// without a request.
+func (ac *AlphaVantageClient) Validate(ctx context.Context) error {
+	_, err := NewAlphaWithClient(ac, ValidateSymbol, []Query{
+		NewQuery("function", "GLOBAL_QUOTE"),
+	}).GetWithContext(ctx)
+
+	switch {
+	case err == nil:
+		return nil
+	case stderrors.Is(err, errors.ErrInvalidAPIKey), stderrors.Is(err, errors.ErrRateLimited):
+		return err
+	case stderrors.Is(err, errors.ErrAPIKeyRequired):
+		return fmt.Errorf("%w: %w", errors.ErrInvalidAPIKey, err)
+	case stderrors.Is(err, errors.ErrRateLimitWouldExceedDaily):
+		return fmt.Errorf("%w: %w", errors.ErrRateLimited, err)
+	default:
+		return fmt.Errorf("%w: %w", errors.ErrUpstreamUnavailable, err)
+	}
+}
+
+// Ping checks that Alpha Vantage is reachable without spending API quota. It
+// sends a request without a function or API key, which Alpha Vantage answers
+// with an error message, so it bypasses the rate limiter and the cache and
+// treats any response below status 500 as success. It returns nil when
+// Alpha Vantage answered, or an error wrapping errors.ErrUpstreamUnavailable.
+func (ac *AlphaVantageClient) Ping(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ac.Timeout())
+		defer cancel()
+	}
+
+	response, err := ac.httpClient.Get(ctx, ac.config.BaseURL, requestHeaders())
+	if err != nil {
+		var httpErr *client.HTTPError
+		if stderrors.As(err, &httpErr) && httpErr.StatusCode < fasthttp.StatusInternalServerError {
+			return nil
+		}
+		return fmt.Errorf("%w: failed to perform HTTP request: %w", errors.ErrUpstreamUnavailable, err)
+	}
+
+	if response.StatusCode >= fasthttp.StatusInternalServerError {
+		return fmt.Errorf("%w: %w", errors.ErrUpstreamUnavailable, statusError(response.StatusCode))
+	}
+
+	return nil
+}
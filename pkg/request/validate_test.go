@@ -0,0 +1,157 @@
+package request
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yeferson59/finance-mcp/pkg/client"
+	"github.com/yeferson59/finance-mcp/pkg/errors"
+)
+
+const validateURL = "https://www.alphavantage.co/query?apikey=test-key&function=GLOBAL_QUOTE&symbol=IBM"
+
+func TestAlphaVantageClient_Validate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response *client.Response
+		err      error
+		want     error
+	}{
+		{
+			name:     "valid key",
+			response: &client.Response{StatusCode: 200, Body: []byte(`{"Global Quote": {"01. symbol": "IBM", "05. price": "187.1500"}}`)},
+		},
+		{
+			name:     "invalid key message",
+			response: &client.Response{StatusCode: 200, Body: []byte(`{"Error Message": "the parameter apikey is invalid or missing."}`)},
+			want:     errors.ErrInvalidAPIKey,
+		},
+		{
+			name:     "unauthorized",
+			response: &client.Response{StatusCode: 401},
+			want:     errors.ErrInvalidAPIKey,
+		},
+		{
+			name:     "rate limited",
+			response: &client.Response{StatusCode: 200, Body: []byte(`{"Information": "We have detected your API key as ... standard API rate limit is 25 requests per day."}`)},
+			want:     errors.ErrRateLimited,
+		},
+		{
+			name: "network failure",
+			err:  stderrors.New("dial tcp: lookup www.alphavantage.co: no such host"),
+			want: errors.ErrUpstreamUnavailable,
+		},
+		{
+			name:     "server error",
+			response: &client.Response{StatusCode: 502},
+			want:     errors.ErrUpstreamUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := client.NewMockClient()
+			if tc.err != nil {
+				mockClient.SetError(validateURL, tc.err)
+			} else {
+				mockClient.SetResponse(validateURL, tc.response)
+			}
+
+			alphaClient := NewAlphaVantageClient(mockClient, &AlphaVantageConfig{
+				BaseURL: "https://www.alphavantage.co/query",
+				APIKey:  "test-key",
+				Timeout: 5 * time.Second,
+			})
+
+			err := alphaClient.Validate(context.Background())
+			assert.Equal(t, 1, mockClient.GetCallCount(validateURL))
+			if tc.want == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tc.want)
+			for _, other := range []error{errors.ErrInvalidAPIKey, errors.ErrRateLimited, errors.ErrUpstreamUnavailable} {
+				if other != tc.want {
+					assert.NotErrorIs(t, err, other)
+				}
+			}
+		})
+	}
+}
+
+func TestAlphaVantageClient_ValidateMissingKey(t *testing.T) {
+	mockClient := client.NewMockClient()
+	alphaClient := NewAlphaVantageClient(mockClient, &AlphaVantageConfig{
+		BaseURL: "https://www.alphavantage.co/query",
+		Timeout: 5 * time.Second,
+	})
+
+	err := alphaClient.Validate(context.Background())
+	assert.ErrorIs(t, err, errors.ErrInvalidAPIKey)
+	assert.Empty(t, mockClient.Requests(), "No request should be sent without a key")
+}
+
+func TestAlphaVantageClient_Ping(t *testing.T) {
+	const pingURL = "https://www.alphavantage.co/query"
+
+	testCases := []struct {
+		name     string
+		response *client.Response
+		err      error
+		wantErr  bool
+	}{
+		{
+			name:     "error message",
+			response: &client.Response{StatusCode: 200, Body: []byte(`{"Error Message": "the parameter apikey is invalid or missing."}`)},
+		},
+		{
+			name:     "client error status",
+			response: &client.Response{StatusCode: 404},
+		},
+		{
+			name:    "network failure",
+			err:     stderrors.New("dial tcp: lookup www.alphavantage.co: no such host"),
+			wantErr: true,
+		},
+		{
+			name:     "server error",
+			response: &client.Response{StatusCode: 503},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := client.NewMockClient()
+			if tc.err != nil {
+				mockClient.SetError(pingURL, tc.err)
+			} else {
+				mockClient.SetResponse(pingURL, tc.response)
+			}
+
+			// A limiter without tokens shows the ping does not spend quota
+			limiter := NewRateLimiter(0, 1)
+			_, ok := limiter.Allow()
+			require.True(t, ok)
+
+			alphaClient := NewAlphaVantageClient(mockClient, &AlphaVantageConfig{
+				BaseURL: pingURL,
+				APIKey:  "test-key",
+				Timeout: 5 * time.Second,
+			}).WithRateLimiter(limiter)
+
+			err := alphaClient.Ping(context.Background())
+			assert.Equal(t, 1, mockClient.GetCallCount(pingURL))
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errors.ErrUpstreamUnavailable)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
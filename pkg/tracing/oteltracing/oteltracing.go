@@ -0,0 +1,109 @@
+// Package oteltracing adapts OpenTelemetry to the tracing.Tracer interface.
+//
+// Only programs that import this package depend on the OpenTelemetry SDK at
+// runtime; everything else uses the no-op tracer from package tracing.
+package oteltracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/yeferson59/finance-mcp/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer implements tracing.Tracer on top of an OpenTelemetry tracer
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// NewTracer creates a Tracer from an OpenTelemetry tracer provider,
+// propagating W3C trace context and baggage headers.
+func NewTracer(provider trace.TracerProvider) *Tracer {
+	return &Tracer{
+		tracer:     provider.Tracer("github.com/yeferson59/finance-mcp"),
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+}
+
+// Setup creates a tracer provider exporting spans over OTLP/HTTP and installs
+// it with tracing.SetTracer. The exporter is configured by the standard
+// OTEL_EXPORTER_OTLP_* environment variables.
+//
+// The returned shutdown function flushes pending spans.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+
+	tracing.SetTracer(NewTracer(provider))
+
+	return provider.Shutdown, nil
+}
+
+// Start creates an OpenTelemetry span that is a child of any span in ctx
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...tracing.Attribute) (context.Context, tracing.Span) {
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(convert(attrs)...))
+	return ctx, &Span{span: span}
+}
+
+// Extract returns ctx carrying the remote trace context found in header
+func (t *Tracer) Extract(ctx context.Context, header http.Header) context.Context {
+	return t.propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Span implements tracing.Span on top of an OpenTelemetry span
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes records attributes on the span
+func (s *Span) SetAttributes(attrs ...tracing.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+// RecordError records err on the span and sets its status to error
+func (s *Span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End completes the span
+func (s *Span) End() {
+	s.span.End()
+}
+
+// convert maps tracing attributes to OpenTelemetry attributes
+func convert(attrs []tracing.Attribute) []attribute.KeyValue {
+	converted := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		switch value := attr.Value.(type) {
+		case string:
+			converted = append(converted, attribute.String(attr.Key, value))
+		case int:
+			converted = append(converted, attribute.Int(attr.Key, value))
+		case int64:
+			converted = append(converted, attribute.Int64(attr.Key, value))
+		case bool:
+			converted = append(converted, attribute.Bool(attr.Key, value))
+		default:
+			converted = append(converted, attribute.String(attr.Key, fmt.Sprint(value)))
+		}
+	}
+	return converted
+}
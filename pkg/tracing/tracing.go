@@ -0,0 +1,98 @@
+// Package tracing defines a minimal tracing abstraction used by the tools and
+// HTTP client to create spans.
+//
+// The default tracer is a no-op, so tracing costs nothing unless a real
+// implementation such as the OpenTelemetry adapter in package oteltracing is
+// installed with SetTracer.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// Attribute is a key/value pair recorded on a span
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String creates a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int creates an integer attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is a single traced operation
+type Span interface {
+	// SetAttributes records attributes on the span
+	SetAttributes(attrs ...Attribute)
+
+	// RecordError records err on the span and marks it as failed
+	RecordError(err error)
+
+	// End completes the span
+	End()
+}
+
+// Tracer creates spans and propagates trace context
+type Tracer interface {
+	// Start creates a span that is a child of any span in ctx
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+
+	// Extract returns ctx carrying the remote trace context found in header
+	Extract(ctx context.Context, header http.Header) context.Context
+}
+
+// tracerHolder wraps the Tracer so atomic.Value always stores the same type
+type tracerHolder struct {
+	tracer Tracer
+}
+
+var global atomic.Value
+
+func init() {
+	global.Store(tracerHolder{tracer: noopTracer{}})
+}
+
+// SetTracer installs the tracer used by Start and Extract.
+// Passing nil restores the no-op tracer.
+func SetTracer(tracer Tracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	global.Store(tracerHolder{tracer: tracer})
+}
+
+// Start creates a span with the installed tracer
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return global.Load().(tracerHolder).tracer.Start(ctx, name, attrs...)
+}
+
+// Extract returns ctx carrying the remote trace context found in header
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return global.Load().(tracerHolder).tracer.Extract(ctx, header)
+}
+
+// noopTracer is the default tracer; it records nothing
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+func (noopTracer) Extract(ctx context.Context, header http.Header) context.Context {
+	return ctx
+}
+
+// noopSpan is returned by noopTracer
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(attrs ...Attribute) {}
+func (noopSpan) RecordError(err error)            {}
+func (noopSpan) End()                             {}
@@ -0,0 +1,64 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedSpan struct {
+	name  string
+	attrs []Attribute
+	err   error
+	ended bool
+}
+
+func (s *recordedSpan) SetAttributes(attrs ...Attribute) { s.attrs = append(s.attrs, attrs...) }
+func (s *recordedSpan) RecordError(err error)            { s.err = err }
+func (s *recordedSpan) End()                             { s.ended = true }
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &recordedSpan{name: name, attrs: attrs}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func (t *recordingTracer) Extract(ctx context.Context, header http.Header) context.Context {
+	return ctx
+}
+
+func TestSetTracer(t *testing.T) {
+	recorder := &recordingTracer{}
+	SetTracer(recorder)
+	defer SetTracer(nil)
+
+	_, span := Start(context.Background(), "tool get_sma", String("finance.symbol", "IBM"))
+	span.RecordError(errors.New("boom"))
+	span.End()
+
+	if assert.Len(t, recorder.spans, 1) {
+		assert.Equal(t, "tool get_sma", recorder.spans[0].name)
+		assert.Equal(t, []Attribute{{Key: "finance.symbol", Value: "IBM"}}, recorder.spans[0].attrs)
+		assert.EqualError(t, recorder.spans[0].err, "boom")
+		assert.True(t, recorder.spans[0].ended)
+	}
+}
+
+func TestNoopTracer(t *testing.T) {
+	ctx := context.Background()
+
+	spanCtx, span := Start(ctx, "noop")
+	assert.Equal(t, ctx, spanCtx)
+	assert.Equal(t, ctx, Extract(ctx, http.Header{}))
+
+	span.SetAttributes(Int("http.response.status_code", 200))
+	span.RecordError(errors.New("ignored"))
+	span.End()
+}